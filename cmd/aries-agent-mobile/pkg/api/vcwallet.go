@@ -59,6 +59,12 @@ type VCWalletController interface {
 	// creates a key pair from wallet.
 	CreateKeyPair(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// exports the wallet contents as an encrypted wallet.
+	Export(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// imports the contents of an encrypted wallet into wallet.
+	Import(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// accepts out-of-band invitations and performs DID exchange.
 	Connect(request *models.RequestEnvelope) *models.ResponseEnvelope
 
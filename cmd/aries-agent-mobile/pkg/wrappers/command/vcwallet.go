@@ -260,6 +260,38 @@ func (v *VCWallet) CreateKeyPair(request *models.RequestEnvelope) *models.Respon
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// Export exports the wallet contents as an encrypted wallet.
+func (v *VCWallet) Export(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ExportRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ExportMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Import imports the contents of an encrypted wallet into wallet.
+func (v *VCWallet) Import(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ImportRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ImportMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // Connect accepts out-of-band invitations and performs DID exchange.
 func (v *VCWallet) Connect(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmddidcommwallet.ConnectRequest{}
@@ -519,6 +519,12 @@ func getVCWalletEndpoints() map[string]*endpoint {
 		cmdvcwallet.CreateKeyPairMethod: {
 			Path: opvcwallet.CreateKeyPairPath, Method: http.MethodPost,
 		},
+		cmdvcwallet.ExportMethod: {
+			Path: opvcwallet.ExportPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.ImportMethod: {
+			Path: opvcwallet.ImportPath, Method: http.MethodPost,
+		},
 		cmddidcommwallet.ConnectMethod: {
 			Path: opvcwallet.ConnectPath, Method: http.MethodPost,
 		},
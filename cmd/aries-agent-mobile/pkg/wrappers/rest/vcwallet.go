@@ -96,6 +96,16 @@ func (wallet *VCWallet) CreateKeyPair(request *models.RequestEnvelope) *models.R
 	return wallet.createRespEnvelope(request, cmdvcwallet.CreateKeyPairMethod)
 }
 
+// Export exports the wallet contents as an encrypted wallet.
+func (wallet *VCWallet) Export(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ExportMethod)
+}
+
+// Import imports the contents of an encrypted wallet into wallet.
+func (wallet *VCWallet) Import(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ImportMethod)
+}
+
 // Connect accepts out-of-band invitations and performs DID exchange.
 func (wallet *VCWallet) Connect(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return wallet.createRespEnvelope(request, cmddidcommwallet.ConnectMethod)
@@ -29,6 +29,7 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/messaging/msghandler"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport/http"
@@ -37,6 +38,10 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/aries/defaults"
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/context"
 	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms/localkms"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local/masterlock"
 	"github.com/markcryptohash/aries-framework-go/pkg/vdr/httpbinding"
 	"github.com/markcryptohash/aries-framework-go/spi/storage"
 )
@@ -85,6 +90,37 @@ const (
 		" This flag can be repeated, allowing for multiple listeners." +
 		" Alternatively, this can be set with the following environment variable (in CSV format): " + agentWebhookEnvKey
 
+	// webhook subscriber flag.
+	agentWebhookSubscriberFlagName  = "webhook-subscriber"
+	agentWebhookSubscriberEnvKey    = "ARIESD_WEBHOOK_SUBSCRIBER"
+	agentWebhookSubscriberFlagUsage = "Webhook subscriber with its own notification signing secret and topics." +
+		" Values should be in `url;secret=<secret>;topics=<topic1>|<topic2>` format, secret and topics are optional." +
+		" Notifications are signed with HMAC-SHA256 when a secret is set and all topics are sent if none are set." +
+		" This flag can be repeated, allowing for multiple subscribers." +
+		" Alternatively, this can be set with the following environment variable (in CSV format): " +
+		agentWebhookSubscriberEnvKey
+
+	// webhook max attempts flag.
+	agentWebhookMaxAttemptsFlagName  = "webhook-max-attempts"
+	agentWebhookMaxAttemptsEnvKey    = "ARIESD_WEBHOOK_MAX_ATTEMPTS"
+	agentWebhookMaxAttemptsFlagUsage = "Number of attempts to deliver a webhook notification. Defaults to 1 if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentWebhookMaxAttemptsEnvKey
+
+	// webhook retry interval flag.
+	agentWebhookRetryIntervalFlagName  = "webhook-retry-interval"
+	agentWebhookRetryIntervalEnvKey    = "ARIESD_WEBHOOK_RETRY_INTERVAL"
+	agentWebhookRetryIntervalFlagUsage = "Delay before retrying a failed webhook notification (e.g. 500ms, 2s)," +
+		" the delay doubles after each retry. Defaults to 1s if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentWebhookRetryIntervalEnvKey
+
+	// webhook dead letters flag.
+	agentWebhookDeadLettersFlagName  = "webhook-dead-letters"
+	agentWebhookDeadLettersEnvKey    = "ARIESD_WEBHOOK_DEAD_LETTERS"
+	agentWebhookDeadLettersFlagUsage = "Store webhook notifications that could not be delivered after the last attempt," +
+		" they can be listed and replayed through the REST API." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentWebhookDeadLettersEnvKey
+
 	// default label flag.
 	agentDefaultLabelFlagName      = "agent-default-label"
 	agentDefaultLabelEnvKey        = "ARIESD_DEFAULT_LABEL"
@@ -207,6 +243,24 @@ const (
 		" Alternatively, this can be set with the following environment variable (in CSV format): " +
 		agentMediaTypeProfilesEnvKey
 
+	// master key flags.
+	agentMasterKeyPathFlagName  = "master-key-path"
+	agentMasterKeyPathEnvKey    = "ARIESD_MASTER_KEY_PATH"
+	agentMasterKeyPathFlagUsage = "Path of the file holding the master key protecting the keys stored by the KMS," +
+		" a new master key is written to it if the file doesn't exist. The keys are not protected if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentMasterKeyPathEnvKey
+
+	agentMasterKeyPassphraseFlagName  = "master-key-passphrase"
+	agentMasterKeyPassphraseEnvKey    = "ARIESD_MASTER_KEY_PASSPHRASE" // nolint:gosec
+	agentMasterKeyPassphraseFlagUsage = "Passphrase protecting the master key file (optional)." +
+		" Alternatively, this can be set with the following environment variable: " + agentMasterKeyPassphraseEnvKey
+
+	agentMasterLockFlagName  = "master-lock"
+	agentMasterLockEnvKey    = "ARIESD_MASTER_LOCK"
+	agentMasterLockFlagUsage = "Lock protecting the master key file with the passphrase." +
+		" Possible values [hkdf] [pbkdf2]. Defaults to hkdf if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentMasterLockEnvKey
+
 	httpProtocol      = "http"
 	websocketProtocol = "ws"
 
@@ -255,6 +309,8 @@ type AgentParameters struct {
 	autoAccept                                     bool
 	msgHandler                                     command.MessageHandler
 	dbParam                                        *dbParam
+	webhook                                        webhookParam
+	masterKey                                      masterKeyParam
 	autoExecuteRFC0593                             bool
 }
 
@@ -264,6 +320,18 @@ type dbParam struct {
 	timeout uint64
 }
 
+type masterKeyParam struct {
+	path       string
+	passphrase string
+	lockType   string
+}
+
+type webhookParam struct {
+	subscribers []*webnotifier.Subscriber
+	retry       *webnotifier.RetryParams
+	deadLetters bool
+}
+
 // nolint:gochecknoglobals
 var supportedStorageProviders = map[string]func(prefix string) (storage.Provider, error){
 	databaseTypeMemOption: func(_ string) (storage.Provider, error) { // nolint:unparam
@@ -365,7 +433,13 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		return nil, err
 	}
 
-	webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey, autoAccept)
+	webhook, err := getWebhookParam(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey,
+		autoAccept || len(webhook.subscribers) > 0)
 	if err != nil {
 		return nil, err
 	}
@@ -422,6 +496,11 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		return nil, err
 	}
 
+	masterKey, err := getMasterKeyParam(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	parameters := &AgentParameters{
 		server:               server,
 		host:                 host,
@@ -432,6 +511,7 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		dbParam:              dbParam,
 		defaultLabel:         defaultLabel,
 		webhookURLs:          webhookURLs,
+		webhook:              webhook,
 		httpResolvers:        httpResolvers,
 		outboundTransports:   outboundTransports,
 		autoAccept:           autoAccept,
@@ -443,6 +523,7 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		keyType:              keyType,
 		keyAgreementType:     keyAgreementType,
 		mediaTypeProfiles:    mediaTypeProfiles,
+		masterKey:            masterKey,
 	}
 
 	return parameters, nil
@@ -497,6 +578,127 @@ func getDBParam(cmd *cobra.Command) (*dbParam, error) {
 	return dbParam, nil
 }
 
+func getMasterKeyParam(cmd *cobra.Command) (masterKeyParam, error) {
+	var (
+		param masterKeyParam
+		err   error
+	)
+
+	param.path, err = getUserSetVar(cmd, agentMasterKeyPathFlagName, agentMasterKeyPathEnvKey, true)
+	if err != nil {
+		return param, err
+	}
+
+	param.passphrase, err = getUserSetVar(cmd, agentMasterKeyPassphraseFlagName, agentMasterKeyPassphraseEnvKey, true)
+	if err != nil {
+		return param, err
+	}
+
+	param.lockType, err = getUserSetVar(cmd, agentMasterLockFlagName, agentMasterLockEnvKey, true)
+	if err != nil {
+		return param, err
+	}
+
+	return param, nil
+}
+
+func getWebhookParam(cmd *cobra.Command) (webhookParam, error) {
+	var param webhookParam
+
+	subscribers, err := getUserSetVars(cmd, agentWebhookSubscriberFlagName, agentWebhookSubscriberEnvKey, true)
+	if err != nil {
+		return param, err
+	}
+
+	for _, subscriber := range subscribers {
+		s, err := parseWebhookSubscriber(subscriber)
+		if err != nil {
+			return param, err
+		}
+
+		param.subscribers = append(param.subscribers, s)
+	}
+
+	param.retry, err = getWebhookRetryParams(cmd)
+	if err != nil {
+		return param, err
+	}
+
+	deadLetters, err := getUserSetVar(cmd, agentWebhookDeadLettersFlagName, agentWebhookDeadLettersEnvKey, true)
+	if err != nil {
+		return param, err
+	}
+
+	if deadLetters != "" {
+		param.deadLetters, err = strconv.ParseBool(deadLetters)
+		if err != nil {
+			return param, fmt.Errorf("failed to parse webhook dead letters %s: %w", deadLetters, err)
+		}
+	}
+
+	return param, nil
+}
+
+// parseWebhookSubscriber parses a subscriber in `url;secret=<secret>;topics=<topic1>|<topic2>` format.
+func parseWebhookSubscriber(value string) (*webnotifier.Subscriber, error) {
+	const numPartsSubscriberProperty = 2
+
+	parts := strings.Split(value, ";")
+	if parts[0] == "" {
+		return nil, fmt.Errorf("invalid webhook subscriber option: url is missing")
+	}
+
+	subscriber := &webnotifier.Subscriber{URL: parts[0]}
+
+	for _, part := range parts[1:] {
+		property := strings.SplitN(part, "=", numPartsSubscriberProperty)
+		if len(property) != numPartsSubscriberProperty {
+			return nil, fmt.Errorf("invalid webhook subscriber option: Use url;secret=<secret>;topics=<topics>")
+		}
+
+		switch property[0] {
+		case "secret":
+			subscriber.Secret = property[1]
+		case "topics":
+			subscriber.Topics = strings.Split(property[1], "|")
+		default:
+			return nil, fmt.Errorf("invalid webhook subscriber option: unknown property [%s]", property[0])
+		}
+	}
+
+	return subscriber, nil
+}
+
+func getWebhookRetryParams(cmd *cobra.Command) (*webnotifier.RetryParams, error) {
+	maxAttempts, err := getUserSetVar(cmd, agentWebhookMaxAttemptsFlagName, agentWebhookMaxAttemptsEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	retryInterval, err := getUserSetVar(cmd, agentWebhookRetryIntervalFlagName, agentWebhookRetryIntervalEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	params := webnotifier.DefaultRetryParams()
+
+	if maxAttempts != "" {
+		params.MaxAttempts, err = strconv.Atoi(maxAttempts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse webhook max attempts %s: %w", maxAttempts, err)
+		}
+	}
+
+	if retryInterval != "" {
+		params.InitialInterval, err = time.ParseDuration(retryInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse webhook retry interval %s: %w", retryInterval, err)
+		}
+	}
+
+	return params, nil
+}
+
 func getAutoAcceptValue(cmd *cobra.Command) (bool, error) {
 	v, err := getUserSetVar(cmd, agentAutoAcceptFlagName, agentAutoAcceptEnvKey, true)
 	if err != nil {
@@ -571,6 +773,16 @@ func createFlags(startCmd *cobra.Command) {
 	// webhook url flag
 	startCmd.Flags().StringSliceP(agentWebhookFlagName, agentWebhookFlagShorthand, []string{}, agentWebhookFlagUsage)
 
+	// webhook subscriber flag
+	startCmd.Flags().StringSliceP(agentWebhookSubscriberFlagName, "", []string{}, agentWebhookSubscriberFlagUsage)
+
+	// webhook retry flags
+	startCmd.Flags().StringP(agentWebhookMaxAttemptsFlagName, "", "", agentWebhookMaxAttemptsFlagUsage)
+	startCmd.Flags().StringP(agentWebhookRetryIntervalFlagName, "", "", agentWebhookRetryIntervalFlagUsage)
+
+	// webhook dead letters flag
+	startCmd.Flags().StringP(agentWebhookDeadLettersFlagName, "", "", agentWebhookDeadLettersFlagUsage)
+
 	// log level
 	startCmd.Flags().StringP(agentLogLevelFlagName, "", "", agentLogLevelFlagUsage)
 
@@ -613,6 +825,11 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(agentKeyAgreementTypeFlagName, "", "", agentKeyAgreementTypeUsage)
 
 	startCmd.Flags().StringSliceP(agentMediaTypeProfilesFlagName, "", []string{}, agentMediaTypeProfilesUsage)
+
+	// master key flags
+	startCmd.Flags().StringP(agentMasterKeyPathFlagName, "", "", agentMasterKeyPathFlagUsage)
+	startCmd.Flags().StringP(agentMasterKeyPassphraseFlagName, "", "", agentMasterKeyPassphraseFlagUsage)
+	startCmd.Flags().StringP(agentMasterLockFlagName, "", "", agentMasterLockFlagUsage)
 }
 
 func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
@@ -686,6 +903,48 @@ func getResolverOpts(httpResolvers []string) ([]aries.Option, error) {
 	return opts, nil
 }
 
+// getSecretLockOpts returns the option protecting the keys of the KMS with the master key file, the framework
+// default is kept if no master key file is set. A rotation of the master key interrupted by a crash is completed
+// first, the keys of the KMS in storeProvider are re-encrypted with the new master key.
+func getSecretLockOpts(param masterKeyParam, storeProvider storage.Provider) ([]aries.Option, error) {
+	if param.path == "" {
+		return nil, nil
+	}
+
+	masterLock, err := getMasterLock(param)
+	if err != nil {
+		return nil, err
+	}
+
+	err = local.ResumeMasterKeyRotation(param.path, masterLock, func(oldLock, newLock secretlock.Service) error {
+		kmsStore, e := kms.NewAriesProviderWrapper(storeProvider)
+		if e != nil {
+			return e
+		}
+
+		return localkms.Rewrap(kmsStore, aries.DefaultMasterKeyURI, oldLock, newLock)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume the rotation of master key file %s: %w", param.path, err)
+	}
+
+	secretLock, err := local.NewServiceFromPath(param.path, masterLock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key file %s: %w", param.path, err)
+	}
+
+	return []aries.Option{aries.WithSecretLock(secretLock)}, nil
+}
+
+// getMasterLock returns the lock protecting the master key file with the passphrase, nil if there is no passphrase.
+func getMasterLock(param masterKeyParam) (secretlock.Service, error) {
+	if param.passphrase == "" {
+		return nil, nil
+	}
+
+	return masterlock.New(param.lockType, param.passphrase)
+}
+
 func getOutboundTransportOpts(outboundTransports []string, readLimit int64) ([]aries.Option, error) {
 	var opts []aries.Option
 
@@ -820,11 +1079,20 @@ func (parameters *AgentParameters) NewRouter() (*mux.Router, error) {
 		return nil, err
 	}
 
+	masterLock, err := getMasterLock(parameters.masterKey)
+	if err != nil {
+		return nil, err
+	}
+
 	// get all HTTP REST API handlers available for controller API
 	handlers, err := controller.GetRESTHandlers(ctx, controller.WithWebhookURLs(parameters.webhookURLs...),
+		controller.WithWebhookSubscribers(parameters.webhook.subscribers...),
+		controller.WithWebhookRetry(parameters.webhook.retry),
+		controller.WithWebhookDeadLetters(parameters.webhook.deadLetters),
 		controller.WithDefaultLabel(parameters.defaultLabel), controller.WithAutoAccept(parameters.autoAccept),
 		controller.WithMessageHandler(parameters.msgHandler),
-		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593))
+		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593),
+		controller.WithMasterKeyFile(parameters.masterKey.path, masterLock))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to get rest service api :  %w",
 			parameters.host, err)
@@ -878,6 +1146,14 @@ func createAriesAgent(parameters *AgentParameters) (*context.Provider, error) {
 
 	opts = append(opts, aries.WithStoreProvider(storePro))
 
+	secretLockOpts, err := getSecretLockOpts(parameters.masterKey, storePro)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to create secret lock : %w",
+			parameters.host, err)
+	}
+
+	opts = append(opts, secretLockOpts...)
+
 	if parameters.transportReturnRoute != "" {
 		opts = append(opts, aries.WithTransportReturnRoute(parameters.transportReturnRoute))
 	}
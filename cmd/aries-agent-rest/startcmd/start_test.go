@@ -13,7 +13,9 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -22,7 +24,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local"
 	spi "github.com/markcryptohash/aries-framework-go/spi/log"
 )
 
@@ -481,6 +485,122 @@ func TestStartCmdWithoutWebhookURLAndAutoAccept(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestStartCmdWithWebhookOptions(t *testing.T) {
+	baseArgs := func() []string {
+		return []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + agentInboundHostExternalFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(),
+			"--"+agentWebhookSubscriberFlagName,
+			"http://localhost:8082;secret=s3cr3t;topics=basicmessages|didexchange_states",
+			"--"+agentWebhookSubscriberFlagName,
+			"http://localhost:8083",
+			"--"+agentWebhookMaxAttemptsFlagName,
+			"5",
+			"--"+agentWebhookRetryIntervalFlagName,
+			"500ms",
+			"--"+agentWebhookDeadLettersFlagName,
+			"true",
+		))
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("error - invalid subscriber", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(),
+			"--"+agentWebhookSubscriberFlagName, "http://localhost:8082;secret",
+		))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid webhook subscriber option")
+	})
+
+	t.Run("error - invalid max attempts", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(),
+			"--"+agentWebhookSubscriberFlagName, "http://localhost:8082",
+			"--"+agentWebhookMaxAttemptsFlagName, "many",
+		))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse webhook max attempts")
+	})
+
+	t.Run("error - invalid retry interval", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(),
+			"--"+agentWebhookSubscriberFlagName, "http://localhost:8082",
+			"--"+agentWebhookRetryIntervalFlagName, "soon",
+		))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse webhook retry interval")
+	})
+
+	t.Run("error - invalid dead letters", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(),
+			"--"+agentWebhookSubscriberFlagName, "http://localhost:8082",
+			"--"+agentWebhookDeadLettersFlagName, "maybe",
+		))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse webhook dead letters")
+	})
+}
+
+func TestParseWebhookSubscriber(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		subscriber, err := parseWebhookSubscriber("http://localhost:8082/hook;secret=a=b;topics=basicmessages|ping")
+		require.NoError(t, err)
+		require.Equal(t, &webnotifier.Subscriber{
+			URL:    "http://localhost:8082/hook",
+			Secret: "a=b",
+			Topics: []string{"basicmessages", "ping"},
+		}, subscriber)
+
+		subscriber, err = parseWebhookSubscriber("http://localhost:8082")
+		require.NoError(t, err)
+		require.Equal(t, &webnotifier.Subscriber{URL: "http://localhost:8082"}, subscriber)
+	})
+
+	t.Run("error - missing url", func(t *testing.T) {
+		_, err := parseWebhookSubscriber(";secret=s3cr3t")
+		require.EqualError(t, err, "invalid webhook subscriber option: url is missing")
+	})
+
+	t.Run("error - unknown property", func(t *testing.T) {
+		_, err := parseWebhookSubscriber("http://localhost:8082;token=s3cr3t")
+		require.EqualError(t, err, "invalid webhook subscriber option: unknown property [token]")
+	})
+}
+
 func TestStartCmdValidArgs(t *testing.T) {
 	startCmd, err := Cmd(&mockServer{})
 	require.NoError(t, err)
@@ -768,6 +888,101 @@ func TestCreateAriesWithKeyType(t *testing.T) {
 	}
 }
 
+func TestCreateAriesWithMasterKey(t *testing.T) {
+	t.Run("master key file is created then reused", func(t *testing.T) {
+		masterKeyPath := filepath.Join(t.TempDir(), "masterkey")
+
+		for i := 0; i < 2; i++ {
+			parameters := &AgentParameters{
+				dbParam:   &dbParam{dbType: databaseTypeMemOption},
+				masterKey: masterKeyParam{path: masterKeyPath, passphrase: "secret", lockType: "pbkdf2"},
+			}
+
+			_, err := createAriesAgent(parameters)
+			require.NoError(t, err)
+
+			_, err = os.Stat(masterKeyPath)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("master key file is rotated by the kms rewrap endpoint", func(t *testing.T) {
+		masterKeyPath := filepath.Join(t.TempDir(), "masterkey")
+
+		parameters := &AgentParameters{
+			host:      "localhost:8080",
+			dbParam:   &dbParam{dbType: databaseTypeMemOption},
+			masterKey: masterKeyParam{path: masterKeyPath, passphrase: "secret", lockType: "pbkdf2"},
+		}
+
+		router, err := parameters.NewRouter()
+		require.NoError(t, err)
+
+		oldMasterKey, err := ioutil.ReadFile(masterKeyPath) // nolint: gosec
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/kms/rewrap", strings.NewReader(`{}`)))
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		newMasterKey, err := ioutil.ReadFile(masterKeyPath) // nolint: gosec
+		require.NoError(t, err)
+		require.NotEqual(t, oldMasterKey, newMasterKey)
+	})
+
+	t.Run("interrupted rotation of the master key file is completed", func(t *testing.T) {
+		masterKeyPath := filepath.Join(t.TempDir(), "masterkey")
+		param := masterKeyParam{path: masterKeyPath, passphrase: "secret", lockType: "pbkdf2"}
+
+		_, err := createAriesAgent(&AgentParameters{dbParam: &dbParam{dbType: databaseTypeMemOption}, masterKey: param})
+		require.NoError(t, err)
+
+		masterLock, err := getMasterLock(param)
+		require.NoError(t, err)
+
+		// the agent stopped before the new master key replaced the master key file.
+		require.NoError(t, local.CreateMasterKeyFile(masterKeyPath+".new", masterLock))
+
+		pendingMasterKey, err := ioutil.ReadFile(masterKeyPath + ".new") // nolint: gosec
+		require.NoError(t, err)
+
+		_, err = createAriesAgent(&AgentParameters{dbParam: &dbParam{dbType: databaseTypeMemOption}, masterKey: param})
+		require.NoError(t, err)
+
+		masterKey, err := ioutil.ReadFile(masterKeyPath) // nolint: gosec
+		require.NoError(t, err)
+		require.Equal(t, pendingMasterKey, masterKey)
+
+		_, err = os.Stat(masterKeyPath + ".new")
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("error - unsupported master lock", func(t *testing.T) {
+		parameters := &AgentParameters{
+			dbParam:   &dbParam{dbType: databaseTypeMemOption},
+			masterKey: masterKeyParam{path: "masterkey", passphrase: "secret", lockType: "unknown"},
+		}
+
+		_, err := createAriesAgent(parameters)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "master lock type 'unknown' is not supported")
+	})
+
+	t.Run("error - invalid master key file", func(t *testing.T) {
+		masterKeyPath := filepath.Join(t.TempDir(), "masterkey")
+		require.NoError(t, ioutil.WriteFile(masterKeyPath, []byte("invalid"), 0o600))
+
+		parameters := &AgentParameters{
+			dbParam:   &dbParam{dbType: databaseTypeMemOption},
+			masterKey: masterKeyParam{path: masterKeyPath},
+		}
+
+		_, err := createAriesAgent(parameters)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read master key file")
+	})
+}
+
 func TestCreateAriesWithKeyAgreementType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -946,6 +1161,18 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	os.Setenv(agentWebhookEnvKey, "agentWebhook")
 	defer os.Unsetenv(agentWebhookEnvKey)
 
+	os.Setenv(agentWebhookSubscriberEnvKey, "agentWebhookSubscriber;secret=secret;topics=topic")
+	defer os.Unsetenv(agentWebhookSubscriberEnvKey)
+
+	os.Setenv(agentWebhookMaxAttemptsEnvKey, "3")
+	defer os.Unsetenv(agentWebhookMaxAttemptsEnvKey)
+
+	os.Setenv(agentWebhookRetryIntervalEnvKey, "2s")
+	defer os.Unsetenv(agentWebhookRetryIntervalEnvKey)
+
+	os.Setenv(agentWebhookDeadLettersEnvKey, "true")
+	defer os.Unsetenv(agentWebhookDeadLettersEnvKey)
+
 	os.Setenv(agentDefaultLabelEnvKey, "agentDefaultLabel")
 	defer os.Unsetenv(agentDefaultLabelEnvKey)
 
@@ -994,6 +1221,15 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	os.Setenv(agentMediaTypeProfilesEnvKey, "agentMediaTypeProfiles")
 	defer os.Unsetenv(agentMediaTypeProfilesEnvKey)
 
+	os.Setenv(agentMasterKeyPathEnvKey, "agentMasterKeyPath")
+	defer os.Unsetenv(agentMasterKeyPathEnvKey)
+
+	os.Setenv(agentMasterKeyPassphraseEnvKey, "agentMasterKeyPassphrase")
+	defer os.Unsetenv(agentMasterKeyPassphraseEnvKey)
+
+	os.Setenv(agentMasterLockEnvKey, "agentMasterLock")
+	defer os.Unsetenv(agentMasterLockEnvKey)
+
 	parameters, err := NewAgentParameters(&mockServer{}, nil)
 
 	require.Nil(t, err)
@@ -1009,6 +1245,14 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	require.Equal(t, "agentDefaultLabel", parameters.defaultLabel)
 	require.Equal(t, true, parameters.autoAccept)
 	require.Equal(t, "agentWebhook", parameters.webhookURLs[0])
+	require.Equal(t, []*webnotifier.Subscriber{{
+		URL:    "agentWebhookSubscriber",
+		Secret: "secret",
+		Topics: []string{"topic"},
+	}}, parameters.webhook.subscribers)
+	require.Equal(t, 3, parameters.webhook.retry.MaxAttempts)
+	require.Equal(t, 2*time.Second, parameters.webhook.retry.InitialInterval)
+	require.True(t, parameters.webhook.deadLetters)
 	require.Equal(t, "agentOutboundTransport", parameters.outboundTransports[0])
 	require.Equal(t, "agentTransportReturnRoute", parameters.transportReturnRoute)
 	require.Equal(t, "agentContextProvider", parameters.contextProviderURLs[0])
@@ -1018,6 +1262,11 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	require.Equal(t, "agentKeyType", parameters.keyType)
 	require.Equal(t, "agentKeyAgreementType", parameters.keyAgreementType)
 	require.Equal(t, "agentMediaTypeProfiles", parameters.mediaTypeProfiles[0])
+	require.Equal(t, masterKeyParam{
+		path:       "agentMasterKeyPath",
+		passphrase: "agentMasterKeyPassphrase",
+		lockType:   "agentMasterLock",
+	}, parameters.masterKey)
 }
 
 func waitForServerToStart(t *testing.T, host, inboundHost string) {
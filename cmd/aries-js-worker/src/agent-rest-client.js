@@ -453,6 +453,42 @@ const pkgs = {
         ImportKey: {
             path: "/kms/import",
             method: "POST",
+        },
+        ListKeys: {
+            path: "/kms/keys",
+            method: "GET",
+        },
+        GetKeyInfo: {
+            path: "/kms/keys/{keyID}",
+            method: "GET",
+            pathParam: "keyID"
+        },
+        SetKeyMetadata: {
+            path: "/kms/keys/{keyID}/metadata",
+            method: "POST",
+            pathParam: "keyID"
+        },
+        DisableKey: {
+            path: "/kms/keys/{keyID}/disable",
+            method: "POST",
+            pathParam: "keyID"
+        },
+        DeleteKey: {
+            path: "/kms/keys/{keyID}",
+            method: "DELETE",
+            pathParam: "keyID"
+        },
+        RewrapKeys: {
+            path: "/kms/rewrap",
+            method: "POST"
+        },
+        BackupKeys: {
+            path: "/kms/backup",
+            method: "POST"
+        },
+        RestoreKeys: {
+            path: "/kms/restore",
+            method: "POST"
         }
     },
     vcwallet: {
@@ -1189,6 +1189,78 @@ const Aries = function (opts) {
             importKey: async function (req) {
                 return invoke(aw, pending, this.pkgname, "ImportKey", req, "timeout while importing key")
             },
+
+            /**
+             * List keys with their type, creation time and metadata.
+             *
+             * @returns {Promise<Object>}
+             */
+            listKeys: async function () {
+                return invoke(aw, pending, this.pkgname, "ListKeys", {}, "timeout while listing keys")
+            },
+
+            /**
+             * Get the type, creation time and metadata of a key.
+             *
+             * @returns {Promise<Object>}
+             */
+            getKeyInfo: async function (req) {
+                return invoke(aw, pending, this.pkgname, "GetKeyInfo", req, "timeout while getting key info")
+            },
+
+            /**
+             * Replace the metadata tags of a key.
+             *
+             * @returns {Promise<Object>}
+             */
+            setKeyMetadata: async function (req) {
+                return invoke(aw, pending, this.pkgname, "SetKeyMetadata", req, "timeout while setting key metadata")
+            },
+
+            /**
+             * Disable a key, the key is kept but can't be used anymore.
+             *
+             * @returns {Promise<Object>}
+             */
+            disableKey: async function (req) {
+                return invoke(aw, pending, this.pkgname, "DisableKey", req, "timeout while disabling key")
+            },
+
+            /**
+             * Permanently delete a key.
+             *
+             * @returns {Promise<Object>}
+             */
+            deleteKey: async function (req) {
+                return invoke(aw, pending, this.pkgname, "DeleteKey", req, "timeout while deleting key")
+            },
+
+            /**
+             * Re-encrypt the stored keys with a new master key.
+             *
+             * @returns {Promise<Object>}
+             */
+            rewrapKeys: async function (req) {
+                return invoke(aw, pending, this.pkgname, "RewrapKeys", req, "timeout while re-wrapping keys")
+            },
+
+            /**
+             * Export the keys in an archive encrypted with a key derived from a passphrase.
+             *
+             * @returns {Promise<Object>}
+             */
+            backupKeys: async function (req) {
+                return invoke(aw, pending, this.pkgname, "BackupKeys", req, "timeout while backing up keys")
+            },
+
+            /**
+             * Import the keys of a backup, the keys keep their IDs.
+             *
+             * @returns {Promise<Object>}
+             */
+            restoreKeys: async function (req) {
+                return invoke(aw, pending, this.pkgname, "RestoreKeys", req, "timeout while restoring keys")
+            },
         },
         /**
          * Verifiable Credential Wallet based on Universal Wallet 2020 https://w3c-ccg.github.io/universal-wallet-interop-spec/#interface
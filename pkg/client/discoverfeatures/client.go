@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	// DIDComm service
+	service.DIDComm
+
+	Query(connectionID string, params *discoverfeatures.QueryParams) ([]*discoverfeatures.Disclosure, error)
+}
+
+// Client enables access to the discover features api.
+type Client struct {
+	service.Event
+	discoverFeaturesSvc protocolService
+}
+
+// QueryOption configures a query.
+type QueryOption func(params *discoverfeatures.QueryParams)
+
+// WithQuery adds a query of the features of the given type matching match, where '*' stands for any sequence of
+// characters. All the protocols are queried if no query is added. Discover Features 1.0 (DIDComm v1 connections)
+// supports a single protocol query.
+func WithQuery(featureType, match string) QueryOption {
+	return func(params *discoverfeatures.QueryParams) {
+		params.Queries = append(params.Queries, &discoverfeatures.FeatureQuery{FeatureType: featureType, Match: match})
+	}
+}
+
+// WithTimeout sets the time to wait for the disclosure, discoverfeatures.DefaultTimeout by default.
+func WithTimeout(timeout time.Duration) QueryOption {
+	return func(params *discoverfeatures.QueryParams) {
+		params.Timeout = timeout
+	}
+}
+
+// WithComment sets a comment sent along with the query (Discover Features 1.0 only).
+func WithComment(comment string) QueryOption {
+	return func(params *discoverfeatures.QueryParams) {
+		params.Comment = comment
+	}
+}
+
+// New returns new instance of discover features client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discover features service: %w", err)
+	}
+
+	discoverFeaturesSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{
+		Event:               discoverFeaturesSvc,
+		discoverFeaturesSvc: discoverFeaturesSvc,
+	}, nil
+}
+
+// Query asks the other party of the given connection which features it supports and waits for the disclosure.
+// The disclosed features are cached on the connection record.
+func (c *Client) Query(connectionID string, opts ...QueryOption) ([]*discoverfeatures.Disclosure, error) {
+	params := &discoverfeatures.QueryParams{
+		Timeout: discoverfeatures.DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	disclosures, err := c.discoverFeaturesSvc.Query(connectionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - query: %w", err)
+	}
+
+	return disclosures, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to discover features service failed")
+	})
+}
+
+func TestClient_Query(t *testing.T) {
+	t.Run("query with default options", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryFunc: func(connectionID string,
+					params *discoverfeatures.QueryParams) ([]*discoverfeatures.Disclosure, error) {
+					require.Equal(t, "connID", connectionID)
+					require.Empty(t, params.Queries)
+					require.Equal(t, discoverfeatures.DefaultTimeout, params.Timeout)
+					require.Empty(t, params.Comment)
+
+					return []*discoverfeatures.Disclosure{{
+						FeatureType: discoverfeatures.FeatureTypeProtocol,
+						ID:          "https://didcomm.org/trust-ping/2.0",
+					}}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		disclosures, err := client.Query("connID")
+		require.NoError(t, err)
+		require.Len(t, disclosures, 1)
+	})
+
+	t.Run("query with options", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryFunc: func(connectionID string,
+					params *discoverfeatures.QueryParams) ([]*discoverfeatures.Disclosure, error) {
+					require.Equal(t, []*discoverfeatures.FeatureQuery{
+						{FeatureType: discoverfeatures.FeatureTypeProtocol, Match: "https://didcomm.org/*"},
+						{FeatureType: discoverfeatures.FeatureTypeGoalCode, Match: "*"},
+					}, params.Queries)
+					require.Equal(t, time.Second, params.Timeout)
+					require.Equal(t, "hello", params.Comment)
+
+					return nil, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		disclosures, err := client.Query("connID",
+			WithQuery(discoverfeatures.FeatureTypeProtocol, "https://didcomm.org/*"),
+			WithQuery(discoverfeatures.FeatureTypeGoalCode, "*"),
+			WithTimeout(time.Second), WithComment("hello"))
+		require.NoError(t, err)
+		require.Empty(t, disclosures)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Query("connID")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "discover features client - query: service error")
+	})
+}
@@ -69,7 +69,7 @@ func New(ctx Provider) (*Client, error) {
 }
 
 // SendProposal sends a proposal to the introducees (the client has not published an out-of-band message).
-func (c *Client) SendProposal(recipient1, recipient2 *Recipient) (string, error) {
+func (c *Client) SendProposal(recipient1, recipient2 *Recipient, options ...SendOptions) (string, error) {
 	_recipient1 := introduce.Recipient(*recipient1)
 	_recipient2 := introduce.Recipient(*recipient2)
 
@@ -77,6 +77,8 @@ func (c *Client) SendProposal(recipient1, recipient2 *Recipient) (string, error)
 	proposal2 := introduce.CreateProposal(&_recipient2)
 
 	introduce.WrapWithMetadataPIID(proposal1, proposal2)
+	proposal1.Localize(service.V1, options...)
+	proposal2.Localize(service.V1, options...)
 
 	_, err := c.service.HandleOutbound(proposal1, recipient1.MyDID, recipient1.TheirDID)
 	if err != nil {
@@ -87,25 +89,30 @@ func (c *Client) SendProposal(recipient1, recipient2 *Recipient) (string, error)
 }
 
 // SendProposalWithOOBInvitation sends a proposal to the introducee (the client has published an out-of-band request).
-func (c *Client) SendProposalWithOOBInvitation(inv *outofband.Invitation, recipient *Recipient) (string, error) {
+func (c *Client) SendProposalWithOOBInvitation(inv *outofband.Invitation, recipient *Recipient,
+	options ...SendOptions) (string, error) {
 	_recipient := introduce.Recipient(*recipient)
 	_req := outofbandsvc.Invitation(*inv)
 
 	proposal := introduce.CreateProposal(&_recipient)
 	introduce.WrapWithMetadataPublicOOBInvitation(proposal, &_req)
+	proposal.Localize(service.V1, options...)
 
 	return c.service.HandleOutbound(proposal, recipient.MyDID, recipient.TheirDID)
 }
 
 // SendRequest sends a request.
 // Sending a request means that the introducee is willing to share their own out-of-band message.
-func (c *Client) SendRequest(to *PleaseIntroduceTo, myDID, theirDID string) (string, error) {
+func (c *Client) SendRequest(to *PleaseIntroduceTo, myDID, theirDID string, options ...SendOptions) (string, error) {
 	_to := introduce.PleaseIntroduceTo(*to)
 
-	return c.service.HandleOutbound(service.NewDIDCommMsgMap(&introduce.Request{
+	request := service.NewDIDCommMsgMap(&introduce.Request{
 		Type:              introduce.RequestMsgType,
 		PleaseIntroduceTo: &_to,
-	}), myDID, theirDID)
+	})
+	request.Localize(service.V1, options...)
+
+	return c.service.HandleOutbound(request, myDID, theirDID)
 }
 
 // AcceptProposalWithOOBInvitation is used when introducee wants to provide an out-of-band request.
@@ -194,3 +201,12 @@ func WithOOBInvitation(req *outofband.Invitation, a ...*decorator.Attachment) in
 
 	return introduce.WithOOBInvitation(&_req, a...)
 }
+
+// SendOptions is custom option for sending proposal and request messages.
+type SendOptions = service.SendOptions
+
+// WithLocale option to provide the locale of the descriptions of the introducees, their translations are given
+// by the DescriptionL10N of To.
+func WithLocale(locale string) SendOptions {
+	return service.WithLocale(locale)
+}
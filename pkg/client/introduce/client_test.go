@@ -186,6 +186,38 @@ func TestClient_SendRequest(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_SendRequestWithLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocksintroduce.NewMockProvider(ctrl)
+
+	svc := mocksintroduce.NewMockProtocolService(ctrl)
+	svc.EXPECT().
+		HandleOutbound(gomock.Any(), "firstMyDID", "firstTheirDID").
+		DoAndReturn(func(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+			require.Equal(t, "en", msg.(service.DIDCommMsgMap).Locale())
+
+			request := introduce.Request{}
+			require.NoError(t, msg.Decode(&request))
+			require.Equal(t, "es", request.PleaseIntroduceTo.DescriptionL10N.Locale())
+			require.Equal(t, "Bob", request.PleaseIntroduceTo.DescriptionL10N.Translations()["en"])
+
+			return expectedPIID, nil
+		})
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	piid, err := client.SendRequest(&PleaseIntroduceTo{To: introduce.To{
+		Description:     "Roberto",
+		DescriptionL10N: introduce.DescriptionL10N{"locale": "es", "en": "Bob"},
+	}}, "firstMyDID", "firstTheirDID", WithLocale("en"))
+	require.Equal(t, expectedPIID, piid)
+	require.NoError(t, err)
+}
+
 func TestClient_AcceptProposalWithOOBRequest(t *testing.T) {
 	t.Run("continues the process instance with the request", func(t *testing.T) {
 		expectedPIID := "abc123"
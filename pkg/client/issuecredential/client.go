@@ -134,12 +134,13 @@ func (c *Client) Actions() ([]Action, error) {
 }
 
 // SendOffer is used by the Issuer to send an offer.
-func (c *Client) SendOffer(offer *OfferCredential, conn *connection.Record) (string, error) {
+func (c *Client) SendOffer(offer *OfferCredential, conn *connection.Record,
+	options ...SendOptions) (string, error) {
 	if offer == nil {
 		return "", errEmptyOffer
 	}
 
-	var msg service.DIDCommMsg
+	var msg service.DIDCommMsgMap
 
 	switch conn.DIDCommVersion {
 	default:
@@ -154,16 +155,19 @@ func (c *Client) SendOffer(offer *OfferCredential, conn *connection.Record) (str
 		msg = service.NewDIDCommMsgMap(offer.AsV3())
 	}
 
+	msg.Localize(conn.DIDCommVersion, options...)
+
 	return c.service.HandleOutbound(msg, conn.MyDID, conn.TheirDID)
 }
 
 // SendProposal is used by the Holder to send a proposal.
-func (c *Client) SendProposal(proposal *ProposeCredential, conn *connection.Record) (string, error) {
+func (c *Client) SendProposal(proposal *ProposeCredential, conn *connection.Record,
+	options ...SendOptions) (string, error) {
 	if proposal == nil {
 		return "", errEmptyProposal
 	}
 
-	var msg service.DIDCommMsg
+	var msg service.DIDCommMsgMap
 
 	switch conn.DIDCommVersion {
 	default:
@@ -178,16 +182,19 @@ func (c *Client) SendProposal(proposal *ProposeCredential, conn *connection.Reco
 		msg = service.NewDIDCommMsgMap(proposal.AsV3())
 	}
 
+	msg.Localize(conn.DIDCommVersion, options...)
+
 	return c.service.HandleOutbound(msg, conn.MyDID, conn.TheirDID)
 }
 
 // SendRequest is used by the Holder to send a request.
-func (c *Client) SendRequest(request *RequestCredential, conn *connection.Record) (string, error) {
+func (c *Client) SendRequest(request *RequestCredential, conn *connection.Record,
+	options ...SendOptions) (string, error) {
 	if request == nil {
 		return "", errEmptyRequest
 	}
 
-	var msg service.DIDCommMsg
+	var msg service.DIDCommMsgMap
 
 	switch conn.DIDCommVersion {
 	default:
@@ -202,6 +209,8 @@ func (c *Client) SendRequest(request *RequestCredential, conn *connection.Record
 		msg = service.NewDIDCommMsgMap(request.AsV3())
 	}
 
+	msg.Localize(conn.DIDCommVersion, options...)
+
 	return c.service.HandleOutbound(msg, conn.MyDID, conn.TheirDID)
 }
 
@@ -336,6 +345,15 @@ func AcceptBySkippingStorage() AcceptCredentialOptions {
 	}
 }
 
+// SendOptions is custom option for sending offer, proposal and request messages.
+type SendOptions = service.SendOptions
+
+// WithLocalizedComment option to provide the locale of the comment and its translations keyed by locale,
+// the issuer or holder can read the comment in their own locale with service.DIDCommMsgMap.Localized.
+func WithLocalizedComment(locale string, translations map[string]string) SendOptions {
+	return service.WithLocalizedComment(locale, translations)
+}
+
 // redirectOpts options for web redirect information to holder from issuer.
 type redirectOpts struct {
 	redirect string
@@ -74,6 +74,30 @@ func TestClient_SendOffer(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Localized comment", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				text, locale := msg.(service.DIDCommMsgMap).Localized("comment", "es-MX")
+				require.Equal(t, "Hola", text)
+				require.Equal(t, "es", locale)
+
+				return expectedPiid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.SendOffer(&OfferCredential{Comment: "Hello"}, &connection.Record{
+			MyDID:    Alice,
+			TheirDID: Bob,
+		}, WithLocalizedComment("en", map[string]string{"es": "Hola"}))
+		require.NoError(t, err)
+	})
+
 	t.Run("Success v3", func(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 
@@ -208,6 +232,35 @@ func TestClient_SendRequest(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Localized comment v3", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				text, locale := msg.(service.DIDCommMsgMap).Localized("comment", "en")
+				require.Equal(t, "Hello", text)
+				require.Equal(t, "en", locale)
+
+				text, locale = msg.(service.DIDCommMsgMap).Localized("comment", "es")
+				require.Equal(t, "Hola", text)
+				require.Equal(t, "es", locale)
+
+				return expectedPiid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.SendRequest(&RequestCredential{Comment: "Hello"}, &connection.Record{
+			MyDID:          Alice,
+			TheirDID:       Bob,
+			DIDCommVersion: service.V2,
+		}, WithLocalizedComment("en", map[string]string{"es": "Hola"}))
+		require.NoError(t, err)
+	})
+
 	t.Run("Success v3", func(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 
@@ -43,6 +43,9 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config(connID string) (*mediator.Config, error)
+
+	// KeylistQuery returns the recipient DIDs registered with the router (Coordinate Mediation 2.0).
+	KeylistQuery(connID string, limit, offset int) (*mediator.KeylistV2Body, error)
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -109,3 +112,14 @@ func (c *Client) GetConfig(connID string) (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// KeylistQuery returns the recipient DIDs the agent registered with the router on the other end of a
+// DIDComm V2 connection. A limit of zero returns the whole keylist.
+func (c *Client) KeylistQuery(connID string, limit, offset int) (*mediator.KeylistV2Body, error) {
+	keylist, err := c.routeSvc.KeylistQuery(connID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("router keylist query : %w", err)
+	}
+
+	return keylist, nil
+}
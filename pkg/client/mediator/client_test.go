@@ -170,3 +170,33 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestClient_KeylistQuery(t *testing.T) {
+	t.Run("returns keylist", func(t *testing.T) {
+		keylist := &mediator.KeylistV2Body{
+			Keys: []mediator.KeylistKeyV2{{RecipientDID: "did:key:z6MkTest"}},
+		}
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				Keylist: keylist,
+			},
+		})
+		require.NoError(t, err)
+		result, err := c.KeylistQuery("conn", 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, keylist, result)
+	})
+	t.Run("wraps keylist query error", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryErr: expected,
+			},
+		})
+		require.NoError(t, err)
+		_, err = c.KeylistQuery("conn", 0, 0)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+		require.Contains(t, err.Error(), "router keylist query")
+	})
+}
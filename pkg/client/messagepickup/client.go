@@ -33,6 +33,14 @@ type protocolService interface {
 	BatchPickup(connectionID string, size int) (int, error)
 
 	Noop(connectionID string) error
+
+	StatusRequestV2(connectionID string) (*messagepickup.StatusV2, error)
+
+	DeliveryRequest(connectionID string, limit int) ([]string, error)
+
+	MessagesReceived(connectionID string, messageIDs []string) error
+
+	LiveDeliveryChange(connectionID string, liveDelivery bool) error
 }
 
 // New return new instance of messagepickup client.
@@ -78,3 +86,45 @@ func (r *Client) BatchPickup(connectionID string, size int) (int, error) {
 func (r *Client) Noop(connectionID string) error {
 	return r.messagepickupSvc.Noop(connectionID)
 }
+
+// StatusRequestV2 requests a Pickup 2.0 status message.
+func (r *Client) StatusRequestV2(connectionID string) (*messagepickup.StatusV2, error) {
+	sts, err := r.messagepickupSvc.StatusRequestV2(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("message pickup client - status request v2: %w", err)
+	}
+
+	return sts, nil
+}
+
+// DeliveryRequest requests delivery of up to limit waiting messages (Pickup 2.0) and returns the IDs of the
+// messages that were processed. The messages remain queued on the mediator until acknowledged with
+// MessagesReceived.
+func (r *Client) DeliveryRequest(connectionID string, limit int) ([]string, error) {
+	ids, err := r.messagepickupSvc.DeliveryRequest(connectionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("message pickup client - delivery request: %w", err)
+	}
+
+	return ids, nil
+}
+
+// MessagesReceived acknowledges delivered messages so that the mediator deletes them (Pickup 2.0).
+func (r *Client) MessagesReceived(connectionID string, messageIDs []string) error {
+	err := r.messagepickupSvc.MessagesReceived(connectionID, messageIDs)
+	if err != nil {
+		return fmt.Errorf("message pickup client - messages received: %w", err)
+	}
+
+	return nil
+}
+
+// LiveDeliveryChange turns live delivery on or off for the connection (Pickup 2.0).
+func (r *Client) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	err := r.messagepickupSvc.LiveDeliveryChange(connectionID, liveDelivery)
+	if err != nil {
+		return fmt.Errorf("message pickup client - live delivery change: %w", err)
+	}
+
+	return nil
+}
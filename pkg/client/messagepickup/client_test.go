@@ -113,3 +113,53 @@ func TestNoop(t *testing.T) {
 		require.Contains(t, err.Error(), "service error")
 	})
 }
+
+func TestPickupV2(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				DeliveryRequestFunc: func(connectionID string, limit int) ([]string, error) {
+					require.Equal(t, 10, limit)
+
+					return []string{"msg-1"}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		sts, err := client.StatusRequestV2("connID")
+		require.NoError(t, err)
+		require.NotNil(t, sts)
+
+		ids, err := client.DeliveryRequest("connID", 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"msg-1"}, ids)
+
+		require.NoError(t, client.MessagesReceived("connID", ids))
+		require.NoError(t, client.LiveDeliveryChange("connID", true))
+	})
+
+	t.Run("service errors", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				StatusRequestV2Err:    errors.New("status error"),
+				DeliveryRequestErr:    errors.New("delivery error"),
+				MessagesReceivedErr:   errors.New("received error"),
+				LiveDeliveryChangeErr: errors.New("live error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.StatusRequestV2("connID")
+		require.EqualError(t, err, "message pickup client - status request v2: status error")
+
+		_, err = client.DeliveryRequest("connID", 10)
+		require.EqualError(t, err, "message pickup client - delivery request: delivery error")
+
+		err = client.MessagesReceived("connID", []string{"msg-1"})
+		require.EqualError(t, err, "message pickup client - messages received: received error")
+
+		err = client.LiveDeliveryChange("connID", true)
+		require.EqualError(t, err, "message pickup client - live delivery change: live error")
+	})
+}
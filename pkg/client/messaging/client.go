@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -27,6 +28,7 @@ import (
 const (
 	// errors.
 	errMsgDestinationMissing = "missing message destination"
+	errMsgExpiryNotSupported = "message expiry is only supported for DIDComm V2 messages"
 )
 
 var logger = log.New("aries-framework/client/messaging")
@@ -75,6 +77,9 @@ type sendMsgOpts struct {
 
 	// context for await reply operation.
 	waitForResponseCtx context.Context
+
+	// Expiry of the message, the message expires_time is set from it.
+	expiresIn time.Duration
 }
 
 // SendMessageOpions is the options for choosing message destinations.
@@ -110,6 +115,14 @@ func WaitForResponse(ctx context.Context, responseType string) SendMessageOpions
 	}
 }
 
+// SendWithExpiry option to set the expires_time of a DIDComm V2 message, the message expires after expiresIn.
+// Expired messages are dropped by their recipient.
+func SendWithExpiry(expiresIn time.Duration) SendMessageOpions {
+	return func(opts *sendMsgOpts) {
+		opts.expiresIn = expiresIn
+	}
+}
+
 // messageDispatcher is message dispatch action which returns id of the message sent or error if it fails.
 type messageDispatcher func() error
 
@@ -173,6 +186,13 @@ func (c *Client) Send(msg json.RawMessage, opts ...SendMessageOpions) (json.RawM
 		return nil, err
 	}
 
+	if sendOpts.expiresIn > 0 {
+		err = setExpiry(didCommMsg, sendOpts.expiresIn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	switch {
 	case sendOpts.connectionID != "":
 		action, err = c.sendToConnection(didCommMsg, sendOpts.connectionID)
@@ -320,6 +340,24 @@ func waitForResponse(ctx context.Context, notificationCh chan NotificationPayloa
 	}
 }
 
+func setExpiry(msg service.DIDCommMsgMap, expiresIn time.Duration) error {
+	isV2, err := service.IsDIDCommV2(&msg)
+	if err != nil {
+		return err
+	}
+
+	if !isV2 {
+		return fmt.Errorf(errMsgExpiryNotSupported)
+	}
+
+	now := time.Now()
+
+	msg.SetCreatedTime(now)
+	msg.SetExpiresTime(now.Add(expiresIn))
+
+	return nil
+}
+
 func prepareMessage(msg json.RawMessage) (service.DIDCommMsgMap, error) {
 	didCommMsg, err := service.ParseDIDCommMsgMap(msg)
 	if err != nil {
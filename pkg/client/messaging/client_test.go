@@ -514,6 +514,29 @@ func TestCommand_Send(t *testing.T) { // nolint: gocognit, gocyclo
 			})
 		}
 	})
+
+	t.Run("Test send new message with expiry", func(t *testing.T) {
+		cmd, err := New(&protocol.MockProvider{
+			StoreProvider:              mem.NewProvider(),
+			ProtocolStateStoreProvider: mem.NewProvider(),
+		}, msghandler.NewMockMsgServiceProvider(), &mockNotifier{})
+		require.NoError(t, err)
+
+		dest := SendByDestination(&service.Destination{ServiceEndpoint: model.NewDIDCommV1Endpoint("url")})
+
+		_, err = cmd.Send(json.RawMessage(`{"id":"1","type":"type","body":{}}`), dest, SendWithExpiry(time.Minute))
+		require.NoError(t, err)
+
+		_, err = cmd.Send(json.RawMessage(`{"text":"sample"}`), dest, SendWithExpiry(time.Minute))
+		require.EqualError(t, err, errMsgExpiryNotSupported)
+
+		msg := service.DIDCommMsgMap{"id": "1", "type": "type"}
+		require.NoError(t, setExpiry(msg, time.Hour))
+		require.Equal(t, time.Hour, msg.ExpiresTime().Sub(msg.CreatedTime()))
+		require.WithinDuration(t, time.Now(), msg.CreatedTime(), time.Minute)
+
+		require.Error(t, setExpiry(service.DIDCommMsgMap{}, time.Hour))
+	})
 }
 
 func TestCommand_Reply(t *testing.T) {
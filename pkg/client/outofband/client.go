@@ -97,14 +97,6 @@ type message struct {
 	ReuseConnection    string
 }
 
-func (m *message) RouterConnection() string {
-	if len(m.RouterConnections) == 0 {
-		return ""
-	}
-
-	return m.RouterConnections[0]
-}
-
 // OobService defines the outofband service.
 type OobService interface {
 	service.Event
@@ -191,12 +183,20 @@ func (c *Client) CreateInvitation(services []interface{}, opts ...MessageOption)
 	}
 
 	if len(inv.Services) == 0 {
-		svc, err := c.didDocSvcFunc(msg.RouterConnection(), inv.Accept)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create a new inlined did doc service block : %w", err)
+		routerConnections := msg.RouterConnections
+		if len(routerConnections) == 0 {
+			routerConnections = []string{""}
 		}
 
-		inv.Services = []interface{}{svc}
+		// a service block per router, the invitee can reach us through any of them.
+		for _, routerConnID := range routerConnections {
+			svc, err := c.didDocSvcFunc(routerConnID, inv.Accept)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create a new inlined did doc service block : %w", err)
+			}
+
+			inv.Services = append(inv.Services, svc)
+		}
 	} else {
 		err := validateServices(inv.Services...)
 		if err != nil {
@@ -153,6 +153,28 @@ func TestCreateInvitation(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, expectedConn, uri)
 	})
+	t.Run("with several router connections", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+
+		c.didDocSvcFunc = func(conn string, accept []string) (*did.Service, error) {
+			return &did.Service{
+				ServiceEndpoint: commonmodel.NewDIDCommV1Endpoint(conn),
+				Accept:          accept,
+				Type:            vdr.DIDCommServiceType,
+			}, nil
+		}
+
+		inv, err := c.CreateInvitation(nil, WithRouterConnections("conn-1", "", "conn-2"))
+		require.NoError(t, err)
+		require.Len(t, inv.Services, 2)
+
+		for i, expected := range []string{"conn-1", "conn-2"} {
+			uri, err := inv.Services[i].(*did.Service).ServiceEndpoint.URI()
+			require.NoError(t, err)
+			require.Equal(t, expected, uri)
+		}
+	})
 	t.Run("WithGoal", func(t *testing.T) {
 		c, err := New(withTestProvider())
 		require.NoError(t, err)
@@ -113,24 +113,26 @@ func (c *Client) Actions() ([]Action, error) {
 // SendRequestPresentation is used by the Verifier to send a request presentation.
 // It returns the threadID of the new instance of the protocol.
 func (c *Client) SendRequestPresentation(
-	params *RequestPresentation, connRec *connection.Record) (string, error) {
+	params *RequestPresentation, connRec *connection.Record, options ...SendOptions) (string, error) {
 	if params == nil {
 		return "", errEmptyRequestPresentation
 	}
 
+	var msg service.DIDCommMsgMap
+
 	switch connRec.DIDCommVersion {
 	default:
 		fallthrough // use didcomm v1 + present-proof v2 by default, if the connection record doesn't indicate version.
 	case service.V1:
-		return c.service.HandleOutbound(service.NewDIDCommMsgMap(&RequestPresentationV2{
+		msg = service.NewDIDCommMsgMap(&RequestPresentationV2{
 			Type:                       presentproof.RequestPresentationMsgTypeV2,
 			Comment:                    params.Comment,
 			WillConfirm:                params.WillConfirm,
 			Formats:                    params.Formats,
 			RequestPresentationsAttach: decorator.GenericAttachmentsToV1(params.Attachments),
-		}), connRec.MyDID, connRec.TheirDID)
+		})
 	case service.V2:
-		return c.service.HandleOutbound(service.NewDIDCommMsgMap(&RequestPresentationV3{
+		msg = service.NewDIDCommMsgMap(&RequestPresentationV3{
 			Type: presentproof.RequestPresentationMsgTypeV3,
 			Body: presentproof.RequestPresentationV3Body{
 				GoalCode:    params.GoalCode,
@@ -138,8 +140,12 @@ func (c *Client) SendRequestPresentation(
 				WillConfirm: params.WillConfirm,
 			},
 			Attachments: decorator.GenericAttachmentsToV2(params.Attachments),
-		}), connRec.MyDID, connRec.TheirDID)
+		})
 	}
+
+	msg.Localize(connRec.DIDCommVersion, options...)
+
+	return c.service.HandleOutbound(msg, connRec.MyDID, connRec.TheirDID)
 }
 
 type addProof func(presentation *verifiable.Presentation) error
@@ -162,31 +168,37 @@ func (c *Client) DeclineRequestPresentation(piID, reason string) error {
 // SendProposePresentation is used by the Prover to send a propose presentation.
 // It returns the threadID of the new instance of the protocol.
 func (c *Client) SendProposePresentation(
-	params *ProposePresentation, connRec *connection.Record) (string, error) {
+	params *ProposePresentation, connRec *connection.Record, options ...SendOptions) (string, error) {
 	if params == nil {
 		return "", errEmptyProposePresentation
 	}
 
+	var msg service.DIDCommMsgMap
+
 	switch connRec.DIDCommVersion {
 	default:
 		fallthrough // use didcomm v1 + present-proof v2 by default, if the connection record doesn't indicate version.
 	case service.V1:
-		return c.service.HandleOutbound(service.NewDIDCommMsgMap(&ProposePresentationV2{
+		msg = service.NewDIDCommMsgMap(&ProposePresentationV2{
 			Type:            presentproof.ProposePresentationMsgTypeV2,
 			Comment:         params.Comment,
 			Formats:         params.Formats,
 			ProposalsAttach: decorator.GenericAttachmentsToV1(params.Attachments),
-		}), connRec.MyDID, connRec.TheirDID)
+		})
 	case service.V2:
-		return c.service.HandleOutbound(service.NewDIDCommMsgMap(&ProposePresentationV3{
+		msg = service.NewDIDCommMsgMap(&ProposePresentationV3{
 			Type: presentproof.ProposePresentationMsgTypeV3,
 			Body: presentproof.ProposePresentationV3Body{
 				GoalCode: params.GoalCode,
 				Comment:  params.Comment,
 			},
 			Attachments: decorator.GenericAttachmentsToV2(params.Attachments),
-		}), connRec.MyDID, connRec.TheirDID)
+		})
 	}
+
+	msg.Localize(connRec.DIDCommVersion, options...)
+
+	return c.service.HandleOutbound(msg, connRec.MyDID, connRec.TheirDID)
 }
 
 // AcceptProposePresentation is used when the Verifier is willing to accept the propose presentation.
@@ -276,6 +288,15 @@ func prepareRedirectProperties(redirect, status string) presentproof.Opt {
 	return presentproof.WithProperties(properties)
 }
 
+// SendOptions is custom option for sending request presentation and propose presentation messages.
+type SendOptions = service.SendOptions
+
+// WithLocalizedComment option to provide the locale of the comment and its translations keyed by locale,
+// the verifier or prover can read the comment in their own locale with service.DIDCommMsgMap.Localized.
+func WithLocalizedComment(locale string, translations map[string]string) SendOptions {
+	return service.WithLocalizedComment(locale, translations)
+}
+
 // declinePresentationOpts options for declining propose presentation and presentation.
 type declinePresentationOpts struct {
 	reason   error
@@ -77,6 +77,34 @@ func TestClient_SendRequestPresentation(t *testing.T) {
 		require.Equal(t, thid, result)
 	})
 
+	t.Run("Localized comment", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				text, locale := msg.(service.DIDCommMsgMap).Localized("comment", "es")
+				require.Equal(t, "Hola", text)
+				require.Equal(t, "es", locale)
+
+				return uuid.New().String(), nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		conn := connection.Record{
+			ConnectionID: uuid.New().String(),
+			MyDID:        Alice,
+			TheirDID:     Bob,
+		}
+
+		_, err = client.SendRequestPresentation(&RequestPresentation{Comment: "Hello"}, &conn,
+			WithLocalizedComment("en", map[string]string{"es": "Hola"}))
+		require.NoError(t, err)
+	})
+
 	t.Run("Empty Invitation Presentation", func(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 
@@ -227,6 +255,38 @@ func TestClient_SendProposePresentationV3(t *testing.T) {
 		require.Equal(t, thid, result)
 	})
 
+	t.Run("Localized comment", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				msgMap := msg.(service.DIDCommMsgMap)
+				require.Equal(t, "en", msgMap.Locale())
+
+				text, locale := msgMap.Localized("comment", "fr-FR")
+				require.Equal(t, "Bonjour", text)
+				require.Equal(t, "fr", locale)
+
+				return uuid.New().String(), nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		conn := connection.Record{
+			ConnectionID:   uuid.New().String(),
+			MyDID:          Alice,
+			TheirDID:       Bob,
+			DIDCommVersion: service.V2,
+		}
+
+		_, err = client.SendProposePresentation(&ProposePresentation{Comment: "Hello"}, &conn,
+			WithLocalizedComment("en", map[string]string{"fr": "Bonjour"}))
+		require.NoError(t, err)
+	})
+
 	t.Run("Empty Invitation Presentation", func(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	// DIDComm service
+	service.DIDComm
+
+	Ping(connectionID string, params *trustping.PingParams) (*trustping.PingResult, error)
+}
+
+// Client enables access to the trust ping api.
+type Client struct {
+	service.Event
+	trustpingSvc protocolService
+}
+
+// PingOption configures a ping.
+type PingOption func(params *trustping.PingParams)
+
+// WithTimeout sets the time to wait for the ping response, trustping.DefaultTimeout by default.
+func WithTimeout(timeout time.Duration) PingOption {
+	return func(params *trustping.PingParams) {
+		params.Timeout = timeout
+	}
+}
+
+// WithComment sets a comment sent along with the ping (Trust Ping 1.0 only).
+func WithComment(comment string) PingOption {
+	return func(params *trustping.PingParams) {
+		params.Comment = comment
+	}
+}
+
+// WithResponseRequested sets whether the other party should respond to the ping, true by default.
+// Ping returns as soon as the ping is sent if no response is requested.
+func WithResponseRequested(responseRequested bool) PingOption {
+	return func(params *trustping.PingParams) {
+		params.ResponseRequested = responseRequested
+	}
+}
+
+// New returns new instance of trust ping client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(trustping.TrustPing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trust ping service: %w", err)
+	}
+
+	trustpingSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to trust ping service failed")
+	}
+
+	return &Client{
+		Event:        trustpingSvc,
+		trustpingSvc: trustpingSvc,
+	}, nil
+}
+
+// Ping sends a trust ping over the given connection and waits for the response. The round-trip latency
+// is returned and recorded on the connection record.
+func (c *Client) Ping(connectionID string, opts ...PingOption) (*trustping.PingResult, error) {
+	params := &trustping.PingParams{
+		ResponseRequested: true,
+		Timeout:           trustping.DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	result, err := c.trustpingSvc.Ping(connectionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("trust ping client - ping: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to trust ping service failed")
+	})
+}
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("ping with default options", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(connectionID string, params *trustping.PingParams) (*trustping.PingResult, error) {
+					require.Equal(t, "connID", connectionID)
+					require.True(t, params.ResponseRequested)
+					require.Equal(t, trustping.DefaultTimeout, params.Timeout)
+					require.Empty(t, params.Comment)
+
+					return &trustping.PingResult{ConnectionID: connectionID, Responded: true, Latency: time.Millisecond}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := client.Ping("connID")
+		require.NoError(t, err)
+		require.True(t, result.Responded)
+		require.Equal(t, time.Millisecond, result.Latency)
+	})
+
+	t.Run("ping with options", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(connectionID string, params *trustping.PingParams) (*trustping.PingResult, error) {
+					require.False(t, params.ResponseRequested)
+					require.Equal(t, time.Second, params.Timeout)
+					require.Equal(t, "hello", params.Comment)
+
+					return &trustping.PingResult{ConnectionID: connectionID}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := client.Ping("connID",
+			WithResponseRequested(false), WithTimeout(time.Second), WithComment("hello"))
+		require.NoError(t, err)
+		require.False(t, result.Responded)
+	})
+
+	t.Run("ping error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Ping("connID")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "trust ping client - ping: service error")
+	})
+}
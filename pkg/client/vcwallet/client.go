@@ -9,7 +9,6 @@ package vcwallet
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/piprate/json-gold/ld"
 
@@ -152,10 +151,11 @@ func (c *Client) Close() bool {
 }
 
 // Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// The wallet contents and the keys created or imported by the wallet are encrypted with a key derived from the
+// passphrase into a locked wallet document.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- passphrase: passphrase to be used to lock the wallet before exporting.
 //
 //	Returns exported locked wallet.
 //
@@ -165,18 +165,23 @@ func (c *Client) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Client) Export(passphrase string) (json.RawMessage, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.Export(auth, passphrase)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
 //
 //	Args:
+//		- passphrase: passphrase used while exporting the wallet.
 //		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -186,9 +191,13 @@ func (c *Client) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Client) Import(passphrase string, contents json.RawMessage) error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	return c.wallet.Import(auth, passphrase, contents)
 }
 
 // Add adds given data model to wallet contents store.
@@ -60,7 +60,6 @@ const (
 	sampleRemoteKMSAuth     = "sample-auth-token"
 	sampleKeyServerURL      = "sample/keyserver/test"
 	sampleUserID            = "sample-user01"
-	toBeImplementedErr      = "to be implemented"
 	sampleClientErr         = "sample client err"
 	sampleDIDKey            = "did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5"
 	sampleDIDKey2           = "did:key:z6MkwFKUCsf8wvn6eSSu1WFAKatN1yexiDM7bf7pZLSFjdz6"
@@ -454,33 +453,53 @@ func TestClient_OpenClose(t *testing.T) {
 	})
 }
 
-func TestClient_Export(t *testing.T) {
+func TestClient_ExportImport(t *testing.T) {
+	const exportPassphrase = "export passphrase"
+
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
 	vcWalletClient, err := New(sampleUserID, mockctx)
 	require.NotEmpty(t, vcWalletClient)
 	require.NoError(t, err)
 
-	result, err := vcWalletClient.Export("")
+	// wallet locked
+	result, err := vcWalletClient.Export(exportPassphrase)
 	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+	require.True(t, errors.Is(err, ErrWalletLocked))
 
-func TestClient_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	err = vcWalletClient.Import(exportPassphrase, nil)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+
+	require.NoError(t, vcWalletClient.Open(wallet.WithUnlockByPassphrase(samplePassPhrase)))
+
+	err = vcWalletClient.Add(wallet.Metadata, testdata.SampleWalletContentMetadata)
 	require.NoError(t, err)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, vcWalletClient)
+	result, err = vcWalletClient.Export(exportPassphrase)
+	require.NoError(t, err)
+	require.NotEmpty(t, result)
+	require.True(t, vcWalletClient.Close())
+
+	// import into a fresh profile.
+	mockctx = newMockProvider(t)
+	err = CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWalletClient, err = New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	err = vcWalletClient.Import("", nil)
+	defer vcWalletClient.Close()
+
+	err = vcWalletClient.Import(exportPassphrase+"wrong", result)
 	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+
+	require.NoError(t, vcWalletClient.Import(exportPassphrase, result))
+
+	content, err := vcWalletClient.Get(wallet.Metadata, "did:example:123456789abcdefghi")
+	require.NoError(t, err)
+	require.JSONEq(t, string(testdata.SampleWalletContentMetadata), string(content))
 }
 
 func TestClient_Add(t *testing.T) {
@@ -10,8 +10,10 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
@@ -19,6 +21,8 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/markcryptohash/aries-framework-go/pkg/internal/logutil"
 	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local"
 )
 
 var logger = log.New("aries-framework/command/kms")
@@ -31,6 +35,22 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// ListKeysError is for failures while listing keys.
+	ListKeysError
+	// GetKeyInfoError is for failures while getting key info.
+	GetKeyInfoError
+	// SetKeyMetadataError is for failures while setting key metadata.
+	SetKeyMetadataError
+	// DisableKeyError is for failures while disabling key.
+	DisableKeyError
+	// DeleteKeyError is for failures while deleting key.
+	DeleteKeyError
+	// RewrapKeysError is for failures while re-wrapping keys.
+	RewrapKeysError
+	// BackupKeysError is for failures while backing up keys.
+	BackupKeysError
+	// RestoreKeysError is for failures while restoring keys.
+	RestoreKeysError
 )
 
 // constants for KMS commands.
@@ -39,12 +59,26 @@ const (
 	CommandName = "kms"
 
 	// command methods.
-	CreateKeySetCommandMethod = "CreateKeySet"
-	ImportKeyCommandMethod    = "ImportKey"
+	CreateKeySetCommandMethod   = "CreateKeySet"
+	ImportKeyCommandMethod      = "ImportKey"
+	ListKeysCommandMethod       = "ListKeys"
+	GetKeyInfoCommandMethod     = "GetKeyInfo"
+	SetKeyMetadataCommandMethod = "SetKeyMetadata"
+	DisableKeyCommandMethod     = "DisableKey"
+	DeleteKeyCommandMethod      = "DeleteKey"
+	RewrapKeysCommandMethod     = "RewrapKeys"
+	BackupKeysCommandMethod     = "BackupKeys"
+	RestoreKeysCommandMethod    = "RestoreKeys"
 
 	// error messages.
-	errEmptyKeyType = "key type is mandatory"
-	errEmptyKeyID   = "key id is mandatory"
+	errEmptyKeyType           = "key type is mandatory"
+	errEmptyKeyID             = "key id is mandatory"
+	errKeyLifecycleNotSupport = "kms does not support key lifecycle management"
+	errKeyRewrapNotSupport    = "kms does not support key re-wrapping"
+	errNoMasterKeyFile        = "kms master key file is not configured"
+	errKeyBackupNotSupport    = "kms does not support key backup"
+	errEmptyPassphrase        = "passphrase is mandatory"
+	errEmptyBackup            = "backup is mandatory"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -57,17 +91,38 @@ type Command struct {
 	ctx       provider
 	importKey func(privKey interface{}, kt kms.KeyType,
 		opts ...kms.PrivateKeyOpts) (string, interface{}, error) // needed for unit test
+	masterKeyPath string
+	masterLock    secretlock.Service
+	rewrapMutex   sync.Mutex
+}
+
+// Option configures the kms command.
+type Option func(o *Command)
+
+// WithMasterKeyFile enables RewrapKeys, which rotates the master key stored in path, protected by masterLock if not
+// nil. path must be the master key file the secret lock of the KMS was created from.
+func WithMasterKeyFile(path string, masterLock secretlock.Service) Option {
+	return func(o *Command) {
+		o.masterKeyPath = path
+		o.masterLock = masterLock
+	}
 }
 
 // New returns new kms command instance.
-func New(p provider) *Command {
-	return &Command{
+func New(p provider, opts ...Option) *Command {
+	o := &Command{
 		ctx: p,
 		importKey: func(privKey interface{}, kt kms.KeyType,
 			opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
 			return p.KMS().ImportPrivateKey(privKey, kt, opts...)
 		},
 	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -75,6 +130,14 @@ func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateKeySetCommandMethod, o.CreateKeySet),
 		cmdutil.NewCommandHandler(CommandName, ImportKeyCommandMethod, o.ImportKey),
+		cmdutil.NewCommandHandler(CommandName, ListKeysCommandMethod, o.ListKeys),
+		cmdutil.NewCommandHandler(CommandName, GetKeyInfoCommandMethod, o.GetKeyInfo),
+		cmdutil.NewCommandHandler(CommandName, SetKeyMetadataCommandMethod, o.SetKeyMetadata),
+		cmdutil.NewCommandHandler(CommandName, DisableKeyCommandMethod, o.DisableKey),
+		cmdutil.NewCommandHandler(CommandName, DeleteKeyCommandMethod, o.DeleteKey),
+		cmdutil.NewCommandHandler(CommandName, RewrapKeysCommandMethod, o.RewrapKeys),
+		cmdutil.NewCommandHandler(CommandName, BackupKeysCommandMethod, o.BackupKeys),
+		cmdutil.NewCommandHandler(CommandName, RestoreKeysCommandMethod, o.RestoreKeys),
 	}
 }
 
@@ -160,3 +223,279 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// ListKeys lists the keys of the KMS with their type, creation time and metadata.
+func (o *Command) ListKeys(rw io.Writer, _ io.Reader) command.Error {
+	keyManager, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewExecuteError(ListKeysError, err)
+	}
+
+	keys, err := keyManager.ListKeys()
+	if err != nil {
+		logutil.LogError(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewExecuteError(ListKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, &ListKeysResponse{Keys: keys}, logger)
+
+	logutil.LogDebug(logger, CommandName, ListKeysCommandMethod, "success")
+
+	return nil
+}
+
+// GetKeyInfo returns the type, creation time and metadata of a key.
+func (o *Command) GetKeyInfo(rw io.Writer, req io.Reader) command.Error {
+	var request KeyIDRequest
+
+	cmdErr := decodeKeyIDRequest(req, &request, GetKeyInfoCommandMethod)
+	if cmdErr != nil {
+		return cmdErr
+	}
+
+	keyManager, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetKeyInfoCommandMethod, err.Error())
+		return command.NewExecuteError(GetKeyInfoError, err)
+	}
+
+	info, err := keyManager.GetKeyInfo(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetKeyInfoCommandMethod, err.Error())
+		return command.NewExecuteError(GetKeyInfoError, err)
+	}
+
+	command.WriteNillableResponse(rw, &GetKeyInfoResponse{KeyInfo: info}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetKeyInfoCommandMethod, "success")
+
+	return nil
+}
+
+// SetKeyMetadata replaces the metadata tags of a key.
+func (o *Command) SetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	var request SetKeyMetadataRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetKeyMetadataCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, SetKeyMetadataCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	keyManager, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, SetKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(SetKeyMetadataError, err)
+	}
+
+	err = keyManager.SetKeyMetadata(request.KeyID, request.Metadata)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SetKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(SetKeyMetadataError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, SetKeyMetadataCommandMethod, "success")
+
+	return nil
+}
+
+// DisableKey disables a key, the key is kept but can't be used anymore.
+func (o *Command) DisableKey(rw io.Writer, req io.Reader) command.Error {
+	var request KeyIDRequest
+
+	cmdErr := decodeKeyIDRequest(req, &request, DisableKeyCommandMethod)
+	if cmdErr != nil {
+		return cmdErr
+	}
+
+	keyManager, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, DisableKeyCommandMethod, err.Error())
+		return command.NewExecuteError(DisableKeyError, err)
+	}
+
+	err = keyManager.DisableKey(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DisableKeyCommandMethod, err.Error())
+		return command.NewExecuteError(DisableKeyError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, DisableKeyCommandMethod, "success")
+
+	return nil
+}
+
+// DeleteKey permanently deletes a key.
+func (o *Command) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	var request KeyIDRequest
+
+	cmdErr := decodeKeyIDRequest(req, &request, DeleteKeyCommandMethod)
+	if cmdErr != nil {
+		return cmdErr
+	}
+
+	keyManager, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeleteKeyCommandMethod, err.Error())
+		return command.NewExecuteError(DeleteKeyError, err)
+	}
+
+	err = keyManager.DeleteKey(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeleteKeyCommandMethod, err.Error())
+		return command.NewExecuteError(DeleteKeyError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, DeleteKeyCommandMethod, "success")
+
+	return nil
+}
+
+// RewrapKeys rotates the configured master key file (see WithMasterKeyFile): the stored keys are re-encrypted with
+// a new master key, which replaces the master key file once all the keys are re-encrypted.
+func (o *Command) RewrapKeys(rw io.Writer, req io.Reader) command.Error {
+	var request RewrapKeysRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RewrapKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if o.masterKeyPath == "" {
+		logutil.LogError(logger, CommandName, RewrapKeysCommandMethod, errNoMasterKeyFile)
+		return command.NewExecuteError(RewrapKeysError, errors.New(errNoMasterKeyFile))
+	}
+
+	keyManager, ok := o.ctx.KMS().(kms.KeyRewrapper)
+	if !ok {
+		logutil.LogError(logger, CommandName, RewrapKeysCommandMethod, errKeyRewrapNotSupport)
+		return command.NewExecuteError(RewrapKeysError, errors.New(errKeyRewrapNotSupport))
+	}
+
+	o.rewrapMutex.Lock()
+	defer o.rewrapMutex.Unlock()
+
+	err = local.RotateMasterKeyFile(o.masterKeyPath, o.masterLock, func(newLock secretlock.Service) error {
+		return keyManager.RewrapKeys(newLock, request.KeyIDs...)
+	})
+	if err != nil {
+		logutil.LogError(logger, CommandName, RewrapKeysCommandMethod, err.Error())
+		return command.NewExecuteError(RewrapKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RewrapKeysCommandMethod, "success")
+
+	return nil
+}
+
+// BackupKeys exports the keys of the KMS in an archive encrypted with a key derived from a passphrase.
+func (o *Command) BackupKeys(rw io.Writer, req io.Reader) command.Error {
+	var request BackupKeysRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, BackupKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.Passphrase == "" {
+		logutil.LogDebug(logger, CommandName, BackupKeysCommandMethod, errEmptyPassphrase)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyPassphrase))
+	}
+
+	keyManager, ok := o.ctx.KMS().(kms.KeyBackupManager)
+	if !ok {
+		logutil.LogError(logger, CommandName, BackupKeysCommandMethod, errKeyBackupNotSupport)
+		return command.NewExecuteError(BackupKeysError, errors.New(errKeyBackupNotSupport))
+	}
+
+	backup, err := keyManager.Backup(request.Passphrase, request.KeyIDs...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, BackupKeysCommandMethod, err.Error())
+		return command.NewExecuteError(BackupKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, &BackupKeysResponse{Backup: backup}, logger)
+
+	logutil.LogDebug(logger, CommandName, BackupKeysCommandMethod, "success")
+
+	return nil
+}
+
+// RestoreKeys imports the keys of a backup created by BackupKeys, the keys keep their IDs.
+func (o *Command) RestoreKeys(rw io.Writer, req io.Reader) command.Error {
+	var request RestoreKeysRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RestoreKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if len(request.Backup) == 0 {
+		logutil.LogDebug(logger, CommandName, RestoreKeysCommandMethod, errEmptyBackup)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyBackup))
+	}
+
+	if request.Passphrase == "" {
+		logutil.LogDebug(logger, CommandName, RestoreKeysCommandMethod, errEmptyPassphrase)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyPassphrase))
+	}
+
+	keyManager, ok := o.ctx.KMS().(kms.KeyBackupManager)
+	if !ok {
+		logutil.LogError(logger, CommandName, RestoreKeysCommandMethod, errKeyBackupNotSupport)
+		return command.NewExecuteError(RestoreKeysError, errors.New(errKeyBackupNotSupport))
+	}
+
+	err = keyManager.Restore(request.Backup, request.Passphrase)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RestoreKeysCommandMethod, err.Error())
+		return command.NewExecuteError(RestoreKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RestoreKeysCommandMethod, "success")
+
+	return nil
+}
+
+func (o *Command) lifecycleManager() (kms.KeyLifecycleManager, error) {
+	keyManager, ok := o.ctx.KMS().(kms.KeyLifecycleManager)
+	if !ok {
+		return nil, errors.New(errKeyLifecycleNotSupport)
+	}
+
+	return keyManager, nil
+}
+
+func decodeKeyIDRequest(req io.Reader, request *KeyIDRequest, method string) command.Error {
+	err := json.NewDecoder(req).Decode(request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, method, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, method, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	return nil
+}
@@ -14,16 +14,23 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/markcryptohash/aries-framework-go/pkg/kms"
 	mockkms "github.com/markcryptohash/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local/masterlock"
 )
 
 func TestNew(t *testing.T) {
@@ -34,7 +41,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 2, len(handlers))
+		require.Equal(t, 10, len(handlers))
 	})
 
 	t.Run("test new command - error from import key", func(t *testing.T) {
@@ -275,3 +282,304 @@ func TestImportKey(t *testing.T) {
 		require.Contains(t, err.Error(), "failed request decode")
 	})
 }
+
+func TestKeyLifecycle(t *testing.T) {
+	keyInfo := &kms.KeyInfo{ID: "k1", Type: kms.ED25519Type, Metadata: map[string]string{"label": "a"}}
+
+	t.Run("test list keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListKeysValue: []*kms.KeyInfo{keyInfo}},
+		})
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.ListKeys(&getRW, nil)
+		require.NoError(t, cmdErr)
+
+		response := ListKeysResponse{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Equal(t, []*kms.KeyInfo{keyInfo}, response.Keys)
+	})
+
+	t.Run("test get key info - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetKeyInfoValue: keyInfo},
+		})
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetKeyInfo(&getRW, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.NoError(t, cmdErr)
+
+		response := GetKeyInfoResponse{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Equal(t, keyInfo, response.KeyInfo)
+	})
+
+	t.Run("test set key metadata, disable and delete key - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SetKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"k1","metadata":{"label":"a"}}`)))
+		require.NoError(t, cmd.DisableKey(&b, bytes.NewBufferString(`{"keyID":"k1"}`)))
+		require.NoError(t, cmd.DeleteKey(&b, bytes.NewBufferString(`{"keyID":"k1"}`)))
+	})
+
+	t.Run("test key lifecycle - KMS errors", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{
+				ListKeysErr:       fmt.Errorf("list error"),
+				GetKeyInfoErr:     fmt.Errorf("get error"),
+				SetKeyMetadataErr: fmt.Errorf("set error"),
+				DisableKeyErr:     fmt.Errorf("disable error"),
+				DeleteKeyErr:      fmt.Errorf("delete error"),
+			},
+		})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.ListKeys(&b, nil)
+		require.EqualError(t, cmdErr, "list error")
+		require.Equal(t, ListKeysError, cmdErr.Code())
+
+		cmdErr = cmd.GetKeyInfo(&b, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.EqualError(t, cmdErr, "get error")
+		require.Equal(t, GetKeyInfoError, cmdErr.Code())
+
+		cmdErr = cmd.SetKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.EqualError(t, cmdErr, "set error")
+		require.Equal(t, SetKeyMetadataError, cmdErr.Code())
+
+		cmdErr = cmd.DisableKey(&b, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.EqualError(t, cmdErr, "disable error")
+		require.Equal(t, DisableKeyError, cmdErr.Code())
+
+		cmdErr = cmd.DeleteKey(&b, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.EqualError(t, cmdErr, "delete error")
+		require.Equal(t, DeleteKeyError, cmdErr.Code())
+	})
+
+	t.Run("test key lifecycle - not supported by KMS", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: struct{ kms.KeyManager }{&mockkms.KeyManager{}},
+		})
+
+		var b bytes.Buffer
+
+		require.EqualError(t, cmd.ListKeys(&b, nil), errKeyLifecycleNotSupport)
+		require.EqualError(t, cmd.GetKeyInfo(&b, bytes.NewBufferString(`{"keyID":"k1"}`)), errKeyLifecycleNotSupport)
+		require.EqualError(t, cmd.SetKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"k1"}`)),
+			errKeyLifecycleNotSupport)
+		require.EqualError(t, cmd.DisableKey(&b, bytes.NewBufferString(`{"keyID":"k1"}`)), errKeyLifecycleNotSupport)
+		require.EqualError(t, cmd.DeleteKey(&b, bytes.NewBufferString(`{"keyID":"k1"}`)), errKeyLifecycleNotSupport)
+	})
+
+	t.Run("test key lifecycle - invalid requests", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		for _, fn := range []command.Exec{cmd.GetKeyInfo, cmd.SetKeyMetadata, cmd.DisableKey, cmd.DeleteKey} {
+			var b bytes.Buffer
+
+			cmdErr := fn(&b, bytes.NewBuffer(nil))
+			require.Error(t, cmdErr)
+			require.Contains(t, cmdErr.Error(), "failed request decode")
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+			cmdErr = fn(&b, bytes.NewBufferString(`{}`))
+			require.EqualError(t, cmdErr, errEmptyKeyID)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		}
+	})
+}
+
+func TestRewrapKeys(t *testing.T) {
+	newMasterKeyFile := func(t *testing.T, masterLock secretlock.Service) (string, []byte) {
+		t.Helper()
+
+		masterKeyPath := filepath.Join(t.TempDir(), "masterkey")
+		require.NoError(t, local.CreateMasterKeyFile(masterKeyPath, masterLock))
+
+		masterKey, err := os.ReadFile(masterKeyPath) // nolint: gosec
+		require.NoError(t, err)
+
+		return masterKeyPath, masterKey
+	}
+
+	t.Run("test rewrap keys - success", func(t *testing.T) {
+		masterLock, err := masterlock.New(masterlock.PBKDF2, "secret")
+		require.NoError(t, err)
+
+		masterKeyPath, oldMasterKey := newMasterKeyFile(t, masterLock)
+
+		var rewrapped []string
+
+		cmd := New(&mockprovider.Provider{KMSValue: &rewrapKeyManager{rewrap: func(newLock secretlock.Service,
+			keyIDs ...string) error {
+			rewrapped = keyIDs
+
+			return nil
+		}}}, WithMasterKeyFile(masterKeyPath, masterLock))
+
+		var b bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&b, bytes.NewBufferString(`{"keyIDs":["k1"]}`))
+		require.NoError(t, cmdErr)
+		require.Equal(t, []string{"k1"}, rewrapped)
+
+		newMasterKey, err := os.ReadFile(masterKeyPath) // nolint: gosec
+		require.NoError(t, err)
+		require.NotEqual(t, oldMasterKey, newMasterKey)
+
+		_, err = local.NewServiceFromPath(masterKeyPath, masterLock)
+		require.NoError(t, err)
+
+		_, err = os.Stat(masterKeyPath + ".new")
+		require.True(t, errors.Is(err, os.ErrNotExist))
+	})
+
+	t.Run("test rewrap keys - KMS error", func(t *testing.T) {
+		masterKeyPath, oldMasterKey := newMasterKeyFile(t, nil)
+
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{RewrapKeysErr: fmt.Errorf("rewrap error")},
+		}, WithMasterKeyFile(masterKeyPath, nil))
+
+		var b bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&b, bytes.NewBufferString(`{}`))
+		require.EqualError(t, cmdErr, "rewrap error")
+		require.Equal(t, RewrapKeysError, cmdErr.Code())
+
+		// the configured master key is kept until the keys are re-encrypted.
+		masterKey, err := os.ReadFile(masterKeyPath) // nolint: gosec
+		require.NoError(t, err)
+		require.Equal(t, oldMasterKey, masterKey)
+	})
+
+	t.Run("test rewrap keys - not supported by KMS", func(t *testing.T) {
+		masterKeyPath, _ := newMasterKeyFile(t, nil)
+
+		cmd := New(&mockprovider.Provider{
+			KMSValue: struct{ kms.KeyManager }{&mockkms.KeyManager{}},
+		}, WithMasterKeyFile(masterKeyPath, nil))
+
+		var b bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&b, bytes.NewBufferString(`{}`))
+		require.EqualError(t, cmdErr, errKeyRewrapNotSupport)
+		require.Equal(t, RewrapKeysError, cmdErr.Code())
+	})
+
+	t.Run("test rewrap keys - master key file not configured", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		var b bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&b, bytes.NewBufferString(`{}`))
+		require.EqualError(t, cmdErr, errNoMasterKeyFile)
+		require.Equal(t, RewrapKeysError, cmdErr.Code())
+	})
+
+	t.Run("test rewrap keys - invalid request", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.RewrapKeys(&b, bytes.NewBuffer(nil))
+		require.Contains(t, cmdErr.Error(), "failed request decode")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("test rewrap keys - invalid pending master key file", func(t *testing.T) {
+		masterKeyPath, _ := newMasterKeyFile(t, nil)
+		require.NoError(t, os.WriteFile(masterKeyPath+".new", []byte("invalid"), 0o600))
+
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}}, WithMasterKeyFile(masterKeyPath, nil))
+
+		var b bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RewrapKeysError, cmdErr.Code())
+	})
+}
+
+type rewrapKeyManager struct {
+	mockkms.KeyManager
+	rewrap func(newLock secretlock.Service, keyIDs ...string) error
+}
+
+func (r *rewrapKeyManager) RewrapKeys(newLock secretlock.Service, keyIDs ...string) error {
+	return r.rewrap(newLock, keyIDs...)
+}
+
+func TestBackupAndRestoreKeys(t *testing.T) {
+	t.Run("test backup and restore keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{BackupValue: []byte(`{"version":1}`)},
+		})
+
+		var b bytes.Buffer
+		cmdErr := cmd.BackupKeys(&b, bytes.NewBufferString(`{"passphrase":"secret","keyIDs":["k1"]}`))
+		require.NoError(t, cmdErr)
+
+		response := BackupKeysResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.JSONEq(t, `{"version":1}`, string(response.Backup))
+
+		cmdErr = cmd.RestoreKeys(&b, bytes.NewBufferString(`{"backup":{"version":1},"passphrase":"secret"}`))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("test backup and restore keys - KMS errors", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{
+				BackupErr:  fmt.Errorf("backup error"),
+				RestoreErr: fmt.Errorf("restore error"),
+			},
+		})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.BackupKeys(&b, bytes.NewBufferString(`{"passphrase":"secret"}`))
+		require.EqualError(t, cmdErr, "backup error")
+		require.Equal(t, BackupKeysError, cmdErr.Code())
+
+		cmdErr = cmd.RestoreKeys(&b, bytes.NewBufferString(`{"backup":{},"passphrase":"secret"}`))
+		require.EqualError(t, cmdErr, "restore error")
+		require.Equal(t, RestoreKeysError, cmdErr.Code())
+	})
+
+	t.Run("test backup and restore keys - not supported by KMS", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: struct{ kms.KeyManager }{&mockkms.KeyManager{}},
+		})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.BackupKeys(&b, bytes.NewBufferString(`{"passphrase":"secret"}`))
+		require.EqualError(t, cmdErr, errKeyBackupNotSupport)
+		require.Equal(t, BackupKeysError, cmdErr.Code())
+
+		cmdErr = cmd.RestoreKeys(&b, bytes.NewBufferString(`{"backup":{},"passphrase":"secret"}`))
+		require.EqualError(t, cmdErr, errKeyBackupNotSupport)
+		require.Equal(t, RestoreKeysError, cmdErr.Code())
+	})
+
+	t.Run("test backup and restore keys - invalid requests", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		var b bytes.Buffer
+
+		for _, fn := range []command.Exec{cmd.BackupKeys, cmd.RestoreKeys} {
+			cmdErr := fn(&b, bytes.NewBuffer(nil))
+			require.Contains(t, cmdErr.Error(), "failed request decode")
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		}
+
+		cmdErr := cmd.BackupKeys(&b, bytes.NewBufferString(`{}`))
+		require.EqualError(t, cmdErr, errEmptyPassphrase)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.RestoreKeys(&b, bytes.NewBufferString(`{"passphrase":"secret"}`))
+		require.EqualError(t, cmdErr, errEmptyBackup)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.RestoreKeys(&b, bytes.NewBufferString(`{"backup":{}}`))
+		require.EqualError(t, cmdErr, errEmptyPassphrase)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+}
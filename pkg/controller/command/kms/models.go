@@ -6,6 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package kms
 
+import (
+	"encoding/json"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
 // CreateKeySetRequest is model for createKeySey request.
 type CreateKeySetRequest struct {
 	KeyType string `json:"keyType,omitempty"`
@@ -30,3 +36,51 @@ type JSONWebKey struct {
 	Y   string `json:"y,omitempty"`
 	D   string `json:"d,omitempty"`
 }
+
+// ListKeysResponse for returning the keys of the KMS.
+type ListKeysResponse struct {
+	Keys []*kms.KeyInfo `json:"keys"`
+}
+
+// KeyIDRequest is model for the requests referring to a key by its ID.
+type KeyIDRequest struct {
+	KeyID string `json:"keyID"`
+}
+
+// GetKeyInfoResponse for returning the type, creation time and metadata of a key.
+type GetKeyInfoResponse struct {
+	KeyInfo *kms.KeyInfo `json:"keyInfo"`
+}
+
+// SetKeyMetadataRequest is model for setKeyMetadata request.
+type SetKeyMetadataRequest struct {
+	KeyID    string            `json:"keyID"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// RewrapKeysRequest is model for rewrapKeys request.
+type RewrapKeysRequest struct {
+	// KeyIDs of the keys the KMS store doesn't list, re-wrapped along with the stored keys.
+	KeyIDs []string `json:"keyIDs,omitempty"`
+}
+
+// BackupKeysRequest is model for backupKeys request.
+type BackupKeysRequest struct {
+	// Passphrase the backup key is derived from.
+	Passphrase string `json:"passphrase"`
+	// KeyIDs of the keys stored before the KMS recorded key information, exported along with the listed keys.
+	KeyIDs []string `json:"keyIDs,omitempty"`
+}
+
+// BackupKeysResponse for returning the encrypted backup of the keys.
+type BackupKeysResponse struct {
+	Backup json.RawMessage `json:"backup"`
+}
+
+// RestoreKeysRequest is model for restoreKeys request.
+type RestoreKeysRequest struct {
+	// Backup returned by backupKeys.
+	Backup json.RawMessage `json:"backup"`
+	// Passphrase given to backupKeys.
+	Passphrase string `json:"passphrase"`
+}
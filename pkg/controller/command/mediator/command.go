@@ -63,6 +63,21 @@ const (
 
 	// ReconnectAllError is typically a code for mediator reconnectAll errors.
 	ReconnectAllError
+
+	// PickupMissingConnIDCode for connection ID validation error in Pickup 2.0 commands.
+	PickupMissingConnIDCode
+
+	// StatusV2RequestErrorCode for Pickup 2.0 status request error.
+	StatusV2RequestErrorCode
+
+	// DeliveryRequestErrorCode for Pickup 2.0 delivery request error.
+	DeliveryRequestErrorCode
+
+	// MessagesReceivedErrorCode for Pickup 2.0 messages received error.
+	MessagesReceivedErrorCode
+
+	// LiveDeliveryChangeErrorCode for Pickup 2.0 live delivery change error.
+	LiveDeliveryChangeErrorCode
 )
 
 // constant for the mediator controller.
@@ -79,6 +94,11 @@ const (
 	BatchPickupCommandMethod    = "BatchPickup"
 	ReconnectAllCommandMethod   = "ReconnectAll"
 
+	StatusV2CommandMethod           = "StatusV2"
+	DeliveryRequestCommandMethod    = "DeliveryRequest"
+	MessagesReceivedCommandMethod   = "MessagesReceived"
+	LiveDeliveryChangeCommandMethod = "LiveDeliveryChange"
+
 	// log constants.
 	connectionID  = "connectionID"
 	successString = "success"
@@ -151,6 +171,10 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, ReconnectAllCommandMethod, o.ReconnectAll),
 		cmdutil.NewCommandHandler(CommandName, StatusCommandMethod, o.Status),
 		cmdutil.NewCommandHandler(CommandName, BatchPickupCommandMethod, o.BatchPickup),
+		cmdutil.NewCommandHandler(CommandName, StatusV2CommandMethod, o.StatusV2),
+		cmdutil.NewCommandHandler(CommandName, DeliveryRequestCommandMethod, o.DeliveryRequest),
+		cmdutil.NewCommandHandler(CommandName, MessagesReceivedCommandMethod, o.MessagesReceived),
+		cmdutil.NewCommandHandler(CommandName, LiveDeliveryChangeCommandMethod, o.LiveDeliveryChange),
 	}
 }
 
@@ -382,3 +406,128 @@ func (o *Command) BatchPickup(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// StatusV2 returns details about pending messages for given connection using Pickup 2.0.
+func (o *Command) StatusV2(rw io.Writer, req io.Reader) command.Error {
+	var request StatusRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, StatusV2CommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, StatusV2CommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(PickupMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	status, err := o.messageClient.StatusRequestV2(request.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, StatusV2CommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(StatusV2RequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &StatusV2Response{&status.Body}, logger)
+
+	logutil.LogDebug(logger, CommandName, StatusV2CommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// DeliveryRequest dispatches up to limit pending messages for given connection using Pickup 2.0.
+// Dispatched messages remain on the mediator until acknowledged through MessagesReceived.
+func (o *Command) DeliveryRequest(rw io.Writer, req io.Reader) command.Error {
+	var request DeliveryRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeliveryRequestCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, DeliveryRequestCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(PickupMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	ids, err := o.messageClient.DeliveryRequest(request.ConnectionID, request.Limit)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeliveryRequestCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(DeliveryRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &DeliveryResponse{MessageIDs: ids}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeliveryRequestCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// MessagesReceived acknowledges delivered messages for given connection, the mediator deletes them.
+func (o *Command) MessagesReceived(rw io.Writer, req io.Reader) command.Error {
+	var request MessagesReceivedRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, MessagesReceivedCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, MessagesReceivedCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(PickupMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	err = o.messageClient.MessagesReceived(request.ConnectionID, request.MessageIDs)
+	if err != nil {
+		logutil.LogError(logger, CommandName, MessagesReceivedCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(MessagesReceivedErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, MessagesReceivedCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// LiveDeliveryChange turns Pickup 2.0 live delivery on or off for given connection.
+func (o *Command) LiveDeliveryChange(rw io.Writer, req io.Reader) command.Error {
+	var request LiveDeliveryChangeRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, LiveDeliveryChangeCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, LiveDeliveryChangeCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(PickupMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	err = o.messageClient.LiveDeliveryChange(request.ConnectionID, request.LiveDelivery)
+	if err != nil {
+		logutil.LogError(logger, CommandName, LiveDeliveryChangeCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(LiveDeliveryChangeErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, LiveDeliveryChangeCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
@@ -39,7 +39,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 7, len(handlers))
+		require.Equal(t, 11, len(handlers))
 	})
 
 	t.Run("test new command - client creation fail", func(t *testing.T) {
@@ -600,6 +600,113 @@ func TestCommand_ReconnectAll(t *testing.T) {
 	})
 }
 
+func TestCommand_PickupV2(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newMockProvider(map[string]interface{}{
+			messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{
+				StatusRequestV2Func: func(connectionID string) (*messagepickupSvc.StatusV2, error) {
+					return &messagepickupSvc.StatusV2{Body: messagepickupSvc.StatusV2Body{MessageCount: 2}}, nil
+				},
+				DeliveryRequestFunc: func(connectionID string, limit int) ([]string, error) {
+					require.Equal(t, 10, limit)
+
+					return []string{"msg-1", "msg-2"}, nil
+				},
+				MessagesReceivedFunc: func(connectionID string, messageIDs []string) error {
+					require.Equal(t, []string{"msg-1", "msg-2"}, messageIDs)
+
+					return nil
+				},
+				LiveDeliveryChangeFunc: func(connectionID string, liveDelivery bool) error {
+					require.True(t, liveDelivery)
+
+					return nil
+				},
+			},
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+			oobsvc.Name:           &mockoob.MockOobService{},
+		}), false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.StatusV2(&b, bytes.NewBufferString(sampleConnRequest)))
+
+		status := StatusV2Response{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&status))
+		require.Equal(t, 2, status.MessageCount)
+
+		b.Reset()
+		require.NoError(t, cmd.DeliveryRequest(&b,
+			bytes.NewBufferString(`{"connectionID":"123-abc", "limit": 10}`)))
+
+		delivery := DeliveryResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&delivery))
+		require.Equal(t, []string{"msg-1", "msg-2"}, delivery.MessageIDs)
+
+		b.Reset()
+		require.NoError(t, cmd.MessagesReceived(&b,
+			bytes.NewBufferString(`{"connectionID":"123-abc", "message_id_list": ["msg-1", "msg-2"]}`)))
+
+		b.Reset()
+		require.NoError(t, cmd.LiveDeliveryChange(&b,
+			bytes.NewBufferString(`{"connectionID":"123-abc", "live_delivery": true}`)))
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		cmd, err := New(newMockProvider(nil), false)
+		require.NoError(t, err)
+
+		for _, fn := range []func(*bytes.Buffer, *bytes.Buffer) error{
+			func(w, r *bytes.Buffer) error { return cmd.StatusV2(w, r) },
+			func(w, r *bytes.Buffer) error { return cmd.DeliveryRequest(w, r) },
+			func(w, r *bytes.Buffer) error { return cmd.MessagesReceived(w, r) },
+			func(w, r *bytes.Buffer) error { return cmd.LiveDeliveryChange(w, r) },
+		} {
+			var b bytes.Buffer
+
+			err = fn(&b, bytes.NewBufferString("--"))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "request decode")
+
+			err = fn(&b, bytes.NewBufferString(sampleEmptyConnectionRequest))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "connectionID is mandatory")
+		}
+	})
+
+	t.Run("execute errors", func(t *testing.T) {
+		cmd, err := New(newMockProvider(map[string]interface{}{
+			messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{
+				StatusRequestV2Err:    errors.New(sampleErr),
+				DeliveryRequestErr:    errors.New(sampleErr),
+				MessagesReceivedErr:   errors.New(sampleErr),
+				LiveDeliveryChangeErr: errors.New(sampleErr),
+			},
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+			oobsvc.Name:           &mockoob.MockOobService{},
+		}), false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.StatusV2(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, cmdErr)
+		require.Equal(t, StatusV2RequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.DeliveryRequest(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, cmdErr)
+		require.Equal(t, DeliveryRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.MessagesReceived(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, cmdErr)
+		require.Equal(t, MessagesReceivedErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.LiveDeliveryChange(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, cmdErr)
+		require.Equal(t, LiveDeliveryChangeErrorCode, cmdErr.Code())
+	})
+}
+
 func newMockProvider(serviceMap map[string]interface{}) *mockprovider.Provider {
 	if serviceMap == nil {
 		serviceMap = map[string]interface{}{
@@ -51,6 +51,41 @@ type BatchPickupResponse struct {
 	MessageCount int `json:"message_count"`
 }
 
+// StatusV2Response is Pickup 2.0 status response containing details about pending messages.
+type StatusV2Response struct {
+	*messagepickup.StatusV2Body
+}
+
+// DeliveryRequest is request for dispatching pending messages using Pickup 2.0.
+type DeliveryRequest struct {
+	// ConnectionID of connection for which pending messages needs to be dispatched.
+	ConnectionID string `json:"connectionID"`
+	// Limit is the maximum number of pending messages to be dispatched.
+	Limit int `json:"limit"`
+}
+
+// DeliveryResponse is response for dispatching pending messages using Pickup 2.0.
+type DeliveryResponse struct {
+	// MessageIDs of the dispatched messages that were processed.
+	MessageIDs []string `json:"message_id_list"`
+}
+
+// MessagesReceivedRequest is request for acknowledging dispatched messages using Pickup 2.0.
+type MessagesReceivedRequest struct {
+	// ConnectionID of connection the messages were dispatched on.
+	ConnectionID string `json:"connectionID"`
+	// MessageIDs of the messages to be deleted by the mediator.
+	MessageIDs []string `json:"message_id_list"`
+}
+
+// LiveDeliveryChangeRequest is request for turning Pickup 2.0 live delivery on or off.
+type LiveDeliveryChangeRequest struct {
+	// ConnectionID of connection for which live delivery needs to be changed.
+	ConnectionID string `json:"connectionID"`
+	// LiveDelivery turns live delivery on when true.
+	LiveDelivery bool `json:"live_delivery"`
+}
+
 // CreateInvitationRequest model
 //
 // This is used for creating an invitation using mediator.
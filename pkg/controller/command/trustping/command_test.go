@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
+	trustpingSvc "github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("test new command - client creation fail", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create trust ping client")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Ping(t *testing.T) {
+	t.Run("ping - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(connID string, params *trustpingSvc.PingParams) (*trustpingSvc.PingResult, error) {
+					require.Equal(t, "123-abc", connID)
+					require.True(t, params.ResponseRequested)
+					require.Equal(t, time.Second, params.Timeout)
+
+					return &trustpingSvc.PingResult{
+						ConnectionID: connID,
+						PingID:       "ping-id",
+						Responded:    true,
+						Latency:      time.Millisecond,
+					}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":"123-abc","timeout":1000000000}`))
+		require.NoError(t, cmdErr)
+
+		response := PingResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, "ping-id", response.PingID)
+		require.True(t, response.Responded)
+		require.Equal(t, time.Millisecond, response.Latency)
+	})
+
+	t.Run("ping without response requested", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":"123-abc","response_requested":false}`))
+		require.NoError(t, cmdErr)
+
+		response := PingResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.False(t, response.Responded)
+	})
+
+	t.Run("ping - invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("ping - missing connection ID", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":""}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, PingMissingConnIDCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "connectionID is mandatory")
+	})
+
+	t.Run("ping - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{PingErr: errors.New("sample-error")},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":"123-abc"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, PingErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "sample-error")
+	})
+}
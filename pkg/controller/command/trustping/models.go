@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+)
+
+// PingRequest is request for sending a trust ping over a connection.
+type PingRequest struct {
+	// ConnectionID of the connection to ping.
+	ConnectionID string `json:"connectionID"`
+
+	// Comment sent along with the ping (Trust Ping 1.0 only).
+	Comment string `json:"comment,omitempty"`
+
+	// ResponseRequested asks the other party to respond to the ping, true if not set.
+	ResponseRequested *bool `json:"response_requested,omitempty"`
+
+	// Timeout (in nanoseconds) waiting for the ping response.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// PingResponse is response of a trust ping.
+type PingResponse struct {
+	// PingID is the ID of the ping message.
+	PingID string `json:"pingID"`
+
+	// Responded is true if the ping response was received.
+	Responded bool `json:"responded"`
+
+	// Latency (in nanoseconds) is the round-trip time of the ping, also recorded on the connection.
+	Latency time.Duration `json:"latency,omitempty"`
+}
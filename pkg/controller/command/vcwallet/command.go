@@ -82,6 +82,12 @@ const (
 
 	// ResolveCredentialManifestErrorCode for errors while resolving credential manifest from wallet.
 	ResolveCredentialManifestErrorCode
+
+	// ExportWalletErrorCode for errors while exporting wallet.
+	ExportWalletErrorCode
+
+	// ImportWalletErrorCode for errors while importing wallet.
+	ImportWalletErrorCode
 )
 
 // All command operations.
@@ -105,6 +111,8 @@ const (
 	DeriveMethod                    = "Derive"
 	CreateKeyPairMethod             = "CreateKeyPair"
 	ResolveCredentialManifestMethod = "ResolveCredentialManifest"
+	ExportMethod                    = "Export"
+	ImportMethod                    = "Import"
 )
 
 // miscellaneous constants for the vc wallet command controller.
@@ -209,6 +217,8 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, DeriveMethod, o.Derive),
 		cmdutil.NewCommandHandler(CommandName, CreateKeyPairMethod, o.CreateKeyPair),
 		cmdutil.NewCommandHandler(CommandName, ResolveCredentialManifestMethod, o.ResolveCredentialManifest),
+		cmdutil.NewCommandHandler(CommandName, ExportMethod, o.Export),
+		cmdutil.NewCommandHandler(CommandName, ImportMethod, o.Import),
 	}
 }
 
@@ -746,6 +756,75 @@ func (o *Command) ResolveCredentialManifest(rw io.Writer, req io.Reader) command
 	return nil
 }
 
+// Export exports wallet contents and keys into a locked wallet encrypted with given passphrase.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#export
+//
+// Writes exported locked wallet to writer or returns error if operation fails.
+//
+func (o *Command) Export(rw io.Writer, req io.Reader) command.Error {
+	request := &ExportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	exported, err := vcWallet.Export(request.Auth, request.Passphrase)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportResponse{Wallet: exported}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Import imports contents and keys of a locked wallet exported with given passphrase.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#import
+func (o *Command) Import(rw io.Writer, req io.Reader) command.Error {
+	request := &ImportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	err = vcWallet.Import(request.Auth, request.Passphrase, request.Wallet)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, ImportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
 // prepareProfileOptions prepares options for creating wallet profile.
 func prepareProfileOptions(rqst *CreateOrUpdateProfileRequest) []wallet.ProfileOptions {
 	var options []wallet.ProfileOptions
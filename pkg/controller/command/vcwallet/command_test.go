@@ -66,7 +66,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetHandlers(), 16)
+		require.Len(t, cmd.GetHandlers(), 18)
 	})
 }
 
@@ -1895,6 +1895,121 @@ func TestCommand_ResolveCredentialManifest(t *testing.T) {
 	})
 }
 
+func TestCommand_ExportImport(t *testing.T) {
+	const (
+		sampleUser1      = "sample-user-e01"
+		sampleUser2      = "sample-user-e02"
+		exportPassphrase = "export passphrase"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	addContent(t, mockctx, &AddContentRequest{
+		Content:     testdata.SampleWalletContentMetadata,
+		ContentType: "metadata",
+		WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token},
+	})
+
+	cmd := New(mockctx, &Config{})
+
+	var b bytes.Buffer
+	cmdErr := cmd.Export(&b, getReader(t, &ExportRequest{
+		WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
+		Passphrase: exportPassphrase,
+	}))
+	require.NoError(t, cmdErr)
+
+	var exported ExportResponse
+	require.NoError(t, json.NewDecoder(&b).Decode(&exported))
+	require.NotEmpty(t, exported.Wallet)
+
+	t.Run("import into a fresh profile", func(t *testing.T) {
+		freshctx := newMockProvider(t)
+
+		createSampleUserProfile(t, freshctx, &CreateOrUpdateProfileRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		token2, lock2 := unlockWallet(t, freshctx, &UnlockWalletRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		defer lock2()
+
+		cmd2 := New(freshctx, &Config{})
+
+		cmdErr := cmd2.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser2, Auth: token2},
+			Passphrase: exportPassphrase,
+			Wallet:     exported.Wallet,
+		}))
+		require.NoError(t, cmdErr)
+
+		var getResponse bytes.Buffer
+		cmdErr = cmd2.Get(&getResponse, getReader(t, &GetContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser2, Auth: token2},
+			ContentType: "metadata",
+			ContentID:   "did:example:123456789abcdefghi",
+		}))
+		require.NoError(t, cmdErr)
+
+		var response GetContentResponse
+		require.NoError(t, json.NewDecoder(&getResponse).Decode(&response))
+		require.JSONEq(t, string(testdata.SampleWalletContentMetadata), string(response.Content))
+	})
+
+	t.Run("export and import errors", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.Export(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+
+		cmdErr = cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token},
+			Passphrase: exportPassphrase,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase: exportPassphrase,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "invalid auth token")
+
+		cmdErr = cmd.Import(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token},
+			Passphrase: exportPassphrase,
+			Wallet:     exported.Wallet,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
+			Passphrase: exportPassphrase,
+			Wallet:     exported.Wallet,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "already exists")
+		require.Empty(t, b.Bytes())
+	})
+}
+
 func createSampleUserProfile(t *testing.T, ctx *mockprovider.Provider, request *CreateOrUpdateProfileRequest) {
 	cmd := New(ctx, &Config{})
 	require.NotNil(t, cmd)
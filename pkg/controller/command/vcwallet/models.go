@@ -338,3 +338,28 @@ type ResolveCredentialManifestResponse struct {
 	// List of Resolved Descriptor results.
 	Resolved []*cm.ResolvedDescriptor `json:"resolved,omitempty"`
 }
+
+// ExportRequest is request model for exporting wallet contents.
+type ExportRequest struct {
+	WalletAuth
+
+	// passphrase to be used to lock the exported wallet.
+	Passphrase string `json:"passphrase"`
+}
+
+// ExportResponse is response model from wallet export operation.
+type ExportResponse struct {
+	// Exported locked wallet.
+	Wallet json.RawMessage `json:"wallet"`
+}
+
+// ImportRequest is request model for importing an exported wallet.
+type ImportRequest struct {
+	WalletAuth
+
+	// passphrase used while exporting the wallet.
+	Passphrase string `json:"passphrase"`
+
+	// Exported locked wallet to be imported.
+	Wallet json.RawMessage `json:"wallet"`
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/internal/cmdutil"
 	ariescrypto "github.com/markcryptohash/aries-framework-go/pkg/crypto"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/didconfig"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/jsonld"
 	verifiablesigner "github.com/markcryptohash/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite"
@@ -84,6 +86,12 @@ const (
 
 	// DeriveCredentialErrorCode for derive credential error.
 	DeriveCredentialErrorCode
+
+	// CreateDomainLinkageCredentialErrorCode for create domain linkage credential error.
+	CreateDomainLinkageCredentialErrorCode
+
+	// CreateDIDConfigurationErrorCode for create DID configuration error.
+	CreateDIDConfigurationErrorCode
 )
 
 // constants for the Verifiable protocol.
@@ -106,6 +114,9 @@ const (
 	RemoveCredentialByNameCommandMethod   = "RemoveCredentialByName"
 	RemovePresentationByNameCommandMethod = "RemovePresentationByName"
 
+	CreateDomainLinkageCredentialCommandMethod = "CreateDomainLinkageCredential"
+	CreateDIDConfigurationCommandMethod        = "CreateDIDConfiguration"
+
 	// error messages.
 	errEmptyCredentialName   = "credential name is mandatory"
 	errEmptyPresentationName = "presentation name is mandatory"
@@ -114,6 +125,9 @@ const (
 	errEmptyDID              = "did is mandatory"
 	errEmptyCredential       = "credential is mandatory is mandatory"
 	errEmptyFrame            = "frame is mandatory is mandatory"
+	errEmptyOrigin           = "origin is mandatory"
+	errEmptyExpirationDate   = "expiration date is mandatory"
+	errEmptyCredentials      = "credentials are mandatory"
 
 	// log constants.
 	vcID   = "vcID"
@@ -148,6 +162,11 @@ const (
 	// JSONWebKey2020 verification key type.
 	JSONWebKey2020 = "JsonWebKey2020"
 
+	// JWTFormat is JWT format of the domain linkage credential.
+	JWTFormat = "jwt"
+	// LDPFormat is JSON-LD format of the domain linkage credential with the linked data proof.
+	LDPFormat = "ldp"
+
 	p256Alg = "ES256"
 	p384Alg = "ES384"
 	p521Alg = "ES521"
@@ -294,6 +313,9 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetPresentationsCommandMethod, o.GetPresentations),
 		cmdutil.NewCommandHandler(CommandName, RemoveCredentialByNameCommandMethod, o.RemoveCredentialByName),
 		cmdutil.NewCommandHandler(CommandName, RemovePresentationByNameCommandMethod, o.RemovePresentationByName),
+		cmdutil.NewCommandHandler(CommandName, CreateDomainLinkageCredentialCommandMethod,
+			o.CreateDomainLinkageCredential),
+		cmdutil.NewCommandHandler(CommandName, CreateDIDConfigurationCommandMethod, o.CreateDIDConfiguration),
 	}
 }
 
@@ -502,6 +524,89 @@ func (o *Command) SignCredential(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// CreateDomainLinkageCredential creates Domain Linkage Credential linking the DID with the origin,
+// signed in either JSON-LD or JWT format.
+func (o *Command) CreateDomainLinkageCredential(rw io.Writer, req io.Reader) command.Error {
+	request := &DomainLinkageCredentialRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateDomainLinkageCredentialCommandMethod,
+			"request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	err = validateDomainLinkageCredentialRequest(request)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, CreateDomainLinkageCredentialCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vc, err := o.createDomainLinkageCredential(request)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreateDomainLinkageCredentialCommandMethod,
+			"create domain linkage credential : "+err.Error())
+
+		return command.NewExecuteError(CreateDomainLinkageCredentialErrorCode,
+			fmt.Errorf("create domain linkage credential : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &DomainLinkageCredentialResponse{
+		DomainLinkageCredential: vc,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateDomainLinkageCredentialCommandMethod, "success")
+
+	return nil
+}
+
+// CreateDIDConfiguration creates DID Configuration resource with the Domain Linkage Credentials of the DIDs,
+// to be served at /.well-known/did-configuration.json of the origin.
+func (o *Command) CreateDIDConfiguration(rw io.Writer, req io.Reader) command.Error {
+	request := &DIDConfigurationRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateDIDConfigurationCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(request.Credentials) == 0 {
+		logutil.LogDebug(logger, CommandName, CreateDIDConfigurationCommandMethod, errEmptyCredentials)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredentials))
+	}
+
+	for i := range request.Credentials {
+		err = validateDomainLinkageCredentialRequest(&request.Credentials[i])
+		if err != nil {
+			logutil.LogDebug(logger, CommandName, CreateDIDConfigurationCommandMethod, err.Error())
+
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+	}
+
+	didConfig, err := o.createDIDConfiguration(request)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreateDIDConfigurationCommandMethod,
+			"create DID configuration : "+err.Error())
+
+		return command.NewExecuteError(CreateDIDConfigurationErrorCode,
+			fmt.Errorf("create DID configuration : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &DIDConfigurationResponse{
+		DIDConfiguration: didConfig,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateDIDConfigurationCommandMethod, "success")
+
+	return nil
+}
+
 // DeriveCredential derives a given verifiable credential for selective disclosure and returns it in response body.
 func (o *Command) DeriveCredential(rw io.Writer, req io.Reader) command.Error {
 	request := &DeriveCredentialRequest{}
@@ -856,7 +961,8 @@ func (o *Command) createAndSignPresentation(credentials []*verifiable.Credential
 	holder string, opts *ProofOptions) ([]byte, error) {
 	var err error
 	if vp == nil {
-		vp, err = verifiable.NewPresentation(verifiable.WithCredentials(credentials...))
+		vp, err = verifiable.NewPresentation(verifiable.WithCredentials(credentials...),
+			verifiable.WithDataModelVersion(verifiable.CredentialsDataModelVersion(credentials...)))
 		if err != nil {
 			return nil, fmt.Errorf("failed to set credentials: %w", err)
 		}
@@ -882,7 +988,8 @@ func (o *Command) createAndSignPresentationByID(vc *verifiable.Credential,
 		return nil, err
 	}
 
-	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc),
+		verifiable.WithDataModelVersion(vc.Version()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vp by ID: %w", err)
 	}
@@ -1186,6 +1293,113 @@ func (o *Command) addCredentialProof(vc *verifiable.Credential, didDoc *did.Doc,
 	return o.addLinkedDataProof(vc, opts)
 }
 
+func validateDomainLinkageCredentialRequest(request *DomainLinkageCredentialRequest) error {
+	switch {
+	case request.DID == "":
+		return errors.New(errEmptyDID)
+	case request.Origin == "":
+		return errors.New(errEmptyOrigin)
+	case request.ExpirationDate == nil:
+		return errors.New(errEmptyExpirationDate)
+	case request.Format != "" && request.Format != JWTFormat && request.Format != LDPFormat:
+		return fmt.Errorf("unsupported domain linkage credential format %s", request.Format)
+	}
+
+	return nil
+}
+
+func (o *Command) createDIDConfiguration(request *DIDConfigurationRequest) (json.RawMessage, error) {
+	linkedDIDs := make([]interface{}, len(request.Credentials))
+
+	for i := range request.Credentials {
+		vc, err := o.createDomainLinkageCredential(&request.Credentials[i])
+		if err != nil {
+			return nil, fmt.Errorf("domain linkage credential of %s : %w", request.Credentials[i].DID, err)
+		}
+
+		linkedDIDs[i] = vc
+	}
+
+	return didconfig.CreateDIDConfiguration(linkedDIDs...)
+}
+
+func (o *Command) createDomainLinkageCredential(request *DomainLinkageCredentialRequest) (json.RawMessage, error) {
+	didDoc, err := o.getDIDDoc(request.DID)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := time.Now()
+	if request.IssuanceDate != nil {
+		issued = *request.IssuanceDate
+	}
+
+	vc, err := didconfig.CreateDomainLinkageCredential(request.DID, request.Origin, issued, *request.ExpirationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := request.ProofOptions
+	if opts == nil {
+		opts = &ProofOptions{}
+	}
+
+	if request.Format == JWTFormat {
+		return o.signDomainLinkageCredentialJWT(vc, didDoc, opts)
+	}
+
+	if opts.SignatureType == "" {
+		opts.SignatureType = Ed25519Signature2018
+	}
+
+	err = o.addCredentialProof(vc, didDoc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return vc.MarshalJSON()
+}
+
+func (o *Command) signDomainLinkageCredentialJWT(vc *verifiable.Credential, didDoc *did.Doc,
+	opts *ProofOptions) (json.RawMessage, error) {
+	opts, err := prepareOpts(opts, didDoc, did.AssertionMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newKMSSigner(o.ctx.KMS(), o.ctx.Crypto(), getKID(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	alg, err := verifiable.KeyTypeToJWSAlgo(s.keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	jwt, err := didconfig.CreateDomainLinkageCredentialJWT(vc, alg, s, opts.VerificationMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jwt)
+}
+
+// getDIDDoc gets DID document from the local storage first, and resolves it if not found.
+func (o *Command) getDIDDoc(didID string) (*did.Doc, error) {
+	didDoc, err := o.didStore.GetDID(didID)
+	if err == nil {
+		return didDoc, nil
+	}
+
+	docResolution, err := o.ctx.VDRegistry().Resolve(didID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get did doc from store or vdr : %w", err)
+	}
+
+	return docResolution.DIDDocument, nil
+}
+
 func isDID(str string) bool {
 	return strings.HasPrefix(str, "did:")
 }
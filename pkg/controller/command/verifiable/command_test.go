@@ -556,7 +556,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 14, len(handlers))
+		require.Equal(t, 16, len(handlers))
 	})
 
 	t.Run("test new command - vc store error", func(t *testing.T) {
@@ -2495,6 +2495,265 @@ func stringToJSONRaw(jsonStr string) json.RawMessage {
 	return []byte(jsonStr)
 }
 
+func TestCommand_CreateDomainLinkageCredential(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	newCmd := func(t *testing.T, keyType kmsapi.KeyType) *Command {
+		t.Helper()
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					if didID == invalidDID {
+						return nil, errors.New("invalid")
+					}
+
+					didDoc, err := did.ParseDocument([]byte(doc))
+					require.NoError(t, err)
+
+					return &did.DocResolution{DIDDocument: didDoc}, nil
+				},
+			},
+			KMSValue:            &kmsmock.KeyManager{ExportPubKeyTypeValue: keyType},
+			CryptoValue:         &cryptomock.Crypto{SignValue: []byte("signature")},
+			DocumentLoaderValue: loader,
+		})
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	expires := time.Now().Add(time.Hour)
+
+	t.Run("success - JSON-LD", func(t *testing.T) {
+		reqBytes, err := json.Marshal(DomainLinkageCredentialRequest{
+			DID:            "did:peer:123456789abcdefghi",
+			Origin:         "https://example.com",
+			ExpirationDate: &expires,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = newCmd(t, "").CreateDomainLinkageCredential(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, err)
+
+		var response DomainLinkageCredentialResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		vc, err := verifiable.ParseCredential(response.DomainLinkageCredential, verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+		require.Contains(t, vc.Types, "DomainLinkageCredential")
+		require.Equal(t, "did:peer:123456789abcdefghi", vc.Issuer.ID)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, Ed25519Signature2018, vc.Proofs[0]["type"])
+	})
+
+	t.Run("success - JWT", func(t *testing.T) {
+		reqBytes, err := json.Marshal(DomainLinkageCredentialRequest{
+			DID:            "did:peer:123456789abcdefghi",
+			Origin:         "https://example.com",
+			ExpirationDate: &expires,
+			Format:         JWTFormat,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = newCmd(t, kmsapi.ED25519Type).CreateDomainLinkageCredential(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, err)
+
+		var response DomainLinkageCredentialResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		var jwt string
+		require.NoError(t, json.Unmarshal(response.DomainLinkageCredential, &jwt))
+		require.Len(t, strings.Split(jwt, "."), 3)
+	})
+
+	t.Run("error - invalid request", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			request string
+			err     string
+		}{
+			{name: "invalid JSON", request: "--", err: "request decode"},
+			{name: "no DID", request: `{"origin":"https://example.com"}`, err: errEmptyDID},
+			{name: "no origin", request: `{"did":"did:peer:123"}`, err: errEmptyOrigin},
+			{
+				name:    "no expiration date",
+				request: `{"did":"did:peer:123","origin":"https://example.com"}`,
+				err:     errEmptyExpirationDate,
+			},
+			{
+				name: "unsupported format",
+				request: `{"did":"did:peer:123","origin":"https://example.com",` +
+					`"expirationDate":"2030-01-01T00:00:00Z","format":"xml"}`,
+				err: "unsupported domain linkage credential format xml",
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				var b bytes.Buffer
+				cmdErr := newCmd(t, "").CreateDomainLinkageCredential(&b, bytes.NewBufferString(tc.request))
+				require.Error(t, cmdErr)
+				require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+				require.Equal(t, command.ValidationError, cmdErr.Type())
+				require.Contains(t, cmdErr.Error(), tc.err)
+			})
+		}
+	})
+
+	t.Run("error - DID resolution", func(t *testing.T) {
+		reqBytes, err := json.Marshal(DomainLinkageCredentialRequest{
+			DID:            invalidDID,
+			Origin:         "https://example.com",
+			ExpirationDate: &expires,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := newCmd(t, "").CreateDomainLinkageCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreateDomainLinkageCredentialErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "failed to get did doc from store or vdr")
+	})
+
+	t.Run("error - unsupported JWT key type", func(t *testing.T) {
+		reqBytes, err := json.Marshal(DomainLinkageCredentialRequest{
+			DID:            "did:peer:123456789abcdefghi",
+			Origin:         "https://example.com",
+			ExpirationDate: &expires,
+			Format:         JWTFormat,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := newCmd(t, "").CreateDomainLinkageCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreateDomainLinkageCredentialErrorCode, cmdErr.Code())
+	})
+
+	t.Run("error - invalid origin", func(t *testing.T) {
+		reqBytes, err := json.Marshal(DomainLinkageCredentialRequest{
+			DID:            "did:peer:123456789abcdefghi",
+			Origin:         "example.com",
+			ExpirationDate: &expires,
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := newCmd(t, "").CreateDomainLinkageCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreateDomainLinkageCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "must have scheme and host")
+	})
+}
+
+func TestCommand_CreateDIDConfiguration(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				if didID == invalidDID {
+					return nil, errors.New("invalid")
+				}
+
+				didDoc, err := did.ParseDocument([]byte(doc))
+				require.NoError(t, err)
+
+				return &did.DocResolution{DIDDocument: didDoc}, nil
+			},
+		},
+		KMSValue:            &kmsmock.KeyManager{ExportPubKeyTypeValue: kmsapi.ED25519Type},
+		CryptoValue:         &cryptomock.Crypto{SignValue: []byte("signature")},
+		DocumentLoaderValue: loader,
+	})
+	require.NoError(t, err)
+
+	expires := time.Now().Add(time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(DIDConfigurationRequest{
+			Credentials: []DomainLinkageCredentialRequest{
+				{
+					DID:            "did:peer:123456789abcdefghi",
+					Origin:         "https://example.com",
+					ExpirationDate: &expires,
+				},
+				{
+					DID:            "did:peer:987654321abcdefghi",
+					Origin:         "https://example.com",
+					ExpirationDate: &expires,
+					Format:         JWTFormat,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.CreateDIDConfiguration(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, err)
+
+		var response DIDConfigurationResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		didConfig := struct {
+			Context    string        `json:"@context"`
+			LinkedDIDs []interface{} `json:"linked_dids"`
+		}{}
+		require.NoError(t, json.Unmarshal(response.DIDConfiguration, &didConfig))
+		require.Equal(t, "https://identity.foundation/.well-known/did-configuration/v1", didConfig.Context)
+		require.Len(t, didConfig.LinkedDIDs, 2)
+		require.IsType(t, map[string]interface{}{}, didConfig.LinkedDIDs[0])
+		require.IsType(t, "", didConfig.LinkedDIDs[1])
+	})
+
+	t.Run("error - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.CreateDIDConfiguration(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.CreateDIDConfiguration(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyCredentials)
+
+		cmdErr = cmd.CreateDIDConfiguration(&b, bytes.NewBufferString(`{"credentials":[{"did":"did:peer:123"}]}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyOrigin)
+	})
+
+	t.Run("error - create domain linkage credential", func(t *testing.T) {
+		reqBytes, err := json.Marshal(DIDConfigurationRequest{
+			Credentials: []DomainLinkageCredentialRequest{{
+				DID:            invalidDID,
+				Origin:         "https://example.com",
+				ExpirationDate: &expires,
+			}},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreateDIDConfiguration(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreateDIDConfigurationErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "domain linkage credential of "+invalidDID)
+	})
+}
+
 func TestCommand_RemoveVCByName(t *testing.T) {
 	loader, err := ldtestutil.DocumentLoader()
 	require.NoError(t, err)
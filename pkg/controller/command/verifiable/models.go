@@ -74,6 +74,39 @@ type SignCredentialResponse struct {
 	VerifiableCredential json.RawMessage `json:"verifiableCredential,omitempty"`
 }
 
+// DomainLinkageCredentialRequest is model for creating Domain Linkage Credential.
+type DomainLinkageCredentialRequest struct {
+	// DID linked with the origin, it issues the credential.
+	DID string `json:"did,omitempty"`
+	// Origin of the domain, e.g. https://example.com.
+	Origin string `json:"origin,omitempty"`
+	// IssuanceDate of the credential. If omitted current system time will be used.
+	IssuanceDate *time.Time `json:"issuanceDate,omitempty"`
+	// ExpirationDate of the credential.
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	// Format of the credential, either "ldp" (JSON-LD, default) or "jwt".
+	Format string `json:"format,omitempty"`
+	*ProofOptions
+}
+
+// DomainLinkageCredentialResponse is model for create domain linkage credential response.
+type DomainLinkageCredentialResponse struct {
+	// DomainLinkageCredential is the JSON-LD credential or the JWT string.
+	DomainLinkageCredential json.RawMessage `json:"domainLinkageCredential,omitempty"`
+}
+
+// DIDConfigurationRequest is model for creating DID Configuration.
+type DIDConfigurationRequest struct {
+	// Credentials are the domain linkage credentials of the DIDs linked with the origin.
+	Credentials []DomainLinkageCredentialRequest `json:"credentials,omitempty"`
+}
+
+// DIDConfigurationResponse is model for create DID configuration response.
+type DIDConfigurationResponse struct {
+	// DIDConfiguration is the DID Configuration resource.
+	DIDConfiguration json.RawMessage `json:"didConfiguration,omitempty"`
+}
+
 // PresentationExt is model for presentation with fields related to command features.
 type PresentationExt struct {
 	Presentation
@@ -25,6 +25,7 @@ import (
 	outofbandcmd "github.com/markcryptohash/aries-framework-go/pkg/controller/command/outofband"
 	outofbandv2cmd "github.com/markcryptohash/aries-framework-go/pkg/controller/command/outofbandv2"
 	presentproofcmd "github.com/markcryptohash/aries-framework-go/pkg/controller/command/presentproof"
+	trustpingcmd "github.com/markcryptohash/aries-framework-go/pkg/controller/command/trustping"
 	vdrcmd "github.com/markcryptohash/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/rest"
@@ -41,12 +42,16 @@ import (
 	outofbandv2rest "github.com/markcryptohash/aries-framework-go/pkg/controller/rest/outofbandv2"
 	presentproofrest "github.com/markcryptohash/aries-framework-go/pkg/controller/rest/presentproof"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/rest/rfc0593"
+	trustpingrest "github.com/markcryptohash/aries-framework-go/pkg/controller/rest/trustping"
 	vcwalletrest "github.com/markcryptohash/aries-framework-go/pkg/controller/rest/vcwallet"
 	vdrrest "github.com/markcryptohash/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/markcryptohash/aries-framework-go/pkg/controller/rest/verifiable"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher/outbound"
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/context"
 	ldsvc "github.com/markcryptohash/aries-framework-go/pkg/ld"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
 )
 
 // HTTPClient represents an HTTP client.
@@ -56,6 +61,9 @@ type HTTPClient interface {
 
 type allOpts struct {
 	webhookURLs        []string
+	webhookSubscribers []*webnotifier.Subscriber
+	webhookRetry       *webnotifier.RetryParams
+	webhookDeadLetters bool
 	defaultLabel       string
 	autoAccept         bool
 	autoExecuteRFC0593 bool
@@ -64,6 +72,8 @@ type allOpts struct {
 	walletConf         *didcommwalletcmd.Config
 	httpClient         HTTPClient
 	ldService          ldsvc.Service
+	masterKeyPath      string
+	masterLock         secretlock.Service
 }
 
 const wsPath = "/ws"
@@ -78,6 +88,28 @@ func WithWebhookURLs(webhookURLs ...string) Opt {
 	}
 }
 
+// WithWebhookSubscribers is an option for setting up webhook subscribers with their own HMAC secret and topics.
+func WithWebhookSubscribers(subscribers ...*webnotifier.Subscriber) Opt {
+	return func(opts *allOpts) {
+		opts.webhookSubscribers = subscribers
+	}
+}
+
+// WithWebhookRetry is an option for retrying failed webhook notifications with an exponential backoff.
+func WithWebhookRetry(params *webnotifier.RetryParams) Opt {
+	return func(opts *allOpts) {
+		opts.webhookRetry = params
+	}
+}
+
+// WithWebhookDeadLetters is an option for storing the webhook notifications that could not be delivered,
+// they can be listed and replayed through the REST API.
+func WithWebhookDeadLetters(enabled bool) Opt {
+	return func(opts *allOpts) {
+		opts.webhookDeadLetters = enabled
+	}
+}
+
 // WithNotifier is an option for setting up a notifier which will notify clients of events.
 func WithNotifier(notifier command.Notifier) Opt {
 	return func(opts *allOpts) {
@@ -127,6 +159,15 @@ func WithHTTPClient(client HTTPClient) Opt {
 	}
 }
 
+// WithMasterKeyFile is an option for rotating the master key file the secret lock of the KMS was created from,
+// protected by masterLock if not nil, through the kms RewrapKeys command.
+func WithMasterKeyFile(path string, masterLock secretlock.Service) Opt {
+	return func(opts *allOpts) {
+		opts.masterKeyPath = path
+		opts.masterLock = masterLock
+	}
+}
+
 // WithLDService is an option for setting up a custom JSON-LD service.
 func WithLDService(svc ldsvc.Service) Opt {
 	return func(opts *allOpts) {
@@ -146,9 +187,14 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		opt(restAPIOpts)
 	}
 
-	notifier := restAPIOpts.notifier
-	if notifier == nil {
-		notifier = webnotifier.New(wsPath, restAPIOpts.webhookURLs)
+	notifier, err := getNotifier(ctx, restAPIOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = registerOutboxEvents(ctx, notifier)
+	if err != nil {
+		return nil, err
 	}
 
 	// DID Exchange REST operation
@@ -228,7 +274,7 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	}
 
 	// kms command operation
-	kmscmd := kmsrest.New(ctx)
+	kmscmd := kmsrest.New(ctx, kms.WithMasterKeyFile(restAPIOpts.masterKeyPath, restAPIOpts.masterLock))
 
 	// vc wallet command controller
 	wallet := vcwalletrest.New(ctx, restAPIOpts.walletConf)
@@ -241,6 +287,12 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create connection rest command : %w", err)
 	}
 
+	// trust ping REST operation
+	trustPingOp, err := trustpingrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping rest command : %w", err)
+	}
+
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
 	allHandlers = append(allHandlers, exchangeOp.GetRESTHandlers()...)
@@ -259,6 +311,7 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, wallet.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, ldOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, connOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, trustPingOp.GetRESTHandlers()...)
 
 	nhp, ok := notifier.(handlerProvider)
 	if ok {
@@ -268,10 +321,56 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	return allHandlers, nil
 }
 
+// getNotifier returns the notifier set in the options or creates a web notifier for the webhook options.
+func getNotifier(ctx *context.Provider, opts *allOpts) (command.Notifier, error) {
+	if opts.notifier != nil {
+		return opts.notifier, nil
+	}
+
+	webhookOpts := []webnotifier.HTTPNotifierOpt{webnotifier.WithSubscribers(opts.webhookSubscribers...)}
+
+	if opts.webhookRetry != nil {
+		webhookOpts = append(webhookOpts, webnotifier.WithRetry(opts.webhookRetry))
+	}
+
+	if opts.webhookDeadLetters {
+		queue, err := webnotifier.NewDeadLetterQueue(ctx.StorageProvider())
+		if err != nil {
+			return nil, fmt.Errorf("create webhook dead letter queue: %w", err)
+		}
+
+		webhookOpts = append(webhookOpts, webnotifier.WithDeadLetterQueue(queue))
+	}
+
+	return webnotifier.New(wsPath, opts.webhookURLs, webhookOpts...), nil
+}
+
 type handlerProvider interface {
 	GetRESTHandlers() []rest.Handler
 }
 
+type msgEventProvider interface {
+	RegisterMsgEvent(ch chan<- service.StateMsg) error
+}
+
+// registerOutboxEvents notifies the delivery status of outbound messages when the outbox is enabled.
+func registerOutboxEvents(ctx *context.Provider, notifier command.Notifier) error {
+	events, ok := ctx.OutboundDispatcher().(msgEventProvider)
+	if !ok {
+		return nil
+	}
+
+	states := make(chan service.StateMsg)
+
+	if err := events.RegisterMsgEvent(states); err != nil {
+		return fmt.Errorf("register outbox msg event: %w", err)
+	}
+
+	webnotifier.NewObserver(notifier).RegisterStateMsg(outbound.OutboxName+"_states", states)
+
+	return nil
+}
+
 // GetCommandHandlers returns all command handlers provided by controller.
 func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler, error) { // nolint: funlen,gocyclo
 	cmdOpts := &allOpts{
@@ -284,9 +383,14 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		opt(cmdOpts)
 	}
 
-	notifier := cmdOpts.notifier
-	if notifier == nil {
-		notifier = webnotifier.New(wsPath, cmdOpts.webhookURLs)
+	notifier, err := getNotifier(ctx, cmdOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = registerOutboxEvents(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register outbox events: %w", err)
 	}
 
 	// did exchange command operation
@@ -358,7 +462,7 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	}
 
 	// kms command operation
-	kmscmd := kms.New(ctx)
+	kmscmd := kms.New(ctx, kms.WithMasterKeyFile(cmdOpts.masterKeyPath, cmdOpts.masterLock))
 
 	// connection command operation
 	conncmd, err := connection.New(ctx)
@@ -366,6 +470,12 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create connection command : %w", err)
 	}
 
+	// trust ping command operation
+	trustping, err := trustpingcmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping command : %w", err)
+	}
+
 	// vc wallet command controller
 	wallet := didcommwalletcmd.New(ctx, cmdOpts.walletConf)
 
@@ -386,6 +496,7 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
 	allHandlers = append(allHandlers, outofbandv2.GetHandlers()...)
 	allHandlers = append(allHandlers, conncmd.GetHandlers()...)
+	allHandlers = append(allHandlers, trustping.GetHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetHandlers()...)
 	allHandlers = append(allHandlers, ldCmd.GetHandlers()...)
 
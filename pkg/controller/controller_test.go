@@ -15,6 +15,7 @@ import (
 
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/command/didcommwallet"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/aries"
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api"
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/aries/defaults"
@@ -114,6 +115,47 @@ func TestGetRESTHandlers_Success(t *testing.T) {
 	})
 }
 
+func TestGetRESTHandlers_WebhookDeadLetters(t *testing.T) {
+	framework, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+		strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+	require.NoError(t, err)
+	require.NotNil(t, framework)
+
+	defer func() { require.NoError(t, framework.Close()) }()
+
+	ctx, err := framework.Context()
+	require.NoError(t, err)
+	require.NotNil(t, ctx)
+
+	handlers, err := GetRESTHandlers(ctx, WithWebhookURLs("sample-wh-url"),
+		WithWebhookSubscribers(&webnotifier.Subscriber{URL: "sample-signed-wh-url", Secret: "secret"}),
+		WithWebhookRetry(webnotifier.DefaultRetryParams()), WithWebhookDeadLetters(true))
+	require.NoError(t, err)
+
+	var paths []string
+	for _, handler := range handlers {
+		paths = append(paths, handler.Path())
+	}
+
+	require.Contains(t, paths, webnotifier.DeadLettersPath)
+	require.Contains(t, paths, webnotifier.ReplayDeadLetterPath)
+}
+
+func TestWithWebhookOptions(t *testing.T) {
+	controllerOpts := &allOpts{}
+
+	subscribers := []*webnotifier.Subscriber{{URL: "localhost:8080", Secret: "secret", Topics: []string{"topic"}}}
+	retry := &webnotifier.RetryParams{MaxAttempts: 3}
+
+	WithWebhookSubscribers(subscribers...)(controllerOpts)
+	WithWebhookRetry(retry)(controllerOpts)
+	WithWebhookDeadLetters(true)(controllerOpts)
+
+	require.Equal(t, subscribers, controllerOpts.webhookSubscribers)
+	require.Equal(t, retry, controllerOpts.webhookRetry)
+	require.True(t, controllerOpts.webhookDeadLetters)
+}
+
 func TestWithWebhookNotifierOption(t *testing.T) {
 	controllerOpts := &allOpts{}
 
@@ -43,3 +43,105 @@ type importKeyReq struct {
 	// in: body
 	kms.JSONWebKey
 }
+
+// listKeysRes model
+//
+// This is used for returning the keys of the KMS
+//
+// swagger:response listKeysRes
+type listKeysRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ListKeysResponse
+}
+
+// keyIDReq model
+//
+// This is used for the requests referring to a key by its ID
+//
+// swagger:parameters getKeyInfo disableKey deleteKey
+type keyIDReq struct { // nolint: unused,deadcode
+	// The ID of the key
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+}
+
+// getKeyInfoRes model
+//
+// This is used for returning the type, creation time and metadata of a key
+//
+// swagger:response getKeyInfoRes
+type getKeyInfoRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.GetKeyInfoResponse
+}
+
+// setKeyMetadataReq model
+//
+// This is used for set key metadata request
+//
+// swagger:parameters setKeyMetadata
+type setKeyMetadataReq struct { // nolint: unused,deadcode
+	// The ID of the key
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+
+	// Params for setKeyMetadata
+	//
+	// in: body
+	Params struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+}
+
+// rewrapKeysReq model
+//
+// This is used for rewrap keys request
+//
+// swagger:parameters rewrapKeys
+type rewrapKeysReq struct { // nolint: unused,deadcode
+	// Params for rewrapKeys
+	//
+	// in: body
+	Params kms.RewrapKeysRequest
+}
+
+// backupKeysReq model
+//
+// This is used for backup keys request
+//
+// swagger:parameters backupKeys
+type backupKeysReq struct { // nolint: unused,deadcode
+	// Params for backupKeys
+	//
+	// in: body
+	Params kms.BackupKeysRequest
+}
+
+// backupKeysRes model
+//
+// This is used for returning the encrypted backup of the keys
+//
+// swagger:response backupKeysRes
+type backupKeysRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.BackupKeysResponse
+}
+
+// restoreKeysReq model
+//
+// This is used for restore keys request
+//
+// swagger:parameters restoreKeys
+type restoreKeysReq struct { // nolint: unused,deadcode
+	// Params for restoreKeys
+	//
+	// in: body
+	Params kms.RestoreKeysRequest
+}
@@ -7,9 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package kms
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
 	cmdkms "github.com/markcryptohash/aries-framework-go/pkg/controller/command/kms"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/internal/cmdutil"
@@ -19,9 +24,16 @@ import (
 
 // constants for KMS operations.
 const (
-	KmsOperationID   = "/kms"
-	CreateKeySetPath = KmsOperationID + "/keyset"
-	ImportKeyPath    = KmsOperationID + "/import"
+	KmsOperationID     = "/kms"
+	CreateKeySetPath   = KmsOperationID + "/keyset"
+	ImportKeyPath      = KmsOperationID + "/import"
+	KeysPath           = KmsOperationID + "/keys"
+	KeyPath            = KeysPath + "/{keyID}"
+	SetKeyMetadataPath = KeyPath + "/metadata"
+	DisableKeyPath     = KeyPath + "/disable"
+	RewrapKeysPath     = KmsOperationID + "/rewrap"
+	BackupKeysPath     = KmsOperationID + "/backup"
+	RestoreKeysPath    = KmsOperationID + "/restore"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -32,6 +44,14 @@ type provider interface {
 type kmsCommand interface {
 	CreateKeySet(rw io.Writer, req io.Reader) command.Error
 	ImportKey(rw io.Writer, req io.Reader) command.Error
+	ListKeys(rw io.Writer, req io.Reader) command.Error
+	GetKeyInfo(rw io.Writer, req io.Reader) command.Error
+	SetKeyMetadata(rw io.Writer, req io.Reader) command.Error
+	DisableKey(rw io.Writer, req io.Reader) command.Error
+	DeleteKey(rw io.Writer, req io.Reader) command.Error
+	RewrapKeys(rw io.Writer, req io.Reader) command.Error
+	BackupKeys(rw io.Writer, req io.Reader) command.Error
+	RestoreKeys(rw io.Writer, req io.Reader) command.Error
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -41,8 +61,8 @@ type Operation struct {
 }
 
 // New returns new kms operations rest client instance.
-func New(p provider) *Operation {
-	cmd := cmdkms.New(p)
+func New(p provider, opts ...cmdkms.Option) *Operation {
+	cmd := cmdkms.New(p, opts...)
 
 	o := &Operation{command: cmd}
 	o.registerHandler()
@@ -60,6 +80,14 @@ func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
+		cmdutil.NewHTTPHandler(KeysPath, http.MethodGet, o.ListKeys),
+		cmdutil.NewHTTPHandler(KeyPath, http.MethodGet, o.GetKeyInfo),
+		cmdutil.NewHTTPHandler(SetKeyMetadataPath, http.MethodPost, o.SetKeyMetadata),
+		cmdutil.NewHTTPHandler(DisableKeyPath, http.MethodPost, o.DisableKey),
+		cmdutil.NewHTTPHandler(KeyPath, http.MethodDelete, o.DeleteKey),
+		cmdutil.NewHTTPHandler(RewrapKeysPath, http.MethodPost, o.RewrapKeys),
+		cmdutil.NewHTTPHandler(BackupKeysPath, http.MethodPost, o.BackupKeys),
+		cmdutil.NewHTTPHandler(RestoreKeysPath, http.MethodPost, o.RestoreKeys),
 	}
 }
 
@@ -83,3 +111,109 @@ func (o *Operation) CreateKeySet(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ImportKey(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ImportKey, rw, req.Body)
 }
+
+// ListKeys swagger:route GET /kms/keys kms listKeys
+//
+// Lists the keys with their type, creation time and metadata.
+//
+// Responses:
+//    default: genericError
+//        200: listKeysRes
+func (o *Operation) ListKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ListKeys, rw, req.Body)
+}
+
+// GetKeyInfo swagger:route GET /kms/keys/{keyID} kms getKeyInfo
+//
+// Gets the type, creation time and metadata of a key.
+//
+// Responses:
+//    default: genericError
+//        200: getKeyInfoRes
+func (o *Operation) GetKeyInfo(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetKeyInfo, rw, keyIDRequest(req))
+}
+
+// SetKeyMetadata swagger:route POST /kms/keys/{keyID}/metadata kms setKeyMetadata
+//
+// Replaces the metadata tags of a key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) SetKeyMetadata(rw http.ResponseWriter, req *http.Request) {
+	var request cmdkms.SetKeyMetadataRequest
+
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdkms.InvalidRequestErrorCode,
+			fmt.Errorf("failed request decode : %w", err))
+
+		return
+	}
+
+	request.KeyID = mux.Vars(req)["keyID"]
+
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, cmdkms.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(o.command.SetKeyMetadata, rw, bytes.NewBuffer(reqBytes))
+}
+
+// DisableKey swagger:route POST /kms/keys/{keyID}/disable kms disableKey
+//
+// Disables a key, the key is kept but can't be used anymore.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DisableKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DisableKey, rw, keyIDRequest(req))
+}
+
+// DeleteKey swagger:route DELETE /kms/keys/{keyID} kms deleteKey
+//
+// Permanently deletes a key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DeleteKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeleteKey, rw, keyIDRequest(req))
+}
+
+// RewrapKeys swagger:route POST /kms/rewrap kms rewrapKeys
+//
+// Re-encrypts the stored keys with a new master key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RewrapKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RewrapKeys, rw, req.Body)
+}
+
+// BackupKeys swagger:route POST /kms/backup kms backupKeys
+//
+// Exports the keys in an archive encrypted with a key derived from a passphrase.
+//
+// Responses:
+//    default: genericError
+//        200: backupKeysRes
+func (o *Operation) BackupKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.BackupKeys, rw, req.Body)
+}
+
+// RestoreKeys swagger:route POST /kms/restore kms restoreKeys
+//
+// Imports the keys of a backup, the keys keep their IDs.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RestoreKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RestoreKeys, rw, req.Body)
+}
+
+func keyIDRequest(req *http.Request) io.Reader {
+	return bytes.NewBufferString(fmt.Sprintf(`{"keyID":%q}`, mux.Vars(req)["keyID"]))
+}
@@ -11,8 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -21,6 +24,7 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/command/kms"
 	"github.com/markcryptohash/aries-framework-go/pkg/controller/rest"
+	kmsapi "github.com/markcryptohash/aries-framework-go/pkg/kms"
 	mockkms "github.com/markcryptohash/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
 )
@@ -31,7 +35,7 @@ func TestNew(t *testing.T) {
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
-		require.Equal(t, 2, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 10, len(cmd.GetRESTHandlers()))
 	})
 }
 
@@ -42,7 +46,7 @@ func TestCreateKeySet(t *testing.T) {
 		})
 		cmd.command = &mockKMSCommand{}
 
-		handler := lookupHandler(t, cmd, CreateKeySetPath)
+		handler := lookupHandler(t, cmd, CreateKeySetPath, http.MethodPost)
 		err := getSuccessResponseFromHandler(handler, CreateKeySetPath)
 		require.NoError(t, err)
 	})
@@ -53,7 +57,7 @@ func TestCreateKeySet(t *testing.T) {
 		})
 		require.NotNil(t, cmd)
 
-		handler := lookupHandler(t, cmd, CreateKeySetPath)
+		handler := lookupHandler(t, cmd, CreateKeySetPath, http.MethodPost)
 
 		req := createKeySetReq{CreateKeySetRequest: kms.CreateKeySetRequest{
 			KeyType: "ED25519",
@@ -75,7 +79,7 @@ func TestImportKey(t *testing.T) {
 		cmd := New(&mockprovider.Provider{})
 		cmd.command = &mockKMSCommand{}
 
-		handler := lookupHandler(t, cmd, ImportKeyPath)
+		handler := lookupHandler(t, cmd, ImportKeyPath, http.MethodPost)
 		err := getSuccessResponseFromHandler(handler, ImportKeyPath)
 		require.NoError(t, err)
 	})
@@ -87,7 +91,7 @@ func TestImportKey(t *testing.T) {
 		cmd.command = &mockKMSCommand{importKeyError: command.NewExecuteError(kms.ImportKeyError,
 			fmt.Errorf("failed to import key"))}
 
-		handler := lookupHandler(t, cmd, ImportKeyPath)
+		handler := lookupHandler(t, cmd, ImportKeyPath, http.MethodPost)
 
 		req := importKeyReq{JSONWebKey: kms.JSONWebKey{Kid: "k1"}}
 		reqBytes, err := json.Marshal(req)
@@ -102,14 +106,168 @@ func TestImportKey(t *testing.T) {
 	})
 }
 
-func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
+func TestKeyLifecycle(t *testing.T) {
+	t.Run("test list keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListKeysValue: []*kmsapi.KeyInfo{{ID: "k1"}}},
+		})
+
+		handler := lookupHandler(t, cmd, KeysPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		response := kms.ListKeysResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Len(t, response.Keys, 1)
+		require.Equal(t, "k1", response.Keys[0].ID)
+	})
+
+	t.Run("test key ID from path", func(t *testing.T) {
+		keyPath := strings.ReplaceAll(KeyPath, "{keyID}", "k1")
+
+		for _, tc := range []struct {
+			path   string
+			method string
+		}{
+			{path: KeyPath, method: http.MethodGet},
+			{path: DisableKeyPath, method: http.MethodPost},
+			{path: KeyPath, method: http.MethodDelete},
+		} {
+			cmd := New(&mockprovider.Provider{})
+			mockCmd := &mockKMSCommand{}
+			cmd.command = mockCmd
+
+			handler := lookupHandler(t, cmd, tc.path, tc.method)
+
+			_, code, err := sendRequestToHandler(handler, nil, strings.ReplaceAll(tc.path, KeyPath, keyPath))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, code)
+			require.JSONEq(t, `{"keyID":"k1"}`, string(mockCmd.request))
+		}
+	})
+
+	t.Run("test set key metadata - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		mockCmd := &mockKMSCommand{}
+		cmd.command = mockCmd
+
+		handler := lookupHandler(t, cmd, SetKeyMetadataPath, http.MethodPost)
+
+		_, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"metadata":{"label":"a"}}`),
+			strings.ReplaceAll(SetKeyMetadataPath, "{keyID}", "k1"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{"keyID":"k1","metadata":{"label":"a"}}`, string(mockCmd.request))
+	})
+
+	t.Run("test set key metadata - invalid request", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+
+		handler := lookupHandler(t, cmd, SetKeyMetadataPath, http.MethodPost)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{`),
+			strings.ReplaceAll(SetKeyMetadataPath, "{keyID}", "k1"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.InvalidRequestErrorCode, "failed request decode", buf.Bytes())
+	})
+
+	t.Run("test delete key - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{DeleteKeyErr: fmt.Errorf("failed to delete key")},
+		})
+
+		handler := lookupHandler(t, cmd, KeyPath, http.MethodDelete)
+
+		buf, code, err := sendRequestToHandler(handler, nil, strings.ReplaceAll(KeyPath, "{keyID}", "k1"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.DeleteKeyError, "failed to delete key", buf.Bytes())
+	})
+}
+
+func TestRewrapKeys(t *testing.T) {
+	t.Run("test rewrap keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		mockCmd := &mockKMSCommand{}
+		cmd.command = mockCmd
+
+		handler := lookupHandler(t, cmd, RewrapKeysPath, http.MethodPost)
+
+		_, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"keyIDs":["k1"]}`), RewrapKeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{"keyIDs":["k1"]}`, string(mockCmd.request))
+	})
+
+	t.Run("test rewrap keys - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{RewrapKeysErr: fmt.Errorf("failed to rewrap keys")},
+		}, kms.WithMasterKeyFile(filepath.Join(t.TempDir(), "masterkey"), nil))
+
+		handler := lookupHandler(t, cmd, RewrapKeysPath, http.MethodPost)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), RewrapKeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.RewrapKeysError, "failed to rewrap keys", buf.Bytes())
+	})
+}
+
+func TestBackupAndRestoreKeys(t *testing.T) {
+	t.Run("test backup keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{BackupValue: []byte(`{"version":1}`)},
+		})
+
+		handler := lookupHandler(t, cmd, BackupKeysPath, http.MethodPost)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"passphrase":"secret"}`),
+			BackupKeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{"backup":{"version":1}}`, buf.String())
+	})
+
+	t.Run("test restore keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		mockCmd := &mockKMSCommand{}
+		cmd.command = mockCmd
+
+		handler := lookupHandler(t, cmd, RestoreKeysPath, http.MethodPost)
+
+		_, code, err := sendRequestToHandler(handler,
+			bytes.NewBufferString(`{"backup":{"version":1},"passphrase":"secret"}`), RestoreKeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{"backup":{"version":1},"passphrase":"secret"}`, string(mockCmd.request))
+	})
+
+	t.Run("test restore keys - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{RestoreErr: fmt.Errorf("failed to restore keys")},
+		})
+
+		handler := lookupHandler(t, cmd, RestoreKeysPath, http.MethodPost)
+
+		buf, code, err := sendRequestToHandler(handler,
+			bytes.NewBufferString(`{"backup":{"version":1},"passphrase":"secret"}`), RestoreKeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.RestoreKeysError, "failed to restore keys", buf.Bytes())
+	})
+}
+
+func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
 	t.Helper()
 
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
 
 	for _, h := range handlers {
-		if h.Path() == path && h.Method() == http.MethodPost {
+		if h.Path() == path && h.Method() == method {
 			return h
 		}
 	}
@@ -176,6 +334,7 @@ func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, da
 
 type mockKMSCommand struct {
 	importKeyError command.Error
+	request        []byte
 }
 
 func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
@@ -185,3 +344,41 @@ func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error
 func (m *mockKMSCommand) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	return m.importKeyError
 }
+
+func (m *mockKMSCommand) ListKeys(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) GetKeyInfo(rw io.Writer, req io.Reader) command.Error {
+	return m.readRequest(req)
+}
+
+func (m *mockKMSCommand) SetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	return m.readRequest(req)
+}
+
+func (m *mockKMSCommand) DisableKey(rw io.Writer, req io.Reader) command.Error {
+	return m.readRequest(req)
+}
+
+func (m *mockKMSCommand) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	return m.readRequest(req)
+}
+
+func (m *mockKMSCommand) RewrapKeys(rw io.Writer, req io.Reader) command.Error {
+	return m.readRequest(req)
+}
+
+func (m *mockKMSCommand) BackupKeys(rw io.Writer, req io.Reader) command.Error {
+	return m.readRequest(req)
+}
+
+func (m *mockKMSCommand) RestoreKeys(rw io.Writer, req io.Reader) command.Error {
+	return m.readRequest(req)
+}
+
+func (m *mockKMSCommand) readRequest(req io.Reader) command.Error {
+	m.request, _ = ioutil.ReadAll(req) // nolint: errcheck
+
+	return nil
+}
@@ -117,3 +117,71 @@ type batchPickupResponse struct {
 	// in: body
 	Params mediator.BatchPickupResponse
 }
+
+// statusV2Request model
+//
+// For getting details about pending messages using Pickup 2.0.
+//
+// swagger:parameters statusV2Request
+type statusV2Request struct { // nolint: unused,deadcode
+	// Params for getting details about pending messages.
+	//
+	// in: body
+	Params mediator.StatusRequest
+}
+
+// statusV2Response model
+//
+// Pickup 2.0 status response containing details about pending messages.
+//
+// swagger:response statusV2Response
+type statusV2Response struct {
+	// in: body
+	Params mediator.StatusV2Response
+}
+
+// deliveryRequest model
+//
+// For dispatching pending messages using Pickup 2.0.
+//
+// swagger:parameters deliveryRequest
+type deliveryRequest struct { // nolint: unused,deadcode
+	// Params for dispatching pending messages for given connection.
+	//
+	// in: body
+	Params mediator.DeliveryRequest
+}
+
+// deliveryResponse model
+//
+// IDs of the messages dispatched using Pickup 2.0.
+//
+// swagger:response deliveryResponse
+type deliveryResponse struct {
+	// in: body
+	Params mediator.DeliveryResponse
+}
+
+// messagesReceivedRequest model
+//
+// For acknowledging dispatched messages using Pickup 2.0.
+//
+// swagger:parameters messagesReceivedRequest
+type messagesReceivedRequest struct { // nolint: unused,deadcode
+	// Params for acknowledging dispatched messages.
+	//
+	// in: body
+	Params mediator.MessagesReceivedRequest
+}
+
+// liveDeliveryChangeRequest model
+//
+// For turning Pickup 2.0 live delivery on or off.
+//
+// swagger:parameters liveDeliveryChangeRequest
+type liveDeliveryChangeRequest struct { // nolint: unused,deadcode
+	// Params for changing live delivery.
+	//
+	// in: body
+	Params mediator.LiveDeliveryChangeRequest
+}
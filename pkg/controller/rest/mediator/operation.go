@@ -26,6 +26,12 @@ const (
 	StatusPath         = RouteOperationID + "/status"
 	BatchPickupPath    = RouteOperationID + "/batchpickup"
 	ReconnectAllPath   = RouteOperationID + "/reconnect-all"
+
+	PickupOperationID      = RouteOperationID + "/pickup"
+	StatusV2Path           = PickupOperationID + "/status"
+	DeliveryRequestPath    = PickupOperationID + "/delivery-request"
+	MessagesReceivedPath   = PickupOperationID + "/messages-received"
+	LiveDeliveryChangePath = PickupOperationID + "/live-delivery"
 )
 
 // provider contains dependencies for the route protocol and is typically created by using aries.Context().
@@ -74,6 +80,10 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(StatusPath, http.MethodPost, o.Status),
 		cmdutil.NewHTTPHandler(BatchPickupPath, http.MethodPost, o.BatchPickup),
 		cmdutil.NewHTTPHandler(ReconnectAllPath, http.MethodGet, o.ReconnectAll),
+		cmdutil.NewHTTPHandler(StatusV2Path, http.MethodPost, o.StatusV2),
+		cmdutil.NewHTTPHandler(DeliveryRequestPath, http.MethodPost, o.DeliveryRequest),
+		cmdutil.NewHTTPHandler(MessagesReceivedPath, http.MethodPost, o.MessagesReceived),
+		cmdutil.NewHTTPHandler(LiveDeliveryChangePath, http.MethodPost, o.LiveDeliveryChange),
 	}
 }
 
@@ -151,3 +161,45 @@ func (o *Operation) BatchPickup(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ReconnectAll(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ReconnectAll, rw, req.Body)
 }
+
+// StatusV2 swagger:route POST /mediator/pickup/status mediator statusV2Request
+//
+// Returns details about pending messages for given connection using Pickup 2.0.
+//
+// Responses:
+//    default: genericError
+//    200: statusV2Response
+func (o *Operation) StatusV2(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.StatusV2, rw, req.Body)
+}
+
+// DeliveryRequest swagger:route POST /mediator/pickup/delivery-request mediator deliveryRequest
+//
+// Dispatches pending messages for given connection using Pickup 2.0.
+//
+// Responses:
+//    default: genericError
+//    200: deliveryResponse
+func (o *Operation) DeliveryRequest(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeliveryRequest, rw, req.Body)
+}
+
+// MessagesReceived swagger:route POST /mediator/pickup/messages-received mediator messagesReceivedRequest
+//
+// Acknowledges dispatched messages so that the mediator deletes them.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) MessagesReceived(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.MessagesReceived, rw, req.Body)
+}
+
+// LiveDeliveryChange swagger:route POST /mediator/pickup/live-delivery mediator liveDeliveryChangeRequest
+//
+// Turns Pickup 2.0 live delivery on or off for given connection.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) LiveDeliveryChange(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.LiveDeliveryChange, rw, req.Body)
+}
@@ -61,7 +61,7 @@ func TestOperation_GetRESTHandlers(t *testing.T) {
 	require.NotNil(t, svc)
 
 	handlers := svc.GetRESTHandlers()
-	require.Equal(t, len(handlers), 7)
+	require.Equal(t, len(handlers), 11)
 }
 
 func TestOperation_Register(t *testing.T) {
@@ -317,6 +317,65 @@ func TestOperation_BatchPickup(t *testing.T) {
 	})
 }
 
+func TestOperation_PickupV2(t *testing.T) {
+	t.Run("test pickup v2 - success", func(t *testing.T) {
+		svc, err := New(
+			newMockProvider(map[string]interface{}{
+				messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{
+					DeliveryRequestFunc: func(connectionID string, limit int) ([]string, error) {
+						return []string{"msg-1"}, nil
+					},
+				},
+				mediatorSvc.Coordination: &mockroute.MockMediatorSvc{},
+				oobsvc.Name:              &mockoob.MockOobService{},
+			}),
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, StatusV2Path)
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer([]byte(connIDRequest)), handler.Path())
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, svc, DeliveryRequestPath)
+		buf, err := getSuccessResponseFromHandler(handler,
+			bytes.NewBuffer([]byte(`{"connectionID":"abc-123","limit":10}`)), handler.Path())
+		require.NoError(t, err)
+
+		response := deliveryResponse{}
+		err = json.Unmarshal(buf.Bytes(), &response.Params)
+		require.NoError(t, err)
+		require.Equal(t, []string{"msg-1"}, response.Params.MessageIDs)
+
+		handler = lookupHandler(t, svc, MessagesReceivedPath)
+		_, err = getSuccessResponseFromHandler(handler,
+			bytes.NewBuffer([]byte(`{"connectionID":"abc-123","message_id_list":["msg-1"]}`)), handler.Path())
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, svc, LiveDeliveryChangePath)
+		_, err = getSuccessResponseFromHandler(handler,
+			bytes.NewBuffer([]byte(`{"connectionID":"abc-123","live_delivery":true}`)), handler.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("test pickup v2 - missing connectionID", func(t *testing.T) {
+		svc, err := New(newMockProvider(nil), false)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		for _, path := range []string{StatusV2Path, DeliveryRequestPath, MessagesReceivedPath, LiveDeliveryChangePath} {
+			handler := lookupHandler(t, svc, path)
+			buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer([]byte(`{}`)), handler.Path())
+			require.NoError(t, err)
+			require.NotEmpty(t, buf)
+
+			require.Equal(t, http.StatusBadRequest, code)
+			verifyError(t, mediator.PickupMissingConnIDCode, "connectionID is mandatory", buf.Bytes())
+		}
+	})
+}
+
 func newMockProvider(serviceMap map[string]interface{}) *mockprovider.Provider {
 	if serviceMap == nil {
 		serviceMap = map[string]interface{}{
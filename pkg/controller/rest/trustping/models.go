@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "github.com/markcryptohash/aries-framework-go/pkg/controller/command/trustping"
+
+// pingRequest model
+//
+// This is used for sending a trust ping over a connection.
+//
+// swagger:parameters pingRequest
+type pingRequest struct { // nolint: unused,deadcode
+	// Params for sending a trust ping.
+	//
+	// in: body
+	Params trustping.PingRequest
+}
+
+// pingResponse model
+//
+// Response of a trust ping containing the round-trip latency.
+//
+// swagger:response pingResponse
+type pingResponse struct {
+	// in: body
+	Params trustping.PingResponse
+}
@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/command/trustping"
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/rest"
+	trustpingSvc "github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new operation", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 1)
+	})
+
+	t.Run("test new operation - command creation fail", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping command")
+		require.Nil(t, op)
+	})
+}
+
+func TestOperation_Ping(t *testing.T) {
+	t.Run("test ping - success", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(connID string, params *trustpingSvc.PingParams) (*trustpingSvc.PingResult, error) {
+					return &trustpingSvc.PingResult{ConnectionID: connID, Responded: true, Latency: time.Second}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, PingPath)
+		buf, code := sendRequestToHandler(t, handler, bytes.NewBufferString(`{"connectionID":"abc-123"}`))
+		require.Equal(t, http.StatusOK, code)
+
+		response := pingResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response.Params))
+		require.True(t, response.Params.Responded)
+		require.Equal(t, time.Second, response.Params.Latency)
+	})
+
+	t.Run("test ping - error", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{PingErr: errors.New("ping error")},
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, PingPath)
+		buf, code := sendRequestToHandler(t, handler, bytes.NewBufferString(`{"connectionID":"abc-123"}`))
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, trustping.PingErrorCode, "ping error", buf.Bytes())
+	})
+
+	t.Run("test ping - missing connection ID", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, PingPath)
+		buf, code := sendRequestToHandler(t, handler, bytes.NewBufferString(`{}`))
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, trustping.PingMissingConnIDCode, "connectionID is mandatory", buf.Bytes())
+	})
+}
+
+func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader) (*bytes.Buffer, int) {
+	t.Helper()
+
+	req, err := http.NewRequest(handler.Method(), handler.Path(), requestBody)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
+
+func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, data []byte) {
+	t.Helper()
+
+	errResponse := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &errResponse))
+
+	require.EqualValues(t, expectedCode, errResponse.Code)
+	require.Contains(t, errResponse.Message, expectedMsg)
+}
@@ -391,3 +391,33 @@ type resolveCredentialManifestResponse struct {
 	// in: body
 	Response *vcwallet.ResolveCredentialManifestResponse `json:"response"`
 }
+
+// exportRequest is request model for exporting wallet.
+//
+// swagger:parameters exportReq
+type exportRequest struct { // nolint: unused,deadcode
+	// Params for exporting wallet.
+	//
+	// in: body
+	Params *vcwallet.ExportRequest
+}
+
+// exportResponse is response model for exporting wallet.
+//
+// swagger:response exportRes
+type exportResponse struct {
+	// Response containing exported locked wallet.
+	//
+	// in: body
+	Response *vcwallet.ExportResponse `json:"response"`
+}
+
+// importRequest is request model for importing wallet.
+//
+// swagger:parameters importReq
+type importRequest struct { // nolint: unused,deadcode
+	// Params for importing wallet.
+	//
+	// in: body
+	Params *vcwallet.ImportRequest
+}
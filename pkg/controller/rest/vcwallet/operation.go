@@ -50,6 +50,8 @@ const (
 	ProposeCredentialPath         = OperationID + "/propose-credential"
 	RequestCredentialPath         = OperationID + "/request-credential"
 	ResolveCredentialManifestPath = OperationID + "/resolve-credential-manifest"
+	ExportPath                    = OperationID + "/export"
+	ImportPath                    = OperationID + "/import"
 )
 
 // provider contains dependencies for the verifiable credential wallet command controller
@@ -120,6 +122,8 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(ProposeCredentialPath, http.MethodPost, o.ProposeCredential),
 		cmdutil.NewHTTPHandler(RequestCredentialPath, http.MethodPost, o.RequestCredential),
 		cmdutil.NewHTTPHandler(ResolveCredentialManifestPath, http.MethodPost, o.ResolveCredentialManifest),
+		cmdutil.NewHTTPHandler(ExportPath, http.MethodPost, o.Export),
+		cmdutil.NewHTTPHandler(ImportPath, http.MethodPost, o.Import),
 	}
 }
 
@@ -432,6 +436,30 @@ func (o *Operation) ResolveCredentialManifest(rw http.ResponseWriter, req *http.
 	rest.Execute(o.command.ResolveCredentialManifest, rw, req.Body)
 }
 
+// Export swagger:route POST /vcwallet/export vcwallet exportReq
+//
+// Exports wallet contents and keys into a locked wallet encrypted with given passphrase.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#export
+//
+// Responses:
+//    default: genericError
+//        200: exportRes
+func (o *Operation) Export(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Export, rw, req.Body)
+}
+
+// Import swagger:route POST /vcwallet/import vcwallet importReq
+//
+// Imports contents and keys of a locked wallet exported with given passphrase.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#import
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) Import(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Import, rw, req.Body)
+}
+
 // getIDFromRequest returns ID from request.
 func getIDFromRequest(rw http.ResponseWriter, req *http.Request) (string, bool) {
 	id := mux.Vars(req)["id"]
@@ -75,7 +75,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &vcwallet.Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetRESTHandlers(), 21)
+		require.Len(t, cmd.GetRESTHandlers(), 23)
 	})
 }
 
@@ -1214,6 +1214,91 @@ func TestOperation_CreateKeyPair(t *testing.T) {
 	})
 }
 
+func TestOperation_ExportImport(t *testing.T) {
+	const (
+		sampleUser1      = "sample-user-e01"
+		sampleUser2      = "sample-user-e02"
+		exportPassphrase = "export passphrase"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	rq := httptest.NewRequest(http.MethodPost, ExportPath, getReader(t, &vcwallet.ExportRequest{
+		WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token},
+		Passphrase: exportPassphrase,
+	}))
+	rw := httptest.NewRecorder()
+
+	cmd := New(mockctx, &vcwallet.Config{})
+	cmd.Export(rw, rq)
+	require.Equal(t, rw.Code, http.StatusOK)
+
+	var r exportResponse
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+	require.NotEmpty(t, r.Response.Wallet)
+
+	t.Run("import into a fresh profile", func(t *testing.T) {
+		freshctx := newMockProvider(t)
+
+		createSampleUserProfile(t, freshctx, &vcwallet.CreateOrUpdateProfileRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		token2, lock2 := unlockWallet(t, freshctx, &vcwallet.UnlockWalletRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		defer lock2()
+
+		rq := httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, &vcwallet.ImportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser2, Auth: token2},
+			Passphrase: exportPassphrase,
+			Wallet:     r.Response.Wallet,
+		}))
+		rw := httptest.NewRecorder()
+
+		New(freshctx, &vcwallet.Config{}).Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+	})
+
+	t.Run("export and import using invalid auth", func(t *testing.T) {
+		rq := httptest.NewRequest(http.MethodPost, ExportPath, getReader(t, &vcwallet.ExportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase: exportPassphrase,
+		}))
+		rw := httptest.NewRecorder()
+
+		cmd.Export(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid auth token")
+
+		rq = httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, &vcwallet.ImportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase: exportPassphrase,
+			Wallet:     r.Response.Wallet,
+		}))
+		rw = httptest.NewRecorder()
+
+		cmd.Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid auth token")
+	})
+}
+
 func TestOperation_Connect(t *testing.T) {
 	const sampleDIDCommUser = "sample-didcomm-user-01"
 
@@ -225,6 +225,52 @@ type signCredentialRes struct {
 	VerifiableCredential json.RawMessage `json:"verifiableCredential,omitempty"`
 }
 
+// createDomainLinkageCredentialReq model
+//
+// This is used to create a domain linkage credential.
+//
+// swagger:parameters createDomainLinkageCredentialReq
+type createDomainLinkageCredentialReq struct { // nolint: unused,deadcode
+	// Params for creating a domain linkage credential
+	//
+	// in: body
+	Params verifiable.DomainLinkageCredentialRequest
+}
+
+// createDomainLinkageCredentialRes model
+//
+// This is used for returning the create domain linkage credential response
+//
+// swagger:response createDomainLinkageCredentialRes
+type createDomainLinkageCredentialRes struct {
+
+	// in: body
+	DomainLinkageCredential json.RawMessage `json:"domainLinkageCredential,omitempty"`
+}
+
+// createDIDConfigurationReq model
+//
+// This is used to create a DID configuration.
+//
+// swagger:parameters createDIDConfigurationReq
+type createDIDConfigurationReq struct { // nolint: unused,deadcode
+	// Params for creating a DID configuration
+	//
+	// in: body
+	Params verifiable.DIDConfigurationRequest
+}
+
+// createDIDConfigurationRes model
+//
+// This is used for returning the create DID configuration response
+//
+// swagger:response createDIDConfigurationRes
+type createDIDConfigurationRes struct {
+
+	// in: body
+	DIDConfiguration json.RawMessage `json:"didConfiguration,omitempty"`
+}
+
 // deriveCredentialReq model
 //
 // This is used for deriving a credential.
@@ -41,6 +41,10 @@ const (
 	DeriveCredentialPath       = VerifiableOperationID + "/derivecredential"
 	RemoveCredentialByNamePath = verifiableCredentialPath + "/remove/name" + "/{name}"
 
+	// DID configuration paths.
+	CreateDomainLinkageCredentialPath = VerifiableOperationID + "/domainlinkagecredential"
+	CreateDIDConfigurationPath        = VerifiableOperationID + "/didconfiguration"
+
 	// presentation paths.
 	GeneratePresentationPath     = verifiablePresentationPath + "/generate"
 	GeneratePresentationByIDPath = verifiablePresentationPath + "/generatebyid"
@@ -100,6 +104,8 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(GetPresentationsPath, http.MethodGet, o.GetPresentations),
 		cmdutil.NewHTTPHandler(RemoveCredentialByNamePath, http.MethodPost, o.RemoveCredentialByName),
 		cmdutil.NewHTTPHandler(RemovePresentationByNamePath, http.MethodPost, o.RemovePresentationByName),
+		cmdutil.NewHTTPHandler(CreateDomainLinkageCredentialPath, http.MethodPost, o.CreateDomainLinkageCredential),
+		cmdutil.NewHTTPHandler(CreateDIDConfigurationPath, http.MethodPost, o.CreateDIDConfiguration),
 	}
 }
 
@@ -226,6 +232,28 @@ func (o *Operation) DeriveCredential(rw http.ResponseWriter, req *http.Request)
 	rest.Execute(o.command.DeriveCredential, rw, req.Body)
 }
 
+// CreateDomainLinkageCredential swagger:route POST /verifiable/domainlinkagecredential verifiable createDomainLinkageCredentialReq
+//
+// Creates Domain Linkage Credential linking the DID with the origin.
+//
+// Responses:
+//    default: genericError
+//        200: createDomainLinkageCredentialRes
+func (o *Operation) CreateDomainLinkageCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateDomainLinkageCredential, rw, req.Body)
+}
+
+// CreateDIDConfiguration swagger:route POST /verifiable/didconfiguration verifiable createDIDConfigurationReq
+//
+// Creates DID Configuration resource with the Domain Linkage Credentials of the DIDs.
+//
+// Responses:
+//    default: genericError
+//        200: createDIDConfigurationRes
+func (o *Operation) CreateDIDConfiguration(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateDIDConfiguration, rw, req.Body)
+}
+
 // GetPresentations swagger:route GET /verifiable/presentations verifiable
 //
 // Retrieves the verifiable credentials.
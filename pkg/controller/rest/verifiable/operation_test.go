@@ -37,6 +37,7 @@ import (
 	verifiableapi "github.com/markcryptohash/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/markcryptohash/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
 	cryptomock "github.com/markcryptohash/aries-framework-go/pkg/mock/crypto"
 	kmsmock "github.com/markcryptohash/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
@@ -280,7 +281,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 14, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 16, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -1385,6 +1386,96 @@ func TestRemoveVPByName(t *testing.T) {
 	})
 }
 
+func TestCreateDIDConfiguration(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				if didID == invalidDID {
+					return nil, errors.New("invalid")
+				}
+
+				didDoc, err := did.ParseDocument([]byte(doc))
+				require.NoError(t, err)
+
+				return &did.DocResolution{DIDDocument: didDoc}, nil
+			},
+		},
+		KMSValue:            &kmsmock.KeyManager{ExportPubKeyTypeValue: kms.ED25519Type},
+		CryptoValue:         &cryptomock.Crypto{SignValue: []byte("signature")},
+		DocumentLoaderValue: loader,
+	})
+	require.NoError(t, err)
+
+	expires := time.Now().Add(time.Hour)
+
+	t.Run("create domain linkage credential - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(verifiable.DomainLinkageCredentialRequest{
+			DID:            "did:peer:21tDAKCERh95uGgKbJNHYp",
+			Origin:         "https://example.com",
+			ExpirationDate: &expires,
+			Format:         verifiable.JWTFormat,
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, CreateDomainLinkageCredentialPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+
+		response := createDomainLinkageCredentialRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.NotEmpty(t, response.DomainLinkageCredential)
+	})
+
+	t.Run("create domain linkage credential - error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, CreateDomainLinkageCredentialPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"origin":"https://example.com"}`),
+			handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "did is mandatory", buf.Bytes())
+	})
+
+	t.Run("create DID configuration - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(verifiable.DIDConfigurationRequest{
+			Credentials: []verifiable.DomainLinkageCredentialRequest{{
+				DID:            "did:peer:21tDAKCERh95uGgKbJNHYp",
+				Origin:         "https://example.com",
+				ExpirationDate: &expires,
+			}},
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, CreateDIDConfigurationPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+
+		response := createDIDConfigurationRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Contains(t, string(response.DIDConfiguration), "linked_dids")
+	})
+
+	t.Run("create DID configuration - error", func(t *testing.T) {
+		reqBytes, err := json.Marshal(verifiable.DIDConfigurationRequest{
+			Credentials: []verifiable.DomainLinkageCredentialRequest{{
+				DID:            invalidDID,
+				Origin:         "https://example.com",
+				ExpirationDate: &expires,
+			}},
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, CreateDIDConfigurationPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, verifiable.CreateDIDConfigurationErrorCode, "create DID configuration", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
 	t.Helper()
 
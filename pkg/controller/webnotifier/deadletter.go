@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/rest"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+const (
+	// DeadLetterStoreName is the name of the store of the notifications that could not be delivered.
+	DeadLetterStoreName = "webhook_deadletters"
+
+	deadLetterTag = "deadletter"
+
+	// DeadLettersPath is the REST path of the dead letter queue.
+	DeadLettersPath = "/webhooks/deadletters"
+	// ReplayDeadLetterPath is the REST path to replay a dead letter.
+	ReplayDeadLetterPath = DeadLettersPath + "/{id}/replay"
+)
+
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.WebNotifier)
+
+	// ListDeadLettersErrorCode is for failures in list dead letters request.
+	ListDeadLettersErrorCode
+
+	// ReplayDeadLetterErrorCode is for failures in replay dead letter request.
+	ReplayDeadLetterErrorCode
+)
+
+// DeadLetter is a notification that could not be delivered to a subscriber.
+type DeadLetter struct {
+	ID       string          `json:"id"`
+	URL      string          `json:"url"`
+	Topic    string          `json:"topic"`
+	Message  json.RawMessage `json:"message"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	FailedAt time.Time       `json:"failed_at"`
+}
+
+func newDeadLetter(webhookURL, topic string, message []byte, attempts int, cause error) *DeadLetter {
+	return &DeadLetter{
+		ID:       uuid.New().String(),
+		URL:      webhookURL,
+		Topic:    topic,
+		Message:  message,
+		Attempts: attempts,
+		Error:    cause.Error(),
+		FailedAt: time.Now().UTC(),
+	}
+}
+
+// DeadLetterQueue persists the notifications that could not be delivered, so that they can be replayed.
+type DeadLetterQueue struct {
+	store storage.Store
+}
+
+// NewDeadLetterQueue returns a new instance of a DeadLetterQueue backed by the given storage provider.
+func NewDeadLetterQueue(provider storage.Provider) (*DeadLetterQueue, error) {
+	store, err := provider.OpenStore(DeadLetterStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open dead letter store: %w", err)
+	}
+
+	err = provider.SetStoreConfig(DeadLetterStoreName, storage.StoreConfiguration{TagNames: []string{deadLetterTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set dead letter store config: %w", err)
+	}
+
+	return &DeadLetterQueue{store: store}, nil
+}
+
+// Put stores the dead letter.
+func (q *DeadLetterQueue) Put(letter *DeadLetter) error {
+	letterBytes, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("marshal dead letter: %w", err)
+	}
+
+	return q.store.Put(letter.ID, letterBytes, storage.Tag{Name: deadLetterTag})
+}
+
+// Get returns the dead letter with the given ID.
+func (q *DeadLetterQueue) Get(id string) (*DeadLetter, error) {
+	letterBytes, err := q.store.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("get dead letter %s: %w", id, err)
+	}
+
+	letter := &DeadLetter{}
+
+	err = json.Unmarshal(letterBytes, letter)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal dead letter: %w", err)
+	}
+
+	return letter, nil
+}
+
+// List returns all the dead letters.
+func (q *DeadLetterQueue) List() ([]*DeadLetter, error) {
+	iter, err := q.store.Query(deadLetterTag)
+	if err != nil {
+		return nil, fmt.Errorf("query dead letter store: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	letters := []*DeadLetter{}
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next dead letter: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dead letter value: %w", err)
+		}
+
+		letter := &DeadLetter{}
+
+		err = json.Unmarshal(value, letter)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal dead letter: %w", err)
+		}
+
+		letters = append(letters, letter)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next dead letter: %w", err)
+		}
+	}
+
+	return letters, nil
+}
+
+// Delete removes the dead letter with the given ID.
+func (q *DeadLetterQueue) Delete(id string) error {
+	return q.store.Delete(id)
+}
+
+// registerHandlers registers the dead letter queue REST API endpoints.
+func (n *HTTPNotifier) registerHandlers() {
+	n.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(DeadLettersPath, http.MethodGet, n.ListDeadLetters),
+		cmdutil.NewHTTPHandler(ReplayDeadLetterPath, http.MethodPost, n.ReplayDeadLetter),
+	}
+}
+
+// GetRESTHandlers returns all REST handlers provided by notifier.
+func (n *HTTPNotifier) GetRESTHandlers() []rest.Handler {
+	return n.handlers
+}
+
+// ListDeadLetters swagger:route GET /webhooks/deadletters webhooks listDeadLetters
+//
+// Lists the webhook notifications that could not be delivered.
+//
+// Responses:
+//    default: genericError
+//        200: listDeadLettersResponse
+func (n *HTTPNotifier) ListDeadLetters(rw http.ResponseWriter, _ *http.Request) {
+	letters, err := n.deadLetters.List()
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, ListDeadLettersErrorCode, err)
+
+		return
+	}
+
+	writeResponse(rw, &DeadLettersResponse{DeadLetters: letters})
+}
+
+// ReplayDeadLetter swagger:route POST /webhooks/deadletters/{id}/replay webhooks replayDeadLetter
+//
+// Sends a webhook notification that could not be delivered again, it is removed from the dead letters once
+// delivered.
+//
+// Responses:
+//    default: genericError
+func (n *HTTPNotifier) ReplayDeadLetter(rw http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)["id"]
+	if id == "" {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, InvalidRequestErrorCode,
+			fmt.Errorf("empty dead letter ID"))
+
+		return
+	}
+
+	err := n.Replay(id)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, ReplayDeadLetterErrorCode, err)
+
+		return
+	}
+
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, ReplayDeadLetterErrorCode, err)
+
+		return
+	}
+
+	writeResponse(rw, struct{}{})
+}
+
+func writeResponse(rw http.ResponseWriter, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		logger.Errorf("Unable to send a response: %v", err)
+	}
+}
+
+// DeadLettersResponse is the response of the list dead letters request.
+type DeadLettersResponse struct {
+	DeadLetters []*DeadLetter `json:"deadLetters"`
+}
+
+// listDeadLettersResponse model
+//
+// This is used for returning the webhook notifications that could not be delivered.
+//
+// swagger:response listDeadLettersResponse
+type listDeadLettersResponse struct { // nolint: unused,deadcode
+	// in: body
+	Params DeadLettersResponse
+}
+
+// replayDeadLetterRequest model
+//
+// This is used for replaying a webhook notification that could not be delivered.
+//
+// swagger:parameters replayDeadLetter
+type replayDeadLetterRequest struct { // nolint: unused,deadcode
+	// The ID of the dead letter
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
@@ -0,0 +1,199 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/command"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNewDeadLetterQueue(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+		require.NoError(t, err)
+		require.NotNil(t, queue)
+	})
+
+	t.Run("error - open store", func(t *testing.T) {
+		queue, err := NewDeadLetterQueue(&mockstore.MockStoreProvider{FailNamespace: DeadLetterStoreName})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open dead letter store")
+		require.Nil(t, queue)
+	})
+
+	t.Run("error - set store config", func(t *testing.T) {
+		provider := mockstore.NewMockStoreProvider()
+		provider.ErrSetStoreConfig = errors.New("config error")
+
+		queue, err := NewDeadLetterQueue(provider)
+		require.EqualError(t, err, "set dead letter store config: config error")
+		require.Nil(t, queue)
+	})
+}
+
+func TestDeadLetterQueue(t *testing.T) {
+	t.Run("put, get, list and delete", func(t *testing.T) {
+		queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		letters, err := queue.List()
+		require.NoError(t, err)
+		require.Empty(t, letters)
+
+		letter := newDeadLetter(localhost8080URL, topic, []byte(`{"id":"1"}`), 3, errors.New("failed"))
+		require.NoError(t, queue.Put(letter))
+
+		stored, err := queue.Get(letter.ID)
+		require.NoError(t, err)
+		require.Equal(t, letter.URL, stored.URL)
+		require.Equal(t, letter.Topic, stored.Topic)
+		require.JSONEq(t, string(letter.Message), string(stored.Message))
+		require.Equal(t, 3, stored.Attempts)
+		require.Equal(t, "failed", stored.Error)
+
+		letters, err = queue.List()
+		require.NoError(t, err)
+		require.Len(t, letters, 1)
+
+		require.NoError(t, queue.Delete(letter.ID))
+
+		letters, err = queue.List()
+		require.NoError(t, err)
+		require.Empty(t, letters)
+	})
+
+	t.Run("error - store errors", func(t *testing.T) {
+		provider := mockstore.NewMockStoreProvider()
+
+		queue, err := NewDeadLetterQueue(provider)
+		require.NoError(t, err)
+
+		provider.Store.ErrPut = errors.New("put error")
+		require.EqualError(t, queue.Put(&DeadLetter{ID: "id"}), "put error")
+
+		provider.Store.ErrGet = errors.New("get error")
+		_, err = queue.Get("id")
+		require.EqualError(t, err, "get dead letter id: get error")
+
+		provider.Store.ErrQuery = errors.New("query error")
+		_, err = queue.List()
+		require.EqualError(t, err, "query dead letter store: query error")
+	})
+
+	t.Run("error - invalid dead letter", func(t *testing.T) {
+		provider := mockstore.NewMockStoreProvider()
+
+		queue, err := NewDeadLetterQueue(provider)
+		require.NoError(t, err)
+
+		provider.Store.Store["id"] = mockstore.DBEntry{Value: []byte("{")}
+
+		_, err = queue.Get("id")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal dead letter")
+	})
+}
+
+func TestDeadLetterHandlers(t *testing.T) {
+	provider := mockstore.NewMockStoreProvider()
+
+	queue, err := NewDeadLetterQueue(provider)
+	require.NoError(t, err)
+
+	client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK), nil
+	}}
+
+	n := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client), WithDeadLetterQueue(queue))
+	require.Len(t, n.GetRESTHandlers(), 2)
+
+	letter := newDeadLetter(localhost8080URL, topic, []byte(`{"id":"1"}`), 1, errors.New("failed"))
+	require.NoError(t, queue.Put(letter))
+
+	t.Run("list dead letters", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		n.ListDeadLetters(rw, httptest.NewRequest(http.MethodGet, DeadLettersPath, nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		resp := DeadLettersResponse{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		require.Len(t, resp.DeadLetters, 1)
+		require.Equal(t, letter.ID, resp.DeadLetters[0].ID)
+	})
+
+	t.Run("replay dead letter", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		n.ReplayDeadLetter(rw, replayRequest(letter.ID))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		letters, err := queue.List()
+		require.NoError(t, err)
+		require.Empty(t, letters)
+	})
+
+	t.Run("error - replay unknown dead letter", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		n.ReplayDeadLetter(rw, replayRequest("unknown"))
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		verifyError(t, rw, ReplayDeadLetterErrorCode, "data not found")
+	})
+
+	t.Run("error - replay without ID", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		n.ReplayDeadLetter(rw, replayRequest(""))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		verifyError(t, rw, InvalidRequestErrorCode, "empty dead letter ID")
+	})
+
+	t.Run("error - replay fails", func(t *testing.T) {
+		failed := newDeadLetter("http://removed", topic, []byte(`{"id":"1"}`), 1, errors.New("failed"))
+		require.NoError(t, queue.Put(failed))
+
+		rw := httptest.NewRecorder()
+		n.ReplayDeadLetter(rw, replayRequest(failed.ID))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		verifyError(t, rw, ReplayDeadLetterErrorCode, "is not configured")
+	})
+
+	t.Run("error - list dead letters", func(t *testing.T) {
+		provider.Store.ErrQuery = errors.New("query error")
+		defer func() { provider.Store.ErrQuery = nil }()
+
+		rw := httptest.NewRecorder()
+		n.ListDeadLetters(rw, httptest.NewRequest(http.MethodGet, DeadLettersPath, nil))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		verifyError(t, rw, ListDeadLettersErrorCode, "query error")
+	})
+}
+
+func replayRequest(id string) *http.Request {
+	return mux.SetURLVars(httptest.NewRequest(http.MethodPost,
+		strings.ReplaceAll(ReplayDeadLetterPath, "{id}", id), nil), map[string]string{"id": id})
+}
+
+func verifyError(t *testing.T, rw *httptest.ResponseRecorder, expectedCode command.Code, expectedMsg string) {
+	t.Helper()
+
+	errResp := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &errResp))
+	require.EqualValues(t, expectedCode, errResp.Code)
+	require.Contains(t, errResp.Message, expectedMsg)
+}
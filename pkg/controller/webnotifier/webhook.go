@@ -9,24 +9,205 @@ package webnotifier
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/controller/rest"
+)
+
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of a notification sent to a subscriber
+	// with a secret, its value is "sha256=" followed by the hex encoded MAC of the TimestampHeader value,
+	// a "." and the request body.
+	SignatureHeader = "X-Aries-Signature"
+
+	// TimestampHeader is the header carrying the unix time at which a notification was signed.
+	TimestampHeader = "X-Aries-Timestamp"
+
+	signaturePrefix = "sha256="
+
+	defaultMaxAttempts     = 1
+	defaultInitialInterval = time.Second
+	defaultMaxInterval     = time.Minute
+	defaultMultiplier      = 2
+
+	// deliveryQueueSize is the number of notifications queued for a subscriber, the notifications are dropped
+	// (and stored in the dead letter queue if enabled) when the queue of a subscriber is full.
+	deliveryQueueSize = 100
 )
 
+// HTTPClient represents an HTTP client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Subscriber is a webhook subscriber.
+type Subscriber struct {
+	// URL is the webhook URL the notifications are posted to.
+	URL string
+	// Secret is the HMAC key the notifications are signed with, notifications are not signed if empty.
+	Secret string
+	// Topics are the topics the subscriber is notified of, the subscriber is notified of all topics if empty.
+	Topics []string
+}
+
+func (s *Subscriber) accepts(topic string) bool {
+	if len(s.Topics) == 0 {
+		return true
+	}
+
+	for _, t := range s.Topics {
+		if t == topic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetryParams configures how the HTTPNotifier retries failed notifications.
+type RetryParams struct {
+	// MaxAttempts is the number of delivery attempts, including the first one, before a notification fails.
+	MaxAttempts int
+	// InitialInterval is the delay before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between two retries.
+	MaxInterval time.Duration
+	// Multiplier is applied to the delay after each retry.
+	Multiplier float64
+}
+
+// DefaultRetryParams returns the default retry parameters, notifications are attempted only once.
+func DefaultRetryParams() *RetryParams {
+	return &RetryParams{
+		MaxAttempts:     defaultMaxAttempts,
+		InitialInterval: defaultInitialInterval,
+		MaxInterval:     defaultMaxInterval,
+		Multiplier:      defaultMultiplier,
+	}
+}
+
+func (p *RetryParams) backOff() backoff.BackOff {
+	var maxRetries uint64
+
+	if p.MaxAttempts > 1 {
+		maxRetries = uint64(p.MaxAttempts - 1)
+	}
+
+	b := &backoff.ExponentialBackOff{
+		InitialInterval: p.InitialInterval,
+		Multiplier:      p.Multiplier,
+		MaxInterval:     p.MaxInterval,
+		Stop:            backoff.Stop,
+		Clock:           backoff.SystemClock,
+	}
+	b.Reset()
+
+	return backoff.WithMaxRetries(b, maxRetries)
+}
+
+// HTTPNotifierOpt configures the HTTPNotifier.
+type HTTPNotifierOpt func(n *HTTPNotifier)
+
+// WithSubscribers adds subscribers with their own secret and topics to the HTTPNotifier.
+func WithSubscribers(subscribers ...*Subscriber) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.subscribers = append(n.subscribers, subscribers...)
+	}
+}
+
+// WithHTTPClient sets the HTTP client the notifications are posted with.
+func WithHTTPClient(client HTTPClient) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.client = client
+	}
+}
+
+// WithRetry enables the retry of failed notifications with an exponential backoff.
+func WithRetry(params *RetryParams) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.retry = params
+	}
+}
+
+// WithDeadLetterQueue stores the notifications that could not be delivered after the last attempt in the given
+// queue, they can be listed and replayed through the REST handlers of the HTTPNotifier.
+func WithDeadLetterQueue(queue *DeadLetterQueue) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.deadLetters = queue
+	}
+}
+
 // HTTPNotifier is a webhook dispatcher capable of notifying multiple subscribers via HTTP.
+// Each subscriber is notified by its own worker, so a slow or unreachable subscriber doesn't delay the others.
 type HTTPNotifier struct {
-	urls []string
+	subscribers []*Subscriber
+	client      HTTPClient
+	retry       *RetryParams
+	deadLetters *DeadLetterQueue
+	handlers    []rest.Handler
+	workers     []*worker
+	workersWG   sync.WaitGroup
+	closeMutex  sync.RWMutex
+	closed      bool
+}
+
+// worker delivers the notifications queued for a subscriber, in order.
+type worker struct {
+	subscriber *Subscriber
+	queue      chan *notification
+}
+
+type notification struct {
+	topic   string
+	message []byte
 }
 
 // NewHTTPNotifier returns a new instance of an HTTPNotifier.
-func NewHTTPNotifier(webhookURLs []string) *HTTPNotifier {
-	return &HTTPNotifier{urls: webhookURLs}
+// Webhook URLs are subscribers of all topics whose notifications are not signed.
+func NewHTTPNotifier(webhookURLs []string, opts ...HTTPNotifierOpt) *HTTPNotifier {
+	n := &HTTPNotifier{
+		client: http.DefaultClient,
+		retry:  DefaultRetryParams(),
+	}
+
+	for _, webhookURL := range webhookURLs {
+		n.subscribers = append(n.subscribers, &Subscriber{URL: webhookURL})
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	if n.deadLetters != nil {
+		n.registerHandlers()
+	}
+
+	for _, subscriber := range n.subscribers {
+		w := &worker{subscriber: subscriber, queue: make(chan *notification, deliveryQueueSize)}
+
+		n.workers = append(n.workers, w)
+		n.workersWG.Add(1)
+
+		go n.deliver(w)
+	}
+
+	return n
 }
 
-// Notify sends the given message to all of the urls.
-// Topic is appended to the end of the webhook (subscriber) URL. E.g. localhost:8080/topic
-// If multiple errors are encountered, then the first one is returned.
+// Notify queues the given message for delivery to all of the subscribers of the topic, it doesn't wait for the
+// message to be delivered. Notifications that could not be delivered are logged and stored in the dead letter queue
+// if enabled. An error is returned if the message could not be queued for a subscriber.
 func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 	if topic == "" {
 		return fmt.Errorf(emptyTopicErrMsg)
@@ -41,27 +222,164 @@ func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 		return fmt.Errorf(failedToCreateErrMsg, err)
 	}
 
+	n.closeMutex.RLock()
+	defer n.closeMutex.RUnlock()
+
+	if n.closed {
+		return errors.New("webhook notifier is closed")
+	}
+
 	var allErrs error
 
-	for _, webhookURL := range n.urls {
-		err := notifyWH(webhookURL, topicMsg)
-		allErrs = appendError(allErrs, err)
+	for _, w := range n.workers {
+		if !w.subscriber.accepts(topic) {
+			continue
+		}
+
+		select {
+		case w.queue <- &notification{topic: topic, message: topicMsg}:
+		default:
+			err = fmt.Errorf("notification queue of %s is full", w.subscriber.URL)
+
+			n.putDeadLetter(w.subscriber.URL, topic, topicMsg, 0, err)
+
+			allErrs = appendError(allErrs, err)
+		}
 	}
 
 	return allErrs
 }
 
-func notifyWH(destination string, message []byte) error {
+// Close stops accepting notifications and waits for the queued notifications to be delivered.
+func (n *HTTPNotifier) Close() {
+	n.closeMutex.Lock()
+
+	if n.closed {
+		n.closeMutex.Unlock()
+
+		return
+	}
+
+	n.closed = true
+
+	for _, w := range n.workers {
+		close(w.queue)
+	}
+
+	n.closeMutex.Unlock()
+
+	n.workersWG.Wait()
+}
+
+// deliver sends the notifications queued for the subscriber of the worker until the notifier is closed.
+func (n *HTTPNotifier) deliver(w *worker) {
+	defer n.workersWG.Done()
+
+	for notif := range w.queue {
+		attempts, err := n.send(w.subscriber, notif.message)
+		if err != nil {
+			logger.Warnf("failed to notify %s of %s after %d attempt(s): %v", w.subscriber.URL, notif.topic,
+				attempts, err)
+
+			n.putDeadLetter(w.subscriber.URL, notif.topic, notif.message, attempts, err)
+		}
+	}
+}
+
+// Replay sends the dead letter with the given ID to its subscriber again, the dead letter is removed from
+// the queue once delivered.
+func (n *HTTPNotifier) Replay(id string) error {
+	if n.deadLetters == nil {
+		return errors.New("dead letter queue is not enabled")
+	}
+
+	letter, err := n.deadLetters.Get(id)
+	if err != nil {
+		return err
+	}
+
+	subscriber := n.subscriber(letter.URL)
+	if subscriber == nil {
+		return fmt.Errorf("webhook subscriber %s is not configured", letter.URL)
+	}
+
+	attempts, err := n.send(subscriber, letter.Message)
+	if err != nil {
+		letter.Attempts += attempts
+		letter.Error = err.Error()
+		letter.FailedAt = time.Now().UTC()
+
+		if e := n.deadLetters.Put(letter); e != nil {
+			logger.Errorf("failed to update dead letter %s: %v", id, e)
+		}
+
+		return fmt.Errorf("replay dead letter %s: %w", id, err)
+	}
+
+	return n.deadLetters.Delete(id)
+}
+
+func (n *HTTPNotifier) subscriber(webhookURL string) *Subscriber {
+	for _, subscriber := range n.subscribers {
+		if subscriber.URL == webhookURL {
+			return subscriber
+		}
+	}
+
+	return nil
+}
+
+// send posts the message to the subscriber, failed attempts are retried with an exponential backoff unless the
+// subscriber rejected the notification. It returns the number of attempts made.
+func (n *HTTPNotifier) send(subscriber *Subscriber, message []byte) (int, error) {
+	var attempts int
+
+	err := backoff.Retry(func() error {
+		attempts++
+
+		return notifyWH(n.client, subscriber, message)
+	}, n.retry.backOff())
+
+	return attempts, err
+}
+
+func (n *HTTPNotifier) putDeadLetter(webhookURL, topic string, message []byte, attempts int, cause error) {
+	if n.deadLetters == nil {
+		return
+	}
+
+	letter := newDeadLetter(webhookURL, topic, message, attempts, cause)
+
+	err := n.deadLetters.Put(letter)
+	if err != nil {
+		logger.Errorf("failed to store notification for %s in the dead letter queue: %v", webhookURL, err)
+
+		return
+	}
+
+	logger.Warnf("notification for %s stored in the dead letter queue as %s", webhookURL, letter.ID)
+}
+
+func notifyWH(client HTTPClient, subscriber *Subscriber, message []byte) error {
 	ctx, cancel := context.WithTimeout(context.Background(), notificationSendTimeout)
 	defer cancel()
 
+	destination := subscriber.URL
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, destination,
 		bytes.NewBuffer(message))
 	if err != nil {
 		return fmt.Errorf("failed to create new http post request for %s: %w", destination, err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	if subscriber.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(subscriber.Secret, timestamp, message))
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to post notification to %s: %w", destination, err)
 	}
@@ -73,8 +391,37 @@ func notifyWH(destination string, message []byte) error {
 		return nil
 	}
 
-	return fmt.Errorf("notification was sent to %s, but %s was received",
+	err = fmt.Errorf("notification was sent to %s, but %s was received",
 		destination, resp.Status)
+
+	// the subscriber rejected the notification, sending it again won't change the outcome.
+	if isClientError(resp.StatusCode) {
+		return backoff.Permanent(err)
+	}
+
+	return err
+}
+
+// isClientError returns true for the 4xx statuses other than the ones asking the client to try again later.
+func isClientError(status int) bool {
+	return status >= http.StatusBadRequest && status < http.StatusInternalServerError &&
+		status != http.StatusRequestTimeout && status != http.StatusTooManyRequests
+}
+
+// Sign returns the SignatureHeader value of a notification body signed with the secret at the given timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	// HMAC returns empty error on Write()
+	_, _ = mac.Write([]byte(timestamp + "."))
+	_, _ = mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the SignatureHeader and TimestampHeader values of a notification received by
+// a subscriber with the given secret.
+func VerifySignature(secret, timestamp, signature string, body []byte) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
 }
 
 func closeResponse(c io.Closer) {
@@ -7,11 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package webnotifier
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,6 +23,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/markcryptohash/aries-framework-go/pkg/internal/test/transportutil"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
 )
 
 const (
@@ -119,12 +124,18 @@ func TestNotifyMultipleWebhooks(t *testing.T) {
 }
 
 func TestNotifyUnsupportedProtocol(t *testing.T) {
-	testNotifier := NewHTTPNotifier([]string{"badURL"})
+	queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+	require.NoError(t, err)
 
-	err := testNotifier.Notify(topic, getTestBasicMessageJSON())
-	require.Error(t, err)
+	testNotifier := NewHTTPNotifier([]string{"badURL"}, WithDeadLetterQueue(queue))
 
-	require.Contains(t, err.Error(), "unsupported protocol")
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	testNotifier.Close()
+
+	letters, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	require.Contains(t, letters[0].Error, "unsupported protocol")
 }
 
 func TestNotifyCorrectJSON(t *testing.T) {
@@ -144,7 +155,9 @@ func TestNotifyCorrectJSON(t *testing.T) {
 	msg, err := PrepareTopicMessage("test-topic", getTestBasicMessageJSON())
 	require.NoError(t, err)
 
-	err = notifyWH(fmt.Sprintf("http://%s%s", clientHost, topicWithLeadingSlash), msg)
+	subscriber := &Subscriber{URL: fmt.Sprintf("http://%s%s", clientHost, topicWithLeadingSlash)}
+
+	err = notifyWH(http.DefaultClient, subscriber, msg)
 	require.NoError(t, err)
 }
 
@@ -168,13 +181,14 @@ func TestNotifyMalformedJSON(t *testing.T) {
 		"state": "SomeState"
    }
 		`)
-	err := notifyWH(fmt.Sprintf("http://%s%s", clientHost, topicWithLeadingSlash), malformedBasicMessage)
+	err := notifyWH(http.DefaultClient,
+		&Subscriber{URL: fmt.Sprintf("http://%s%s", clientHost, topicWithLeadingSlash)}, malformedBasicMessage)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "400 Bad Request")
 }
 
 func TestWebhookNotificationMalformedURL(t *testing.T) {
-	err := notifyWH("%", nil)
+	err := notifyWH(http.DefaultClient, &Subscriber{URL: "%"}, nil)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), `invalid URL escape "%"`)
 }
@@ -198,12 +212,91 @@ func TestNotifyEmptyMessage(t *testing.T) {
 }
 
 func TestNotifyMultipleErrors(t *testing.T) {
-	testNotifier := NewHTTPNotifier([]string{"badURL1", "badURL2"})
+	queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	testNotifier := NewHTTPNotifier([]string{"badURL1", "badURL2"}, WithDeadLetterQueue(queue))
+
+	require.NoError(t, testNotifier.Notify("someTopic", []byte(`{}`)))
+	testNotifier.Close()
+
+	letters, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+
+	for _, letter := range letters {
+		require.Contains(t, letter.Error, `unsupported protocol scheme`)
+	}
+}
+
+func TestNotifyQueueFull(t *testing.T) {
+	queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	unblock := make(chan struct{})
+
+	client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		<-unblock
+
+		return newResponse(http.StatusOK), nil
+	}}
+
+	testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client), WithDeadLetterQueue(queue))
+
+	// the first notification is taken by the worker, the next ones fill the queue.
+	for i := 0; i <= deliveryQueueSize+1; i++ {
+		err = testNotifier.Notify(topic, getTestBasicMessageJSON())
+		if err != nil {
+			break
+		}
+	}
 
-	err := testNotifier.Notify("someTopic", []byte(`{}`))
 	require.Error(t, err)
+	require.Contains(t, err.Error(), "notification queue of "+localhost8080URL+" is full")
 
-	require.Contains(t, err.Error(), `unsupported protocol scheme`)
+	close(unblock)
+	testNotifier.Close()
+
+	letters, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	require.Equal(t, 0, letters[0].Attempts)
+}
+
+func TestNotifySlowSubscriber(t *testing.T) {
+	unblock := make(chan struct{})
+	delivered := make(chan struct{})
+
+	client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == "http://slow" {
+			<-unblock
+		} else {
+			close(delivered)
+		}
+
+		return newResponse(http.StatusOK), nil
+	}}
+
+	testNotifier := NewHTTPNotifier([]string{"http://slow", "http://fast"}, WithHTTPClient(client))
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		require.FailNow(t, "the fast subscriber was not notified")
+	}
+
+	close(unblock)
+	testNotifier.Close()
+}
+
+func TestNotifyClosed(t *testing.T) {
+	testNotifier := NewHTTPNotifier([]string{localhost8080URL})
+	testNotifier.Close()
+	testNotifier.Close()
+
+	require.EqualError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()), "webhook notifier is closed")
 }
 
 func TestWebhookNotificationClient500Response(t *testing.T) {
@@ -224,11 +317,263 @@ func TestWebhookNotificationClient500Response(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err := notifyWH(fmt.Sprintf("http://%s%s", clientHost, clientHandlerPattern), nil)
+	subscriber := &Subscriber{URL: fmt.Sprintf("http://%s%s", clientHost, clientHandlerPattern)}
+
+	err := notifyWH(http.DefaultClient, subscriber, nil)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "500 Internal Server Error", err.Error())
 }
 
+func TestNotifySignedMessage(t *testing.T) {
+	const secret = "s3cr3t"
+
+	var received int
+
+	client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		received++
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		timestamp := req.Header.Get(TimestampHeader)
+		require.NotEmpty(t, timestamp)
+
+		signature := req.Header.Get(SignatureHeader)
+		require.True(t, strings.HasPrefix(signature, "sha256="))
+		require.True(t, VerifySignature(secret, timestamp, signature, body))
+		require.False(t, VerifySignature("other", timestamp, signature, body))
+
+		return newResponse(http.StatusOK), nil
+	}}
+
+	testNotifier := NewHTTPNotifier(nil, WithHTTPClient(client),
+		WithSubscribers(&Subscriber{URL: localhost8080URL, Secret: secret}))
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	testNotifier.Close()
+
+	require.Equal(t, 1, received)
+}
+
+func TestNotifyUnsignedMessage(t *testing.T) {
+	client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		require.Empty(t, req.Header.Get(TimestampHeader))
+		require.Empty(t, req.Header.Get(SignatureHeader))
+
+		return newResponse(http.StatusOK), nil
+	}}
+
+	testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client))
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	testNotifier.Close()
+}
+
+func TestNotifyTopicFilter(t *testing.T) {
+	var mutex sync.Mutex
+
+	received := map[string]int{}
+
+	// the subscribers are notified concurrently.
+	client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		received[req.URL.String()]++
+
+		return newResponse(http.StatusOK), nil
+	}}
+
+	testNotifier := NewHTTPNotifier([]string{"http://all"}, WithHTTPClient(client), WithSubscribers(
+		&Subscriber{URL: "http://basicmessages", Topics: []string{topic}},
+		&Subscriber{URL: "http://other", Topics: []string{"didexchange_states", "present-proof_actions"}},
+	))
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	require.NoError(t, testNotifier.Notify("didexchange_states", getTestBasicMessageJSON()))
+	testNotifier.Close()
+
+	require.Equal(t, map[string]int{
+		"http://all":           2,
+		"http://basicmessages": 1,
+		"http://other":         1,
+	}, received)
+}
+
+func TestNotifyRetry(t *testing.T) {
+	retry := &RetryParams{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     10 * time.Millisecond,
+		Multiplier:      2,
+	}
+
+	t.Run("delivered after retries", func(t *testing.T) {
+		var attempts int
+
+		client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			attempts++
+
+			if attempts < retry.MaxAttempts {
+				return newResponse(http.StatusServiceUnavailable), nil
+			}
+
+			return newResponse(http.StatusOK), nil
+		}}
+
+		testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client), WithRetry(retry))
+
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		testNotifier.Close()
+
+		require.Equal(t, retry.MaxAttempts, attempts)
+	})
+
+	t.Run("fails after the last attempt", func(t *testing.T) {
+		var attempts int
+
+		client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			attempts++
+
+			return nil, errors.New("connection refused")
+		}}
+
+		testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client), WithRetry(retry),
+			WithDeadLetterQueue(newDeadLetterQueue(t)))
+
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		testNotifier.Close()
+
+		letters, err := testNotifier.deadLetters.List()
+		require.NoError(t, err)
+		require.Len(t, letters, 1)
+		require.Contains(t, letters[0].Error, "connection refused")
+		require.Equal(t, retry.MaxAttempts, attempts)
+	})
+
+	t.Run("attempted once by default", func(t *testing.T) {
+		var attempts int
+
+		client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			attempts++
+
+			return newResponse(http.StatusInternalServerError), nil
+		}}
+
+		testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client))
+
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		testNotifier.Close()
+
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("rejected notifications are not retried", func(t *testing.T) {
+		var attempts int
+
+		client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			attempts++
+
+			return newResponse(http.StatusBadRequest), nil
+		}}
+
+		testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client), WithRetry(retry),
+			WithDeadLetterQueue(newDeadLetterQueue(t)))
+
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		testNotifier.Close()
+
+		letters, err := testNotifier.deadLetters.List()
+		require.NoError(t, err)
+		require.Len(t, letters, 1)
+		require.Contains(t, letters[0].Error, "400 Bad Request")
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("throttled notifications are retried", func(t *testing.T) {
+		var attempts int
+
+		client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			attempts++
+
+			return newResponse(http.StatusTooManyRequests), nil
+		}}
+
+		testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client), WithRetry(retry))
+
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		testNotifier.Close()
+
+		require.Equal(t, retry.MaxAttempts, attempts)
+	})
+}
+
+func TestNotifyDeadLetter(t *testing.T) {
+	available := false
+
+	client := &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if available {
+			return newResponse(http.StatusOK), nil
+		}
+
+		return newResponse(http.StatusInternalServerError), nil
+	}}
+
+	queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	testNotifier := NewHTTPNotifier([]string{localhost8080URL}, WithHTTPClient(client), WithDeadLetterQueue(queue),
+		WithRetry(&RetryParams{MaxAttempts: 2, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}))
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	testNotifier.Close()
+
+	letters, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	require.Equal(t, localhost8080URL, letters[0].URL)
+	require.Equal(t, topic, letters[0].Topic)
+	require.Equal(t, 2, letters[0].Attempts)
+	require.Contains(t, letters[0].Error, "500 Internal Server Error")
+
+	t.Run("replay fails", func(t *testing.T) {
+		replayErr := testNotifier.Replay(letters[0].ID)
+		require.Error(t, replayErr)
+		require.Contains(t, replayErr.Error(), "replay dead letter")
+
+		letter, getErr := queue.Get(letters[0].ID)
+		require.NoError(t, getErr)
+		require.Equal(t, 4, letter.Attempts)
+	})
+
+	t.Run("replay succeeds", func(t *testing.T) {
+		available = true
+
+		require.NoError(t, testNotifier.Replay(letters[0].ID))
+
+		_, getErr := queue.Get(letters[0].ID)
+		require.True(t, errors.Is(getErr, storage.ErrDataNotFound))
+	})
+
+	t.Run("replay unknown dead letter", func(t *testing.T) {
+		replayErr := testNotifier.Replay("unknown")
+		require.True(t, errors.Is(replayErr, storage.ErrDataNotFound))
+	})
+
+	t.Run("replay to a removed subscriber", func(t *testing.T) {
+		letter := newDeadLetter("http://removed", topic, getTestBasicMessageJSON(), 1, errors.New("failed"))
+		require.NoError(t, queue.Put(letter))
+
+		replayErr := testNotifier.Replay(letter.ID)
+		require.EqualError(t, replayErr, "webhook subscriber http://removed is not configured")
+	})
+
+	t.Run("dead letter queue not enabled", func(t *testing.T) {
+		replayErr := NewHTTPNotifier([]string{localhost8080URL}).Replay(letters[0].ID)
+		require.EqualError(t, replayErr, "dead letter queue is not enabled")
+	})
+}
+
 func getTestBasicMessageJSON() []byte {
 	return []byte(`
    {
@@ -308,6 +653,31 @@ func listenAndStopAfterReceivingNotification(addr string) error {
 	}
 }
 
+type mockHTTPClient struct {
+	doFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (c *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return c.doFunc(req)
+}
+
+func newDeadLetterQueue(t *testing.T) *DeadLetterQueue {
+	t.Helper()
+
+	queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	return queue
+}
+
+func newResponse(status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}
+}
+
 func randomURL() string {
 	return fmt.Sprintf("localhost:%d", transportutil.GetRandomPort(3))
 }
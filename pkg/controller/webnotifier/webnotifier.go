@@ -34,13 +34,14 @@ type WebNotifier struct {
 }
 
 // New returns a new instance of a WebNotifier.
-func New(wsPath string, webhookURLs []string) *WebNotifier {
-	webhook := NewHTTPNotifier(webhookURLs)
+// The webhook options configure the subscribers, retries and dead letter queue of the HTTP notifier.
+func New(wsPath string, webhookURLs []string, webhookOpts ...HTTPNotifierOpt) *WebNotifier {
+	webhook := NewHTTPNotifier(webhookURLs, webhookOpts...)
 	ws := NewWSNotifier(wsPath)
 
 	n := WebNotifier{
 		notifiers: []command.Notifier{webhook, ws},
-		handlers:  ws.GetRESTHandlers(),
+		handlers:  append(ws.GetRESTHandlers(), webhook.GetRESTHandlers()...),
 	}
 
 	return &n
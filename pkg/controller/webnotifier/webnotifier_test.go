@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
 )
 
 func TestNew(t *testing.T) {
@@ -20,6 +22,16 @@ func TestNew(t *testing.T) {
 		require.Equal(t, 1, len(n.handlers))
 	})
 
+	t.Run("New WebNotifier (dead letter queue)", func(t *testing.T) {
+		queue, err := NewDeadLetterQueue(mockstore.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		n := New("/", []string{"http://localhost:8080"}, WithDeadLetterQueue(queue))
+		require.NotNil(t, n)
+		require.Equal(t, 2, len(n.notifiers))
+		require.Equal(t, 3, len(n.handlers))
+	})
+
 	t.Run("New WebNotifier (nil)", func(t *testing.T) {
 		n := New("", nil)
 		require.NotNil(t, n)
@@ -54,8 +54,29 @@ func New(p Provider) *Creator {
 	}
 }
 
+// Option configures the peer DID created by CreatePeerDIDV2.
+type Option func(opts *createOpts)
+
+type createOpts struct {
+	numAlgo string
+}
+
+// WithNumAlgo sets the numalgo of the peer DID, see peer.NumAlgo0, peer.NumAlgo1 and peer.NumAlgo2. Numalgo 0
+// DIDs only carry the signing key and can't be used as DIDComm V2 DIDs.
+func WithNumAlgo(numAlgo string) Option {
+	return func(opts *createOpts) {
+		opts.numAlgo = numAlgo
+	}
+}
+
 // CreatePeerDIDV2 create a peer DID suitable for use in DIDComm V2.
-func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
+func (s *Creator) CreatePeerDIDV2(opts ...Option) (*did.Doc, error) {
+	createOpt := &createOpts{}
+
+	for _, opt := range opts {
+		opt(createOpt)
+	}
+
 	// TODO: add routing keys so edge agents can rotate (currently only cloud agents do)
 	newDID := &did.Doc{Service: []did.Service{{Type: vdrapi.DIDCommV2ServiceType}}}
 
@@ -67,7 +88,13 @@ func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
 	// set KeyAgreement.ID as RecipientKeys as part of DIDComm V2 service
 	newDID.Service[0].RecipientKeys = []string{newDID.KeyAgreement[0].VerificationMethod.ID}
 
-	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID)
+	var vdrOpts []vdrapi.DIDMethodOption
+
+	if createOpt.numAlgo != "" {
+		vdrOpts = append(vdrOpts, vdrapi.WithOption(peer.NumAlgoOpt, createOpt.numAlgo))
+	}
+
+	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID, vdrOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating new peer DID via VDR failed: %w", err)
 	}
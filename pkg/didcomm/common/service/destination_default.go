@@ -12,6 +12,7 @@ package service
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/markcryptohash/aries-framework-go/pkg/common/model"
@@ -38,6 +39,62 @@ func CreateDestination(didDoc *diddoc.Doc) (*Destination, error) {
 	return nil, fmt.Errorf("create destination: missing DID doc service")
 }
 
+// CreateDestinations makes a DIDComm Destination for each service of the DID Doc with the DIDComm service type
+// selected by CreateDestination, ordered by service priority: the first destination is the one returned by
+// CreateDestination. Agents registered with several routers publish a service per router, the next destinations
+// are alternatives to use when the first one is unreachable. Invalid alternative services are skipped.
+func CreateDestinations(didDoc *diddoc.Doc) ([]*Destination, error) {
+	for _, b := range []struct {
+		serviceType string
+		create      func(*diddoc.Doc, *diddoc.Service) (*Destination, error)
+	}{
+		{serviceType: didCommV2ServiceType, create: createDIDCommV2Destination},
+		{serviceType: didCommServiceType, create: createDIDCommV1Destination},
+		{serviceType: legacyDIDCommServiceType, create: createLegacyDestination},
+	} {
+		services := lookupServices(didDoc, b.serviceType)
+		if len(services) == 0 {
+			continue
+		}
+
+		var destinations []*Destination
+
+		for i, svc := range services {
+			dest, err := b.create(didDoc, svc)
+			if err != nil {
+				if i == 0 {
+					return nil, err
+				}
+
+				continue
+			}
+
+			destinations = append(destinations, dest)
+		}
+
+		return destinations, nil
+	}
+
+	return nil, fmt.Errorf("create destination: missing DID doc service")
+}
+
+// lookupServices returns the services of didDoc with serviceType, ordered like diddoc.LookupService selects them.
+func lookupServices(didDoc *diddoc.Doc, serviceType string) []*diddoc.Service {
+	var services []*diddoc.Service
+
+	for i := range didDoc.Service {
+		if didDoc.Service[i].Type == serviceType {
+			services = append(services, &didDoc.Service[i])
+		}
+	}
+
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].Priority < services[j].Priority
+	})
+
+	return services
+}
+
 func createDIDCommV2Destination(didDoc *diddoc.Doc, didCommService *diddoc.Service) (*Destination, error) {
 	var (
 		sp                  model.Endpoint
@@ -49,6 +49,17 @@ func CreateDestination(didDoc *diddoc.Doc) (*Destination, error) {
 	}, nil
 }
 
+// CreateDestinations makes the DIDComm Destination of the DID Doc, routers failover is not supported in interop
+// mode.
+func CreateDestinations(didDoc *diddoc.Doc) ([]*Destination, error) {
+	dest, err := CreateDestination(didDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*Destination{dest}, nil
+}
+
 func convertAnyB58Keys(keys []string) []string {
 	var didKeys []string
 
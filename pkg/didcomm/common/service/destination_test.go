@@ -114,6 +114,71 @@ func TestGetDestinationFromDID(t *testing.T) {
 	})
 }
 
+func TestCreateDestinations(t *testing.T) {
+	t.Run("destination per router service, by priority", func(t *testing.T) {
+		doc := mockdiddoc.GetMockDIDDoc(t, false)
+
+		primary := doc.Service[0]
+		primary.Priority = 1
+
+		secondary := doc.Service[0]
+		secondary.ID = primary.ID + "-2"
+		secondary.Priority = 0
+		secondary.ServiceEndpoint = model.NewDIDCommV1Endpoint("https://router2.example.com")
+		secondary.RoutingKeys = []string{"did:key:z6MkrouterKey2"}
+
+		invalid := doc.Service[0]
+		invalid.ID = primary.ID + "-3"
+		invalid.Priority = 2
+		invalid.RecipientKeys = nil
+
+		doc.Service = []did.Service{primary, invalid, secondary}
+
+		destinations, err := CreateDestinations(doc)
+		require.NoError(t, err)
+		require.Len(t, destinations, 2)
+
+		first, err := CreateDestination(doc)
+		require.NoError(t, err)
+		require.Equal(t, first, destinations[0])
+
+		uri, err := destinations[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://router2.example.com", uri)
+		require.Equal(t, secondary.RoutingKeys, destinations[0].RoutingKeys)
+
+		uri, err = destinations[1].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://localhost:8090", uri)
+	})
+
+	t.Run("DIDComm V2 services first", func(t *testing.T) {
+		doc := mockdiddoc.GetMockDIDDocWithDIDCommV2Bloc(t, "alicedid")
+
+		destinations, err := CreateDestinations(doc)
+		require.NoError(t, err)
+		require.Len(t, destinations, 1)
+
+		expected, err := CreateDestination(doc)
+		require.NoError(t, err)
+		require.Equal(t, expected, destinations[0])
+	})
+
+	t.Run("error - invalid or missing service", func(t *testing.T) {
+		doc := mockdiddoc.GetMockDIDDoc(t, false)
+		doc.Service[0].RecipientKeys = nil
+
+		_, err := CreateDestinations(doc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no recipient keys")
+
+		doc.Service = nil
+
+		_, err = CreateDestinations(doc)
+		require.EqualError(t, err, "create destination: missing DID doc service")
+	})
+}
+
 func TestPrepareDestination(t *testing.T) {
 	t.Run("successfully prepared destination", func(t *testing.T) {
 		doc := mockdiddoc.GetMockDIDDoc(t, false)
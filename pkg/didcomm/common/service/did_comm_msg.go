@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
 
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
 )
 
@@ -29,6 +30,8 @@ const (
 	jsonThreadID       = "thid"
 	jsonParentThreadID = "pthid"
 	jsonMetadata       = "_internal_metadata"
+	jsonCreatedTime    = "created_time"
+	jsonExpiresTime    = "expires_time"
 
 	basePIURI = "https://didcomm.org/"
 	oldPIURI  = "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/"
@@ -286,6 +289,85 @@ func (m DIDCommMsgMap) ParentThreadID() string {
 	return ""
 }
 
+// CreatedTime returns the DIDComm V2 created_time header, the zero time if the message has none.
+func (m DIDCommMsgMap) CreatedTime() time.Time {
+	return m.unixTime(jsonCreatedTime)
+}
+
+// ExpiresTime returns the DIDComm V2 expires_time header, the zero time if the message has none.
+func (m DIDCommMsgMap) ExpiresTime() time.Time {
+	return m.unixTime(jsonExpiresTime)
+}
+
+// SetCreatedTime sets the DIDComm V2 created_time header.
+func (m DIDCommMsgMap) SetCreatedTime(t time.Time) {
+	if m == nil {
+		return
+	}
+
+	m[jsonCreatedTime] = t.Unix()
+}
+
+// SetExpiresTime sets the DIDComm V2 expires_time header.
+func (m DIDCommMsgMap) SetExpiresTime(t time.Time) {
+	if m == nil {
+		return
+	}
+
+	m[jsonExpiresTime] = t.Unix()
+}
+
+// IsExpired returns true if the message has an expires_time header which is before t.
+func (m DIDCommMsgMap) IsExpired(t time.Time) bool {
+	expires := m.ExpiresTime()
+
+	return !expires.IsZero() && t.After(expires)
+}
+
+// PleaseAck returns the acknowledgements requested by the ~please_ack decorator of DIDComm V1 messages or by the
+// please_ack header of DIDComm V2 messages, nil if the message requests none.
+func (m DIDCommMsgMap) PleaseAck() *decorator.PleaseAck {
+	var headers struct {
+		PleaseAckV1 *decorator.PleaseAck `json:"~please_ack,omitempty"`
+		PleaseAckV2 []string             `json:"please_ack,omitempty"`
+	}
+
+	if err := m.Decode(&headers); err != nil {
+		return nil
+	}
+
+	if headers.PleaseAckV1 != nil {
+		return headers.PleaseAckV1
+	}
+
+	return decorator.PleaseAckFromV2(headers.PleaseAckV2)
+}
+
+// unixTime reads a time header, which is a number of seconds since the Unix epoch.
+func (m DIDCommMsgMap) unixTime(header string) time.Time {
+	var seconds int64
+
+	switch v := m[header].(type) {
+	case float64:
+		seconds = int64(v)
+	case int64:
+		seconds = v
+	case int:
+		seconds = int64(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}
+		}
+
+		seconds = n
+	default:
+		return time.Time{}
+	}
+
+	return time.Unix(seconds, 0)
+}
+
 func (m DIDCommMsgMap) idV1() string {
 	if m == nil || m[jsonIDV1] == nil {
 		return ""
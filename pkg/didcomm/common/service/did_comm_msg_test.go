@@ -13,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
 )
 
@@ -293,6 +294,74 @@ func TestDIDCommMsgMap_ParentThreadID(t *testing.T) {
 	}
 }
 
+func TestDIDCommMsgMap_Times(t *testing.T) {
+	created := time.Unix(1600000000, 0)
+	expires := created.Add(time.Hour)
+
+	msg := DIDCommMsgMap{"id": "ID", "type": "type"}
+	require.True(t, msg.CreatedTime().IsZero())
+	require.True(t, msg.ExpiresTime().IsZero())
+	require.False(t, msg.IsExpired(expires.Add(time.Hour)))
+
+	msg.SetCreatedTime(created)
+	msg.SetExpiresTime(expires)
+	require.Equal(t, created, msg.CreatedTime())
+	require.Equal(t, expires, msg.ExpiresTime())
+	require.False(t, msg.IsExpired(expires))
+	require.True(t, msg.IsExpired(expires.Add(time.Second)))
+
+	raw, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	parsed, err := ParseDIDCommMsgMap(raw)
+	require.NoError(t, err)
+	require.Equal(t, created, parsed.CreatedTime())
+	require.Equal(t, expires, parsed.ExpiresTime())
+
+	require.Equal(t, created, DIDCommMsgMap{"created_time": int(created.Unix())}.CreatedTime())
+	require.Equal(t, created, DIDCommMsgMap{"created_time": json.Number("1600000000")}.CreatedTime())
+	require.True(t, DIDCommMsgMap{"created_time": json.Number("1.5")}.CreatedTime().IsZero())
+	require.True(t, DIDCommMsgMap{"created_time": "now"}.CreatedTime().IsZero())
+
+	var nilMsg DIDCommMsgMap
+
+	nilMsg.SetCreatedTime(created)
+	nilMsg.SetExpiresTime(expires)
+	require.True(t, nilMsg.CreatedTime().IsZero())
+}
+
+func TestDIDCommMsgMap_PleaseAck(t *testing.T) {
+	require.Nil(t, DIDCommMsgMap{"@id": "ID", "@type": "type"}.PleaseAck())
+	require.Nil(t, DIDCommMsgMap{"id": "ID", "type": "type", "please_ack": []interface{}{}}.PleaseAck())
+	require.Nil(t, DIDCommMsgMap{"@id": "ID", "~please_ack": "RECEIPT"}.PleaseAck())
+
+	pleaseAck := DIDCommMsgMap{"@id": "ID", "~please_ack": map[string]interface{}{}}.PleaseAck()
+	require.True(t, pleaseAck.OnReceipt())
+	require.False(t, pleaseAck.OnOutcome())
+
+	raw, err := json.Marshal(NewDIDCommMsgMap(struct {
+		ID        string               `json:"@id"`
+		PleaseAck *decorator.PleaseAck `json:"~please_ack"`
+	}{
+		ID:        "ID",
+		PleaseAck: decorator.NewPleaseAck(decorator.PleaseAckOnOutcome),
+	}))
+	require.NoError(t, err)
+
+	msg, err := ParseDIDCommMsgMap(raw)
+	require.NoError(t, err)
+	require.False(t, msg.PleaseAck().OnReceipt())
+	require.True(t, msg.PleaseAck().OnOutcome())
+
+	pleaseAck = DIDCommMsgMap{
+		"id":         "ID",
+		"type":       "type",
+		"please_ack": []interface{}{decorator.PleaseAckOnReceipt, decorator.PleaseAckOnOutcome},
+	}.PleaseAck()
+	require.True(t, pleaseAck.OnReceipt())
+	require.True(t, pleaseAck.OnOutcome())
+}
+
 func TestDIDCommMsgMap_ToStruct(t *testing.T) {
 	type Test struct {
 		Time  time.Time
@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	jsonL10n       = "~l10n"
+	jsonL10nV2     = "l10n"
+	jsonL10nLocale = "locale"
+	jsonL10nInline = "inline"
+	jsonLang       = "lang"
+	jsonBody       = "body"
+	jsonComment    = "comment"
+
+	// an inline translation of DIDComm V2 messages is a [locale, field, value] triple.
+	l10nInlineLen = 3
+)
+
+// Locale returns the locale of the human-readable fields of the message, given by the ~l10n decorator of DIDComm V1
+// messages or by the lang header of DIDComm V2 messages.
+func (m DIDCommMsgMap) Locale() string {
+	var headers struct {
+		L10n *decorator.L10n `json:"~l10n,omitempty"`
+		Lang string          `json:"lang,omitempty"`
+	}
+
+	if err := m.Decode(&headers); err != nil {
+		return ""
+	}
+
+	if headers.L10n != nil && headers.L10n.Locale != "" {
+		return headers.L10n.Locale
+	}
+
+	return headers.Lang
+}
+
+// SetLocale sets the locale of the human-readable fields of the message.
+func (m DIDCommMsgMap) SetLocale(locale string, opts ...Opt) {
+	if m == nil || locale == "" {
+		return
+	}
+
+	if getOptions(opts...).V == V2 {
+		m[jsonLang] = locale
+
+		return
+	}
+
+	l10n, ok := m[jsonL10n].(map[string]interface{})
+	if !ok {
+		l10n = map[string]interface{}{}
+	}
+
+	l10n[jsonL10nLocale] = locale
+	m[jsonL10n] = l10n
+}
+
+// SetLocalized adds translations keyed by locale of a human-readable field of the message. The field is a top-level
+// field of DIDComm V1 messages, localized with the <field>~l10n decorator, or a field of the body of DIDComm V2
+// messages, localized with the inline translations of the l10n header.
+func (m DIDCommMsgMap) SetLocalized(field string, translations map[string]string, opts ...Opt) {
+	if m == nil || len(translations) == 0 {
+		return
+	}
+
+	locales := make([]string, 0, len(translations))
+	for locale := range translations {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	if getOptions(opts...).V == V2 {
+		l10n, ok := m[jsonL10nV2].(map[string]interface{})
+		if !ok {
+			l10n = map[string]interface{}{}
+		}
+
+		inline, _ := l10n[jsonL10nInline].([]interface{}) // nolint: errcheck
+
+		for _, locale := range locales {
+			inline = append(inline, []interface{}{locale, field, translations[locale]})
+		}
+
+		l10n[jsonL10nInline] = inline
+		m[jsonL10nV2] = l10n
+
+		return
+	}
+
+	fieldL10n, ok := m[field+decorator.L10nFieldSuffix].(map[string]interface{})
+	if !ok {
+		fieldL10n = map[string]interface{}{}
+	}
+
+	for _, locale := range locales {
+		fieldL10n[locale] = translations[locale]
+	}
+
+	m[field+decorator.L10nFieldSuffix] = fieldL10n
+}
+
+// sendOpts options for localizing the messages sent by the protocol clients.
+type sendOpts struct {
+	locale       string
+	translations map[string]string
+}
+
+// SendOptions is custom option for localizing the messages sent by the protocol clients.
+type SendOptions func(opts *sendOpts)
+
+// WithLocale option to provide the locale of the human-readable fields of the message.
+func WithLocale(locale string) SendOptions {
+	return func(opts *sendOpts) {
+		opts.locale = locale
+	}
+}
+
+// WithLocalizedComment option to provide the locale of the comment and its translations keyed by locale,
+// the recipient can read the comment in their own locale with DIDCommMsgMap.Localized.
+func WithLocalizedComment(locale string, translations map[string]string) SendOptions {
+	return func(opts *sendOpts) {
+		opts.locale = locale
+		opts.translations = translations
+	}
+}
+
+// Localize sets the locale of the message and the translations of its comment given by options.
+func (m DIDCommMsgMap) Localize(v Version, options ...SendOptions) {
+	opts := &sendOpts{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	m.SetLocale(opts.locale, WithVersion(v))
+	m.SetLocalized(jsonComment, opts.translations, WithVersion(v))
+}
+
+// Localized returns the value of a human-readable field of the message which best matches locale, along with the
+// locale of the value. The value is picked among the field value, its inline translations and its translations in
+// the given catalogs, the field value is returned if none of them matches locale.
+func (m DIDCommMsgMap) Localized(field, locale string, catalogs ...decorator.L10nCatalog) (string, string) {
+	value, valueLocale, translations := m.localizations(field, catalogs)
+
+	if value != "" && valueLocale != "" {
+		translations[valueLocale] = value
+	}
+
+	available := make([]string, 0, len(translations))
+	for l := range translations {
+		available = append(available, l)
+	}
+
+	match, ok := decorator.MatchLocale(locale, available)
+	if !ok {
+		return value, valueLocale
+	}
+
+	return translations[match], match
+}
+
+// localizations returns the value of field, its locale and its translations keyed by locale.
+func (m DIDCommMsgMap) localizations(field string, catalogs []decorator.L10nCatalog) (string, string,
+	map[string]string) {
+	if isV2, err := IsDIDCommV2(&m); err == nil && isV2 {
+		return m.localizationsV2(field)
+	}
+
+	translations := make(map[string]string)
+
+	value, _ := m[field].(string) // nolint: errcheck
+	valueLocale := m.Locale()
+
+	var fieldL10n decorator.FieldL10n
+
+	if err := mapstructure.WeakDecode(m[field+decorator.L10nFieldSuffix], &fieldL10n); err != nil {
+		return value, valueLocale, translations
+	}
+
+	if fieldL10n.Locale() != "" {
+		valueLocale = fieldL10n.Locale()
+	}
+
+	if code := fieldL10n.Code(); code != "" {
+		for _, catalog := range catalogs {
+			for l, v := range catalog[code] {
+				translations[l] = v
+			}
+		}
+	}
+
+	// inline translations take precedence over the catalogs.
+	for l, v := range fieldL10n.Translations() {
+		translations[l] = v
+	}
+
+	return value, valueLocale, translations
+}
+
+func (m DIDCommMsgMap) localizationsV2(field string) (string, string, map[string]string) {
+	translations := make(map[string]string)
+
+	body, _ := m[jsonBody].(map[string]interface{}) // nolint: errcheck
+	value, _ := body[field].(string)                // nolint: errcheck
+
+	var headers struct {
+		L10n *decorator.L10nV2 `json:"l10n,omitempty"`
+	}
+
+	if err := m.Decode(&headers); err != nil || headers.L10n == nil {
+		return value, m.Locale(), translations
+	}
+
+	for _, t := range headers.L10n.Inline {
+		if len(t) == l10nInlineLen && t[1] == field {
+			translations[t[0]] = t[2]
+		}
+	}
+
+	return value, m.Locale(), translations
+}
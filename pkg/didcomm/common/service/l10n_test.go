@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+func TestDIDCommMsgMap_Localized(t *testing.T) {
+	t.Run("DIDComm V1", func(t *testing.T) {
+		msg := DIDCommMsgMap{"@id": "ID", "@type": "type", "comment": "Hello"}
+		require.Empty(t, msg.Locale())
+
+		text, locale := msg.Localized("comment", "es")
+		require.Equal(t, "Hello", text)
+		require.Empty(t, locale)
+
+		msg.SetLocale("en")
+		msg.SetLocalized("comment", map[string]string{"es": "Hola", "fr-CA": "Bonjour"})
+
+		raw, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		msg, err = ParseDIDCommMsgMap(raw)
+		require.NoError(t, err)
+		require.Equal(t, "en", msg.Locale())
+
+		for requested, expected := range map[string][2]string{
+			"es-ES": {"Hola", "es"},
+			"fr":    {"Bonjour", "fr-CA"},
+			"en-US": {"Hello", "en"},
+			"de":    {"Hello", "en"},
+		} {
+			text, locale = msg.Localized("comment", requested)
+			require.Equal(t, expected, [2]string{text, locale}, requested)
+		}
+	})
+
+	t.Run("DIDComm V1 with catalogs", func(t *testing.T) {
+		catalog := decorator.L10nCatalog{
+			"greeting": {"es": "Buenos días", "de": "Guten Tag"},
+		}
+
+		msg := DIDCommMsgMap{
+			"@id":     "ID",
+			"~l10n":   map[string]interface{}{"locale": "en", "catalogs": []interface{}{"https://example.com/catalog"}},
+			"comment": "Good day",
+			"comment~l10n": map[string]interface{}{
+				"locale": "en-AU",
+				"code":   "greeting",
+				"es":     "Buen día",
+			},
+		}
+
+		text, locale := msg.Localized("comment", "es", catalog)
+		require.Equal(t, "Buen día", text)
+		require.Equal(t, "es", locale)
+
+		text, locale = msg.Localized("comment", "de", catalog)
+		require.Equal(t, "Guten Tag", text)
+		require.Equal(t, "de", locale)
+
+		text, locale = msg.Localized("comment", "de")
+		require.Equal(t, "Good day", text)
+		require.Equal(t, "en-AU", locale)
+
+		msg["comment~l10n"] = "invalid"
+
+		text, locale = msg.Localized("comment", "es", catalog)
+		require.Equal(t, "Good day", text)
+		require.Equal(t, "en", locale)
+	})
+
+	t.Run("DIDComm V2", func(t *testing.T) {
+		msg := DIDCommMsgMap{"id": "ID", "type": "type", "body": map[string]interface{}{"comment": "Hello"}}
+
+		msg.SetLocale("en", WithVersion(V2))
+		msg.SetLocalized("comment", map[string]string{"es": "Hola"}, WithVersion(V2))
+		msg.SetLocalized("comment", map[string]string{"fr": "Bonjour"}, WithVersion(V2))
+		msg.SetLocalized("goal", map[string]string{"es": "Objetivo"}, WithVersion(V2))
+
+		raw, err := json.Marshal(msg)
+		require.NoError(t, err)
+		require.Contains(t, string(raw), `"lang":"en"`)
+		require.Contains(t, string(raw), `["es","comment","Hola"]`)
+
+		msg, err = ParseDIDCommMsgMap(raw)
+		require.NoError(t, err)
+		require.Equal(t, "en", msg.Locale())
+
+		text, locale := msg.Localized("comment", "fr-FR")
+		require.Equal(t, "Bonjour", text)
+		require.Equal(t, "fr", locale)
+
+		text, locale = msg.Localized("comment", "de")
+		require.Equal(t, "Hello", text)
+		require.Equal(t, "en", locale)
+
+		text, locale = msg.Localized("goal", "es")
+		require.Equal(t, "Objetivo", text)
+		require.Equal(t, "es", locale)
+	})
+
+	t.Run("nil message", func(t *testing.T) {
+		var msg DIDCommMsgMap
+
+		msg.SetLocale("en")
+		msg.SetLocalized("comment", map[string]string{"es": "Hola"})
+		require.Empty(t, msg.Locale())
+	})
+}
+
+func TestDIDCommMsgMap_Localize(t *testing.T) {
+	t.Run("DIDComm V1", func(t *testing.T) {
+		msg := DIDCommMsgMap{"@id": "ID", "@type": "type", "comment": "Hello"}
+		msg.Localize(V1, WithLocalizedComment("en", map[string]string{"es": "Hola"}))
+
+		require.Equal(t, "en", msg.Locale())
+
+		text, locale := msg.Localized("comment", "es")
+		require.Equal(t, "Hola", text)
+		require.Equal(t, "es", locale)
+	})
+
+	t.Run("DIDComm V2", func(t *testing.T) {
+		msg := DIDCommMsgMap{"id": "ID", "type": "type", "body": map[string]interface{}{"comment": "Hello"}}
+		msg.Localize(V2, WithLocalizedComment("en", map[string]string{"es": "Hola"}))
+
+		require.Equal(t, "en", msg.Locale())
+
+		text, locale := msg.Localized("comment", "es")
+		require.Equal(t, "Hola", text)
+		require.Equal(t, "es", locale)
+	})
+
+	t.Run("locale only", func(t *testing.T) {
+		msg := DIDCommMsgMap{"@id": "ID", "@type": "type"}
+		msg.Localize(V1, WithLocale("en"))
+
+		require.Equal(t, "en", msg.Locale())
+		require.NotContains(t, msg, "comment"+decorator.L10nFieldSuffix)
+	})
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"fmt"
+
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
 )
 
@@ -44,3 +46,22 @@ type MessageTypeTarget struct {
 	MsgType string
 	Target  string
 }
+
+// InboundGuard checks the unpacked inbound messages before they are dispatched to a service.
+type InboundGuard interface {
+	// Check returns a *RejectedError if msg must be rejected. sender is the DID or the base58 key which authenticated
+	// the message, it is empty for anonymous messages, and size is the size of the unpacked message.
+	Check(msg service.DIDCommMsgMap, sender string, size int) error
+}
+
+// RejectedError is returned by an InboundGuard rejecting a message, Code is reported to the sender. Retry is true if
+// the message may be accepted later, e.g. once the sender is no longer rate limited.
+type RejectedError struct {
+	Code   string
+	Reason string
+	Retry  bool
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("message rejected (%s): %s", e.Code, e.Reason)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inbound
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+)
+
+const (
+	// RateLimitedCode is the problem code of the messages rejected because their sender exceeded its rate limit.
+	RateLimitedCode = "rate-limited"
+	// ReplayedCode is the problem code of the messages rejected because their ID was already received.
+	ReplayedCode = "replayed"
+	// TooLargeCode is the problem code of the messages rejected because of their size.
+	TooLargeCode = "message-too-large"
+	// TooOldCode is the problem code of the messages rejected because of their created_time.
+	TooOldCode = "message-too-old"
+	// ExpiredCode is the problem code of the DIDComm V2 messages rejected because of their expires_time, they are
+	// always rejected.
+	ExpiredCode = "message-expired"
+
+	// MaxClockSkew is the tolerated difference between the clocks of the agents when checking message times.
+	MaxClockSkew = time.Minute
+
+	defaultMaxSenders    = 10000
+	defaultMaxReplayKeys = 100000
+)
+
+// GuardOption configures a Guard.
+type GuardOption func(g *Guard)
+
+// WithRateLimit limits the number of messages accepted per sender to rate messages per second, with bursts of up
+// to burst messages. Anonymous messages are not rate limited.
+func WithRateLimit(rate float64, burst int) GuardOption {
+	return func(g *Guard) {
+		g.limiter = newRateLimiter(rate, burst, defaultMaxSenders)
+	}
+}
+
+// WithReplayProtection rejects the messages whose ID was received from the same sender in the last ttl. At most
+// maxEntries IDs are remembered, the oldest being forgotten first.
+func WithReplayProtection(ttl time.Duration, maxEntries int) GuardOption {
+	return func(g *Guard) {
+		if maxEntries <= 0 {
+			maxEntries = defaultMaxReplayKeys
+		}
+
+		g.replays = newReplayCache(ttl, maxEntries)
+	}
+}
+
+// WithMaxMessageSize rejects the messages whose unpacked size is above size bytes.
+func WithMaxMessageSize(size int) GuardOption {
+	return func(g *Guard) {
+		g.maxSize = size
+	}
+}
+
+// WithMaxMessageAge rejects the DIDComm V2 messages whose created_time is older than age. The replay protection TTL
+// should not be shorter than age, otherwise a message can be replayed once its ID is forgotten.
+func WithMaxMessageAge(age time.Duration) GuardOption {
+	return func(g *Guard) {
+		g.maxAge = age
+	}
+}
+
+// GuardStats are the counters of the messages checked by a Guard.
+type GuardStats struct {
+	Accepted uint64
+	// Rejected counts the rejected messages by problem code.
+	Rejected map[string]uint64
+}
+
+// Guard is the default dispatcher.InboundGuard, it protects an agent against floods and replayed messages. The
+// checks are enabled by the options.
+type Guard struct {
+	limiter *rateLimiter
+	replays *replayCache
+	maxSize int
+	maxAge  time.Duration
+	now     func() time.Time
+
+	statsMu  sync.Mutex
+	accepted uint64
+	rejected map[string]uint64
+}
+
+// NewGuard returns a new Guard.
+func NewGuard(opts ...GuardOption) *Guard {
+	g := &Guard{
+		now:      time.Now,
+		rejected: make(map[string]uint64),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Check returns a *dispatcher.RejectedError if msg must be rejected.
+func (g *Guard) Check(msg service.DIDCommMsgMap, sender string, size int) error {
+	err := g.check(msg, sender, size)
+
+	g.statsMu.Lock()
+	defer g.statsMu.Unlock()
+
+	var rejected *dispatcher.RejectedError
+
+	if errors.As(err, &rejected) {
+		g.rejected[rejected.Code]++
+	} else {
+		g.accepted++
+	}
+
+	return err
+}
+
+// Stats returns the counters of the messages checked by the guard.
+func (g *Guard) Stats() GuardStats {
+	g.statsMu.Lock()
+	defer g.statsMu.Unlock()
+
+	stats := GuardStats{Accepted: g.accepted, Rejected: make(map[string]uint64, len(g.rejected))}
+
+	for code, count := range g.rejected {
+		stats.Rejected[code] = count
+	}
+
+	return stats
+}
+
+func (g *Guard) check(msg service.DIDCommMsgMap, sender string, size int) error {
+	now := g.now()
+
+	if g.maxSize > 0 && size > g.maxSize {
+		return reject(TooLargeCode, "message size %d exceeds %d bytes", size, g.maxSize)
+	}
+
+	if g.limiter != nil && sender != "" && !g.limiter.allow(sender, now) {
+		return &dispatcher.RejectedError{
+			Code:   RateLimitedCode,
+			Reason: fmt.Sprintf("rate limit exceeded by %s", sender),
+			Retry:  true,
+		}
+	}
+
+	if created := msg.CreatedTime(); !created.IsZero() && g.maxAge > 0 {
+		if now.Sub(created) > g.maxAge {
+			return reject(TooOldCode, "message created at %s is older than %s",
+				created.UTC().Format(time.RFC3339), g.maxAge)
+		}
+
+		if created.Sub(now) > MaxClockSkew {
+			return reject(TooOldCode, "message created at %s is in the future", created.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if g.replays != nil && msg.ID() != "" && !g.replays.add(sender+" "+msg.ID(), now) {
+		return reject(ReplayedCode, "message %s was already received", msg.ID())
+	}
+
+	return nil
+}
+
+func reject(code, format string, args ...interface{}) error {
+	return &dispatcher.RejectedError{Code: code, Reason: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,332 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/middleware"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
+	mocks "github.com/markcryptohash/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/msghandler"
+	mockdidexchange "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	"github.com/markcryptohash/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	guardMyDID    = "did:test:my-did"
+	guardTheirDID = "did:test:their-did"
+)
+
+func TestGuard_Check(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	newGuard := func(opts ...GuardOption) *Guard {
+		g := NewGuard(opts...)
+		g.now = func() time.Time { return now }
+
+		return g
+	}
+
+	requireRejected := func(t *testing.T, err error, code string) {
+		t.Helper()
+
+		rejected := &dispatcher.RejectedError{}
+		require.True(t, errors.As(err, &rejected))
+		require.Equal(t, code, rejected.Code)
+	}
+
+	t.Run("no checks", func(t *testing.T) {
+		g := newGuard()
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, g.Check(service.DIDCommMsgMap{"@id": "1"}, "sender", 1<<20))
+		}
+
+		require.Equal(t, GuardStats{Accepted: 10, Rejected: map[string]uint64{}}, g.Stats())
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		g := newGuard(WithRateLimit(1, 2))
+
+		require.NoError(t, g.Check(service.DIDCommMsgMap{}, "alice", 0))
+		require.NoError(t, g.Check(service.DIDCommMsgMap{}, "alice", 0))
+
+		err := g.Check(service.DIDCommMsgMap{}, "alice", 0)
+		requireRejected(t, err, RateLimitedCode)
+		require.True(t, err.(*dispatcher.RejectedError).Retry)
+
+		// other and anonymous senders are not limited
+		require.NoError(t, g.Check(service.DIDCommMsgMap{}, "bob", 0))
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, g.Check(service.DIDCommMsgMap{}, "", 0))
+		}
+
+		now = now.Add(time.Second)
+
+		require.NoError(t, g.Check(service.DIDCommMsgMap{}, "alice", 0))
+		requireRejected(t, g.Check(service.DIDCommMsgMap{}, "alice", 0), RateLimitedCode)
+
+		require.Equal(t, GuardStats{Accepted: 7, Rejected: map[string]uint64{RateLimitedCode: 2}}, g.Stats())
+	})
+
+	t.Run("rate limit drops the least recently used senders", func(t *testing.T) {
+		l := newRateLimiter(1, 1, 2)
+
+		require.True(t, l.allow("alice", now))
+		require.True(t, l.allow("bob", now))
+		require.False(t, l.allow("alice", now))
+		require.True(t, l.allow("carol", now))
+		require.Len(t, l.buckets, 2)
+
+		// bob was dropped
+		require.True(t, l.allow("bob", now))
+		require.False(t, l.allow("carol", now))
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		g := newGuard(WithReplayProtection(time.Minute, 0))
+
+		require.NoError(t, g.Check(service.DIDCommMsgMap{"@id": "1"}, "alice", 0))
+		requireRejected(t, g.Check(service.DIDCommMsgMap{"@id": "1"}, "alice", 0), ReplayedCode)
+		require.NoError(t, g.Check(service.DIDCommMsgMap{"id": "1", "type": "type"}, "bob", 0))
+		require.NoError(t, g.Check(service.DIDCommMsgMap{"@id": "2"}, "alice", 0))
+		require.NoError(t, g.Check(service.DIDCommMsgMap{}, "alice", 0))
+		require.NoError(t, g.Check(service.DIDCommMsgMap{}, "alice", 0))
+
+		now = now.Add(time.Minute)
+
+		require.NoError(t, g.Check(service.DIDCommMsgMap{"@id": "1"}, "alice", 0))
+	})
+
+	t.Run("replay cache is bounded", func(t *testing.T) {
+		c := newReplayCache(time.Minute, 2)
+
+		require.True(t, c.add("1", now))
+		require.True(t, c.add("2", now))
+		require.True(t, c.add("3", now))
+		require.Len(t, c.entries, 2)
+		require.False(t, c.add("3", now))
+		require.True(t, c.add("1", now))
+	})
+
+	t.Run("message size", func(t *testing.T) {
+		g := newGuard(WithMaxMessageSize(10))
+
+		require.NoError(t, g.Check(service.DIDCommMsgMap{}, "", 10))
+		requireRejected(t, g.Check(service.DIDCommMsgMap{}, "", 11), TooLargeCode)
+	})
+
+	t.Run("message times", func(t *testing.T) {
+		g := newGuard(WithMaxMessageAge(time.Hour))
+
+		msg := func(header string, at time.Time) service.DIDCommMsgMap {
+			m := service.DIDCommMsgMap{}
+			require.NoError(t, json.Unmarshal([]byte(
+				`{"id":"1","type":"type","`+header+`":`+strconv.FormatInt(at.Unix(), 10)+`}`), &m))
+
+			return m
+		}
+
+		require.NoError(t, g.Check(msg("created_time", now.Add(-time.Hour)), "", 0))
+		require.NoError(t, g.Check(msg("created_time", now.Add(MaxClockSkew)), "", 0))
+		require.NoError(t, g.Check(service.DIDCommMsgMap{"created_time": "now"}, "", 0))
+		require.NoError(t, g.Check(service.DIDCommMsgMap{"created_time": json.Number("1.5")}, "", 0))
+
+		requireRejected(t, g.Check(msg("created_time", now.Add(-2*time.Hour)), "", 0), TooOldCode)
+		requireRejected(t, g.Check(msg("created_time", now.Add(2*MaxClockSkew)), "", 0), TooOldCode)
+		requireRejected(t, g.Check(service.DIDCommMsgMap{"created_time": now.Unix() - 3*3600}, "", 0), TooOldCode)
+
+		// without max age, the created_time is not checked
+		g = newGuard()
+
+		require.NoError(t, g.Check(msg("created_time", now.Add(-2*time.Hour)), "", 0))
+	})
+}
+
+func TestMessageHandler_Guard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	}
+
+	connectionRecorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	require.NoError(t, connectionRecorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "12345",
+		MyDID:        guardMyDID,
+		TheirDID:     guardTheirDID,
+		State:        connection.StateNameCompleted,
+	}))
+
+	didRotator, err := middleware.New(p)
+	require.NoError(t, err)
+
+	newHandler := func(guard dispatcher.InboundGuard, messenger service.InboundMessenger) *MessageHandler {
+		return NewInboundMessageHandler(&mockprovider.Provider{
+			DIDConnectionStoreValue: &mockDIDStore{results: map[string]mockDIDResult{
+				base58.Encode([]byte("my_key")):    {did: guardMyDID},
+				base58.Encode([]byte("their_key")): {did: guardTheirDID},
+			}},
+			MessageServiceProviderValue: &msghandler.MockMsgSvcProvider{},
+			InboundMessengerValue:       messenger,
+			ServiceValue: &mockdidexchange.MockDIDExchangeSvc{
+				AcceptFunc: func(string) bool { return true },
+				HandleFunc: func(service.DIDCommMsg) (string, error) { return "", nil },
+			},
+			DIDRotatorValue:   *didRotator,
+			InboundGuardValue: guard,
+		})
+	}
+
+	t.Run("rejected messages are reported to known senders", func(t *testing.T) {
+		messenger := mocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+		var reports []service.DIDCommMsgMap
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), guardMyDID, guardTheirDID, gomock.Any()).Times(2).
+			DoAndReturn(func(_, out service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				reports = append(reports, out)
+
+				return nil
+			})
+
+		h := newHandler(NewGuard(WithReplayProtection(time.Minute, 10)), messenger)
+
+		for _, msg := range []string{`{"@id":"1","@type":"message-type"}`, `{"id":"2","type":"message-type","body":{}}`} {
+			envelope := &transport.Envelope{Message: []byte(msg), ToKey: []byte("my_key"), FromKey: []byte("their_key")}
+
+			require.NoError(t, h.HandleInboundEnvelope(envelope))
+
+			err := h.HandleInboundEnvelope(envelope)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "check inbound message: message rejected (replayed)")
+		}
+
+		require.Len(t, reports, 2)
+		require.Equal(t, ProblemReportMsgType, reports[0].Type())
+		require.Equal(t, map[string]interface{}{"code": ReplayedCode}, reports[0]["description"])
+		require.Equal(t, ProblemReportMsgTypeV2, reports[1].Type())
+		require.Equal(t, "e.p.msg."+ReplayedCode, reports[1]["body"].(map[string]interface{})["code"])
+	})
+
+	t.Run("rejected messages are not reported to unknown senders", func(t *testing.T) {
+		messenger := mocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+		h := newHandler(NewGuard(WithMaxMessageSize(1)), messenger)
+
+		for _, fromKey := range [][]byte{nil, []byte("unknown_key")} {
+			err := h.HandleInboundEnvelope(&transport.Envelope{
+				Message: []byte(`{"@id":"1","@type":"message-type"}`),
+				ToKey:   []byte("my_key"),
+				FromKey: fromKey,
+			})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "message rejected ("+TooLargeCode+")")
+		}
+	})
+
+	t.Run("rate limited messages are not reported", func(t *testing.T) {
+		messenger := mocks.NewMockMessengerHandler(ctrl)
+
+		h := newHandler(NewGuard(WithRateLimit(1, 0)), messenger)
+
+		err := h.HandleInboundEnvelope(&transport.Envelope{
+			Message: []byte(`{"@id":"1","@type":"message-type"}`),
+			ToKey:   []byte("my_key"),
+			FromKey: []byte("their_key"),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message rejected ("+RateLimitedCode+")")
+	})
+
+	t.Run("reports are rate limited", func(t *testing.T) {
+		messenger := mocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), guardMyDID, guardTheirDID, gomock.Any()).
+			Times(reportBurst).Return(nil)
+
+		h := newHandler(NewGuard(WithMaxMessageSize(1)), messenger)
+
+		for i := 0; i < 2*reportBurst; i++ {
+			err := h.HandleInboundEnvelope(&transport.Envelope{
+				Message: []byte(`{"@id":"1","@type":"message-type"}`),
+				ToKey:   []byte("my_key"),
+				FromKey: []byte("their_key"),
+			})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "message rejected ("+TooLargeCode+")")
+		}
+	})
+
+	t.Run("failure to report a rejected message", func(t *testing.T) {
+		messenger := mocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), guardMyDID, guardTheirDID, gomock.Any()).
+			Return(errors.New("send error"))
+
+		h := newHandler(NewGuard(WithMaxMessageSize(1)), messenger)
+
+		err := h.HandleInboundEnvelope(&transport.Envelope{
+			Message: []byte(`{"@id":"1","@type":"message-type"}`),
+			ToKey:   []byte("my_key"),
+			FromKey: []byte("their_key"),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message rejected ("+TooLargeCode+")")
+	})
+
+	t.Run("expired messages are rejected without guard", func(t *testing.T) {
+		messenger := mocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+		var report service.DIDCommMsgMap
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), guardMyDID, guardTheirDID, gomock.Any()).
+			DoAndReturn(func(_, out service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				report = out
+
+				return nil
+			})
+
+		h := newHandler(nil, messenger)
+
+		handle := func(expires time.Time) error {
+			return h.HandleInboundEnvelope(&transport.Envelope{
+				Message: []byte(`{"id":"1","type":"message-type","body":{},"expires_time":` +
+					strconv.FormatInt(expires.Unix(), 10) + `}`),
+				ToKey:   []byte("my_key"),
+				FromKey: []byte("their_key"),
+			})
+		}
+
+		require.NoError(t, handle(time.Now().Add(time.Minute)))
+		require.NoError(t, handle(time.Now().Add(-MaxClockSkew/2)))
+
+		err := handle(time.Now().Add(-2 * MaxClockSkew))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message rejected ("+ExpiredCode+")")
+		require.Equal(t, "e.p.msg."+ExpiredCode, report["body"].(map[string]interface{})["code"])
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
 	"github.com/markcryptohash/aries-framework-go/pkg/crypto"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/middleware"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/didexchange"
@@ -36,6 +37,18 @@ var logger = log.New("dispatcher/inbound")
 
 const (
 	kaIdentifier = "#"
+
+	// ProblemReportMsgType is the type of the problem reports sent for the rejected DIDComm V1 messages.
+	ProblemReportMsgType = "https://didcomm.org/notification/1.0/problem-report"
+	// ProblemReportMsgTypeV2 is the type of the problem reports sent for the rejected DIDComm V2 messages.
+	ProblemReportMsgTypeV2 = "https://didcomm.org/report-problem/2.0/problem-report"
+
+	// the problem reports of the rejected messages are rate limited per sender and in total, so that reporting
+	// doesn't amplify floods.
+	reportRate       = 0.1
+	reportBurst      = 5
+	totalReportRate  = 10
+	totalReportBurst = 20
 )
 
 // MessageHandler handles inbound envelopes, processing then dispatching to a protocol service based on the
@@ -49,6 +62,9 @@ type MessageHandler struct {
 	getDIDsMaxRetries      uint64
 	messenger              service.InboundMessenger
 	vdr                    vdrapi.Registry
+	guard                  dispatcher.InboundGuard
+	reports                *rateLimiter
+	totalReports           *rateLimiter
 	initialized            bool
 }
 
@@ -61,6 +77,7 @@ type provider interface {
 	InboundMessenger() service.InboundMessenger
 	DIDRotator() *middleware.DIDCommMessageMiddleware
 	VDRegistry() vdrapi.Registry
+	InboundGuard() dispatcher.InboundGuard
 }
 
 // NewInboundMessageHandler creates an inbound message handler, that processes inbound message Envelopes,
@@ -86,6 +103,9 @@ func (handler *MessageHandler) Initialize(p provider) {
 	handler.messenger = p.InboundMessenger()
 	handler.didcommV2Handler = p.DIDRotator()
 	handler.vdr = p.VDRegistry()
+	handler.guard = p.InboundGuard()
+	handler.reports = newRateLimiter(reportRate, reportBurst, defaultMaxSenders)
+	handler.totalReports = newRateLimiter(totalReportRate, totalReportBurst, 1)
 
 	handler.initialized = true
 }
@@ -118,6 +138,11 @@ func (handler *MessageHandler) HandleInboundEnvelope(envelope *transport.Envelop
 		return err
 	}
 
+	err = handler.checkInbound(envelope, msg, isV2)
+	if err != nil {
+		return err
+	}
+
 	var (
 		myDID, theirDID string
 		gotDIDs         bool
@@ -218,8 +243,108 @@ func (handler *MessageHandler) HandleInboundEnvelope(envelope *transport.Envelop
 	return fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
 }
 
-func (handler *MessageHandler) getDIDs( // nolint:funlen,gocyclo,gocognit
+// checkInbound rejects the expired DIDComm V2 messages and the messages rejected by the inbound guard. The rejected
+// messages are reported to their sender if the sender is a known connection, anonymous senders would make the agent
+// amplify floods. Rate limited messages are never reported and the reports themselves are rate limited.
+func (handler *MessageHandler) checkInbound(envelope *transport.Envelope, msg service.DIDCommMsgMap, isV2 bool) error {
+	var err error
+
+	if isV2 && msg.IsExpired(time.Now().Add(-MaxClockSkew)) {
+		err = &dispatcher.RejectedError{
+			Code:   ExpiredCode,
+			Reason: fmt.Sprintf("message expired at %s", msg.ExpiresTime().UTC().Format(time.RFC3339)),
+		}
+	} else if handler.guard != nil {
+		err = handler.guard.Check(msg, handler.sender(envelope), len(envelope.Message))
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	var rejected *dispatcher.RejectedError
+
+	if errors.As(err, &rejected) && rejected.Code != RateLimitedCode {
+		handler.reportRejected(envelope, msg, isV2, rejected)
+	}
+
+	return fmt.Errorf("check inbound message: %w", err)
+}
+
+// sender returns the DID or the base58 key which authenticated the envelope, or the empty string if the envelope is
+// anonymous.
+func (handler *MessageHandler) sender(envelope *transport.Envelope) string {
+	if len(envelope.FromKey) == 0 {
+		return ""
+	}
+
+	if senderDID, err := handler.getDIDGivenKey(envelope.FromKey); err == nil && senderDID != "" {
+		return senderDID
+	}
+
+	return base58.Encode(envelope.FromKey)
+}
+
+func (handler *MessageHandler) reportRejected(envelope *transport.Envelope, msg service.DIDCommMsgMap, isV2 bool,
+	rejected *dispatcher.RejectedError) {
+	messenger, ok := handler.messenger.(service.Messenger)
+	if !ok || len(envelope.FromKey) == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	// checked before looking up the sender, the lookup is as costly as the report.
+	if !handler.reports.allow(base58.Encode(envelope.FromKey), now) || !handler.totalReports.allow("", now) {
+		logger.Debugf("rejected message %s not reported, too many reports: %s", msg.ID(), rejected.Reason)
+
+		return
+	}
+
+	// no retries, the report must not slow down the rejection of floods.
+	myDID, theirDID, err := handler.lookupDIDs(envelope, nil, 0)
+	if err != nil || myDID == "" || theirDID == "" {
+		logger.Debugf("rejected message %s from unknown sender: %s", msg.ID(), rejected.Reason)
+
+		return
+	}
+
+	var report service.DIDCommMsgMap
+
+	if isV2 {
+		report = service.NewDIDCommMsgMap(&model.ProblemReportV2{
+			Type: ProblemReportMsgTypeV2,
+			Body: model.ProblemReportV2Body{Code: "e.p.msg." + rejected.Code, Comment: rejected.Reason},
+		})
+	} else {
+		report = service.NewDIDCommMsgMap(&model.ProblemReport{
+			Type:        ProblemReportMsgType,
+			Description: model.Code{Code: rejected.Code},
+		})
+	}
+
+	err = messenger.ReplyToMsg(msg, report, myDID, theirDID, service.WithVersion(versionOf(isV2)))
+	if err != nil {
+		logger.Warnf("failed to report rejected message %s: %v", msg.ID(), err)
+	}
+}
+
+func versionOf(isV2 bool) service.Version {
+	if isV2 {
+		return service.V2
+	}
+
+	return service.V1
+}
+
+func (handler *MessageHandler) getDIDs(
 	envelope *transport.Envelope, message service.DIDCommMsgMap,
+) (string, string, error) {
+	return handler.lookupDIDs(envelope, message, handler.getDIDsMaxRetries)
+}
+
+func (handler *MessageHandler) lookupDIDs( // nolint:funlen,gocyclo,gocognit
+	envelope *transport.Envelope, message service.DIDCommMsgMap, maxRetries uint64,
 ) (string, string, error) {
 	var (
 		myDID    string
@@ -295,7 +420,7 @@ func (handler *MessageHandler) getDIDs( // nolint:funlen,gocyclo,gocognit
 		}
 
 		return nil
-	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(handler.getDIDsBackOffDuration), handler.getDIDsMaxRetries))
+	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(handler.getDIDsBackOffDuration), maxRetries))
 }
 
 // getDIDGivenKey returns a did:key if the input key is a JWK. If the input key is not a JWK, returns the empty string.
@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inbound
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket rate limiter per sender. The buckets of at most maxSenders senders are kept, the
+// least recently used being dropped first.
+type rateLimiter struct {
+	rate       float64
+	burst      float64
+	maxSenders int
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	lru     *list.List
+}
+
+type bucket struct {
+	sender string
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst, maxSenders int) *rateLimiter {
+	return &rateLimiter{
+		rate:       rate,
+		burst:      float64(burst),
+		maxSenders: maxSenders,
+		buckets:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// allow takes a token from the bucket of sender, it returns false if the bucket is empty.
+func (l *rateLimiter) allow(sender string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.buckets[sender]
+	if !ok {
+		if l.lru.Len() >= l.maxSenders {
+			oldest := l.lru.Front()
+			l.lru.Remove(oldest)
+			delete(l.buckets, oldest.Value.(*bucket).sender)
+		}
+
+		elem = l.lru.PushBack(&bucket{sender: sender, tokens: l.burst, last: now})
+		l.buckets[sender] = elem
+	}
+
+	l.lru.MoveToBack(elem)
+
+	b := elem.Value.(*bucket)
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+	}
+
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// replayCache remembers keys for a TTL. At most maxEntries keys are remembered, the oldest being forgotten first.
+type replayCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	fifo    *list.List
+}
+
+type replayEntry struct {
+	key     string
+	expires time.Time
+}
+
+func newReplayCache(ttl time.Duration, maxEntries int) *replayCache {
+	return &replayCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		fifo:       list.New(),
+	}
+}
+
+// add remembers key, it returns false if key is already remembered.
+func (c *replayCache) add(key string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the entries share the same TTL, the oldest ones expire first.
+	for front := c.fifo.Front(); front != nil; front = c.fifo.Front() {
+		entry := front.Value.(*replayEntry)
+		if now.Before(entry.expires) && c.fifo.Len() < c.maxEntries {
+			break
+		}
+
+		c.fifo.Remove(front)
+		delete(c.entries, entry.key)
+	}
+
+	if _, ok := c.entries[key]; ok {
+		return false
+	}
+
+	c.entries[key] = c.fifo.PushBack(&replayEntry{key: key, expires: now.Add(c.ttl)})
+
+	return true
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -55,6 +56,7 @@ type connectionRecorder interface {
 
 // Dispatcher dispatch msgs to destination.
 type Dispatcher struct {
+	service.Message
 	outboundTransports   []transport.OutboundTransport
 	packager             transport.Packager
 	transportReturnRoute string
@@ -64,6 +66,7 @@ type Dispatcher struct {
 	connections          connectionRecorder
 	mediaTypeProfiles    []string
 	didcommV2Handler     *middleware.DIDCommMessageMiddleware
+	outbox               *outbox
 }
 
 // legacyForward is DIDComm V1 route Forward msg as declared in
@@ -78,7 +81,7 @@ type legacyForward struct {
 var logger = log.New("aries-framework/didcomm/dispatcher")
 
 // NewOutbound return new dispatcher outbound instance.
-func NewOutbound(prov provider) (*Dispatcher, error) {
+func NewOutbound(prov provider, opts ...Option) (*Dispatcher, error) {
 	o := &Dispatcher{
 		outboundTransports:   prov.OutboundTransports(),
 		packager:             prov.Packager(),
@@ -90,6 +93,10 @@ func NewOutbound(prov provider) (*Dispatcher, error) {
 		didcommV2Handler:     prov.DIDRotator(),
 	}
 
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	var err error
 
 	o.connections, err = connection.NewRecorder(prov)
@@ -97,6 +104,12 @@ func NewOutbound(prov provider) (*Dispatcher, error) {
 		return nil, fmt.Errorf("failed to init connection recorder: %w", err)
 	}
 
+	if o.outbox != nil {
+		if err = o.initOutbox(prov); err != nil {
+			return nil, fmt.Errorf("failed to init outbox: %w", err)
+		}
+	}
+
 	return o, nil
 }
 
@@ -163,18 +176,22 @@ func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
 		}
 	}
 
-	dest, err := service.CreateDestination(theirDocResolution.DIDDocument)
+	// their DID doc has a service per router when they are registered with several routers, the destinations are
+	// tried in order until the message is sent.
+	dests, err := service.CreateDestinations(theirDocResolution.DIDDocument)
 	if err != nil {
 		return fmt.Errorf(
 			"outboundDispatcher.SendToDID failed to get didcomm destination for theirDID [%s]: %w", theirDID, err)
 	}
 
 	if len(connRec.MediaTypeProfiles) > 0 {
-		dest.MediaTypeProfiles = make([]string, len(connRec.MediaTypeProfiles))
-		copy(dest.MediaTypeProfiles, connRec.MediaTypeProfiles)
+		for _, dest := range dests {
+			dest.MediaTypeProfiles = make([]string, len(connRec.MediaTypeProfiles))
+			copy(dest.MediaTypeProfiles, connRec.MediaTypeProfiles)
+		}
 	}
 
-	mtp := o.mediaTypeProfile(dest)
+	mtp := o.mediaTypeProfile(dests[0])
 	switch mtp {
 	case transport.MediaTypeV1PlaintextPayload, transport.MediaTypeV1EncryptedEnvelope,
 		transport.MediaTypeRFC0019EncryptedEnvelope, transport.MediaTypeAIP2RFC0019Profile:
@@ -182,7 +199,7 @@ func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
 	}
 
 	if sendWithAnoncrypt {
-		return o.Send(msg, "", dest)
+		return o.send(msg, "", dests)
 	}
 
 	src, err := service.CreateDestination(myDocResolution.DIDDocument)
@@ -194,7 +211,7 @@ func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
 	//  (right now, with only one key type used for sending)
 	key := src.RecipientKeys[0]
 
-	return o.Send(msg, key, dest)
+	return o.send(msg, key, dests)
 }
 
 func (o *Dispatcher) defaultMediaTypeProfiles() []string {
@@ -242,42 +259,79 @@ func (o *Dispatcher) getOrCreateConnection(myDID, theirDID string, connectionVer
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
-func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destination) error { // nolint:funlen,gocyclo
-	// check if outbound accepts routing keys, else use recipient keys
-	keys := des.RecipientKeys
-	if routingKeys, err := des.ServiceEndpoint.RoutingKeys(); err == nil && len(routingKeys) > 0 { // DIDComm V2
-		keys = routingKeys
-	} else if len(des.RoutingKeys) > 0 { // DIDComm V1
-		keys = routingKeys
-	}
+// When the outbox is enabled, messages that cannot be delivered are retried in the background.
+func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destination) error {
+	return o.send(msg, senderKey, []*service.Destination{des})
+}
 
-	var outboundTransport transport.OutboundTransport
+// send sends msg to the first destination accepting it, the other destinations are alternatives tried in order when
+// the message can't be delivered. With an outbox, the message is packed for every destination and each attempt of
+// the outbox tries the destinations in order.
+func (o *Dispatcher) send(msg interface{}, senderKey string,
+	dests []*service.Destination) error { // nolint:funlen,gocyclo
+	var (
+		req       []byte
+		sendErr   error
+		envelopes []*outboxEnvelope
+	)
 
-	for _, v := range o.outboundTransports {
-		uri, err := des.ServiceEndpoint.URI()
+	for i, des := range dests {
+		last := i == len(dests)-1
+
+		outboundTransport, err := o.outboundTransport(des)
 		if err != nil {
-			logger.Debugf("destination ServiceEndpoint empty: %w, it will not be checked", err)
+			if last && sendErr == nil && len(envelopes) == 0 {
+				return fmt.Errorf("outboundDispatcher.Send: %w", err)
+			}
+
+			logger.Debugf("outboundDispatcher.Send: skipping destination: %s", err)
+
+			continue
 		}
 
-		if v.AcceptRecipient(keys) || v.Accept(uri) {
-			outboundTransport = v
-			break
+		if req == nil {
+			req, err = json.Marshal(withCreatedTime(msg))
+			if err != nil {
+				return fmt.Errorf("outboundDispatcher.Send: failed marshal to bytes: %w", err)
+			}
+		}
+
+		packedMsg, err := o.pack(req, senderKey, des)
+		if err != nil {
+			return err
+		}
+
+		if o.outbox != nil {
+			envelopes = append(envelopes, newOutboxEnvelope(packedMsg, des))
+
+			continue
+		}
+
+		_, sendErr = outboundTransport.Send(packedMsg, des)
+		if sendErr == nil {
+			return nil
 		}
-	}
 
-	if outboundTransport == nil {
-		return fmt.Errorf("outboundDispatcher.Send: no transport found for destination: %+v", des)
+		if !last {
+			uri, _ := des.ServiceEndpoint.URI() // nolint:errcheck
+			logger.Warnf("outboundDispatcher.Send: failed to send msg to %s, trying the next destination: %s",
+				uri, sendErr)
+		}
 	}
 
-	req, err := json.Marshal(msg)
-	if err != nil {
-		return fmt.Errorf("outboundDispatcher.Send: failed marshal to bytes: %w", err)
+	if len(envelopes) > 0 {
+		return o.sendWithOutbox(req, envelopes)
 	}
 
+	return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", sendErr)
+}
+
+// pack packs req for des with the transport route options.
+func (o *Dispatcher) pack(req []byte, senderKey string, des *service.Destination) ([]byte, error) {
 	// update the outbound message with transport return route option [all or thread]
-	req, err = o.addTransportRouteOptions(req, des)
+	req, err := o.addTransportRouteOptions(req, des)
 	if err != nil {
-		return fmt.Errorf("outboundDispatcher.Send: failed to add transport route options: %w", err)
+		return nil, fmt.Errorf("outboundDispatcher.Send: failed to add transport route options: %w", err)
 	}
 
 	mtp := o.mediaTypeProfile(des)
@@ -295,7 +349,7 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 		ToKeys:           des.RecipientKeys,
 	})
 	if err != nil {
-		return fmt.Errorf("outboundDispatcher.Send: failed to pack msg: %w", err)
+		return nil, fmt.Errorf("outboundDispatcher.Send: failed to pack msg: %w", err)
 	}
 
 	// set the return route option
@@ -303,15 +357,65 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 
 	packedMsg, err = o.createForwardMessage(packedMsg, des)
 	if err != nil {
-		return fmt.Errorf("outboundDispatcher.Send: failed to create forward msg: %w", err)
+		return nil, fmt.Errorf("outboundDispatcher.Send: failed to create forward msg: %w", err)
 	}
 
-	_, err = outboundTransport.Send(packedMsg, des)
-	if err != nil {
-		return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
+	return packedMsg, nil
+}
+
+// withCreatedTime returns a copy of the DIDComm V2 messages which don't have a created_time header with the header
+// set, the message of the caller is left untouched. Other messages are returned as is.
+func withCreatedTime(msg interface{}) interface{} {
+	var didcommMsg service.DIDCommMsgMap
+
+	switch m := msg.(type) {
+	case service.DIDCommMsgMap:
+		didcommMsg = m
+	case *service.DIDCommMsgMap:
+		if m == nil {
+			return msg
+		}
+
+		didcommMsg = *m
+	default:
+		return msg
+	}
+
+	if isV2, err := service.IsDIDCommV2(&didcommMsg); err != nil || !isV2 {
+		return msg
+	}
+
+	if !didcommMsg.CreatedTime().IsZero() {
+		return msg
+	}
+
+	stamped := didcommMsg.Clone()
+	stamped.SetCreatedTime(time.Now())
+
+	return stamped
+}
+
+func (o *Dispatcher) outboundTransport(des *service.Destination) (transport.OutboundTransport, error) {
+	// check if outbound accepts routing keys, else use recipient keys
+	keys := des.RecipientKeys
+	if routingKeys, err := des.ServiceEndpoint.RoutingKeys(); err == nil && len(routingKeys) > 0 { // DIDComm V2
+		keys = routingKeys
+	} else if len(des.RoutingKeys) > 0 { // DIDComm V1
+		keys = routingKeys
+	}
+
+	for _, v := range o.outboundTransports {
+		uri, err := des.ServiceEndpoint.URI()
+		if err != nil {
+			logger.Debugf("destination ServiceEndpoint empty: %w, it will not be checked", err)
+		}
+
+		if v.AcceptRecipient(keys) || v.Accept(uri) {
+			return v, nil
+		}
 	}
 
-	return nil
+	return nil, fmt.Errorf("no transport found for destination: %+v", des)
 }
 
 // Forward forwards the message without packing to the destination.
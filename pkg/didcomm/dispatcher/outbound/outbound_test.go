@@ -10,7 +10,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -117,6 +119,51 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 		}))
 	})
 
+	t.Run("test created_time is set on DIDComm V2 messages", func(t *testing.T) {
+		ot := &endpointTransport{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{ot},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		dest := &service.Destination{ServiceEndpoint: model.NewDIDCommV1Endpoint("url")}
+		created := time.Unix(1600000000, 0)
+
+		msgV1 := service.DIDCommMsgMap{"@id": "1", "@type": "type"}
+		msgV2 := service.DIDCommMsgMap{"id": "1", "type": "type"}
+		msgV2Created := service.DIDCommMsgMap{"id": "1", "type": "type", "created_time": created.Unix()}
+
+		require.NoError(t, o.Send(msgV1, mockdiddoc.MockDIDKey(t), dest))
+		require.NoError(t, o.Send(&msgV2, mockdiddoc.MockDIDKey(t), dest))
+		require.NoError(t, o.Send(msgV2Created, mockdiddoc.MockDIDKey(t), dest))
+
+		sent := ot.sentMessages()
+		require.Len(t, sent, 3)
+
+		for i, src := range sent {
+			msg, e := service.ParseDIDCommMsgMap(src)
+			require.NoError(t, e)
+
+			switch i {
+			case 0:
+				require.True(t, msg.CreatedTime().IsZero())
+			case 1:
+				require.WithinDuration(t, time.Now(), msg.CreatedTime(), time.Minute)
+			case 2:
+				require.Equal(t, created, msg.CreatedTime())
+			}
+		}
+
+		// the messages of the caller are not modified
+		require.True(t, msgV2.CreatedTime().IsZero())
+		require.Equal(t, service.DIDCommMsgMap{"id": "1", "type": "type"}, msgV2)
+	})
+
 	t.Run("test no outbound transport found", func(t *testing.T) {
 		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{},
@@ -562,6 +609,76 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 
 		require.NoError(t, o.SendToDID(service.DIDCommMsgMap{}, testDID, ""))
 	})
+
+	t.Run("failover to the next router service", func(t *testing.T) {
+		doc := mockdiddoc.GetMockDIDDoc(t, false)
+
+		backup := doc.Service[0]
+		backup.ID = "backup"
+		backup.Priority = 1
+		backup.ServiceEndpoint = model.NewDIDCommV1Endpoint("https://backup.example.com")
+		doc.Service = append([]did.Service{backup}, doc.Service...)
+
+		newDispatcher := func(ot transport.OutboundTransport, opts ...Option) *Dispatcher {
+			o, err := NewOutbound(&mockProvider{
+				packagerValue:           &mockpackager.Packager{PackValue: createPackedMsgForForward(t)},
+				vdr:                     &mockvdr.MockVDRegistry{ResolveValue: doc},
+				outboundTransportsValue: []transport.OutboundTransport{ot},
+				storageProvider:         mockstore.NewMockStoreProvider(),
+				protoStorageProvider:    mockstore.NewMockStoreProvider(),
+				mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			}, opts...)
+			require.NoError(t, err)
+
+			o.connections = &mockConnectionLookup{
+				getConnectionByDIDsVal: "mock1",
+				getConnectionRecordVal: &connection.Record{},
+			}
+
+			return o
+		}
+
+		ot := &endpointTransport{failing: map[string]bool{"https://localhost:8090": true}}
+
+		require.NoError(t, newDispatcher(ot).SendToDID(service.DIDCommMsgMap{"@id": "123", "@type": "abc"}, testDID, ""))
+		require.Equal(t, []string{"https://localhost:8090", "https://backup.example.com"}, ot.sentURIs())
+
+		ot = &endpointTransport{failing: map[string]bool{
+			"https://localhost:8090":     true,
+			"https://backup.example.com": true,
+		}}
+
+		err := newDispatcher(ot).SendToDID(service.DIDCommMsgMap{"@id": "123", "@type": "abc"}, testDID, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to send msg using outbound transport")
+		require.Len(t, ot.sentURIs(), 2)
+
+		// each attempt of the outbox tries every router
+		ot = &endpointTransport{failing: map[string]bool{
+			"https://localhost:8090":     true,
+			"https://backup.example.com": true,
+		}}
+
+		o := newDispatcher(ot, WithOutbox(&RetryParams{
+			MaxAttempts:     2,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond,
+			Multiplier:      1,
+		}))
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, o.RegisterMsgEvent(states))
+
+		require.NoError(t, o.SendToDID(service.DIDCommMsgMap{"@id": "123", "@type": "abc"}, testDID, ""))
+
+		state := waitForOutboxEvent(t, states)
+		require.Equal(t, StateIDFailed, state.StateID)
+		require.Equal(t, "send error to https://backup.example.com", state.Properties.All()[errorPropKey])
+		require.Equal(t, []string{
+			"https://localhost:8090", "https://backup.example.com",
+			"https://localhost:8090", "https://backup.example.com",
+		}, ot.sentURIs())
+	})
 }
 
 func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
@@ -823,6 +940,59 @@ func (o *mockOutboundTransport) Accept(url string) bool {
 	return true
 }
 
+// endpointTransport records the service endpoints of the sent messages and fails sending to the failing ones.
+type endpointTransport struct {
+	failing map[string]bool
+	sent    []string
+	packed  [][]byte
+	lock    sync.Mutex
+}
+
+func (o *endpointTransport) Start(prov transport.Provider) error {
+	return nil
+}
+
+func (o *endpointTransport) Send(data []byte, destination *service.Destination) (string, error) {
+	uri, err := destination.ServiceEndpoint.URI()
+	if err != nil {
+		return "", err
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.sent = append(o.sent, uri)
+	o.packed = append(o.packed, data)
+
+	if o.failing[uri] {
+		return "", fmt.Errorf("send error to %s", uri)
+	}
+
+	return "", nil
+}
+
+func (o *endpointTransport) sentURIs() []string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return append([]string(nil), o.sent...)
+}
+
+func (o *endpointTransport) sentMessages() [][]byte {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return append([][]byte(nil), o.packed...)
+}
+
+func (o *endpointTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (o *endpointTransport) Accept(url string) bool {
+	return true
+}
+
 // mockPackager mock packager.
 type mockPackager struct {
 	mock.Mock
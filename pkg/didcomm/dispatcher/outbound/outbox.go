@@ -0,0 +1,448 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbound
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+const (
+	// OutboxName is the protocol name of the events emitted by the outbox.
+	OutboxName = "outbox"
+
+	// StateIDDelivered is the state of the outbox events emitted once a message was delivered.
+	StateIDDelivered = "delivered"
+
+	// StateIDFailed is the state of the outbox events emitted once a message was dropped after the last attempt.
+	StateIDFailed = "failed"
+
+	outboxStoreName = "outbox"
+	outboxKey       = "outbox_%s"
+	outboxTag       = "outbox"
+
+	messageIDPropKey   = "messageID"
+	messageTypePropKey = "messageType"
+	attemptsPropKey    = "attempts"
+	errorPropKey       = "error"
+
+	defaultMaxAttempts         = 10
+	defaultInitialInterval     = time.Second
+	defaultMaxInterval         = 5 * time.Minute
+	defaultMultiplier          = 2
+	defaultRandomizationFactor = 0.5
+)
+
+// RetryParams configures how the outbox retries undelivered messages.
+type RetryParams struct {
+	// MaxAttempts is the number of delivery attempts, including the first one, before a message is dropped.
+	MaxAttempts int
+	// InitialInterval is the delay before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between two retries.
+	MaxInterval time.Duration
+	// Multiplier is applied to the delay after each retry.
+	Multiplier float64
+	// RandomizationFactor adds jitter to the delay, a delay d is picked in [d - f*d, d + f*d].
+	RandomizationFactor float64
+}
+
+// DefaultRetryParams returns the default outbox retry parameters.
+func DefaultRetryParams() *RetryParams {
+	return &RetryParams{
+		MaxAttempts:         defaultMaxAttempts,
+		InitialInterval:     defaultInitialInterval,
+		MaxInterval:         defaultMaxInterval,
+		Multiplier:          defaultMultiplier,
+		RandomizationFactor: defaultRandomizationFactor,
+	}
+}
+
+// Option configures the outbound dispatcher.
+type Option func(o *Dispatcher)
+
+// WithOutbox enables the persistent outbox. Messages that cannot be delivered by the outbound transport are
+// stored with their packed envelope and retried in the background with an exponential backoff, Send does not
+// fail on transient transport errors anymore and delivery is reported through the message events instead.
+func WithOutbox(params *RetryParams) Option {
+	return func(o *Dispatcher) {
+		if params == nil {
+			params = DefaultRetryParams()
+		}
+
+		o.outbox = &outbox{params: params, inflight: map[string]*time.Timer{}}
+	}
+}
+
+type outbox struct {
+	store    storage.Store
+	params   *RetryParams
+	inflight map[string]*time.Timer
+	lock     sync.Mutex
+	closed   bool
+}
+
+// outboxRecord is a message waiting for delivery.
+type outboxRecord struct {
+	// Key identifies the delivery of the message to its destinations, a message is queued once per destinations.
+	Key         string `json:"key"`
+	MessageID   string `json:"message_id"`
+	MessageType string `json:"message_type,omitempty"`
+	// Envelopes are the message packed for each destination, they are tried in order at each attempt.
+	Envelopes []*outboxEnvelope `json:"envelopes"`
+	Attempts  int               `json:"attempts"`
+	LastError string            `json:"last_error,omitempty"`
+}
+
+// outboxEnvelope is a message packed for a destination.
+type outboxEnvelope struct {
+	Packed      []byte               `json:"packed"`
+	Destination *service.Destination `json:"destination"`
+}
+
+var errNoOutboundTransport = errors.New("no transport found for the destinations")
+
+type outboxEventProps struct {
+	record *outboxRecord
+}
+
+// All implements EventProperties interface.
+func (e *outboxEventProps) All() map[string]interface{} {
+	all := map[string]interface{}{
+		messageIDPropKey: e.record.MessageID,
+		attemptsPropKey:  e.record.Attempts,
+	}
+
+	if e.record.MessageType != "" {
+		all[messageTypePropKey] = e.record.MessageType
+	}
+
+	if e.record.LastError != "" {
+		all[errorPropKey] = e.record.LastError
+	}
+
+	return all
+}
+
+func (o *Dispatcher) initOutbox(prov provider) error {
+	store, err := prov.StorageProvider().OpenStore(outboxStoreName)
+	if err != nil {
+		return fmt.Errorf("open outbox store: %w", err)
+	}
+
+	err = prov.StorageProvider().SetStoreConfig(outboxStoreName,
+		storage.StoreConfiguration{TagNames: []string{outboxTag}})
+	if err != nil {
+		return fmt.Errorf("set outbox store config: %w", err)
+	}
+
+	o.outbox.store = store
+
+	records, err := o.pendingRecords()
+	if err != nil {
+		return fmt.Errorf("load outbox: %w", err)
+	}
+
+	// resume the deliveries interrupted by a restart
+	for _, record := range records {
+		o.scheduleRetry(record)
+	}
+
+	return nil
+}
+
+// Close stops the pending outbox retries, undelivered messages stay in the outbox until the next start.
+func (o *Dispatcher) Close() error {
+	if o.outbox == nil {
+		return nil
+	}
+
+	o.outbox.lock.Lock()
+	defer o.outbox.lock.Unlock()
+
+	o.outbox.closed = true
+
+	for id, timer := range o.outbox.inflight {
+		if timer != nil {
+			timer.Stop()
+		}
+
+		delete(o.outbox.inflight, id)
+	}
+
+	return nil
+}
+
+func newOutboxEnvelope(packedMsg []byte, des *service.Destination) *outboxEnvelope {
+	return &outboxEnvelope{
+		Packed: packedMsg,
+		// the DID doc is only needed to build the destination, it is not used by the transports
+		Destination: &service.Destination{
+			RecipientKeys:        des.RecipientKeys,
+			ServiceEndpoint:      des.ServiceEndpoint,
+			RoutingKeys:          des.RoutingKeys,
+			TransportReturnRoute: des.TransportReturnRoute,
+			MediaTypeProfiles:    des.MediaTypeProfiles,
+		},
+	}
+}
+
+// sendWithOutbox stores the envelopes then makes the first delivery attempt, the envelopes stay stored for later
+// retries on failure. Each attempt tries the destinations of the envelopes in order.
+func (o *Dispatcher) sendWithOutbox(req []byte, envelopes []*outboxEnvelope) error {
+	record := &outboxRecord{Envelopes: envelopes}
+
+	if msg, err := service.ParseDIDCommMsgMap(req); err == nil {
+		record.MessageID = msg.ID()
+		record.MessageType = msg.Type()
+	}
+
+	if record.MessageID == "" {
+		record.MessageID = uuid.New().String()
+	}
+
+	record.Key = outboxRecordKey(record.MessageID, record.Envelopes)
+
+	if !o.reserve(record.Key) {
+		logger.Debugf("outbox: message %s is already waiting for delivery to these destinations, skipping",
+			record.MessageID)
+
+		return nil
+	}
+
+	// the envelope is stored first so that a restart during the first attempt doesn't lose it
+	err := o.saveRecord(record)
+	if err != nil {
+		o.release(record)
+
+		return fmt.Errorf("outboundDispatcher.Send: failed to store message %s in the outbox: %w",
+			record.MessageID, err)
+	}
+
+	o.deliver(record)
+
+	return nil
+}
+
+// outboxRecordKey returns the key of the delivery of a message to its destinations, built from the message ID, the
+// service endpoints and the recipient keys.
+func outboxRecordKey(msgID string, envelopes []*outboxEnvelope) string {
+	h := sha256.New()
+
+	for _, envelope := range envelopes {
+		uri, _ := envelope.Destination.ServiceEndpoint.URI() // nolint:errcheck
+
+		for _, s := range append([]string{uri}, envelope.Destination.RecipientKeys...) {
+			// SHA256 digest returns empty error on Write()
+			_, _ = h.Write(append([]byte(s), 0))
+		}
+
+		_, _ = h.Write([]byte{1})
+	}
+
+	return msgID + "_" + base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// reserve returns false if the delivery with the same key is already queued.
+func (o *Dispatcher) reserve(key string) bool {
+	o.outbox.lock.Lock()
+	defer o.outbox.lock.Unlock()
+
+	if _, ok := o.outbox.inflight[key]; ok {
+		return false
+	}
+
+	if _, err := o.outbox.store.Get(fmt.Sprintf(outboxKey, key)); err == nil {
+		return false
+	}
+
+	// the delivery is reserved until its retry is scheduled
+	o.outbox.inflight[key] = nil
+
+	return true
+}
+
+func (o *Dispatcher) deliver(record *outboxRecord) {
+	record.Attempts++
+
+	err := o.attempt(record)
+	if err == nil {
+		o.release(record)
+		o.sendOutboxEvent(record, StateIDDelivered)
+
+		return
+	}
+
+	record.LastError = err.Error()
+
+	if errors.Is(err, errNoOutboundTransport) || record.Attempts >= o.outbox.params.MaxAttempts {
+		logger.Warnf("outbox: dropping message %s after %d attempts: %s", record.MessageID, record.Attempts, err)
+
+		o.release(record)
+		o.sendOutboxEvent(record, StateIDFailed)
+
+		return
+	}
+
+	logger.Debugf("outbox: delivery attempt %d of message %s failed: %s", record.Attempts, record.MessageID, err)
+
+	if err = o.saveRecord(record); err != nil {
+		logger.Errorf("outbox: failed to save message %s: %s", record.MessageID, err)
+
+		o.release(record)
+		o.sendOutboxEvent(record, StateIDFailed)
+
+		return
+	}
+
+	o.scheduleRetry(record)
+}
+
+func (o *Dispatcher) scheduleRetry(record *outboxRecord) {
+	o.outbox.lock.Lock()
+	defer o.outbox.lock.Unlock()
+
+	if o.outbox.closed {
+		return
+	}
+
+	o.outbox.inflight[record.Key] = time.AfterFunc(o.outbox.params.interval(record.Attempts), func() {
+		o.deliver(record)
+	})
+}
+
+// attempt sends the message to the destinations of its envelopes in order until one accepts it, it returns the
+// error of the last destination otherwise.
+func (o *Dispatcher) attempt(record *outboxRecord) error {
+	err := errNoOutboundTransport
+
+	for _, envelope := range record.Envelopes {
+		outboundTransport, e := o.outboundTransport(envelope.Destination)
+		if e != nil {
+			logger.Debugf("outbox: skipping destination of message %s: %s", record.MessageID, e)
+
+			continue
+		}
+
+		_, err = outboundTransport.Send(envelope.Packed, envelope.Destination)
+		if err == nil {
+			return nil
+		}
+
+		uri, _ := envelope.Destination.ServiceEndpoint.URI() // nolint:errcheck
+		logger.Debugf("outbox: failed to send message %s to %s: %s", record.MessageID, uri, err)
+	}
+
+	return err
+}
+
+// release removes the message from the outbox.
+func (o *Dispatcher) release(record *outboxRecord) {
+	o.outbox.lock.Lock()
+	delete(o.outbox.inflight, record.Key)
+	o.outbox.lock.Unlock()
+
+	err := o.outbox.store.Delete(fmt.Sprintf(outboxKey, record.Key))
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		logger.Errorf("outbox: failed to delete message %s: %s", record.MessageID, err)
+	}
+}
+
+func (o *Dispatcher) saveRecord(record *outboxRecord) error {
+	src, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal outbox record: %w", err)
+	}
+
+	return o.outbox.store.Put(fmt.Sprintf(outboxKey, record.Key), src, storage.Tag{Name: outboxTag})
+}
+
+func (o *Dispatcher) pendingRecords() ([]*outboxRecord, error) {
+	iter, err := o.outbox.store.Query(outboxTag)
+	if err != nil {
+		return nil, fmt.Errorf("query outbox store: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var records []*outboxRecord
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value from records: %w", err)
+		}
+
+		record := &outboxRecord{}
+
+		err = json.Unmarshal(value, record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox record: %w", err)
+		}
+
+		records = append(records, record)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	return records, nil
+}
+
+// sendOutboxEvent notifies the message event handlers without blocking, the events are mostly sent from the retry
+// timers which must not wait for the handlers.
+func (o *Dispatcher) sendOutboxEvent(record *outboxRecord, stateID string) {
+	msg := service.StateMsg{
+		ProtocolName: OutboxName,
+		Type:         service.PostState,
+		StateID:      stateID,
+		Properties:   &outboxEventProps{record: record},
+	}
+
+	for _, handler := range o.MsgEvents() {
+		go func(handler chan<- service.StateMsg) {
+			handler <- msg
+		}(handler)
+	}
+}
+
+// interval returns the delay before the next attempt of a message that was already attempted n times.
+func (p *RetryParams) interval(attempts int) time.Duration {
+	b := &backoff.ExponentialBackOff{
+		InitialInterval:     p.InitialInterval,
+		RandomizationFactor: p.RandomizationFactor,
+		Multiplier:          p.Multiplier,
+		MaxInterval:         p.MaxInterval,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}
+	b.Reset()
+
+	for i := 1; i < attempts; i++ {
+		b.NextBackOff()
+	}
+
+	return b.NextBackOff()
+}
@@ -0,0 +1,328 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/common/model"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
+	mockpackager "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/packager"
+	mockdiddoc "github.com/markcryptohash/aries-framework-go/pkg/mock/diddoc"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Run("message is delivered after retries", func(t *testing.T) {
+		outboundTransport := &flakyOutboundTransport{failures: 2}
+		o, store := newOutboxDispatcher(t, outboundTransport, mockstore.NewMockStoreProvider())
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, o.RegisterMsgEvent(states))
+
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination()))
+
+		state := waitForOutboxEvent(t, states)
+		require.Equal(t, OutboxName, state.ProtocolName)
+		require.Equal(t, StateIDDelivered, state.StateID)
+		require.Equal(t, "msg-1", state.Properties.All()[messageIDPropKey])
+		require.Equal(t, 3, state.Properties.All()[attemptsPropKey])
+		require.Equal(t, 3, outboundTransport.calls())
+
+		_, err := store.Get(outboxTestKey("msg-1"))
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("events don't block the delivery", func(t *testing.T) {
+		outboundTransport := &flakyOutboundTransport{}
+		o, _ := newOutboxDispatcher(t, outboundTransport, mockstore.NewMockStoreProvider())
+
+		states := make(chan service.StateMsg)
+		require.NoError(t, o.RegisterMsgEvent(states))
+
+		// the event handler only reads once the message was delivered
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination()))
+		require.Equal(t, 1, outboundTransport.calls())
+
+		state := waitForOutboxEvent(t, states)
+		require.Equal(t, StateIDDelivered, state.StateID)
+	})
+
+	t.Run("message is dropped after the last attempt", func(t *testing.T) {
+		outboundTransport := &flakyOutboundTransport{failures: 10}
+		o, store := newOutboxDispatcher(t, outboundTransport, mockstore.NewMockStoreProvider())
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, o.RegisterMsgEvent(states))
+
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination()))
+
+		state := waitForOutboxEvent(t, states)
+		require.Equal(t, StateIDFailed, state.StateID)
+		require.Equal(t, 3, state.Properties.All()[attemptsPropKey])
+		require.Equal(t, "endpoint down", state.Properties.All()[errorPropKey])
+		require.Equal(t, 3, outboundTransport.calls())
+
+		_, err := store.Get(outboxTestKey("msg-1"))
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("duplicate messages are not queued twice", func(t *testing.T) {
+		outboundTransport := &flakyOutboundTransport{failures: 10}
+		o, store := newOutboxDispatcher(t, outboundTransport, mockstore.NewMockStoreProvider())
+		o.outbox.params.InitialInterval = time.Hour
+		o.outbox.params.MaxInterval = time.Hour
+
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination()))
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination()))
+		require.Equal(t, 1, outboundTransport.calls())
+
+		src, err := store.Get(outboxTestKey("msg-1"))
+		require.NoError(t, err)
+
+		record := &outboxRecord{}
+		require.NoError(t, json.Unmarshal(src, record))
+		require.Equal(t, 1, record.Attempts)
+		require.Len(t, record.Envelopes, 1)
+		require.Equal(t, "https://example.com/endpoint", mustURI(t, record.Envelopes[0].Destination))
+
+		require.NoError(t, o.Close())
+	})
+
+	t.Run("message is queued once per destination", func(t *testing.T) {
+		outboundTransport := &flakyOutboundTransport{failures: 10}
+		o, store := newOutboxDispatcher(t, outboundTransport, mockstore.NewMockStoreProvider())
+		o.outbox.params.InitialInterval = time.Hour
+		o.outbox.params.MaxInterval = time.Hour
+
+		otherDestination := &service.Destination{
+			ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com/other"),
+		}
+
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination()))
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), otherDestination))
+		require.Equal(t, 2, outboundTransport.calls())
+
+		_, err := store.Get(outboxTestKey("msg-1"))
+		require.NoError(t, err)
+
+		_, err = store.Get(fmt.Sprintf(outboxKey, outboxRecordKey("msg-1",
+			[]*outboxEnvelope{newOutboxEnvelope([]byte("packed"), otherDestination)})))
+		require.NoError(t, err)
+
+		require.NoError(t, o.Close())
+	})
+
+	t.Run("message is stored before the first attempt", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+
+		outboundTransport := &flakyOutboundTransport{
+			onSend: func() {
+				_, err := storeProvider.Store.Get(outboxTestKey("msg-1"))
+				require.NoError(t, err)
+			},
+		}
+
+		o, store := newOutboxDispatcher(t, outboundTransport, storeProvider)
+
+		require.NoError(t, o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination()))
+		require.Equal(t, 1, outboundTransport.calls())
+
+		_, err := store.Get(outboxTestKey("msg-1"))
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("error - message can't be stored", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		storeProvider.Store.ErrPut = errors.New("put error")
+
+		outboundTransport := &flakyOutboundTransport{}
+		o, _ := newOutboxDispatcher(t, outboundTransport, storeProvider)
+
+		err := o.Send(outboxTestMsg("msg-1"), mockdiddoc.MockDIDKey(t), outboxTestDestination())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "put error")
+		require.Equal(t, 0, outboundTransport.calls())
+	})
+
+	t.Run("pending messages are resumed on start", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		store, err := storeProvider.OpenStore(outboxStoreName)
+		require.NoError(t, err)
+
+		envelopes := []*outboxEnvelope{newOutboxEnvelope([]byte("packed"), outboxTestDestination())}
+
+		src, err := json.Marshal(&outboxRecord{
+			Key:       outboxRecordKey("msg-1", envelopes),
+			MessageID: "msg-1",
+			Envelopes: envelopes,
+			Attempts:  1,
+		})
+		require.NoError(t, err)
+		require.NoError(t, store.Put(outboxTestKey("msg-1"), src, storage.Tag{Name: outboxTag}))
+
+		outboundTransport := &flakyOutboundTransport{}
+		_, _ = newOutboxDispatcher(t, outboundTransport, storeProvider)
+
+		require.Eventually(t, func() bool {
+			_, err = store.Get(outboxTestKey("msg-1"))
+
+			return errors.Is(err, storage.ErrDataNotFound)
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, 1, outboundTransport.calls())
+	})
+
+	t.Run("open outbox store error", func(t *testing.T) {
+		storeProvider := mockstore.NewMockStoreProvider()
+		storeProvider.FailNamespace = outboxStoreName
+
+		_, err := NewOutbound(&mockProvider{
+			packagerValue:        &mockpackager.Packager{},
+			storageProvider:      storeProvider,
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeV1PlaintextPayload},
+		}, WithOutbox(nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init outbox")
+	})
+}
+
+func TestRetryParams_interval(t *testing.T) {
+	params := &RetryParams{
+		InitialInterval:     time.Second,
+		MaxInterval:         5 * time.Second,
+		Multiplier:          2,
+		RandomizationFactor: 0.5,
+	}
+
+	for attempts, expected := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		5: 5 * time.Second,
+	} {
+		interval := params.interval(attempts)
+		require.GreaterOrEqual(t, interval, expected/2)
+		require.LessOrEqual(t, interval, expected*3/2)
+	}
+
+	require.Equal(t, defaultMaxAttempts, DefaultRetryParams().MaxAttempts)
+}
+
+func newOutboxDispatcher(t *testing.T, outboundTransport transport.OutboundTransport,
+	storeProvider *mockstore.MockStoreProvider) (*Dispatcher, *mockstore.MockStore) {
+	t.Helper()
+
+	o, err := NewOutbound(&mockProvider{
+		packagerValue:           &mockpackager.Packager{PackValue: []byte("packed")},
+		outboundTransportsValue: []transport.OutboundTransport{outboundTransport},
+		storageProvider:         storeProvider,
+		protoStorageProvider:    mockstore.NewMockStoreProvider(),
+		mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+	}, WithOutbox(&RetryParams{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      1,
+	}))
+	require.NoError(t, err)
+
+	return o, storeProvider.Store
+}
+
+func outboxTestMsg(id string) service.DIDCommMsgMap {
+	return service.DIDCommMsgMap{
+		"@id":   id,
+		"@type": "https://didcomm.org/test/1.0/message",
+	}
+}
+
+func outboxTestDestination() *service.Destination {
+	return &service.Destination{
+		ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com/endpoint"),
+	}
+}
+
+func waitForOutboxEvent(t *testing.T, states chan service.StateMsg) service.StateMsg {
+	t.Helper()
+
+	select {
+	case state := <-states:
+		return state
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for outbox event")
+	}
+
+	return service.StateMsg{}
+}
+
+func outboxTestKey(msgID string) string {
+	return fmt.Sprintf(outboxKey, outboxRecordKey(msgID,
+		[]*outboxEnvelope{newOutboxEnvelope([]byte("packed"), outboxTestDestination())}))
+}
+
+func mustURI(t *testing.T, des *service.Destination) string {
+	t.Helper()
+
+	uri, err := des.ServiceEndpoint.URI()
+	require.NoError(t, err)
+
+	return uri
+}
+
+// flakyOutboundTransport fails the first sends.
+type flakyOutboundTransport struct {
+	failures int
+	onSend   func()
+	count    int
+	lock     sync.Mutex
+}
+
+func (o *flakyOutboundTransport) Start(transport.Provider) error {
+	return nil
+}
+
+func (o *flakyOutboundTransport) Send([]byte, *service.Destination) (string, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.count++
+
+	if o.onSend != nil {
+		o.onSend()
+	}
+
+	if o.count <= o.failures {
+		return "", errors.New("endpoint down")
+	}
+
+	return "", nil
+}
+
+func (o *flakyOutboundTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (o *flakyOutboundTransport) Accept(string) bool {
+	return true
+}
+
+func (o *flakyOutboundTransport) calls() int {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return o.count
+}
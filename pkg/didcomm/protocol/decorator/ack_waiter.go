@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package decorator
+
+import (
+	"sync"
+	"time"
+)
+
+// AckWaiter keeps track of the acknowledgements requested with the please_ack decorator, per thread. It is used by
+// the protocol services to notify the acknowledgements which are not received in time.
+type AckWaiter struct {
+	mu   sync.Mutex
+	acks map[string]*pendingAck
+}
+
+// pendingAck is an acknowledgement requested with the please_ack decorator.
+type pendingAck struct {
+	timer *time.Timer
+	// the outcome is acknowledged by the final ack or problem report of the protocol.
+	outcome bool
+}
+
+// NewAckWaiter returns a new AckWaiter.
+func NewAckWaiter() *AckWaiter {
+	return &AckWaiter{acks: make(map[string]*pendingAck)}
+}
+
+// Await waits for the acknowledgements requested by pleaseAck in the thread thID, onTimeout is called if they are not
+// received within timeout, DefaultAckTimeout if timeout is not positive. It replaces the acknowledgements awaited in
+// the thread.
+func (w *AckWaiter) Await(thID string, pleaseAck *PleaseAck, timeout time.Duration, onTimeout func()) {
+	if timeout <= 0 {
+		timeout = DefaultAckTimeout
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if previous, ok := w.acks[thID]; ok {
+		previous.timer.Stop()
+	}
+
+	pending := &pendingAck{outcome: pleaseAck.OnOutcome()}
+	pending.timer = time.AfterFunc(timeout, func() {
+		if w.timedOut(thID, pending) {
+			onTimeout()
+		}
+	})
+
+	w.acks[thID] = pending
+}
+
+// Stop stops waiting for the acknowledgement of the thread thID, unless only the receipt of a message whose outcome
+// is awaited is acknowledged. It returns true if an acknowledgement was awaited.
+func (w *AckWaiter) Stop(thID string, outcome bool) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pending, ok := w.acks[thID]
+	if !ok {
+		return false
+	}
+
+	if outcome || !pending.outcome {
+		pending.timer.Stop()
+		delete(w.acks, thID)
+	}
+
+	return true
+}
+
+// Awaiting returns true if an acknowledgement is awaited in the thread thID.
+func (w *AckWaiter) Awaiting(thID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, ok := w.acks[thID]
+
+	return ok
+}
+
+func (w *AckWaiter) timedOut(thID string, pending *pendingAck) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.acks[thID] != pending {
+		return false
+	}
+
+	delete(w.acks, thID)
+
+	return true
+}
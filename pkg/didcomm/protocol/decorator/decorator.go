@@ -281,8 +281,10 @@ type AttachmentData struct {
 	JWS json.RawMessage `json:"jws,omitempty"`
 }
 
-// Fetch this attachment's contents.
-func (d *AttachmentData) Fetch() ([]byte, error) {
+// Fetch this attachment's contents. The linked contents are fetched when there are no inline contents and a link
+// fetcher is given with WithLinkFetcher, their sha256 is required. The sha256 of the base64 contents is verified
+// when present.
+func (d *AttachmentData) Fetch(opts ...FetchOpt) ([]byte, error) {
 	if d.JSON != nil {
 		bits, err := json.Marshal(d.JSON)
 		if err != nil {
@@ -298,12 +300,16 @@ func (d *AttachmentData) Fetch() ([]byte, error) {
 			return nil, fmt.Errorf("failed to base64 decode attachment contents : %w", err)
 		}
 
+		if !d.checksumMatches(bits) {
+			return nil, ErrChecksumMismatch
+		}
+
 		return bits, nil
 	}
 
-	// TODO add support for checksum verification
-
-	// TODO add support to fetch links
+	if len(d.Links) > 0 {
+		return d.fetchLinks(opts...)
+	}
 
 	return nil, errors.New("no contents in this attachment")
 }
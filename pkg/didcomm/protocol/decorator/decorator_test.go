@@ -15,7 +15,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/tink/go/keyset"
 	"github.com/stretchr/testify/require"
@@ -163,6 +167,112 @@ func TestAttachmentData_Fetch(t *testing.T) {
 	})
 }
 
+func TestAttachmentData_FetchLinks(t *testing.T) {
+	content := []byte(`{"FirstName":"John","LastName":"Doe"}`)
+
+	t.Run("fetch linked contents over HTTP", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/content" {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, err := w.Write(content)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		data := NewLinkedAttachmentData(content, server.URL+"/missing", server.URL+"/content")
+		require.Equal(t, []string{server.URL + "/missing", server.URL + "/content"}, data.Links)
+		require.NotEmpty(t, data.Sha256)
+
+		// the links are not fetched unless a link fetcher is given.
+		_, err := data.Fetch()
+		require.True(t, errors.Is(err, ErrLinksDisabled))
+
+		fetcher := WithLinkFetcher(NewHTTPLinkFetcher(server.Client()))
+
+		bits, err := data.Fetch(fetcher)
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+
+		_, err = data.Fetch(fetcher, WithMaxLinkedContentSize(int64(len(content)-1)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceed")
+
+		missing := NewLinkedAttachmentData(content, server.URL+"/missing")
+
+		_, err = missing.Fetch(fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected response status 404")
+	})
+
+	t.Run("fetch linked contents with a custom fetcher", func(t *testing.T) {
+		fetcher := NewMemoryLinkFetcher(map[string][]byte{
+			"file:///tampered.json": []byte(`{}`),
+			"file:///content.json":  content,
+		})
+
+		data := NewLinkedAttachmentData(content, "file:///tampered.json", "file:///content.json")
+
+		bits, err := data.Fetch(WithLinkFetcher(fetcher))
+		require.NoError(t, err)
+		require.Equal(t, content, bits)
+
+		_, err = data.Fetch(WithLinkFetcher(fetcher), WithMaxLinkedContentSize(1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceed 1 bytes")
+	})
+
+	t.Run("error - checksum mismatch", func(t *testing.T) {
+		fetcher := NewMemoryLinkFetcher(map[string][]byte{"file:///content.json": []byte(`{}`)})
+
+		data := NewLinkedAttachmentData(content, "file:///content.json", "file:///missing.json")
+
+		_, err := data.Fetch(WithLinkFetcher(fetcher))
+		require.True(t, errors.Is(err, ErrChecksumMismatch))
+		require.Contains(t, err.Error(), "attachment link file:///missing.json not found")
+
+		data = AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString([]byte(`{}`)),
+			Sha256: NewLinkedAttachmentData(content).Sha256,
+		}
+
+		_, err = data.Fetch()
+		require.True(t, errors.Is(err, ErrChecksumMismatch))
+
+		data.Sha256 = strings.ToUpper(NewLinkedAttachmentData([]byte(`{}`)).Sha256)
+
+		_, err = data.Fetch()
+		require.NoError(t, err)
+	})
+
+	t.Run("error - links without checksum", func(t *testing.T) {
+		fetcher := NewMemoryLinkFetcher(map[string][]byte{"file:///content.json": content})
+
+		_, err := (&AttachmentData{Links: []string{"file:///content.json"}}).Fetch(WithLinkFetcher(fetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "attachment links without sha256")
+	})
+
+	t.Run("error - unsupported link scheme", func(t *testing.T) {
+		fetcher := WithLinkFetcher(NewHTTPLinkFetcher(http.DefaultClient))
+
+		data := NewLinkedAttachmentData(content, "file:///etc/passwd")
+
+		_, err := data.Fetch(fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported attachment link scheme 'file'")
+
+		data = NewLinkedAttachmentData(content, "http://[::1")
+
+		_, err = data.Fetch(fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid attachment link")
+	})
+}
+
 type testStruct struct {
 	FirstName string
 	LastName  string
@@ -471,3 +581,138 @@ func newKMS(t *testing.T) kms.KeyManager {
 
 	return customKMS
 }
+
+func TestPleaseAck(t *testing.T) {
+	var pleaseAck *PleaseAck
+
+	require.False(t, pleaseAck.OnReceipt())
+	require.False(t, pleaseAck.OnOutcome())
+	require.Nil(t, pleaseAck.AsV2())
+	require.Nil(t, PleaseAckFromV2(nil))
+
+	pleaseAck = NewPleaseAck()
+	require.True(t, pleaseAck.OnReceipt())
+	require.False(t, pleaseAck.OnOutcome())
+	require.Equal(t, []string{PleaseAckOnReceipt}, pleaseAck.AsV2())
+
+	pleaseAck = PleaseAckFromV2(NewPleaseAck(PleaseAckOnOutcome).AsV2())
+	require.False(t, pleaseAck.OnReceipt())
+	require.True(t, pleaseAck.OnOutcome())
+
+	raw, err := json.Marshal(NewPleaseAck(PleaseAckOnReceipt, PleaseAckOnOutcome))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"on":["RECEIPT","OUTCOME"]}`, string(raw))
+}
+
+func TestAckWaiter(t *testing.T) {
+	const thID = "thread"
+
+	timedOut := func(t *testing.T, w *AckWaiter, pleaseAck *PleaseAck) chan struct{} {
+		t.Helper()
+
+		done := make(chan struct{})
+
+		w.Await(thID, pleaseAck, 10*time.Millisecond, func() { close(done) })
+		require.True(t, w.Awaiting(thID))
+
+		return done
+	}
+
+	t.Run("acknowledgement not received in time", func(t *testing.T) {
+		w := NewAckWaiter()
+
+		select {
+		case <-timedOut(t, w, NewPleaseAck()):
+		case <-time.After(time.Second):
+			require.FailNow(t, "timeout not notified")
+		}
+
+		require.False(t, w.Awaiting(thID))
+		require.False(t, w.Stop(thID, true))
+	})
+
+	t.Run("receipt acknowledged", func(t *testing.T) {
+		w := NewAckWaiter()
+		done := timedOut(t, w, NewPleaseAck(PleaseAckOnReceipt))
+
+		require.True(t, w.Stop(thID, false))
+		require.False(t, w.Awaiting(thID))
+		require.False(t, w.Stop(thID, false))
+
+		select {
+		case <-done:
+			require.FailNow(t, "timeout notified after the acknowledgement")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("outcome awaited after the receipt", func(t *testing.T) {
+		w := NewAckWaiter()
+		timedOut(t, w, NewPleaseAck(PleaseAckOnReceipt, PleaseAckOnOutcome))
+
+		require.True(t, w.Stop(thID, false))
+		require.True(t, w.Awaiting(thID))
+		require.True(t, w.Stop(thID, true))
+		require.False(t, w.Awaiting(thID))
+	})
+
+	t.Run("wait replaced", func(t *testing.T) {
+		w := NewAckWaiter()
+		first := timedOut(t, w, NewPleaseAck())
+
+		w.Await(thID, NewPleaseAck(), time.Minute, func() {})
+
+		select {
+		case <-first:
+			require.FailNow(t, "timeout of a replaced wait notified")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		require.True(t, w.Stop(thID, true))
+	})
+}
+
+func TestFieldL10n(t *testing.T) {
+	var fieldL10n FieldL10n
+
+	require.Empty(t, fieldL10n.Locale())
+	require.Empty(t, fieldL10n.Code())
+	require.Empty(t, fieldL10n.Translations())
+
+	fieldL10n = NewFieldL10n("en", map[string]string{"es": "Hola", "fr": "Bonjour"})
+	require.Equal(t, "en", fieldL10n.Locale())
+	require.Equal(t, map[string]string{"es": "Hola", "fr": "Bonjour"}, fieldL10n.Translations())
+
+	fieldL10n = FieldL10n{"code": "greeting", "de": "Hallo"}
+	require.Empty(t, fieldL10n.Locale())
+	require.Equal(t, "greeting", fieldL10n.Code())
+	require.Equal(t, map[string]string{"de": "Hallo"}, fieldL10n.Translations())
+
+	raw, err := json.Marshal(NewFieldL10n("", map[string]string{"es": "Hola"}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"es":"Hola"}`, string(raw))
+}
+
+func TestMatchLocale(t *testing.T) {
+	available := []string{"en-GB", "en", "es_MX", "fr-CA"}
+
+	tests := []struct {
+		locale string
+		match  string
+	}{
+		{locale: "en-GB", match: "en-GB"},
+		{locale: "en-us", match: "en"},
+		{locale: "EN", match: "en"},
+		{locale: "es-MX", match: "es_MX"},
+		{locale: "es", match: "es_MX"},
+		{locale: "fr_FR", match: "fr-CA"},
+		{locale: "de"},
+		{},
+	}
+
+	for _, tc := range tests {
+		match, ok := MatchLocale(tc.locale, available)
+		require.Equal(t, tc.match, match, tc.locale)
+		require.Equal(t, tc.match != "", ok, tc.locale)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package decorator
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// L10nFieldSuffix is the suffix of the name of the decorator localizing a field, e.g. comment~l10n.
+	L10nFieldSuffix = "~l10n"
+
+	l10nLocale = "locale"
+	l10nCode   = "code"
+)
+
+// L10n is the ~l10n decorator of DIDComm V1 messages, it gives the locale of the localizable fields of the message
+// and the catalogs of their translations.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0043-l10n
+type L10n struct {
+	Locale      string   `json:"locale,omitempty"`
+	Localizable []string `json:"localizable,omitempty"`
+	Catalogs    []string `json:"catalogs,omitempty"`
+}
+
+// L10nV2 is the l10n header of DIDComm V2 messages, each inline translation is a [locale, field, value] triple
+// of a field of the message body. The locale of the body is given by the lang header.
+// https://identity.foundation/didcomm-messaging/spec/#l10n
+type L10nV2 struct {
+	Inline [][]string `json:"inline,omitempty"`
+}
+
+// FieldL10n is the decorator localizing a field of DIDComm V1 messages. It holds the locale of the field value, the
+// code of its translations in the message catalogs and its inline translations keyed by locale
+// e.g. { "locale": "en", "es": "Donde se toma el MRI; no en el centro" }.
+type FieldL10n map[string]string
+
+// NewFieldL10n returns the decorator of a field in locale with the given inline translations.
+func NewFieldL10n(locale string, translations map[string]string) FieldL10n {
+	f := FieldL10n{}
+
+	for l, v := range translations {
+		f[l] = v
+	}
+
+	if locale != "" {
+		f[l10nLocale] = locale
+	}
+
+	return f
+}
+
+// Locale returns the locale of the field value, the field is in the locale of the message when it is empty.
+func (f FieldL10n) Locale() string {
+	return f[l10nLocale]
+}
+
+// Code returns the code of the field translations in the message catalogs.
+func (f FieldL10n) Code() string {
+	return f[l10nCode]
+}
+
+// Translations returns the inline translations of the field keyed by locale.
+func (f FieldL10n) Translations() map[string]string {
+	translations := make(map[string]string)
+
+	for k, v := range f {
+		if k != l10nLocale && k != l10nCode {
+			translations[k] = v
+		}
+	}
+
+	return translations
+}
+
+// L10nCatalog is a message catalog, the translations of the localized fields keyed by code then by locale.
+type L10nCatalog map[string]map[string]string
+
+// MatchLocale returns the best match of locale among the available locales: the same locale or else a locale of the
+// same language, the language itself being preferred to its regional variants. Locales are compared case
+// insensitively and "_" is the same as "-".
+func MatchLocale(locale string, available []string) (string, bool) {
+	want := normalizeLocale(locale)
+	if want == "" {
+		return "", false
+	}
+
+	sorted := append([]string(nil), available...)
+	sort.Strings(sorted)
+
+	var variant string
+
+	for _, l := range sorted {
+		got := normalizeLocale(l)
+
+		switch {
+		case got == want:
+			return l, true
+		case got == language(want):
+			variant = l
+		case variant == "" && language(got) == language(want):
+			variant = l
+		}
+	}
+
+	return variant, variant != ""
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func language(locale string) string {
+	return strings.SplitN(locale, "-", 2)[0]
+}
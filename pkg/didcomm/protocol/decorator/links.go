@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package decorator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
+)
+
+const (
+	// DefaultMaxLinkedContentSize is the default maximum size in bytes of the contents fetched from attachment links.
+	DefaultMaxLinkedContentSize = 10 << 20
+
+	// DefaultLinkFetchTimeout is the recommended timeout of the HTTP client of NewHTTPLinkFetcher.
+	DefaultLinkFetchTimeout = time.Minute
+)
+
+var (
+	// ErrChecksumMismatch is returned when the sha256 of the contents of an attachment doesn't match the contents.
+	ErrChecksumMismatch = errors.New("attachment checksum mismatch")
+
+	// ErrLinksDisabled is returned when fetching the contents of an attachment links without a link fetcher, the
+	// links of inbound messages are untrusted and must not be fetched unless the caller opts in.
+	ErrLinksDisabled = errors.New("fetching attachment links is disabled")
+)
+
+var logger = log.New("aries-framework/didcomm/decorator")
+
+// LinkFetcher fetches the contents of an attachment link, the fetch must fail if the contents are larger than
+// maxSize bytes.
+type LinkFetcher func(link string, maxSize int64) ([]byte, error)
+
+// NewHTTPLinkFetcher returns a fetcher downloading the contents of http and https links with client.
+func NewHTTPLinkFetcher(client *http.Client) LinkFetcher {
+	return func(link string, maxSize int64) ([]byte, error) {
+		u, err := url.Parse(link)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attachment link: %w", err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported attachment link scheme '%s'", u.Scheme)
+		}
+
+		resp, err := client.Get(link) //nolint:noctx
+		if err != nil {
+			return nil, fmt.Errorf("fetch attachment link: %w", err)
+		}
+
+		defer func() {
+			if e := resp.Body.Close(); e != nil {
+				logger.Warnf("failed to close response body: %s", e)
+			}
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetch attachment link: unexpected response status %d", resp.StatusCode)
+		}
+
+		if resp.ContentLength > maxSize {
+			return nil, fmt.Errorf("attachment link contents of %d bytes exceed %d bytes", resp.ContentLength, maxSize)
+		}
+
+		return readLimited(resp.Body, maxSize)
+	}
+}
+
+// NewMemoryLinkFetcher returns a fetcher serving the contents of links from memory, it is a stand-in of the HTTP
+// fetcher for tests and for agents without network access. The links can use any scheme, file:// for instance.
+func NewMemoryLinkFetcher(contents map[string][]byte) LinkFetcher {
+	return func(link string, maxSize int64) ([]byte, error) {
+		content, ok := contents[link]
+		if !ok {
+			return nil, fmt.Errorf("attachment link %s not found", link)
+		}
+
+		if int64(len(content)) > maxSize {
+			return nil, fmt.Errorf("attachment link contents of %d bytes exceed %d bytes", len(content), maxSize)
+		}
+
+		return content, nil
+	}
+}
+
+// FetchOpt configures the fetch of the contents of an attachment.
+type FetchOpt func(opts *fetchOpts)
+
+type fetchOpts struct {
+	linkFetcher LinkFetcher
+	maxSize     int64
+}
+
+// WithLinkFetcher enables fetching the linked contents with fetcher, they are not fetched by default.
+func WithLinkFetcher(fetcher LinkFetcher) FetchOpt {
+	return func(opts *fetchOpts) {
+		opts.linkFetcher = fetcher
+	}
+}
+
+// WithMaxLinkedContentSize sets the maximum size in bytes of the linked contents, DefaultMaxLinkedContentSize
+// by default.
+func WithMaxLinkedContentSize(size int64) FetchOpt {
+	return func(opts *fetchOpts) {
+		opts.maxSize = size
+	}
+}
+
+// NewLinkedAttachmentData returns the data of an attachment whose content is fetched from links, the sha256 of
+// content makes the attachment tamper-evident.
+func NewLinkedAttachmentData(content []byte, links ...string) AttachmentData {
+	return AttachmentData{
+		Sha256: checksum(content),
+		Links:  links,
+	}
+}
+
+func (d *AttachmentData) fetchLinks(opts ...FetchOpt) ([]byte, error) {
+	options := &fetchOpts{maxSize: DefaultMaxLinkedContentSize}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.linkFetcher == nil {
+		return nil, ErrLinksDisabled
+	}
+
+	// the linked contents are only trusted if they match the sha256 given by the sender.
+	if d.Sha256 == "" {
+		return nil, errors.New("attachment links without sha256")
+	}
+
+	var (
+		errs     []string
+		mismatch bool
+	)
+
+	// the links are alternative locations of the same content, the first valid content is returned.
+	for _, link := range d.Links {
+		content, err := options.linkFetcher(link, options.maxSize)
+		if err != nil {
+			errs = append(errs, err.Error())
+
+			continue
+		}
+
+		if !d.checksumMatches(content) {
+			errs = append(errs, fmt.Sprintf("invalid contents of attachment link %s", link))
+			mismatch = true
+
+			continue
+		}
+
+		return content, nil
+	}
+
+	if mismatch {
+		return nil, fmt.Errorf("failed to fetch attachment links: %w: %s", ErrChecksumMismatch, strings.Join(errs, "; "))
+	}
+
+	return nil, fmt.Errorf("failed to fetch attachment links: %s", strings.Join(errs, "; "))
+}
+
+func (d *AttachmentData) checksumMatches(content []byte) bool {
+	return d.Sha256 == "" || strings.EqualFold(d.Sha256, checksum(content))
+}
+
+func checksum(content []byte) string {
+	digest := sha256.Sum256(content)
+
+	return hex.EncodeToString(digest[:])
+}
+
+func readLimited(r io.Reader, maxSize int64) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read attachment link contents: %w", err)
+	}
+
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("attachment link contents exceed %d bytes", maxSize)
+	}
+
+	return content, nil
+}
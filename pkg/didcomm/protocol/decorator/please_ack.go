@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package decorator
+
+import "time"
+
+// Acknowledgements which can be requested with the please_ack decorator.
+const (
+	// PleaseAckOnReceipt requests an acknowledgement as soon as the message is received.
+	PleaseAckOnReceipt = "RECEIPT"
+	// PleaseAckOnOutcome requests an acknowledgement once the message is processed, the acknowledgement is the
+	// final ack or problem report of the protocol.
+	PleaseAckOnOutcome = "OUTCOME"
+
+	// DefaultAckTimeout is the time to wait for a requested acknowledgement.
+	DefaultAckTimeout = 5 * time.Minute
+)
+
+// PleaseAck is the ~please_ack decorator of DIDComm V1 messages, requesting acknowledgements of the message. The
+// please_ack header of DIDComm V2 messages is the list of requested acknowledgements.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0317-please-ack
+type PleaseAck struct {
+	// On lists the requested acknowledgements, an acknowledgement of receipt is requested when it is empty.
+	On []string `json:"on,omitempty"`
+}
+
+// NewPleaseAck returns a decorator requesting the on acknowledgements.
+func NewPleaseAck(on ...string) *PleaseAck {
+	return &PleaseAck{On: on}
+}
+
+// PleaseAckFromV2 returns the decorator of a please_ack header of DIDComm V2 messages, nil if the header is empty.
+func PleaseAckFromV2(header []string) *PleaseAck {
+	if len(header) == 0 {
+		return nil
+	}
+
+	return NewPleaseAck(header...)
+}
+
+// AsV2 returns the please_ack header of DIDComm V2 messages requesting the same acknowledgements.
+func (p *PleaseAck) AsV2() []string {
+	if p == nil {
+		return nil
+	}
+
+	if len(p.On) == 0 {
+		return []string{PleaseAckOnReceipt}
+	}
+
+	return p.On
+}
+
+// OnReceipt returns true if an acknowledgement of receipt is requested.
+func (p *PleaseAck) OnReceipt() bool {
+	return p != nil && (len(p.On) == 0 || p.requests(PleaseAckOnReceipt))
+}
+
+// OnOutcome returns true if an acknowledgement of the outcome is requested.
+func (p *PleaseAck) OnOutcome() bool {
+	return p != nil && p.requests(PleaseAckOnOutcome)
+}
+
+func (p *PleaseAck) requests(on string) bool {
+	for _, v := range p.On {
+		if v == on {
+			return true
+		}
+	}
+
+	return false
+}
@@ -772,41 +772,27 @@ func (ctx *context) getMyDIDDoc(pubDID string, routerConnections []string, servi
 	return docResolution.DIDDocument, nil
 }
 
+// addRouterKeys adds the recipient keys of doc to each of the routers, doc has a DIDComm service per router.
 func (ctx *context) addRouterKeys(doc *did.Doc, routerConnections []string) error {
+	var recKeys []string
+
 	// try DIDComm V2 and use it if found, else use default DIDComm v1 bloc.
-	_, ok := did.LookupService(doc, didCommV2ServiceType)
-	if ok {
+	if _, ok := did.LookupService(doc, didCommV2ServiceType); ok {
 		// use KeyAgreement.ID as recKey for DIDComm V2
 		for _, ka := range doc.KeyAgreement {
-			for _, connID := range routerConnections {
-				// TODO https://github.com/markcryptohash/aries-framework-go/issues/1105 Support to Add multiple
-				//  recKeys to the Router. (DIDComm V2 uses list of keyAgreements as router keys here, double check
-				//  if this issue can be closed).
-				kaID := ka.VerificationMethod.ID
-				if strings.HasPrefix(kaID, "#") {
-					kaID = doc.ID + kaID
-				}
-
-				if err := mediator.AddKeyToRouter(ctx.routeSvc, connID, kaID); err != nil {
-					return fmt.Errorf("did doc - add key to the router: %w", err)
-				}
+			kaID := ka.VerificationMethod.ID
+			if strings.HasPrefix(kaID, "#") {
+				kaID = doc.ID + kaID
 			}
-		}
 
-		return nil
+			recKeys = append(recKeys, kaID)
+		}
+	} else if svc, ok := did.LookupService(doc, didCommServiceType); ok {
+		recKeys = svc.RecipientKeys
 	}
 
-	svc, ok := did.LookupService(doc, didCommServiceType)
-	if ok {
-		for _, recKey := range svc.RecipientKeys {
-			for _, connID := range routerConnections {
-				// TODO https://github.com/markcryptohash/aries-framework-go/issues/1105 Support to Add multiple
-				//  recKeys to the Router
-				if err := mediator.AddKeyToRouter(ctx.routeSvc, connID, recKey); err != nil {
-					return fmt.Errorf("did doc - add key to the router: %w", err)
-				}
-			}
-		}
+	if err := mediator.AddKeyToRouters(ctx.routeSvc, routerConnections, recKeys...); err != nil {
+		return fmt.Errorf("did doc - add key to the router: %w", err)
 	}
 
 	return nil
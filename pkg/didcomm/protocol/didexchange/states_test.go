@@ -1871,6 +1871,60 @@ func TestGetDIDDocAndConnection(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, didDoc)
 	})
+	t.Run("successfully created peer did with a service per router", func(t *testing.T) {
+		connRec, err := connection.NewRecorder(&protocol.MockProvider{})
+		require.NoError(t, err)
+		didConnStore, err := didstore.NewConnectionStore(&protocol.MockProvider{})
+		require.NoError(t, err)
+
+		addedKeys := map[string]int{}
+		createdDoc := mockdiddoc.GetMockDIDDoc(t, false)
+
+		ctx := context{
+			kms: newKMS(t, mockstorage.NewMockStoreProvider()),
+			vdRegistry: &mockvdr.MockVDRegistry{
+				CreateFunc: func(_ string, doc *diddoc.Doc, _ ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+					require.Len(t, doc.Service, 2)
+
+					for i, router := range []string{"router1", "router2"} {
+						uri, e := doc.Service[i].ServiceEndpoint.URI()
+						require.NoError(t, e)
+						require.Equal(t, "http://"+router, uri)
+						require.Equal(t, []string{router + "-key"}, doc.Service[i].RoutingKeys)
+					}
+
+					return &diddoc.DocResolution{DIDDocument: createdDoc}, nil
+				},
+			},
+			connectionRecorder: connRec,
+			connectionStore:    didConnStore,
+			routeSvc: &mockroute.MockMediatorSvc{
+				ConfigFunc: func(connID string) (*mediator.Config, error) {
+					return mediator.NewConfig("http://"+connID, []string{connID + "-key"}), nil
+				},
+				AddKeyFunc: func(recKey string) error {
+					addedKeys[recKey]++
+
+					return nil
+				},
+			},
+			keyType:          kms.ED25519Type,
+			keyAgreementType: kms.X25519ECDHKWType,
+		}
+
+		didDoc, err := ctx.getMyDIDDoc("", []string{"router1", "router2"}, didCommServiceType)
+		require.NoError(t, err)
+		require.Equal(t, createdDoc, didDoc)
+
+		// the recipient keys are added to both routers.
+		svc, ok := diddoc.LookupService(createdDoc, didCommServiceType)
+		require.True(t, ok)
+		require.NotEmpty(t, svc.RecipientKeys)
+
+		for _, recKey := range svc.RecipientKeys {
+			require.Equal(t, 2, addedKeys[recKey])
+		}
+	})
 	t.Run("test create did doc - router service config error", func(t *testing.T) {
 		connRec, err := connection.NewRecorder(&protocol.MockProvider{})
 		require.NoError(t, err)
@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Query is sent to ask the other party which protocols it supports.
+// https://github.com/markcryptohash/aries-rfcs/tree/master/features/0031-discover-features#query-message-type
+type Query struct {
+	Type    string `json:"@type,omitempty"`
+	ID      string `json:"@id,omitempty"`
+	Query   string `json:"query"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Disclose is sent in response to a Query.
+// https://github.com/markcryptohash/aries-rfcs/tree/master/features/0031-discover-features#disclose-message-type
+type Disclose struct {
+	Type      string             `json:"@type,omitempty"`
+	ID        string             `json:"@id,omitempty"`
+	Thread    *decorator.Thread  `json:"~thread,omitempty"`
+	Protocols []*ProtocolFeature `json:"protocols"`
+}
+
+// ProtocolFeature is a protocol disclosed in a Disclose message.
+type ProtocolFeature struct {
+	PID   string   `json:"pid"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// QueriesV2 is sent to ask the other party which features it supports.
+// https://identity.foundation/didcomm-messaging/spec/#discover-features-protocol-20
+type QueriesV2 struct {
+	ID   string        `json:"id,omitempty"`
+	Type string        `json:"type,omitempty"`
+	Body QueriesV2Body `json:"body"`
+}
+
+// QueriesV2Body is the body of a QueriesV2 message.
+type QueriesV2Body struct {
+	Queries []*FeatureQuery `json:"queries"`
+}
+
+// FeatureQuery matches the features of a type. Match is a feature ID where '*' stands for any sequence of
+// characters.
+type FeatureQuery struct {
+	FeatureType string `json:"feature-type"`
+	Match       string `json:"match"`
+}
+
+// DiscloseV2 is sent in response to a QueriesV2.
+// https://identity.foundation/didcomm-messaging/spec/#discover-features-protocol-20
+type DiscloseV2 struct {
+	ID       string         `json:"id,omitempty"`
+	Type     string         `json:"type,omitempty"`
+	ThreadID string         `json:"thid,omitempty"`
+	Body     DiscloseV2Body `json:"body"`
+}
+
+// DiscloseV2Body is the body of a DiscloseV2 message.
+type DiscloseV2Body struct {
+	Disclosures []*Disclosure `json:"disclosures"`
+}
+
+// Disclosure is a feature disclosed by an agent.
+type Disclosure struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
@@ -0,0 +1,545 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/introduce"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/store/connection"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+const (
+	// DiscoverFeatures defines the protocol name.
+	DiscoverFeatures = "discoverfeatures"
+	// Spec defines the Discover Features 1.0 protocol spec.
+	Spec = "https://didcomm.org/discover-features/1.0/"
+	// QueryMsgType defines the Discover Features 1.0 query message type.
+	QueryMsgType = Spec + "query"
+	// DiscloseMsgType defines the Discover Features 1.0 disclose message type.
+	DiscloseMsgType = Spec + "disclose"
+
+	// SpecV2 defines the Discover Features 2.0 protocol spec.
+	SpecV2 = "https://didcomm.org/discover-features/2.0/"
+	// QueriesMsgTypeV2 defines the Discover Features 2.0 queries message type.
+	QueriesMsgTypeV2 = SpecV2 + "queries"
+	// DiscloseMsgTypeV2 defines the Discover Features 2.0 disclose message type.
+	DiscloseMsgTypeV2 = SpecV2 + "disclose"
+)
+
+// Feature types, only protocols can be queried with Discover Features 1.0.
+const (
+	// FeatureTypeProtocol is the type of the protocols, identified by their PIURI.
+	FeatureTypeProtocol = "protocol"
+	// FeatureTypeGoalCode is the type of the goal codes.
+	FeatureTypeGoalCode = "goal-code"
+	// FeatureTypeMediaTypeProfile is the type of the DIDComm media type profiles.
+	FeatureTypeMediaTypeProfile = "media-type-profile"
+	// FeatureTypeKeyAgreementType is the type of the KMS key types used for key agreement.
+	FeatureTypeKeyAgreementType = "key-agreement-type"
+)
+
+// DefaultTimeout is the time to wait for a disclosure when no timeout is given.
+const DefaultTimeout = 10 * time.Second
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrTimeout is returned when no disclosure was received in time.
+	ErrTimeout = errors.New("timeout waiting for disclosure")
+
+	logger = log.New("aries-framework/discoverfeatures")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	AllServices() []dispatcher.ProtocolService
+	ServiceMsgTypeTargets() []dispatcher.MessageTypeTarget
+	MediaTypeProfiles() []string
+	KeyAgreementType() kms.KeyType
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	SaveConnectionRecord(*connection.Record) error
+}
+
+// protocolDiscloser can be implemented by protocol services to disclose protocols which are not well known by this
+// service.
+type protocolDiscloser interface {
+	// Protocols returns the PIURIs of the protocols supported by the service.
+	Protocols() []string
+}
+
+// QueryParams holds the parameters of a query.
+type QueryParams struct {
+	// Queries are the features to query, all the protocols are queried if not set.
+	// Discover Features 1.0 supports a single protocol query.
+	Queries []*FeatureQuery
+	// Comment is an optional comment sent along with the query (Discover Features 1.0 only).
+	Comment string
+	// Timeout is the time to wait for the disclosure, DefaultTimeout is used if not set.
+	Timeout time.Duration
+}
+
+// Service for the discover features protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connections   connections
+	outbound      dispatcher.Outbound
+	features      []*Disclosure
+	responseMap   map[string]chan []*Disclosure
+	responseMapMu sync.RWMutex
+	initialized   bool
+}
+
+// New returns the discover features service.
+func New(prov provider) (*Service, error) {
+	svc := Service{}
+
+	err := svc.Initialize(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+// Initialize initializes the Service. If Initialize succeeds, any further call is a no-op.
+// The disclosed features are collected from the services registered in the provider at this time.
+func (s *Service) Initialize(p interface{}) error {
+	if s.initialized {
+		return nil
+	}
+
+	prov, ok := p.(provider)
+	if !ok {
+		return fmt.Errorf("expected provider of type `%T`, got type `%T`", provider(nil), p)
+	}
+
+	connectionRecorder, err := connection.NewRecorder(prov)
+	if err != nil {
+		return fmt.Errorf("create connection recorder: %w", err)
+	}
+
+	s.connections = connectionRecorder
+	s.outbound = prov.OutboundDispatcher()
+	s.features = collectFeatures(prov)
+	s.responseMap = make(map[string]chan []*Disclosure)
+
+	s.initialized = true
+
+	return nil
+}
+
+// HandleInbound handles inbound discover features messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	// perform action asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case QueryMsgType:
+			err = s.handleQuery(msg, ctx.MyDID(), ctx.TheirDID())
+		case QueriesMsgTypeV2:
+			err = s.handleQueriesV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case DiscloseMsgType, DiscloseMsgTypeV2:
+			err = s.handleDisclose(msg)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%w)\n", err)
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case QueryMsgType, DiscloseMsgType, QueriesMsgTypeV2, DiscloseMsgTypeV2:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return DiscoverFeatures
+}
+
+// Features returns the features of this agent matching the given queries.
+func (s *Service) Features(queries ...*FeatureQuery) []*Disclosure {
+	disclosures := []*Disclosure{}
+	disclosed := make(map[*Disclosure]struct{})
+
+	for _, query := range queries {
+		for _, feature := range s.features {
+			if _, ok := disclosed[feature]; ok || feature.FeatureType != query.FeatureType ||
+				!matchFeature(query.Match, feature.ID) {
+				continue
+			}
+
+			disclosed[feature] = struct{}{}
+			disclosures = append(disclosures, feature)
+		}
+	}
+
+	return disclosures
+}
+
+// Query asks the other party of the given connection which features it supports and waits for the disclosure.
+// The disclosed features are cached on the connection record.
+func (s *Service) Query(connectionID string, params *QueryParams) ([]*Disclosure, error) {
+	if params == nil {
+		params = &QueryParams{}
+	}
+
+	queries := params.Queries
+	if len(queries) == 0 {
+		queries = []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: "*"}}
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	query, err := newQuery(msgID, conn.DIDCommVersion, queries, params.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	// register chan for callback processing, buffered so that a late disclosure never blocks the handler
+	responseCh := make(chan []*Disclosure, 1)
+	s.setResponseCh(msgID, responseCh)
+
+	defer s.setResponseCh(msgID, nil)
+
+	if err = s.outbound.SendToDID(query, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send query: %w", err)
+	}
+
+	timeout := params.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	var disclosures []*Disclosure
+
+	select {
+	case disclosures = <-responseCh:
+	case <-time.After(timeout):
+		return nil, ErrTimeout
+	}
+
+	if err = s.saveDisclosedFeatures(connectionID, disclosures); err != nil {
+		return nil, err
+	}
+
+	return disclosures, nil
+}
+
+func newQuery(msgID string, version service.Version, queries []*FeatureQuery,
+	comment string) (service.DIDCommMsgMap, error) {
+	if version == service.V2 {
+		return service.NewDIDCommMsgMap(&QueriesV2{
+			ID:   msgID,
+			Type: QueriesMsgTypeV2,
+			Body: QueriesV2Body{Queries: queries},
+		}), nil
+	}
+
+	if len(queries) != 1 || queries[0].FeatureType != FeatureTypeProtocol {
+		return nil, errors.New("discover features 1.0 supports a single protocol query")
+	}
+
+	return service.NewDIDCommMsgMap(&Query{
+		Type:    QueryMsgType,
+		ID:      msgID,
+		Query:   queries[0].Match,
+		Comment: comment,
+	}), nil
+}
+
+func (s *Service) handleQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	query := &Query{}
+
+	err := msg.Decode(query)
+	if err != nil {
+		return fmt.Errorf("query message unmarshal: %w", err)
+	}
+
+	disclose := &Disclose{
+		Type:      DiscloseMsgType,
+		ID:        uuid.New().String(),
+		Thread:    &decorator.Thread{ID: msg.ID()},
+		Protocols: []*ProtocolFeature{},
+	}
+
+	for _, feature := range s.Features(&FeatureQuery{FeatureType: FeatureTypeProtocol, Match: query.Query}) {
+		disclose.Protocols = append(disclose.Protocols, &ProtocolFeature{PID: feature.ID, Roles: feature.Roles})
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(disclose), myDID, theirDID)
+}
+
+func (s *Service) handleQueriesV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	queries := &QueriesV2{}
+
+	err := msg.Decode(queries)
+	if err != nil {
+		return fmt.Errorf("queries message unmarshal: %w", err)
+	}
+
+	disclose := &DiscloseV2{
+		ID:       uuid.New().String(),
+		Type:     DiscloseMsgTypeV2,
+		ThreadID: msg.ID(),
+		Body:     DiscloseV2Body{Disclosures: s.Features(queries.Body.Queries...)},
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(disclose), myDID, theirDID)
+}
+
+func (s *Service) handleDisclose(msg service.DIDCommMsg) error {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("disclose without thread ID: %w", err)
+	}
+
+	responseCh := s.getResponseCh(thID)
+	if responseCh == nil {
+		logger.Debugf("no query is waiting for disclosure with thread ID %s", thID)
+
+		return nil
+	}
+
+	var disclosures []*Disclosure
+
+	if msg.Type() == DiscloseMsgTypeV2 {
+		disclose := &DiscloseV2{}
+
+		if err = msg.Decode(disclose); err != nil {
+			return fmt.Errorf("disclose message unmarshal: %w", err)
+		}
+
+		disclosures = disclose.Body.Disclosures
+	} else {
+		disclose := &Disclose{}
+
+		if err = msg.Decode(disclose); err != nil {
+			return fmt.Errorf("disclose message unmarshal: %w", err)
+		}
+
+		for _, protocol := range disclose.Protocols {
+			disclosures = append(disclosures, &Disclosure{
+				FeatureType: FeatureTypeProtocol,
+				ID:          protocol.PID,
+				Roles:       protocol.Roles,
+			})
+		}
+	}
+
+	select {
+	case responseCh <- disclosures:
+	default:
+		// duplicate disclosure
+	}
+
+	return nil
+}
+
+// saveDisclosedFeatures adds the disclosed features to the ones cached on the connection record.
+func (s *Service) saveDisclosedFeatures(connectionID string, disclosures []*Disclosure) error {
+	// re-read the record, it might have been updated while waiting for the disclosure
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if conn.DisclosedFeatures == nil {
+		conn.DisclosedFeatures = &connection.FeaturesRecord{}
+	}
+
+	conn.DisclosedFeatures.Time = time.Now()
+
+	for _, disclosure := range disclosures {
+		conn.DisclosedFeatures.Features = mergeFeature(conn.DisclosedFeatures.Features, &connection.FeatureRecord{
+			FeatureType: disclosure.FeatureType,
+			ID:          disclosure.ID,
+			Roles:       disclosure.Roles,
+		})
+	}
+
+	if err = s.connections.SaveConnectionRecord(conn); err != nil {
+		return fmt.Errorf("save disclosed features on connection: %w", err)
+	}
+
+	return nil
+}
+
+func mergeFeature(features []*connection.FeatureRecord, feature *connection.FeatureRecord) []*connection.FeatureRecord {
+	for i, f := range features {
+		if f.FeatureType == feature.FeatureType && f.ID == feature.ID {
+			features[i] = feature
+
+			return features
+		}
+	}
+
+	return append(features, feature)
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) getResponseCh(msgID string) chan []*Disclosure {
+	s.responseMapMu.RLock()
+	defer s.responseMapMu.RUnlock()
+
+	return s.responseMap[msgID]
+}
+
+func (s *Service) setResponseCh(msgID string, responseCh chan []*Disclosure) {
+	s.responseMapMu.Lock()
+	defer s.responseMapMu.Unlock()
+
+	if responseCh == nil {
+		delete(s.responseMap, msgID)
+	} else {
+		s.responseMap[msgID] = responseCh
+	}
+}
+
+// collectFeatures returns the features of the agent: the protocols of the registered services, the goal codes of
+// the service message type targets, the media type profiles and the key agreement type.
+func collectFeatures(prov provider) []*Disclosure {
+	var features []*Disclosure
+
+	known := make(map[string]struct{})
+
+	add := func(featureType, id string) {
+		if _, ok := known[featureType+" "+id]; ok || id == "" {
+			return
+		}
+
+		known[featureType+" "+id] = struct{}{}
+		features = append(features, &Disclosure{FeatureType: featureType, ID: id})
+	}
+
+	for _, svc := range prov.AllServices() {
+		for _, msgType := range wellKnownMsgTypes() {
+			if svc.Accept(msgType) {
+				add(FeatureTypeProtocol, msgType[:strings.LastIndex(msgType, "/")])
+			}
+		}
+
+		if discloser, ok := svc.(protocolDiscloser); ok {
+			for _, piuri := range discloser.Protocols() {
+				add(FeatureTypeProtocol, piuri)
+			}
+		}
+	}
+
+	for _, target := range prov.ServiceMsgTypeTargets() {
+		add(FeatureTypeGoalCode, target.Target)
+	}
+
+	for _, profile := range prov.MediaTypeProfiles() {
+		add(FeatureTypeMediaTypeProfile, profile)
+	}
+
+	add(FeatureTypeKeyAgreementType, string(prov.KeyAgreementType()))
+
+	return features
+}
+
+// wellKnownMsgTypes returns a message type of each protocol implemented by the framework, the PIURI of the
+// protocol is the message type without its last path segment.
+func wellKnownMsgTypes() []string {
+	return []string{
+		service.ForwardMsgType, service.ForwardMsgTypeV2,
+		messagepickup.StatusRequestMsgType, messagepickup.StatusRequestMsgTypeV2,
+		mediator.RequestMsgType, mediator.RequestMsgTypeV2,
+		didexchange.RequestMsgType, legacyconnection.RequestMsgType,
+		outofband.InvitationMsgType, outofbandv2.InvitationMsgType,
+		introduce.ProposalMsgType,
+		issuecredential.ProposeCredentialMsgTypeV2, issuecredential.ProposeCredentialMsgTypeV3,
+		presentproof.RequestPresentationMsgTypeV2, presentproof.RequestPresentationMsgTypeV3,
+		trustping.PingMsgType, trustping.PingMsgTypeV2,
+		QueryMsgType, QueriesMsgTypeV2,
+	}
+}
+
+// matchFeature returns true if id matches pattern, where '*' stands for any sequence of characters.
+func matchFeature(pattern, id string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == id
+	}
+
+	if !strings.HasPrefix(id, parts[0]) {
+		return false
+	}
+
+	id = id[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(id, part)
+		if i < 0 {
+			return false
+		}
+
+		id = id[i+len(part):]
+	}
+
+	return strings.HasSuffix(id, parts[len(parts)-1])
+}
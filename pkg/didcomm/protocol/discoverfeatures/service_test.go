@@ -0,0 +1,400 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	mockdispatcher "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	"github.com/markcryptohash/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+	connID   = "conn"
+
+	trustPingPIURI   = "https://didcomm.org/trust_ping/1.0"
+	trustPingPIURIV2 = "https://didcomm.org/trust-ping/2.0"
+	customPIURI      = "https://didcomm.org/custom/1.0"
+	goalCode         = "aries.vc.issue"
+)
+
+func TestService_Initialize(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		prov := newProvider(nil)
+
+		svc := Service{}
+
+		err := svc.Initialize(prov)
+		require.NoError(t, err)
+		require.Equal(t, DiscoverFeatures, svc.Name())
+
+		// second init is no-op
+		err = svc.Initialize(prov)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure, not given a valid provider", func(t *testing.T) {
+		svc := Service{}
+
+		err := svc.Initialize("not a provider")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected provider of type")
+	})
+
+	t.Run("failure, store error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("store error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create connection recorder")
+		require.Nil(t, svc)
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc, err := New(newProvider(nil))
+	require.NoError(t, err)
+
+	require.True(t, svc.Accept(QueryMsgType))
+	require.True(t, svc.Accept(DiscloseMsgType))
+	require.True(t, svc.Accept(QueriesMsgTypeV2))
+	require.True(t, svc.Accept(DiscloseMsgTypeV2))
+	require.False(t, svc.Accept("unsupported msg type"))
+
+	_, err = svc.HandleOutbound(nil, "", "")
+	require.EqualError(t, err, "not implemented")
+}
+
+func TestService_Features(t *testing.T) {
+	svc, err := New(newProvider(nil))
+	require.NoError(t, err)
+
+	features := svc.Features(&FeatureQuery{FeatureType: FeatureTypeProtocol, Match: "*"})
+	require.Equal(t, []string{customPIURI, trustPingPIURI, trustPingPIURIV2}, ids(features))
+
+	features = svc.Features(
+		&FeatureQuery{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/trust*/2.*"},
+		&FeatureQuery{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/trust-ping/2.0"},
+		&FeatureQuery{FeatureType: FeatureTypeGoalCode, Match: "aries.vc.*"},
+		&FeatureQuery{FeatureType: FeatureTypeMediaTypeProfile, Match: "*"},
+		&FeatureQuery{FeatureType: FeatureTypeKeyAgreementType, Match: "*"},
+	)
+	require.Equal(t, []string{
+		trustPingPIURIV2, goalCode, transport.MediaTypeDIDCommV2Profile, string(kms.X25519ECDHKWType),
+	}, ids(features))
+
+	require.Empty(t, svc.Features(&FeatureQuery{FeatureType: "header", Match: "*"}))
+	require.Empty(t, svc.Features(&FeatureQuery{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/*/3.*"}))
+}
+
+func TestMatchFeature(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		id      string
+		match   bool
+	}{
+		{pattern: "a", id: "a", match: true},
+		{pattern: "a", id: "ab", match: false},
+		{pattern: "*", id: "", match: true},
+		{pattern: "a*", id: "abc", match: true},
+		{pattern: "*c", id: "abc", match: true},
+		{pattern: "a*c", id: "ac", match: true},
+		{pattern: "a*b*c", id: "axbyc", match: true},
+		{pattern: "a*b*b", id: "ab", match: false},
+		{pattern: "a*a", id: "a", match: false},
+		{pattern: "b*", id: "abc", match: false},
+	} {
+		require.Equal(t, tc.match, matchFeature(tc.pattern, tc.id), "%s %s", tc.pattern, tc.id)
+	}
+}
+
+func TestService_Query(t *testing.T) {
+	t.Run("query all protocols - DIDComm v1", func(t *testing.T) {
+		requester, responder := newServicePair(t)
+		saveConnection(t, requester.prov, service.V1)
+
+		disclosures, err := requester.svc.Query(connID, &QueryParams{Comment: "hi", Timeout: time.Second})
+		require.NoError(t, err)
+		require.Equal(t, []string{customPIURI, trustPingPIURI, trustPingPIURIV2}, ids(disclosures))
+
+		require.Equal(t, QueryMsgType, responder.lastReceived.Type())
+
+		conn, err := requester.svc.connections.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.NotNil(t, conn.DisclosedFeatures)
+		require.Len(t, conn.DisclosedFeatures.Features, 3)
+		require.Equal(t, FeatureTypeProtocol, conn.DisclosedFeatures.Features[0].FeatureType)
+	})
+
+	t.Run("queries are cached on the connection - DIDComm v2", func(t *testing.T) {
+		requester, responder := newServicePair(t)
+		saveConnection(t, requester.prov, service.V2)
+
+		disclosures, err := requester.svc.Query(connID, &QueryParams{
+			Queries: []*FeatureQuery{
+				{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/trust-ping/*"},
+				{FeatureType: FeatureTypeGoalCode, Match: "*"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{trustPingPIURIV2, goalCode}, ids(disclosures))
+
+		require.Equal(t, QueriesMsgTypeV2, responder.lastReceived.Type())
+
+		_, err = requester.svc.Query(connID, &QueryParams{
+			Queries: []*FeatureQuery{
+				{FeatureType: FeatureTypeProtocol, Match: "*"},
+				{FeatureType: FeatureTypeKeyAgreementType, Match: "*"},
+			},
+		})
+		require.NoError(t, err)
+
+		conn, err := requester.svc.connections.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.NotNil(t, conn.DisclosedFeatures)
+
+		var cached []string
+		for _, feature := range conn.DisclosedFeatures.Features {
+			cached = append(cached, feature.ID)
+		}
+
+		require.Equal(t, []string{
+			trustPingPIURIV2, goalCode, customPIURI, trustPingPIURI, string(kms.X25519ECDHKWType),
+		}, cached)
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		requester, _ := newServicePair(t)
+		saveConnection(t, requester.prov, service.V1)
+
+		disclosures, err := requester.svc.Query(connID, &QueryParams{
+			Queries: []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: "unknown"}},
+		})
+		require.NoError(t, err)
+		require.Empty(t, disclosures)
+	})
+
+	t.Run("invalid query for DIDComm v1", func(t *testing.T) {
+		requester, _ := newServicePair(t)
+		saveConnection(t, requester.prov, service.V1)
+
+		_, err := requester.svc.Query(connID, &QueryParams{
+			Queries: []*FeatureQuery{{FeatureType: FeatureTypeGoalCode, Match: "*"}},
+		})
+		require.EqualError(t, err, "discover features 1.0 supports a single protocol query")
+	})
+
+	t.Run("timeout waiting for disclosure", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{})
+		saveConnection(t, prov, service.V1)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Query(connID, &QueryParams{Timeout: 10 * time.Millisecond})
+		require.ErrorIs(t, err, ErrTimeout)
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		_, err = svc.Query(connID, nil)
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		saveConnection(t, prov, service.V2)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Query(connID, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send query: send error")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("queries are disclosed", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		queries := service.NewDIDCommMsgMap(&QueriesV2{
+			ID:   "queries-id",
+			Type: QueriesMsgTypeV2,
+			Body: QueriesV2Body{Queries: []*FeatureQuery{{FeatureType: FeatureTypeMediaTypeProfile, Match: "*"}}},
+		})
+
+		_, err = svc.HandleInbound(queries, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case resp := <-sent:
+			require.Equal(t, DiscloseMsgTypeV2, resp.Type())
+
+			thID, err := resp.ThreadID()
+			require.NoError(t, err)
+			require.Equal(t, "queries-id", thID)
+
+			disclose := &DiscloseV2{}
+			require.NoError(t, resp.Decode(disclose))
+			require.Equal(t, []string{transport.MediaTypeDIDCommV2Profile}, ids(disclose.Body.Disclosures))
+		case <-time.After(time.Second):
+			require.Fail(t, "disclose was not sent")
+		}
+	})
+
+	t.Run("disclosure without waiting query is ignored", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		disclose := service.NewDIDCommMsgMap(&DiscloseV2{ID: "id", Type: DiscloseMsgTypeV2, ThreadID: "unknown"})
+
+		require.NoError(t, svc.handleDisclose(disclose))
+
+		err = svc.handleDisclose(service.DIDCommMsgMap{"@type": DiscloseMsgType})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "disclose without thread ID")
+	})
+
+	t.Run("duplicate disclosure doesn't block", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		responseCh := make(chan []*Disclosure, 1)
+		svc.setResponseCh("query-id", responseCh)
+
+		disclose := service.NewDIDCommMsgMap(&DiscloseV2{ID: "id", Type: DiscloseMsgTypeV2, ThreadID: "query-id"})
+
+		require.NoError(t, svc.handleDisclose(disclose))
+		require.NoError(t, svc.handleDisclose(disclose))
+
+		require.Len(t, responseCh, 1)
+	})
+}
+
+type customService struct {
+	dispatcher.ProtocolService
+}
+
+func (c *customService) Accept(string) bool {
+	return false
+}
+
+func (c *customService) Protocols() []string {
+	return []string{customPIURI}
+}
+
+type testAgent struct {
+	prov *mockprovider.Provider
+	svc  *Service
+
+	lastReceived service.DIDCommMsgMap
+}
+
+// newServicePair creates two services which deliver outbound messages to each other.
+func newServicePair(t *testing.T) (*testAgent, *testAgent) {
+	t.Helper()
+
+	requester := &testAgent{}
+	responder := &testAgent{}
+
+	deliver := func(to *testAgent) *mockdispatcher.MockOutbound {
+		return &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				msgMap := msg.(service.DIDCommMsgMap)
+				to.lastReceived = msgMap
+
+				_, err := to.svc.HandleInbound(msgMap, service.NewDIDCommContext(theirDID, myDID, nil))
+
+				return err
+			},
+		}
+	}
+
+	var err error
+
+	requester.prov = newProvider(deliver(responder))
+	requester.svc, err = New(requester.prov)
+	require.NoError(t, err)
+
+	responder.prov = newProvider(deliver(requester))
+	responder.svc, err = New(responder.prov)
+	require.NoError(t, err)
+
+	return requester, responder
+}
+
+func newProvider(outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+		// AllServices returns the services of ServiceMap first, in random order, keep a single one.
+		ServiceValue:               &trustping.Service{},
+		ServiceMap:                 map[string]interface{}{"custom": &customService{}},
+		ServiceMsgTypeTargetsValue: []dispatcher.MessageTypeTarget{{
+			MsgType: "https://didcomm.org/issue-credential/3.0/propose-credential",
+			Target:  goalCode,
+		}},
+		MediaTypeProfilesValue:     []string{transport.MediaTypeDIDCommV2Profile},
+		KeyAgreementTypeValue:      kms.X25519ECDHKWType,
+	}
+}
+
+func saveConnection(t *testing.T, prov *mockprovider.Provider, version service.Version) {
+	t.Helper()
+
+	r, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+		ConnectionID:   connID,
+		MyDID:          MYDID,
+		TheirDID:       THEIRDID,
+		State:          connection.StateNameCompleted,
+		DIDCommVersion: version,
+	}))
+}
+
+func ids(disclosures []*Disclosure) []string {
+	var featureIDs []string
+
+	for _, disclosure := range disclosures {
+		featureIDs = append(featureIDs, disclosure.ID)
+	}
+
+	return featureIDs
+}
@@ -31,19 +31,10 @@ type To struct {
 	Proposed        bool            `json:"proposed,omitempty"`
 }
 
-// DescriptionL10N may contain locale field and key->val pair for translation
-// e.g { "locale": "en", "es": "Donde se toma el MRI; no en el centro"},
-// where locale field tells that field Description form To struct has en translation.
-type DescriptionL10N map[string]string
-
-// Locale returns locale for the specified description (To.Description).
-func (d DescriptionL10N) Locale() string {
-	if d == nil {
-		return ""
-	}
-	// TODO: clarify whether it should be default locale e.g "en" or empty string
-	return d["locale"]
-}
+// DescriptionL10N localizes the Description of To, it may contain the locale of the description and its
+// translations keyed by locale e.g { "locale": "en", "es": "Donde se toma el MRI; no en el centro"},
+// the description is in the locale of the message when the locale field is empty.
+type DescriptionL10N = decorator.FieldL10n
 
 // ImgAttach represent information about the image.
 type ImgAttach struct {
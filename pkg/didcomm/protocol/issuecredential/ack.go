@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	// StateIDAckReceived is the state ID of the message events sent when an acknowledgement requested with the
+	// please_ack decorator is received, the message of the event is the acknowledgement.
+	StateIDAckReceived = "ack-received"
+	// StateIDAckTimeout is the state ID of the message events sent when an acknowledgement requested with the
+	// please_ack decorator is not received in time.
+	StateIDAckTimeout = "ack-timeout"
+)
+
+// WithAckTimeout sets the time to wait for the acknowledgements requested by the issued credential,
+// decorator.DefaultAckTimeout by default.
+// USAGE: This function should be used along with WithIssueCredential when the issued credential has a please_ack.
+func WithAckTimeout(timeout time.Duration) Opt {
+	return func(md *MetaData) {
+		md.ackTimeout = timeout
+	}
+}
+
+// isReceipt returns true if msg is the acknowledgement of receipt of a message.
+func isReceipt(msg service.DIDCommMsg) bool {
+	switch msg.Type() {
+	case AckMsgTypeV2:
+		ack := model.Ack{}
+
+		return msg.Decode(&ack) == nil && ack.Status == model.AckStatusPENDING
+	case AckMsgTypeV3:
+		ack := model.AckV2{}
+
+		return msg.Decode(&ack) == nil && ack.Body.Status == model.AckStatusPENDING
+	}
+
+	return false
+}
+
+// canRequestAck returns true if the sender of msg can request acknowledgements of it.
+func canRequestAck(msg service.DIDCommMsg) bool {
+	switch msg.Type() {
+	case AckMsgTypeV2, AckMsgTypeV3, ProblemReportMsgTypeV2, ProblemReportMsgTypeV3:
+		return false
+	}
+
+	return true
+}
+
+// sendReceipt acknowledges the receipt of the message of md.
+func (s *Service) sendReceipt(md *MetaData) error {
+	v := getVersion(md.Msg.Type())
+
+	var ack interface{} = model.Ack{Type: AckMsgTypeV2, Status: model.AckStatusPENDING}
+
+	if v == SpecV3 {
+		ack = model.AckV2{Type: AckMsgTypeV3, Body: model.AckV2Body{Status: model.AckStatusPENDING}}
+	}
+
+	err := s.messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(ack), md.MyDID, md.TheirDID,
+		service.WithVersion(getDIDVersion(v)))
+	if err != nil {
+		return fmt.Errorf("send receipt: %w", err)
+	}
+
+	return nil
+}
+
+// handleReceipt notifies the acknowledgement of receipt msg if it was awaited, receipts don't change the state of the
+// protocol.
+func (s *Service) handleReceipt(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	piID, err := getPIID(msg)
+	if err != nil {
+		return "", fmt.Errorf("piID: %w", err)
+	}
+
+	if s.acks.Stop(piID, false) {
+		s.sendAckEvent(msg, StateIDAckReceived, &eventProps{
+			properties: map[string]interface{}{},
+			myDID:      ctx.MyDID(),
+			theirDID:   ctx.TheirDID(),
+			piid:       piID,
+		})
+	}
+
+	return msg.ThreadID()
+}
+
+// awaitAck waits for the acknowledgements requested by the message sent for md, an event is sent if they are not
+// received in time.
+func (s *Service) awaitAck(md *MetaData) {
+	msg, props := md.msgClone, newEventProps(md)
+
+	s.acks.Await(md.PIID, md.pleaseAck, md.ackTimeout, func() {
+		s.sendAckEvent(msg, StateIDAckTimeout, props)
+	})
+}
+
+func (s *Service) sendAckEvent(msg service.DIDCommMsg, stateID string, props *eventProps) {
+	for _, handler := range s.MsgEvents() {
+		handler <- service.StateMsg{
+			ProtocolName: Name,
+			Type:         service.PostState,
+			Msg:          msg,
+			StateID:      stateID,
+			Properties:   props,
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/component/storageutil/mem"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+	serviceMocks "github.com/markcryptohash/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	issuecredentialMocks "github.com/markcryptohash/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
+)
+
+func TestService_PleaseAck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newService := func(t *testing.T, messenger service.Messenger) (*Service, chan service.DIDCommAction,
+		chan service.StateMsg) {
+		t.Helper()
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		events := make(chan service.StateMsg, 100)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		return svc, actions, events
+	}
+
+	// issue sends a credential with the given please_ack, it returns the PIID.
+	issue := func(t *testing.T, svc *Service, actions chan service.DIDCommAction, pleaseAck *decorator.PleaseAck,
+		opts ...Opt) string {
+		t.Helper()
+
+		request := service.NewDIDCommMsgMap(RequestCredentialV2{Type: RequestCredentialMsgTypeV2})
+		request.SetID(uuid.New().String())
+
+		_, err := svc.HandleInbound(request, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		<-actions
+
+		opts = append(opts, WithIssueCredential(&IssueCredentialParams{PleaseAck: pleaseAck}))
+		require.NoError(t, svc.ActionContinue(request.ID(), opts...))
+
+		return request.ID()
+	}
+
+	ack := func(piID, status string) service.DIDCommMsgMap {
+		msg := service.NewDIDCommMsgMap(model.Ack{
+			Type:   AckMsgTypeV2,
+			Status: status,
+			Thread: &decorator.Thread{ID: piID},
+		})
+		msg.SetID(uuid.New().String())
+
+		return msg
+	}
+
+	waitEvent := func(t *testing.T, events chan service.StateMsg, stateID string) service.StateMsg {
+		t.Helper()
+
+		for {
+			select {
+			case event := <-events:
+				if event.StateID == stateID {
+					return event
+				}
+			case <-time.After(time.Second):
+				require.FailNow(t, "timeout waiting for event "+stateID)
+			}
+		}
+	}
+
+	t.Run("issuer receives the acknowledgements of receipt and outcome", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				sent <- msg
+
+				return nil
+			})
+
+		svc, actions, events := newService(t, messenger)
+
+		piID := issue(t, svc, actions,
+			decorator.NewPleaseAck(decorator.PleaseAckOnReceipt, decorator.PleaseAckOnOutcome))
+
+		issued := <-sent
+		require.True(t, issued.PleaseAck().OnReceipt())
+		require.True(t, issued.PleaseAck().OnOutcome())
+
+		waitEvent(t, events, stateNameCredentialIssued)
+
+		_, err := svc.HandleInbound(ack(piID, model.AckStatusPENDING), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		event := waitEvent(t, events, StateIDAckReceived)
+		require.Equal(t, piID, event.Properties.All()["piid"])
+
+		stateName, err := svc.currentStateName(piID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameCredentialIssued, stateName)
+
+		_, err = svc.HandleInbound(ack(piID, model.AckStatusOK), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		event = waitEvent(t, events, StateIDAckReceived)
+		require.Equal(t, AckMsgTypeV2, event.Msg.Type())
+
+		stateName, err = svc.currentStateName(piID)
+		require.NoError(t, err)
+		require.Equal(t, stateNameDone, stateName)
+
+		require.False(t, svc.acks.Awaiting(piID))
+
+		// receipts which are not awaited are not notified.
+		_, err = svc.HandleInbound(ack(piID, model.AckStatusPENDING), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case event = <-events:
+			require.NotEqual(t, StateIDAckReceived, event.StateID)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("holder acknowledges the receipt of the credential", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		receipt := make(chan service.DIDCommMsgMap, 1)
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob, gomock.Any()).
+			DoAndReturn(func(_, msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				receipt <- msg
+
+				return errors.New("send error")
+			})
+
+		svc, actions, _ := newService(t, messenger)
+
+		piID := uuid.New().String()
+		require.NoError(t, svc.saveStateName(piID, stateNameRequestSent))
+
+		msg := service.NewDIDCommMsgMap(IssueCredentialV3{
+			Type:      IssueCredentialMsgTypeV3,
+			ID:        uuid.New().String(),
+			PleaseAck: []string{decorator.PleaseAckOnReceipt},
+		})
+		msg.SetThread(piID, "", service.WithVersion(service.V2))
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		ack := model.AckV2{}
+		require.NoError(t, (<-receipt).Decode(&ack))
+		require.Equal(t, AckMsgTypeV3, ack.Type)
+		require.Equal(t, model.AckStatusPENDING, ack.Body.Status)
+
+		// failing to send the receipt doesn't prevent handling the credential.
+		require.Equal(t, IssueCredentialMsgTypeV3, (<-actions).Message.Type())
+	})
+}
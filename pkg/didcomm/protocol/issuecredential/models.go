@@ -15,7 +15,6 @@ type ProposeCredentialV2 struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
 	// CredentialProposal is an optional JSON-LD object that represents
 	// the credential data that the Prover wants to receive.
@@ -66,7 +65,6 @@ type OfferCredentialV2 struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
 	Comment string `json:"comment,omitempty"`
 	// CredentialPreview is a JSON-LD object that represents the credential data that Issuer is willing to issue.
 	CredentialPreview PreviewCredential `json:"credential_preview,omitempty"`
@@ -109,7 +107,6 @@ type RequestCredentialV2 struct {
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
 	Comment string `json:"comment,omitempty"`
 	// Formats contains an entry for each requests~attach array entry, providing the the value
 	// of the attachment @id and the verifiable credential format and version of the attachment.
@@ -141,12 +138,10 @@ type RequestCredentialV3Body struct {
 
 // IssueCredentialV2 contains as attached payload the credentials being issued and is
 // sent in response to a valid Invitation Credential message.
-// TODO: Need to add ~please-ack decorator [Issue #1299].
 type IssueCredentialV2 struct { //nolint: golint
 	Type string `json:"@type,omitempty"`
 	// Comment is an optional field that provides human readable information about this Credential Offer,
 	// so the offer can be evaluated by human judgment.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
 	Comment string `json:"comment,omitempty"`
 	// Formats contains an entry for each credentials~attach array entry, providing the value
 	// of the attachment @id and the verifiable credential format and version of the attachment.
@@ -155,6 +150,8 @@ type IssueCredentialV2 struct { //nolint: golint
 	CredentialsAttach []decorator.Attachment `json:"credentials~attach,omitempty"`
 	// WebRedirect contains optional web redirect info to be sent to holder for redirect.
 	WebRedirect *decorator.WebRedirect `json:"~web-redirect,omitempty"`
+	// PleaseAck requests acknowledgements of the issuance besides the ack of the protocol.
+	PleaseAck *decorator.PleaseAck `json:"~please_ack,omitempty"`
 }
 
 // IssueCredentialV3 contains as attached payload the credentials being issued and is
@@ -165,6 +162,8 @@ type IssueCredentialV3 struct { //nolint: golint
 	Body IssueCredentialV3Body `json:"body,omitempty"`
 	// WebRedirect contains optional web redirect info to be sent to holder for redirect.
 	WebRedirect *decorator.WebRedirect `json:"web_redirect,omitempty"`
+	// PleaseAck lists the acknowledgements of the issuance requested besides the ack of the protocol.
+	PleaseAck []string `json:"please_ack,omitempty"`
 	// Attachments is an array of attachments containing the presentation in the requested format(s).
 	// Accepted values for the format attribute of each attachment are provided in the per format Attachment
 	// registry immediately below.
@@ -452,6 +452,7 @@ type IssueCredentialParams struct { // nolint: golint
 	GoalCode      string
 	ReplacementID string
 	WebRedirect   *decorator.WebRedirect
+	PleaseAck     *decorator.PleaseAck
 }
 
 // AsV2 translates this credential issuance into an issue credential 2.0 issuance message.
@@ -462,6 +463,7 @@ func (p *IssueCredentialParams) AsV2() *IssueCredentialV2 {
 		Formats:           p.Formats,
 		CredentialsAttach: decorator.GenericAttachmentsToV1(p.Attachments),
 		WebRedirect:       p.WebRedirect,
+		PleaseAck:         p.PleaseAck,
 	}
 }
 
@@ -477,6 +479,7 @@ func (p *IssueCredentialParams) AsV3() *IssueCredentialV3 {
 		},
 		Attachments: decorator.GenericAttachmentsToV2(p.Attachments),
 		WebRedirect: p.WebRedirect,
+		PleaseAck:   p.PleaseAck.AsV2(),
 	}
 }
 
@@ -490,6 +493,7 @@ func (p *IssueCredentialParams) FromV2(v2 *IssueCredentialV2) {
 	p.ReplacementID = ""
 	p.Attachments = decorator.V1AttachmentsToGeneric(v2.CredentialsAttach)
 	p.WebRedirect = v2.WebRedirect
+	p.PleaseAck = v2.PleaseAck
 }
 
 // FromV3 initialized this credential issuance from an issue credential 3.0 issuance message.
@@ -502,6 +506,7 @@ func (p *IssueCredentialParams) FromV3(v3 *IssueCredentialV3) {
 	p.ReplacementID = v3.Body.ReplacementID
 	p.Attachments = decorator.V2AttachmentsToGeneric(v3.Attachments)
 	p.WebRedirect = v3.WebRedirect
+	p.PleaseAck = decorator.PleaseAckFromV2(v3.PleaseAck)
 }
 
 type rawIssuance struct {
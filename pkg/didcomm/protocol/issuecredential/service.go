@@ -11,11 +11,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/markcryptohash/aries-framework-go/spi/storage"
 )
 
@@ -100,6 +102,9 @@ type MetaData struct {
 	proposeCredentialV3 *ProposeCredentialV3
 	requestCredentialV3 *RequestCredentialV3
 	issueCredentialV3   *IssueCredentialV3
+	// acknowledgements requested by the sent message and the time to wait for them.
+	pleaseAck  *decorator.PleaseAck
+	ackTimeout time.Duration
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function.
@@ -328,6 +333,8 @@ type Service struct {
 	messenger   service.Messenger
 	middleware  Handler
 	initialized bool
+	// acknowledgements requested with the please_ack decorator, by PIID.
+	acks *decorator.AckWaiter
 }
 
 // New returns the issuecredential service.
@@ -367,6 +374,7 @@ func (s *Service) Initialize(prov interface{}) error {
 	s.store = store
 	s.callbacks = make(chan *MetaData)
 	s.middleware = initialHandler
+	s.acks = decorator.NewAckWaiter()
 
 	// start the listener
 	go s.startInternalListener()
@@ -404,6 +412,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		return "", errors.New("no clients are registered to handle the message")
 	}
 
+	if isReceipt(msg) {
+		return s.handleReceipt(msg, ctx)
+	}
+
 	md, err := s.doHandle(msg, false)
 	if err != nil {
 		return "", fmt.Errorf("doHandle: %w", err)
@@ -414,6 +426,17 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	md.MyDID = ctx.MyDID()
 	md.TheirDID = ctx.TheirDID()
 
+	if canRequestAck(msg) && md.Msg.PleaseAck().OnReceipt() {
+		if err = s.sendReceipt(md); err != nil {
+			logger.Warnf("failed to acknowledge the receipt of message %s: %s", msg.ID(), err)
+		}
+	}
+
+	// a problem report is the negative acknowledgement of the outcome.
+	if msg.Type() == ProblemReportMsgTypeV2 || msg.Type() == ProblemReportMsgTypeV3 {
+		s.acks.Stop(md.PIID, true)
+	}
+
 	// trigger action event based on message type for inbound messages
 	if canTriggerActionEvents(msg) {
 		err = s.saveTransitionalPayload(md.PIID, &md.transitionalPayload)
@@ -431,6 +454,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		return "", fmt.Errorf("handle inbound: %w", err)
 	}
 
+	if (msg.Type() == AckMsgTypeV2 || msg.Type() == AckMsgTypeV3) && s.acks.Stop(md.PIID, true) {
+		s.sendAckEvent(msg, StateIDAckReceived, newEventProps(md))
+	}
+
 	return msg.ThreadID()
 }
 
@@ -562,8 +589,15 @@ func (s *Service) handle(md *MetaData) error {
 		return fmt.Errorf("failed to persist state %s: %w", stateName, err)
 	}
 
+	// the acknowledgements can be received before the actions return.
+	if md.pleaseAck != nil {
+		s.awaitAck(md)
+	}
+
 	for _, action := range actions {
 		if err := action(s.messenger); err != nil {
+			s.acks.Stop(md.PIID, true)
+
 			return fmt.Errorf("action %s: %w", stateName, err)
 		}
 	}
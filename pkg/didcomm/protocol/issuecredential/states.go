@@ -12,6 +12,7 @@ import (
 
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
 )
 
 const (
@@ -300,6 +301,12 @@ func (s *requestReceived) ExecuteInbound(md *MetaData) (state, stateAction, erro
 		return nil, nil, errors.New("issue credential was not provided")
 	}
 
+	if md.issueCredentialV2 != nil {
+		md.pleaseAck = md.issueCredentialV2.PleaseAck
+	} else {
+		md.pleaseAck = decorator.PleaseAckFromV2(md.issueCredentialV3.PleaseAck)
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
 		if s.V == SpecV3 {
@@ -607,18 +607,15 @@ func (ctx *context) getMyDIDDoc(pubDID string, routerConnections []string, servi
 	return docResolution.DIDDocument, nil
 }
 
+// addRouterKeys adds the recipient keys of doc to each of the routers, doc has a DIDComm service per router.
 func (ctx *context) addRouterKeys(doc *did.Doc, routerConnections []string) error {
 	svc, ok := did.LookupService(doc, legacyDIDCommServiceType)
-	if ok {
-		for _, recKey := range svc.RecipientKeys {
-			for _, connID := range routerConnections {
-				// TODO https://github.com/markcryptohash/aries-framework-go/issues/1105 Support to Add multiple
-				//  recKeys to the Router
-				if err := mediator.AddKeyToRouter(ctx.routeSvc, connID, recKey); err != nil {
-					return fmt.Errorf("did doc - add key to the router: %w", err)
-				}
-			}
-		}
+	if !ok {
+		return nil
+	}
+
+	if err := mediator.AddKeyToRouters(ctx.routeSvc, routerConnections, svc.RecipientKeys...); err != nil {
+		return fmt.Errorf("did doc - add key to the router: %w", err)
 	}
 
 	return nil
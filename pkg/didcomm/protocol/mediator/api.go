@@ -8,8 +8,8 @@ package mediator
 
 // ProtocolService service interface for router.
 type ProtocolService interface {
-	// AddKey adds agents recKey to the router
-	AddKey(connID, recKey string) error
+	// AddKey adds agents recKeys to the router
+	AddKey(connID string, recKeys ...string) error
 
 	// Config gives back the router configuration
 	Config(connID string) (*Config, error)
@@ -56,3 +56,120 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// RequestV2 mediate-request message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-request
+type RequestV2 struct {
+	ID   string   `json:"id,omitempty"`
+	Type string   `json:"type,omitempty"`
+	Body struct{} `json:"body"`
+}
+
+// GrantV2 mediate-grant message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-grant
+type GrantV2 struct {
+	ID       string      `json:"id,omitempty"`
+	Type     string      `json:"type,omitempty"`
+	ThreadID string      `json:"thid,omitempty"`
+	Body     GrantV2Body `json:"body"`
+}
+
+// GrantV2Body is the body of a GrantV2 message.
+type GrantV2Body struct {
+	RoutingDIDs []string `json:"routing_did"`
+}
+
+// DenyV2 mediate-deny message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-deny
+type DenyV2 struct {
+	ID       string   `json:"id,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	ThreadID string   `json:"thid,omitempty"`
+	Body     struct{} `json:"body"`
+}
+
+// KeylistUpdateV2 keylist-update message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update
+type KeylistUpdateV2 struct {
+	ID   string              `json:"id,omitempty"`
+	Type string              `json:"type,omitempty"`
+	Body KeylistUpdateV2Body `json:"body"`
+}
+
+// KeylistUpdateV2Body is the body of a KeylistUpdateV2 message.
+type KeylistUpdateV2Body struct {
+	Updates []UpdateV2 `json:"updates"`
+}
+
+// UpdateV2 recipient DID update.
+type UpdateV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+}
+
+// KeylistUpdateResponseV2 keylist-update-response message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update-response
+type KeylistUpdateResponseV2 struct {
+	ID       string                      `json:"id,omitempty"`
+	Type     string                      `json:"type,omitempty"`
+	ThreadID string                      `json:"thid,omitempty"`
+	Body     KeylistUpdateResponseV2Body `json:"body"`
+}
+
+// KeylistUpdateResponseV2Body is the body of a KeylistUpdateResponseV2 message.
+type KeylistUpdateResponseV2Body struct {
+	Updated []UpdateResponseV2 `json:"updated"`
+}
+
+// UpdateResponseV2 recipient DID update result.
+type UpdateResponseV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Result       string `json:"result,omitempty"`
+}
+
+// KeylistQueryV2 keylist-query message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-query
+type KeylistQueryV2 struct {
+	ID   string             `json:"id,omitempty"`
+	Type string             `json:"type,omitempty"`
+	Body KeylistQueryV2Body `json:"body"`
+}
+
+// KeylistQueryV2Body is the body of a KeylistQueryV2 message.
+type KeylistQueryV2Body struct {
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// Paginate requests a page of the keylist.
+type Paginate struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// KeylistV2 keylist message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#keylist
+type KeylistV2 struct {
+	ID       string        `json:"id,omitempty"`
+	Type     string        `json:"type,omitempty"`
+	ThreadID string        `json:"thid,omitempty"`
+	Body     KeylistV2Body `json:"body"`
+}
+
+// KeylistV2Body is the body of a KeylistV2 message.
+type KeylistV2Body struct {
+	Keys       []KeylistKeyV2 `json:"keys"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+// KeylistKeyV2 is a recipient DID registered with the mediator.
+type KeylistKeyV2 struct {
+	RecipientDID string `json:"recipient_did"`
+}
+
+// Pagination describes the page of the keylist returned by the mediator.
+type Pagination struct {
+	Count     int `json:"count"`
+	Offset    int `json:"offset"`
+	Remaining int `json:"remaining"`
+}
@@ -53,6 +53,30 @@ const (
 
 	// KeyListUpdateResponseMsgType defines the route coordination key list update message response type.
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
+
+	// CoordinationSpecV2 defines the Coordinate Mediation 2.0 spec.
+	CoordinationSpecV2 = "https://didcomm.org/coordinate-mediation/2.0/"
+
+	// RequestMsgTypeV2 defines the Coordinate Mediation 2.0 mediate-request message type.
+	RequestMsgTypeV2 = CoordinationSpecV2 + "mediate-request"
+
+	// GrantMsgTypeV2 defines the Coordinate Mediation 2.0 mediate-grant message type.
+	GrantMsgTypeV2 = CoordinationSpecV2 + "mediate-grant"
+
+	// DenyMsgTypeV2 defines the Coordinate Mediation 2.0 mediate-deny message type.
+	DenyMsgTypeV2 = CoordinationSpecV2 + "mediate-deny"
+
+	// KeylistUpdateMsgTypeV2 defines the Coordinate Mediation 2.0 keylist-update message type.
+	KeylistUpdateMsgTypeV2 = CoordinationSpecV2 + "keylist-update"
+
+	// KeylistUpdateResponseMsgTypeV2 defines the Coordinate Mediation 2.0 keylist-update-response message type.
+	KeylistUpdateResponseMsgTypeV2 = CoordinationSpecV2 + "keylist-update-response"
+
+	// KeylistQueryMsgTypeV2 defines the Coordinate Mediation 2.0 keylist-query message type.
+	KeylistQueryMsgTypeV2 = CoordinationSpecV2 + "keylist-query"
+
+	// KeylistMsgTypeV2 defines the Coordinate Mediation 2.0 keylist message type.
+	KeylistMsgTypeV2 = CoordinationSpecV2 + "keylist"
 )
 
 // constants for key list update processing
@@ -69,6 +93,12 @@ const (
 
 	// key save success.
 	success = "success"
+
+	// key already in the requested state.
+	noChange = "no_change"
+
+	// key update rejected because of a client error.
+	clientError = "client_error"
 )
 
 const (
@@ -79,6 +109,9 @@ const (
 	routeConfigDataKey = "route_config_%s"
 
 	routeGrantKey = "grant_%s"
+
+	// tag name to query the recipient DIDs registered by a given agent.
+	routeKeylistTag = "route_keylist"
 )
 
 const (
@@ -91,6 +124,9 @@ var ErrConnectionNotFound = errors.New("connection not found")
 // ErrRouterNotRegistered router not registered error.
 var ErrRouterNotRegistered = errors.New("router not registered")
 
+// ErrMediationDenied mediation denied error.
+var ErrMediationDenied = errors.New("mediation denied")
+
 // provider contains dependencies for the Routing protocol and is typically created by using aries.Context().
 type provider interface {
 	OutboundDispatcher() dispatcher.Outbound
@@ -150,6 +186,8 @@ type Service struct {
 	vdRegistry           vdr.Registry
 	keylistUpdateMap     map[string]chan *KeylistUpdateResponse
 	keylistUpdateMapLock sync.RWMutex
+	keylistMap           map[string]chan *KeylistV2
+	keylistMapLock       sync.RWMutex
 	callbacks            chan *callback
 	messagePickupSvc     messagepickup.ProtocolService
 	keyAgreementType     kms.KeyType
@@ -186,7 +224,7 @@ func (s *Service) Initialize(p interface{}) error {
 	}
 
 	err = prov.StorageProvider().SetStoreConfig(Coordination,
-		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey}})
+		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey, routeKeylistTag}})
 	if err != nil {
 		return fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -213,6 +251,7 @@ func (s *Service) Initialize(p interface{}) error {
 	s.vdRegistry = prov.VDRegistry()
 	s.connectionLookup = connectionLookup
 	s.keylistUpdateMap = make(map[string]chan *KeylistUpdateResponse)
+	s.keylistMap = make(map[string]chan *KeylistV2)
 	s.callbacks = make(chan *callback)
 	s.messagePickupSvc = messagePickupSvc
 	s.keyAgreementType = prov.KeyAgreementType()
@@ -243,6 +282,11 @@ func (s *Service) listenForCallbacks() {
 			if err != nil {
 				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
 			}
+		case RequestMsgTypeV2:
+			err := s.handleInboundRequestV2(c)
+			if err != nil {
+				logger.Errorf("failed to handle inbound request v2: %+v : %w", c.msg, err)
+			}
 		default:
 			logger.Warnf("ignoring unsupported message type %s", c.msg.Type())
 		}
@@ -251,10 +295,24 @@ func (s *Service) listenForCallbacks() {
 
 func (s *Service) handleUserRejection(c *callback) {
 	logger.Infof("user aborted response action for msgID=%s", c.msg.ID())
+
+	if c.msg.Type() != RequestMsgTypeV2 {
+		return
+	}
+
+	deny := &DenyV2{
+		ID:       uuid.New().String(),
+		Type:     DenyMsgTypeV2,
+		ThreadID: c.msg.ID(),
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(deny), c.myDID, c.theirDID); err != nil {
+		logger.Errorf("failed to send mediate-deny for msgID=%s : %w", c.msg.ID(), err)
+	}
 }
 
 func triggersActionEvent(msgType string) bool {
-	return msgType == RequestMsgType
+	return msgType == RequestMsgType || msgType == RequestMsgTypeV2
 }
 
 func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string) error {
@@ -311,12 +369,20 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		var err error
 
 		switch msg.Type() {
-		case GrantMsgType:
+		case GrantMsgType, GrantMsgTypeV2, DenyMsgTypeV2:
 			err = s.saveGrant(msg)
 		case KeylistUpdateMsgType:
 			err = s.handleKeylistUpdate(msg, ctx.MyDID(), ctx.TheirDID())
 		case KeylistUpdateResponseMsgType:
 			err = s.handleKeylistUpdateResponse(msg)
+		case KeylistUpdateMsgTypeV2:
+			err = s.handleKeylistUpdateV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case KeylistUpdateResponseMsgTypeV2:
+			err = s.handleKeylistUpdateResponseV2(msg)
+		case KeylistQueryMsgTypeV2:
+			err = s.handleKeylistQueryV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case KeylistMsgTypeV2:
+			err = s.handleKeylistV2(msg)
 		case service.ForwardMsgType, service.ForwardMsgTypeV2:
 			err = s.handleForward(msg)
 		}
@@ -358,7 +424,7 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 	}
 
 	switch msg.Type() {
-	case RequestMsgType:
+	case RequestMsgType, RequestMsgTypeV2:
 		return "", s.handleOutboundRequest(msg, myDID, theirDID)
 	default:
 		return "", fmt.Errorf("invalid or unsupported outbound message type %s", msg.Type())
@@ -371,6 +437,9 @@ func (s *Service) Accept(msgType string) bool {
 	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType,
 		service.ForwardMsgTypeV2:
 		return true
+	case RequestMsgTypeV2, GrantMsgTypeV2, DenyMsgTypeV2, KeylistUpdateMsgTypeV2, KeylistUpdateResponseMsgTypeV2,
+		KeylistQueryMsgTypeV2, KeylistMsgTypeV2:
+		return true
 	}
 
 	return false
@@ -581,7 +650,14 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 
 	err = s.outbound.Forward(forward.Msg, dest)
 	if err != nil && s.messagePickupSvc != nil {
-		return s.messagePickupSvc.AddMessage(forward.Msg, string(theirDID))
+		var expiresTime time.Time
+
+		// the message is dropped from the mailbox once the forward message expires.
+		if msgMap, ok := msg.(service.DIDCommMsgMap); ok {
+			expiresTime = msgMap.ExpiresTime()
+		}
+
+		return s.messagePickupSvc.AddMessageWithExpiry(forward.Msg, string(theirDID), expiresTime)
 	}
 
 	return err
@@ -625,12 +701,20 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 	// demonstrates? additionally `ExpiresTime` would need to be migrated to int64
 	req.ExpiresTime = time.Now().UTC().Add(timeout)
 
+	var msg service.DIDCommMsgMap
+
 	if record.DIDCommVersion == service.V2 {
-		req.DIDCommV2 = true
+		// DIDComm V2 connections use Coordinate Mediation 2.0, the timeout is enforced on our side only.
+		msg = service.NewDIDCommMsgMap(&RequestV2{
+			ID:   req.ID,
+			Type: RequestMsgTypeV2,
+		})
+	} else {
+		msg = service.NewDIDCommMsgMap(req)
 	}
 
 	// send message to the router
-	if err = s.outbound.SendToDID(service.NewDIDCommMsgMap(req), record.MyDID, record.TheirDID); err != nil {
+	if err = s.outbound.SendToDID(msg, record.MyDID, record.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
 
@@ -640,6 +724,14 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 		return fmt.Errorf("get grant for request ID '%s': %w", req.ID, err)
 	}
 
+	if grant.Endpoint == "" && record.DIDCommVersion == service.V2 {
+		// Coordinate Mediation 2.0 grants carry routing DIDs only, messages are sent to the mediator's endpoint.
+		grant.Endpoint, err = s.routerEndpoint(record.TheirDID)
+		if err != nil {
+			return fmt.Errorf("get router endpoint: %w", err)
+		}
+	}
+
 	err = s.saveRouterConfig(record.ConnectionID, &config{
 		RouterEndpoint: grant.Endpoint,
 		RoutingKeys:    grant.RoutingKeys,
@@ -670,6 +762,29 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 		return nil, fmt.Errorf("store: %w", err)
 	}
 
+	msg, err := service.ParseDIDCommMsgMap(src)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal grant: %w", err)
+	}
+
+	switch msg.Type() {
+	case DenyMsgTypeV2:
+		return nil, ErrMediationDenied
+	case GrantMsgTypeV2:
+		grantV2 := &GrantV2{}
+
+		err = msg.Decode(grantV2)
+		if err != nil {
+			return nil, fmt.Errorf("decode grant v2: %w", err)
+		}
+
+		return &Grant{
+			Type:        grantV2.Type,
+			ID:          grantV2.ID,
+			RoutingKeys: grantV2.Body.RoutingDIDs,
+		}, nil
+	}
+
 	var grant *Grant
 
 	err = json.Unmarshal(src, &grant)
@@ -686,7 +801,13 @@ func (s *Service) saveGrant(grant service.DIDCommMsg) error {
 		return fmt.Errorf("marshal grant: %w", err)
 	}
 
-	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID()), src)
+	// grants are stored by request ID: the v1 grant reuses the request @id, the v2 grant/deny refer to it by thid.
+	requestID, err := grant.ThreadID()
+	if err != nil {
+		requestID = grant.ID()
+	}
+
+	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, requestID), src)
 }
 
 // Unregister unregisters the agent with the router.
@@ -752,12 +873,14 @@ func (s *Service) GetConnections(options ...ConnectionOption) ([]string, error)
 	return conns, nil
 }
 
-// AddKey adds a recKey of the agent to the registered router. This method blocks until a response is
-// received from the router or it times out.
-// TODO https://github.com/markcryptohash/aries-framework-go/issues/1076 Support for multiple routers
-// TODO https://github.com/markcryptohash/aries-framework-go/issues/1105 Support to Add multiple
-//  recKeys to the Router
-func (s *Service) AddKey(connID, recKey string) error {
+// AddKey adds recKeys of the agent to the registered router with a single keylist update. This method blocks
+// until a response is received from the router or it times out. The keys of an agent registered with several
+// routers must be added to each router used by the connection.
+func (s *Service) AddKey(connID string, recKeys ...string) error {
+	if len(recKeys) == 0 {
+		return nil
+	}
+
 	// check if router is already registered
 	err := s.ensureConnectionExists(connID)
 	if err != nil {
@@ -773,19 +896,39 @@ func (s *Service) AddKey(connID, recKey string) error {
 	// generate message ID
 	msgID := uuid.New().String()
 
-	// register chan for callback processing
-	keyUpdateCh := make(chan *KeylistUpdateResponse)
+	// register chan for callback processing, it is buffered so that a late response doesn't block its handler
+	keyUpdateCh := make(chan *KeylistUpdateResponse, 1)
 	s.setKeyUpdateResponseCh(msgID, keyUpdateCh)
 
-	keyUpdate := &KeylistUpdate{
-		ID:   msgID,
-		Type: KeylistUpdateMsgType,
-		Updates: []Update{
-			{
-				RecipientKey: recKey,
-				Action:       add,
-			},
-		},
+	// remove the channel once its been processed
+	defer s.setKeyUpdateResponseCh(msgID, nil)
+
+	var keyUpdate interface{}
+
+	if conn.DIDCommVersion == service.V2 {
+		updates := make([]UpdateV2, len(recKeys))
+
+		for i, recKey := range recKeys {
+			updates[i] = UpdateV2{RecipientDID: recKey, Action: add}
+		}
+
+		keyUpdate = &KeylistUpdateV2{
+			ID:   msgID,
+			Type: KeylistUpdateMsgTypeV2,
+			Body: KeylistUpdateV2Body{Updates: updates},
+		}
+	} else {
+		updates := make([]Update, len(recKeys))
+
+		for i, recKey := range recKeys {
+			updates[i] = Update{RecipientKey: recKey, Action: add}
+		}
+
+		keyUpdate = &KeylistUpdate{
+			ID:      msgID,
+			Type:    KeylistUpdateMsgType,
+			Updates: updates,
+		}
 	}
 
 	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(keyUpdate), conn.MyDID, conn.TheirDID); err != nil {
@@ -794,17 +937,10 @@ func (s *Service) AddKey(connID, recKey string) error {
 
 	select {
 	case keyUpdateResp := <-keyUpdateCh:
-		if err := processKeylistUpdateResp(recKey, keyUpdateResp); err != nil {
-			return err
-		}
+		return processKeylistUpdateResp(recKeys, keyUpdateResp)
 	case <-time.After(updateTimeout):
 		return errors.New("timeout waiting for keylist update response from the router")
 	}
-
-	// remove the channel once its been processed
-	s.setKeyUpdateResponseCh(msgID, nil)
-
-	return nil
 }
 
 // Config fetches the router config - endpoint and routingKeys.
@@ -817,10 +953,16 @@ func (s *Service) Config(connID string) (*Config, error) {
 	return s.getRouterConfig(connID)
 }
 
-func processKeylistUpdateResp(recKey string, keyUpdateResp *KeylistUpdateResponse) error {
+func processKeylistUpdateResp(recKeys []string, keyUpdateResp *KeylistUpdateResponse) error {
 	for _, result := range keyUpdateResp.Updated {
-		if result.RecipientKey == recKey && result.Action == add && result.Result != success {
-			return errors.New("failed to update the recipient key with the router")
+		if result.Action != add || result.Result == success || result.Result == noChange {
+			continue
+		}
+
+		for _, recKey := range recKeys {
+			if result.RecipientKey == recKey {
+				return fmt.Errorf("failed to update the recipient key with the router: %s", recKey)
+			}
 		}
 	}
 
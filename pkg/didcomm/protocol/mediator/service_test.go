@@ -910,6 +910,51 @@ func TestMessagePickup(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test service handle inbound message pick up - forward expiry", func(t *testing.T) {
+		to := randomID()
+		expires := time.Unix(1900000000, 0)
+
+		var queuedExpiry time.Time
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{
+					AddMessageExpFunc: func(_ []byte, _ string, expiresTime time.Time) error {
+						queuedExpiry = expiresTime
+						return nil
+					},
+				},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateForward: func(_ interface{}, _ *service.Destination) error {
+					return errors.New("websocket connection failed")
+				},
+			},
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					return &did.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t, false)}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		err = svc.routeStore.Put(dataKey(to), []byte("did:example:123"))
+		require.NoError(t, err)
+
+		msg := generateForwardMsgPayload(t, randomID(), to, []byte("{}"))
+
+		require.NoError(t, svc.handleForward(msg))
+		require.True(t, queuedExpiry.IsZero())
+
+		msg.(service.DIDCommMsgMap).SetExpiresTime(expires)
+
+		require.NoError(t, svc.handleForward(msg))
+		require.Equal(t, expires, queuedExpiry)
+	})
+
 	t.Run("test service handle inbound message pick up - add message error", func(t *testing.T) {
 		to := randomID()
 
@@ -1208,6 +1253,73 @@ func TestKeylistUpdate(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test keylist update - multiple keys", func(t *testing.T) {
+		keyUpdateMsg := make(chan KeylistUpdate)
+		recKeys := []string{"ojaosdjoajs123jkas", "ppakspdkpakd456kas"}
+
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					request := &KeylistUpdate{}
+
+					require.NoError(t, msg.(service.DIDCommMsgMap).Decode(request))
+
+					keyUpdateMsg <- *request
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn", ""))
+
+		connBytes, err := json.Marshal(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		})
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		go func() {
+			updateMsg := <-keyUpdateMsg
+
+			require.Len(t, updateMsg.Updates, len(recKeys))
+
+			var updates []UpdateResponse
+
+			for i, update := range updateMsg.Updates {
+				require.Equal(t, recKeys[i], update.RecipientKey)
+
+				result := success
+				if i == 1 {
+					result = serverError
+				}
+
+				updates = append(updates, UpdateResponse{
+					RecipientKey: update.RecipientKey,
+					Action:       update.Action,
+					Result:       result,
+				})
+			}
+
+			require.NoError(t, svc.handleKeylistUpdateResponse(generateKeylistUpdateResponseMsgPayload(
+				t, updateMsg.ID, updates)))
+		}()
+
+		err = svc.AddKey("conn", recKeys...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to update the recipient key with the router: "+recKeys[1])
+
+		// no keys, no keylist update
+		require.NoError(t, svc.AddKey("conn"))
+	})
+
 	t.Run("test keylist update - failure", func(t *testing.T) {
 		keyUpdateMsg := make(chan KeylistUpdate)
 		recKey := "ojaosdjoajs123jkas"
@@ -0,0 +1,386 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mediator
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util/kmsdidkey"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+func (s *Service) handleInboundRequestV2(c *callback) error {
+	logger.Debugf("handling v2 callback: %+v", c)
+
+	request := &RequestV2{}
+
+	err := c.msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("handleInboundRequestV2: route request message unmarshal : %w", err)
+	}
+
+	err = validateRequestVersion(s.mediaTypeProfiles, true)
+	if err != nil {
+		return err
+	}
+
+	routingDIDs := c.options.RoutingKeys
+
+	if len(routingDIDs) == 0 {
+		_, pubKeyBytes, e := s.kms.CreateAndExportPubKeyBytes(s.keyAgreementType)
+		if e != nil {
+			return fmt.Errorf("handleInboundRequestV2: kms failed to create and export %v key: %w",
+				s.keyAgreementType, e)
+		}
+
+		routingDID, e := kmsdidkey.BuildDIDKeyByKeyType(pubKeyBytes, s.keyAgreementType)
+		if e != nil {
+			return fmt.Errorf("handleInboundRequestV2: failed to build routing did:key: %w", e)
+		}
+
+		routingDIDs = []string{routingDID}
+	}
+
+	grant := &GrantV2{
+		ID:       uuid.New().String(),
+		Type:     GrantMsgTypeV2,
+		ThreadID: request.ID,
+		Body: GrantV2Body{
+			RoutingDIDs: routingDIDs,
+		},
+	}
+
+	logger.Debugf("outbound grant v2: %+v", grant)
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(grant), c.myDID, c.theirDID)
+}
+
+func (s *Service) handleKeylistUpdateV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	keyUpdate := &KeylistUpdateV2{}
+
+	err := msg.Decode(keyUpdate)
+	if err != nil {
+		return fmt.Errorf("route key list update v2 message unmarshal : %w", err)
+	}
+
+	updates := make([]UpdateResponseV2, 0, len(keyUpdate.Body.Updates))
+
+	for _, v := range keyUpdate.Body.Updates {
+		var result string
+
+		switch v.Action {
+		case add:
+			result = s.addRecipientDID(v.RecipientDID, theirDID)
+		case remove:
+			result = s.removeRecipientDID(v.RecipientDID, theirDID)
+		default:
+			result = clientError
+		}
+
+		updates = append(updates, UpdateResponseV2{
+			RecipientDID: v.RecipientDID,
+			Action:       v.Action,
+			Result:       result,
+		})
+	}
+
+	updateResponse := &KeylistUpdateResponseV2{
+		ID:       uuid.New().String(),
+		Type:     KeylistUpdateResponseMsgTypeV2,
+		ThreadID: msg.ID(),
+		Body: KeylistUpdateResponseV2Body{
+			Updated: updates,
+		},
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(updateResponse), myDID, theirDID)
+}
+
+func (s *Service) addRecipientDID(recipientDID, theirDID string) string {
+	val, err := s.routeStore.Get(dataKey(recipientDID))
+
+	switch {
+	case err == nil && string(val) == theirDID:
+		return noChange
+	case err == nil:
+		// agents may not take over the recipient DIDs registered by other agents
+		return clientError
+	case !errors.Is(err, storage.ErrDataNotFound):
+		logger.Errorf("failed to fetch the recipient DID from store : %s", err)
+
+		return serverError
+	}
+
+	err = s.routeStore.Put(dataKey(recipientDID), []byte(theirDID), storage.Tag{
+		Name:  routeKeylistTag,
+		Value: keylistTagValue(theirDID),
+	})
+	if err != nil {
+		logger.Errorf("failed to add the recipient DID to store : %s", err)
+
+		return serverError
+	}
+
+	return success
+}
+
+func (s *Service) removeRecipientDID(recipientDID, theirDID string) string {
+	val, err := s.routeStore.Get(dataKey(recipientDID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return noChange
+	}
+
+	if err != nil {
+		logger.Errorf("failed to fetch the recipient DID from store : %s", err)
+
+		return serverError
+	}
+
+	// agents may only remove the recipient DIDs they registered themselves
+	if string(val) != theirDID {
+		return clientError
+	}
+
+	err = s.routeStore.Delete(dataKey(recipientDID))
+	if err != nil {
+		logger.Errorf("failed to remove the recipient DID from store : %s", err)
+
+		return serverError
+	}
+
+	return success
+}
+
+func (s *Service) handleKeylistUpdateResponseV2(msg service.DIDCommMsg) error {
+	respMsg := &KeylistUpdateResponseV2{}
+
+	err := msg.Decode(respMsg)
+	if err != nil {
+		return fmt.Errorf("route keylist update response v2 message unmarshal : %w", err)
+	}
+
+	// AddKey waits for the v1 response model, keyed by the ID of the keylist-update message.
+	resp := &KeylistUpdateResponse{
+		ID:   respMsg.ThreadID,
+		Type: respMsg.Type,
+	}
+
+	for _, v := range respMsg.Body.Updated {
+		resp.Updated = append(resp.Updated, UpdateResponse{
+			RecipientKey: v.RecipientDID,
+			Action:       v.Action,
+			Result:       v.Result,
+		})
+	}
+
+	keylistUpdateCh := s.getKeyUpdateResponseCh(respMsg.ThreadID)
+
+	if keylistUpdateCh != nil {
+		keylistUpdateCh <- resp
+	}
+
+	return nil
+}
+
+func (s *Service) handleKeylistQueryV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	query := &KeylistQueryV2{}
+
+	err := msg.Decode(query)
+	if err != nil {
+		return fmt.Errorf("route keylist query v2 message unmarshal : %w", err)
+	}
+
+	recipientDIDs, err := s.recipientDIDs(theirDID)
+	if err != nil {
+		return fmt.Errorf("route keylist query v2: %w", err)
+	}
+
+	keys, pagination := paginateKeylist(recipientDIDs, query.Body.Paginate)
+
+	keylist := &KeylistV2{
+		ID:       uuid.New().String(),
+		Type:     KeylistMsgTypeV2,
+		ThreadID: msg.ID(),
+		Body: KeylistV2Body{
+			Keys:       keys,
+			Pagination: pagination,
+		},
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(keylist), myDID, theirDID)
+}
+
+func (s *Service) recipientDIDs(theirDID string) ([]string, error) {
+	records, err := s.routeStore.Query(fmt.Sprintf("%s:%s", routeKeylistTag, keylistTagValue(theirDID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query route store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	var recipientDIDs []string
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		key, err := records.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key from records: %w", err)
+		}
+
+		recipientDIDs = append(recipientDIDs, key[len(dataKey("")):])
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	sort.Strings(recipientDIDs)
+
+	return recipientDIDs, nil
+}
+
+func paginateKeylist(recipientDIDs []string, paginate *Paginate) ([]KeylistKeyV2, *Pagination) {
+	start, end := 0, len(recipientDIDs)
+
+	if paginate != nil {
+		if paginate.Offset > 0 {
+			start = paginate.Offset
+		}
+
+		if start > len(recipientDIDs) {
+			start = len(recipientDIDs)
+		}
+
+		if paginate.Limit > 0 && start+paginate.Limit < end {
+			end = start + paginate.Limit
+		}
+	}
+
+	keys := make([]KeylistKeyV2, 0, end-start)
+
+	for _, recipientDID := range recipientDIDs[start:end] {
+		keys = append(keys, KeylistKeyV2{RecipientDID: recipientDID})
+	}
+
+	if paginate == nil {
+		return keys, nil
+	}
+
+	return keys, &Pagination{
+		Count:     len(keys),
+		Offset:    start,
+		Remaining: len(recipientDIDs) - end,
+	}
+}
+
+func (s *Service) handleKeylistV2(msg service.DIDCommMsg) error {
+	keylist := &KeylistV2{}
+
+	err := msg.Decode(keylist)
+	if err != nil {
+		return fmt.Errorf("route keylist v2 message unmarshal : %w", err)
+	}
+
+	keylistCh := s.getKeylistCh(keylist.ThreadID)
+
+	if keylistCh != nil {
+		keylistCh <- keylist
+	}
+
+	return nil
+}
+
+// KeylistQuery queries the recipient DIDs the agent registered with the router on the other end of the
+// Coordinate Mediation 2.0 connection identified by connID. A limit of zero returns the whole keylist.
+// This method blocks until a response is received from the router or it times out.
+func (s *Service) KeylistQuery(connID string, limit, offset int) (*KeylistV2Body, error) {
+	err := s.ensureConnectionExists(connID)
+	if err != nil {
+		return nil, fmt.Errorf("ensure connection exists: %w", err)
+	}
+
+	conn, err := s.getConnection(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	if conn.DIDCommVersion != service.V2 {
+		return nil, errors.New("keylist query is only supported on DIDComm V2 connections")
+	}
+
+	query := &KeylistQueryV2{
+		ID:   uuid.New().String(),
+		Type: KeylistQueryMsgTypeV2,
+	}
+
+	if limit > 0 || offset > 0 {
+		query.Body.Paginate = &Paginate{
+			Limit:  limit,
+			Offset: offset,
+		}
+	}
+
+	keylistCh := make(chan *KeylistV2)
+	s.setKeylistCh(query.ID, keylistCh)
+
+	defer s.setKeylistCh(query.ID, nil)
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(query), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send keylist query: %w", err)
+	}
+
+	select {
+	case keylist := <-keylistCh:
+		return &keylist.Body, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for keylist from the router")
+	}
+}
+
+func (s *Service) routerEndpoint(routerDID string) (string, error) {
+	dest, err := service.GetDestination(routerDID, s.vdRegistry)
+	if err != nil {
+		return "", fmt.Errorf("get destination: %w", err)
+	}
+
+	return dest.ServiceEndpoint.URI()
+}
+
+func (s *Service) getKeylistCh(msgID string) chan *KeylistV2 {
+	s.keylistMapLock.RLock()
+	defer s.keylistMapLock.RUnlock()
+
+	return s.keylistMap[msgID]
+}
+
+func (s *Service) setKeylistCh(msgID string, keylistCh chan *KeylistV2) {
+	s.keylistMapLock.Lock()
+	defer s.keylistMapLock.Unlock()
+
+	if keylistCh == nil {
+		delete(s.keylistMap, msgID)
+	} else {
+		s.keylistMap[msgID] = keylistCh
+	}
+}
+
+// keylistTagValue encodes theirDID for use as a tag value, DIDs contain ':' which is not allowed in tags.
+func keylistTagValue(theirDID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(theirDID))
+}
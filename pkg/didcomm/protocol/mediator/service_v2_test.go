@@ -0,0 +1,454 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mediator
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	mockdispatcher "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockmessagep "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/protocol/messagepickup"
+	mockdiddoc "github.com/markcryptohash/aries-framework-go/pkg/mock/diddoc"
+	mockkms "github.com/markcryptohash/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/markcryptohash/aries-framework-go/pkg/mock/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/store/connection"
+)
+
+func TestServiceAcceptV2(t *testing.T) {
+	s := &Service{}
+
+	require.True(t, s.Accept(RequestMsgTypeV2))
+	require.True(t, s.Accept(GrantMsgTypeV2))
+	require.True(t, s.Accept(DenyMsgTypeV2))
+	require.True(t, s.Accept(KeylistUpdateMsgTypeV2))
+	require.True(t, s.Accept(KeylistUpdateResponseMsgTypeV2))
+	require.True(t, s.Accept(KeylistQueryMsgTypeV2))
+	require.True(t, s.Accept(KeylistMsgTypeV2))
+}
+
+func TestServiceRequestMsgV2(t *testing.T) {
+	t.Run("sends mediate-grant with routing did", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		svc := newServiceV2(t, sent, make(map[string]mockstore.DBEntry))
+
+		requestID := randomID()
+
+		err := svc.handleInboundRequestV2(&callback{
+			msg:      generateRequestV2MsgPayload(requestID),
+			myDID:    MYDID,
+			theirDID: THEIRDID,
+			options:  &Options{},
+		})
+		require.NoError(t, err)
+
+		msg := <-sent
+		require.Equal(t, GrantMsgTypeV2, msg.Type())
+
+		grant := &GrantV2{}
+		require.NoError(t, msg.Decode(grant))
+		require.Equal(t, requestID, grant.ThreadID)
+		require.Len(t, grant.Body.RoutingDIDs, 1)
+		require.Contains(t, grant.Body.RoutingDIDs[0], "did:key:")
+	})
+
+	t.Run("sends mediate-grant with routing dids from options", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		svc := newServiceV2(t, sent, make(map[string]mockstore.DBEntry))
+
+		err := svc.handleInboundRequestV2(&callback{
+			msg:      generateRequestV2MsgPayload(randomID()),
+			myDID:    MYDID,
+			theirDID: THEIRDID,
+			options:  &Options{RoutingKeys: []string{"did:example:routing"}},
+		})
+		require.NoError(t, err)
+
+		grant := &GrantV2{}
+		require.NoError(t, (<-sent).Decode(grant))
+		require.Equal(t, []string{"did:example:routing"}, grant.Body.RoutingDIDs)
+	})
+
+	t.Run("mediator does not support didcomm v2", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+			MediaTypeProfilesValue:            []string{transport.MediaTypeAIP2RFC0019Profile},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleInboundRequestV2(&callback{
+			msg:      generateRequestV2MsgPayload(randomID()),
+			myDID:    MYDID,
+			theirDID: THEIRDID,
+			options:  &Options{},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not support didcomm v2")
+	})
+
+	t.Run("kms error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("kms error")},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+			MediaTypeProfilesValue:            []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleInboundRequestV2(&callback{
+			msg:      generateRequestV2MsgPayload(randomID()),
+			myDID:    MYDID,
+			theirDID: THEIRDID,
+			options:  &Options{},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kms error")
+	})
+
+	t.Run("user rejection sends mediate-deny", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		svc := newServiceV2(t, sent, make(map[string]mockstore.DBEntry))
+
+		requestID := randomID()
+
+		svc.handleUserRejection(&callback{
+			msg:      generateRequestV2MsgPayload(requestID),
+			myDID:    MYDID,
+			theirDID: THEIRDID,
+		})
+
+		msg := <-sent
+		require.Equal(t, DenyMsgTypeV2, msg.Type())
+
+		thid, err := msg.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, requestID, thid)
+	})
+}
+
+func TestRegisterV2(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		store := make(map[string]mockstore.DBEntry)
+		svc := newServiceV2(t, sent, store)
+		saveConnectionV2(t, store, "conn")
+
+		go func() {
+			msg := <-sent
+			require.Equal(t, RequestMsgTypeV2, msg.Type())
+
+			require.NoError(t, svc.saveGrant(service.NewDIDCommMsgMap(&GrantV2{
+				ID:       randomID(),
+				Type:     GrantMsgTypeV2,
+				ThreadID: msg.ID(),
+				Body:     GrantV2Body{RoutingDIDs: []string{"did:example:routing"}},
+			})))
+		}()
+
+		require.NoError(t, svc.Register("conn"))
+
+		conf, err := svc.Config("conn")
+		require.NoError(t, err)
+		require.Equal(t, "https://localhost:8090", conf.Endpoint())
+		require.Equal(t, []string{"did:example:routing"}, conf.Keys())
+
+		conns, err := svc.GetConnections(ConnectionByVersion(service.V2))
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn"}, conns)
+	})
+
+	t.Run("mediation denied", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		store := make(map[string]mockstore.DBEntry)
+		svc := newServiceV2(t, sent, store)
+		saveConnectionV2(t, store, "conn")
+
+		go func() {
+			msg := <-sent
+
+			require.NoError(t, svc.saveGrant(service.NewDIDCommMsgMap(&DenyV2{
+				ID:       randomID(),
+				Type:     DenyMsgTypeV2,
+				ThreadID: msg.ID(),
+			})))
+		}()
+
+		err := svc.Register("conn")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrMediationDenied))
+
+		_, err = svc.Config("conn")
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+}
+
+func TestKeylistUpdateV2(t *testing.T) {
+	t.Run("mediator updates keylist", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		svc := newServiceV2(t, sent, make(map[string]mockstore.DBEntry))
+
+		update := func(theirDID string, updates ...UpdateV2) []UpdateResponseV2 {
+			msgID := randomID()
+
+			err := svc.handleKeylistUpdateV2(service.NewDIDCommMsgMap(&KeylistUpdateV2{
+				ID:   msgID,
+				Type: KeylistUpdateMsgTypeV2,
+				Body: KeylistUpdateV2Body{Updates: updates},
+			}), MYDID, theirDID)
+			require.NoError(t, err)
+
+			resp := &KeylistUpdateResponseV2{}
+			require.NoError(t, (<-sent).Decode(resp))
+			require.Equal(t, msgID, resp.ThreadID)
+
+			return resp.Body.Updated
+		}
+
+		results := update(THEIRDID,
+			UpdateV2{RecipientDID: "did:example:1", Action: add},
+			UpdateV2{RecipientDID: "did:example:2", Action: add},
+			UpdateV2{RecipientDID: "did:example:3", Action: "invalid"},
+		)
+		require.Equal(t, success, results[0].Result)
+		require.Equal(t, success, results[1].Result)
+		require.Equal(t, clientError, results[2].Result)
+
+		val, err := svc.routeStore.Get(dataKey("did:example:1"))
+		require.NoError(t, err)
+		require.Equal(t, THEIRDID, string(val))
+
+		results = update(THEIRDID, UpdateV2{RecipientDID: "did:example:1", Action: add})
+		require.Equal(t, noChange, results[0].Result)
+
+		results = update("did:example:other", UpdateV2{RecipientDID: "did:example:1", Action: remove})
+		require.Equal(t, clientError, results[0].Result)
+
+		results = update("did:example:other", UpdateV2{RecipientDID: "did:example:1", Action: add})
+		require.Equal(t, clientError, results[0].Result)
+
+		val, err = svc.routeStore.Get(dataKey("did:example:1"))
+		require.NoError(t, err)
+		require.Equal(t, THEIRDID, string(val))
+
+		results = update(THEIRDID,
+			UpdateV2{RecipientDID: "did:example:1", Action: remove},
+			UpdateV2{RecipientDID: "did:example:4", Action: remove},
+		)
+		require.Equal(t, success, results[0].Result)
+		require.Equal(t, noChange, results[1].Result)
+
+		recipientDIDs, err := svc.recipientDIDs(THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:2"}, recipientDIDs)
+	})
+
+	t.Run("recipient adds key", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		store := make(map[string]mockstore.DBEntry)
+		svc := newServiceV2(t, sent, store)
+		saveConnectionV2(t, store, "conn")
+		require.NoError(t, svc.saveRouterConnectionID("conn", service.V2))
+
+		go func() {
+			msg := <-sent
+			require.Equal(t, KeylistUpdateMsgTypeV2, msg.Type())
+
+			update := &KeylistUpdateV2{}
+			require.NoError(t, msg.Decode(update))
+
+			require.NoError(t, svc.handleKeylistUpdateResponseV2(service.NewDIDCommMsgMap(&KeylistUpdateResponseV2{
+				ID:       randomID(),
+				Type:     KeylistUpdateResponseMsgTypeV2,
+				ThreadID: update.ID,
+				Body: KeylistUpdateResponseV2Body{
+					Updated: []UpdateResponseV2{{
+						RecipientDID: update.Body.Updates[0].RecipientDID,
+						Action:       add,
+						Result:       noChange,
+					}},
+				},
+			})))
+		}()
+
+		require.NoError(t, svc.AddKey("conn", "did:example:recipient"))
+	})
+}
+
+func TestKeylistQueryV2(t *testing.T) {
+	t.Run("mediator returns paginated keylist", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		svc := newServiceV2(t, sent, make(map[string]mockstore.DBEntry))
+
+		for _, recipientDID := range []string{"did:example:3", "did:example:1", "did:example:2"} {
+			require.Equal(t, success, svc.addRecipientDID(recipientDID, THEIRDID))
+		}
+
+		require.Equal(t, success, svc.addRecipientDID("did:example:other", "did:example:other-agent"))
+
+		queryID := randomID()
+
+		err := svc.handleKeylistQueryV2(service.NewDIDCommMsgMap(&KeylistQueryV2{
+			ID:   queryID,
+			Type: KeylistQueryMsgTypeV2,
+			Body: KeylistQueryV2Body{Paginate: &Paginate{Limit: 1, Offset: 1}},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		keylist := &KeylistV2{}
+		require.NoError(t, (<-sent).Decode(keylist))
+		require.Equal(t, queryID, keylist.ThreadID)
+		require.Equal(t, []KeylistKeyV2{{RecipientDID: "did:example:2"}}, keylist.Body.Keys)
+		require.Equal(t, &Pagination{Count: 1, Offset: 1, Remaining: 1}, keylist.Body.Pagination)
+	})
+
+	t.Run("recipient queries keylist", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+		store := make(map[string]mockstore.DBEntry)
+		svc := newServiceV2(t, sent, store)
+		saveConnectionV2(t, store, "conn")
+		require.NoError(t, svc.saveRouterConnectionID("conn", service.V2))
+
+		go func() {
+			msg := <-sent
+			require.Equal(t, KeylistQueryMsgTypeV2, msg.Type())
+
+			require.NoError(t, svc.handleKeylistV2(service.NewDIDCommMsgMap(&KeylistV2{
+				ID:       randomID(),
+				Type:     KeylistMsgTypeV2,
+				ThreadID: msg.ID(),
+				Body: KeylistV2Body{
+					Keys: []KeylistKeyV2{{RecipientDID: "did:example:1"}},
+				},
+			})))
+		}()
+
+		keylist, err := svc.KeylistQuery("conn", 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, []KeylistKeyV2{{RecipientDID: "did:example:1"}}, keylist.Keys)
+	})
+
+	t.Run("router not registered", func(t *testing.T) {
+		svc := newServiceV2(t, make(chan service.DIDCommMsgMap, 1), make(map[string]mockstore.DBEntry))
+
+		_, err := svc.KeylistQuery("conn", 0, 0)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("didcomm v1 connection", func(t *testing.T) {
+		store := make(map[string]mockstore.DBEntry)
+		svc := newServiceV2(t, make(chan service.DIDCommMsgMap, 1), store)
+		require.NoError(t, svc.saveRouterConnectionID("conn", service.V1))
+
+		connBytes, err := json.Marshal(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		})
+		require.NoError(t, err)
+		store["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		_, err = svc.KeylistQuery("conn", 0, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "only supported on DIDComm V2 connections")
+	})
+}
+
+func TestPaginateKeylist(t *testing.T) {
+	recipientDIDs := []string{"did:example:1", "did:example:2", "did:example:3"}
+
+	keys, pagination := paginateKeylist(recipientDIDs, nil)
+	require.Len(t, keys, 3)
+	require.Nil(t, pagination)
+
+	keys, pagination = paginateKeylist(recipientDIDs, &Paginate{Limit: 2})
+	require.Len(t, keys, 2)
+	require.Equal(t, &Pagination{Count: 2, Offset: 0, Remaining: 1}, pagination)
+
+	keys, pagination = paginateKeylist(recipientDIDs, &Paginate{Offset: 5})
+	require.Empty(t, keys)
+	require.Equal(t, &Pagination{Count: 0, Offset: 3, Remaining: 0}, pagination)
+}
+
+func newServiceV2(t *testing.T, sent chan service.DIDCommMsgMap, store map[string]mockstore.DBEntry) *Service {
+	t.Helper()
+
+	svc, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: store}},
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		KMSValue: &mockkms.KeyManager{
+			CrAndExportPubKeyValue: make([]byte, 32),
+		},
+		KeyAgreementTypeValue:  kms.ED25519Type,
+		MediaTypeProfilesValue: []string{transport.MediaTypeDIDCommV2Profile},
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				msgMap, ok := msg.(service.DIDCommMsgMap)
+				require.True(t, ok)
+
+				select {
+				case sent <- msgMap:
+				case <-time.After(time.Second):
+					t.Error("outbound message was not consumed")
+				}
+
+				return nil
+			},
+		},
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t, true)}, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+func saveConnectionV2(t *testing.T, store map[string]mockstore.DBEntry, connID string) {
+	t.Helper()
+
+	connBytes, err := json.Marshal(&connection.Record{
+		ConnectionID:   connID,
+		MyDID:          MYDID,
+		TheirDID:       THEIRDID,
+		State:          "complete",
+		DIDCommVersion: service.V2,
+	})
+	require.NoError(t, err)
+
+	store["conn_"+connID] = mockstore.DBEntry{Value: connBytes}
+}
+
+func generateRequestV2MsgPayload(id string) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(&RequestV2{
+		ID:   id,
+		Type: RequestMsgTypeV2,
+	})
+}
@@ -27,10 +27,22 @@ func GetRouterConfig(routeSvc ProtocolService, connID, endpoint string) (string,
 }
 
 // AddKeyToRouter util to add the recipient keys to the router.
-func AddKeyToRouter(routeSvc ProtocolService, connID, recKey string) error {
-	if err := routeSvc.AddKey(connID, recKey); err != nil && !errors.Is(err, ErrRouterNotRegistered) {
+func AddKeyToRouter(routeSvc ProtocolService, connID string, recKeys ...string) error {
+	if err := routeSvc.AddKey(connID, recKeys...); err != nil && !errors.Is(err, ErrRouterNotRegistered) {
 		return fmt.Errorf("addKey: %w", err)
 	}
 
 	return nil
 }
+
+// AddKeyToRouters util to add the recipient keys to each of the routers of a connection, so that messages can be
+// routed through any of them.
+func AddKeyToRouters(routeSvc ProtocolService, routerConnections []string, recKeys ...string) error {
+	for _, connID := range routerConnections {
+		if err := AddKeyToRouter(routeSvc, connID, recKeys...); err != nil {
+			return fmt.Errorf("router connection %s: %w", connID, err)
+		}
+	}
+
+	return nil
+}
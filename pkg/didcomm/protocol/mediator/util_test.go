@@ -72,6 +72,38 @@ func TestAddKeyToRouter(t *testing.T) {
 	})
 }
 
+func TestAddKeyToRouters(t *testing.T) {
+	t.Run("test add keys to routers - success", func(t *testing.T) {
+		added := map[string][]string{}
+
+		err := AddKeyToRouters(&mockRouteSvc{
+			AddKeyFunc: func(connID string, recKeys ...string) error {
+				added[connID] = recKeys
+
+				return nil
+			},
+		}, []string{"conn1", "conn2"}, "key1", "key2")
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{
+			"conn1": {"key1", "key2"},
+			"conn2": {"key1", "key2"},
+		}, added)
+	})
+
+	t.Run("test add keys to routers - router error", func(t *testing.T) {
+		err := AddKeyToRouters(&mockRouteSvc{
+			AddKeyFunc: func(connID string, recKeys ...string) error {
+				if connID == "conn2" {
+					return errors.New("router error")
+				}
+
+				return nil
+			},
+		}, []string{"conn1", "conn2"}, "key1")
+		require.EqualError(t, err, "router connection conn2: addKey: router error")
+	})
+}
+
 type mockRouteSvc struct {
 	Connections    []string
 	ConnectionsErr error
@@ -79,10 +111,15 @@ type mockRouteSvc struct {
 	RoutingKeys    []string
 	ConfigErr      error
 	AddKeyErr      error
+	AddKeyFunc     func(connID string, recKeys ...string) error
 }
 
-// AddKey adds agents recKey to the router.
-func (m *mockRouteSvc) AddKey(connID, recKey string) error {
+// AddKey adds agents recKeys to the router.
+func (m *mockRouteSvc) AddKey(connID string, recKeys ...string) error {
+	if m.AddKeyFunc != nil {
+		return m.AddKeyFunc(connID, recKeys...)
+	}
+
 	return m.AddKeyErr
 }
 
@@ -6,7 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package messagepickup
 
+import "time"
+
 // ProtocolService service interface for message pickup.
 type ProtocolService interface {
 	AddMessage(message []byte, theirDID string) error
+	AddMessageWithExpiry(message []byte, theirDID string, expiresTime time.Time) error
 }
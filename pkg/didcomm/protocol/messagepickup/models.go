@@ -54,9 +54,10 @@ type Batch struct {
 
 // Message messagepickup wrapper.
 type Message struct {
-	ID        string    `json:"id"`
-	AddedTime time.Time `json:"added_time"`
-	Message   []byte    `json:"msg,omitempty"`
+	ID          string     `json:"id"`
+	AddedTime   time.Time  `json:"added_time"`
+	ExpiresTime *time.Time `json:"expires_time,omitempty"`
+	Message     []byte     `json:"msg,omitempty"`
 }
 
 // Noop message
@@ -65,3 +66,92 @@ type Noop struct {
 	Type string `json:"@type,omitempty"`
 	ID   string `json:"@id,omitempty"`
 }
+
+// StatusRequestV2 sent by the recipient to the mediator to request a status message.
+// https://didcomm.org/messagepickup/2.0/#status-request
+type StatusRequestV2 struct {
+	ID   string              `json:"id,omitempty"`
+	Type string              `json:"type,omitempty"`
+	Body StatusRequestV2Body `json:"body"`
+}
+
+// StatusRequestV2Body is the body of a StatusRequestV2 message.
+type StatusRequestV2Body struct {
+	RecipientKey string `json:"recipient_key,omitempty"`
+}
+
+// StatusV2 details about pending messages.
+// https://didcomm.org/messagepickup/2.0/#status
+type StatusV2 struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	ThreadID string       `json:"thid,omitempty"`
+	Body     StatusV2Body `json:"body"`
+}
+
+// StatusV2Body is the body of a StatusV2 message. Times are expressed in seconds since the Unix epoch.
+type StatusV2Body struct {
+	RecipientKey         string `json:"recipient_key,omitempty"`
+	MessageCount         int    `json:"message_count"`
+	LongestWaitedSeconds int    `json:"longest_waited_seconds,omitempty"`
+	NewestReceivedTime   int64  `json:"newest_received_time,omitempty"`
+	OldestReceivedTime   int64  `json:"oldest_received_time,omitempty"`
+	TotalBytes           int    `json:"total_bytes,omitempty"`
+	LiveDelivery         bool   `json:"live_delivery"`
+}
+
+// DeliveryRequestV2 a request to have up to Limit waiting messages delivered.
+// https://didcomm.org/messagepickup/2.0/#delivery-request
+type DeliveryRequestV2 struct {
+	ID   string                `json:"id,omitempty"`
+	Type string                `json:"type,omitempty"`
+	Body DeliveryRequestV2Body `json:"body"`
+}
+
+// DeliveryRequestV2Body is the body of a DeliveryRequestV2 message.
+type DeliveryRequestV2Body struct {
+	Limit        int    `json:"limit"`
+	RecipientKey string `json:"recipient_key,omitempty"`
+}
+
+// DeliveryV2 a message that contains waiting messages as attachments, the attachment ID being the message ID.
+// https://didcomm.org/messagepickup/2.0/#message-delivery
+type DeliveryV2 struct {
+	ID          string                    `json:"id,omitempty"`
+	Type        string                    `json:"type,omitempty"`
+	ThreadID    string                    `json:"thid,omitempty"`
+	Body        DeliveryV2Body            `json:"body"`
+	Attachments []*decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// DeliveryV2Body is the body of a DeliveryV2 message.
+type DeliveryV2Body struct {
+	RecipientKey string `json:"recipient_key,omitempty"`
+}
+
+// MessagesReceivedV2 acknowledges the delivered messages, allowing the mediator to delete them.
+// https://didcomm.org/messagepickup/2.0/#messages-received
+type MessagesReceivedV2 struct {
+	ID       string                 `json:"id,omitempty"`
+	Type     string                 `json:"type,omitempty"`
+	ThreadID string                 `json:"thid,omitempty"`
+	Body     MessagesReceivedV2Body `json:"body"`
+}
+
+// MessagesReceivedV2Body is the body of a MessagesReceivedV2 message.
+type MessagesReceivedV2Body struct {
+	MessageIDList []string `json:"message_id_list"`
+}
+
+// LiveDeliveryChangeV2 toggles live mode for the connection it is sent on.
+// https://didcomm.org/messagepickup/2.0/#live-mode-change
+type LiveDeliveryChangeV2 struct {
+	ID   string                   `json:"id,omitempty"`
+	Type string                   `json:"type,omitempty"`
+	Body LiveDeliveryChangeV2Body `json:"body"`
+}
+
+// LiveDeliveryChangeV2Body is the body of a LiveDeliveryChangeV2 message.
+type LiveDeliveryChangeV2Body struct {
+	LiveDelivery bool `json:"live_delivery"`
+}
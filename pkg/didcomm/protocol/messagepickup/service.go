@@ -39,6 +39,21 @@ const (
 	BatchMsgType = Spec + "batch"
 	// NoopMsgType defines the protocol request-credential message type.
 	NoopMsgType = Spec + "noop"
+
+	// SpecV2 defines the Pickup 2.0 protocol spec.
+	SpecV2 = "https://didcomm.org/messagepickup/2.0/"
+	// StatusRequestMsgTypeV2 defines the Pickup 2.0 status-request message type.
+	StatusRequestMsgTypeV2 = SpecV2 + "status-request"
+	// StatusMsgTypeV2 defines the Pickup 2.0 status message type.
+	StatusMsgTypeV2 = SpecV2 + "status"
+	// DeliveryRequestMsgTypeV2 defines the Pickup 2.0 delivery-request message type.
+	DeliveryRequestMsgTypeV2 = SpecV2 + "delivery-request"
+	// DeliveryMsgTypeV2 defines the Pickup 2.0 delivery message type.
+	DeliveryMsgTypeV2 = SpecV2 + "delivery"
+	// MessagesReceivedMsgTypeV2 defines the Pickup 2.0 messages-received message type.
+	MessagesReceivedMsgTypeV2 = SpecV2 + "messages-received"
+	// LiveDeliveryChangeMsgTypeV2 defines the Pickup 2.0 live-delivery-change message type.
+	LiveDeliveryChangeMsgTypeV2 = SpecV2 + "live-delivery-change"
 )
 
 const (
@@ -79,6 +94,12 @@ type Service struct {
 	batchMapLock     sync.RWMutex
 	statusMap        map[string]chan Status
 	statusMapLock    sync.RWMutex
+	statusV2Map      map[string]chan StatusV2
+	statusV2MapLock  sync.RWMutex
+	deliveryMap      map[string]chan DeliveryV2
+	deliveryMapLock  sync.RWMutex
+	liveDelivery     map[string]string
+	liveDeliveryLock sync.RWMutex
 	inboxLock        sync.Mutex
 	initialized      bool
 }
@@ -123,6 +144,9 @@ func (s *Service) Initialize(p interface{}) error {
 	s.msgHandler = prov.InboundMessageHandler()
 	s.batchMap = make(map[string]chan Batch)
 	s.statusMap = make(map[string]chan Status)
+	s.statusV2Map = make(map[string]chan StatusV2)
+	s.deliveryMap = make(map[string]chan DeliveryV2)
+	s.liveDelivery = make(map[string]string)
 
 	s.initialized = true
 
@@ -146,6 +170,18 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 			err = s.handleBatch(msg)
 		case NoopMsgType:
 			err = s.handleNoop(msg)
+		case StatusRequestMsgTypeV2:
+			err = s.handleStatusRequestV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case StatusMsgTypeV2:
+			err = s.handleStatusV2(msg)
+		case DeliveryRequestMsgTypeV2:
+			err = s.handleDeliveryRequestV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case DeliveryMsgTypeV2:
+			err = s.handleDeliveryV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case MessagesReceivedMsgTypeV2:
+			err = s.handleMessagesReceivedV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case LiveDeliveryChangeMsgTypeV2:
+			err = s.handleLiveDeliveryChangeV2(msg, ctx.MyDID(), ctx.TheirDID())
 		}
 
 		if err != nil {
@@ -166,6 +202,9 @@ func (s *Service) Accept(msgType string) bool {
 	switch msgType {
 	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType:
 		return true
+	case StatusRequestMsgTypeV2, StatusMsgTypeV2, DeliveryRequestMsgTypeV2, DeliveryMsgTypeV2,
+		MessagesReceivedMsgTypeV2, LiveDeliveryChangeMsgTypeV2:
+		return true
 	}
 
 	return false
@@ -263,6 +302,8 @@ func (s *Service) handleBatchPickup(msg service.DIDCommMsg, myDID, theirDID stri
 		return fmt.Errorf("batch pickup decode : %w", err)
 	}
 
+	msgs = unexpired(msgs, time.Now())
+
 	end := len(msgs)
 	if request.BatchSize < end {
 		end = request.BatchSize
@@ -371,19 +412,45 @@ func (r *inbox) EncodeMessages(msg []*Message) error {
 	return nil
 }
 
-// AddMessage add message to inbox.
+// AddMessage add message to inbox. If the recipient enabled Pickup 2.0 live mode, the message is delivered right
+// away, it stays queued until the recipient acknowledges it.
 func (s *Service) AddMessage(message []byte, theirDID string) error {
+	return s.AddMessageWithExpiry(message, theirDID, time.Time{})
+}
+
+// AddMessageWithExpiry adds a message which is dropped from the inbox once expiresTime is passed, the message never
+// expires if expiresTime is the zero time.
+func (s *Service) AddMessageWithExpiry(message []byte, theirDID string, expiresTime time.Time) error {
+	m, err := s.addMessage(message, theirDID, expiresTime)
+	if err != nil {
+		return err
+	}
+
+	if myDID, ok := s.getLiveDelivery(theirDID); ok {
+		// only the new message is delivered, the messages queued before were delivered when live mode was enabled
+		// or when they were added.
+		go func() {
+			if e := s.sendDeliveryV2(myDID, theirDID, "", []*Message{m}); e != nil {
+				logger.Warnf("live delivery of message %s to %s failed, it remains queued: %s", m.ID, theirDID, e)
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (s *Service) addMessage(message []byte, theirDID string, expiresTime time.Time) (*Message, error) {
 	s.inboxLock.Lock()
 	defer s.inboxLock.Unlock()
 
 	outbox, err := s.createInbox(theirDID)
 	if err != nil {
-		return fmt.Errorf("unable to pull messages: %w", err)
+		return nil, fmt.Errorf("unable to pull messages: %w", err)
 	}
 
 	msgs, err := outbox.DecodeMessages()
 	if err != nil {
-		return fmt.Errorf("unable to decode messages: %w", err)
+		return nil, fmt.Errorf("unable to decode messages: %w", err)
 	}
 
 	m := Message{
@@ -392,22 +459,43 @@ func (s *Service) AddMessage(message []byte, theirDID string) error {
 		Message:   message,
 	}
 
-	msgs = append(msgs, &m)
+	if !expiresTime.IsZero() {
+		m.ExpiresTime = &expiresTime
+	}
+
+	msgs = append(unexpired(msgs, m.AddedTime), &m)
 
 	outbox.LastDeliveredTime = time.Now()
 	outbox.LastRemovedTime = outbox.LastDeliveredTime
 
 	err = outbox.EncodeMessages(msgs)
 	if err != nil {
-		return fmt.Errorf("unable to encode messages: %w", err)
+		return nil, fmt.Errorf("unable to encode messages: %w", err)
 	}
 
 	err = s.putInbox(theirDID, outbox)
 	if err != nil {
-		return fmt.Errorf("unable to put messages: %w", err)
+		return nil, fmt.Errorf("unable to put messages: %w", err)
 	}
 
-	return nil
+	return &m, nil
+}
+
+// unexpired returns the messages which are not expired at t.
+func unexpired(msgs []*Message, t time.Time) []*Message {
+	var res []*Message
+
+	for _, m := range msgs {
+		if m.ExpiresTime != nil && t.After(*m.ExpiresTime) {
+			logger.Debugf("dropping expired message %s", m.ID)
+
+			continue
+		}
+
+		res = append(res, m)
+	}
+
+	return res
 }
 
 func (s *Service) createInbox(theirDID string) (*inbox, error) {
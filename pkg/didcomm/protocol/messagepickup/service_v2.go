@@ -0,0 +1,528 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+// Pickup 2.0 (https://didcomm.org/messagepickup/2.0/) shares the mailbox with messagepickup 1.0: messages queued by
+// the mediator through AddMessage can be picked up with either protocol. Unlike batch pickup, delivered messages are
+// only removed from the mailbox once the recipient acknowledges them with a messages-received message.
+
+func (s *Service) handleStatusRequestV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	request := &StatusRequestV2{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("status request v2 message unmarshal: %w", err)
+	}
+
+	return s.sendStatusV2(myDID, theirDID, msg.ID(), request.Body.RecipientKey)
+}
+
+func (s *Service) handleDeliveryRequestV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	request := &DeliveryRequestV2{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("delivery request message unmarshal: %w", err)
+	}
+
+	if request.Body.Limit <= 0 {
+		return fmt.Errorf("delivery request: invalid limit %d", request.Body.Limit)
+	}
+
+	return s.deliverV2(myDID, theirDID, msg.ID(), request.Body.Limit)
+}
+
+func (s *Service) handleMessagesReceivedV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	request := &MessagesReceivedV2{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("messages received message unmarshal: %w", err)
+	}
+
+	err = s.removeMessages(theirDID, request.Body.MessageIDList)
+	if err != nil {
+		return fmt.Errorf("messages received: %w", err)
+	}
+
+	return s.sendStatusV2(myDID, theirDID, msg.ID(), "")
+}
+
+func (s *Service) handleLiveDeliveryChangeV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	request := &LiveDeliveryChangeV2{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("live delivery change message unmarshal: %w", err)
+	}
+
+	s.setLiveDelivery(theirDID, myDID, request.Body.LiveDelivery)
+
+	if !request.Body.LiveDelivery {
+		return nil
+	}
+
+	// flush whatever was queued while the recipient was offline
+	return s.deliverV2(myDID, theirDID, "", 0)
+}
+
+func (s *Service) handleStatusV2(msg service.DIDCommMsg) error {
+	statusMsg := &StatusV2{}
+
+	err := msg.Decode(statusMsg)
+	if err != nil {
+		return fmt.Errorf("status v2 message unmarshal: %w", err)
+	}
+
+	if statusCh := s.getStatusV2Ch(statusMsg.ThreadID); statusCh != nil {
+		statusCh <- *statusMsg
+
+		return nil
+	}
+
+	// the mediator answers a delivery request with a status message when there is nothing to deliver
+	if deliveryCh := s.getDeliveryCh(statusMsg.ThreadID); deliveryCh != nil {
+		deliveryCh <- DeliveryV2{ID: statusMsg.ID, ThreadID: statusMsg.ThreadID}
+	}
+
+	return nil
+}
+
+func (s *Service) handleDeliveryV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	delivery := &DeliveryV2{}
+
+	err := msg.Decode(delivery)
+	if err != nil {
+		return fmt.Errorf("delivery message unmarshal: %w", err)
+	}
+
+	if deliveryCh := s.getDeliveryCh(delivery.ThreadID); deliveryCh != nil {
+		deliveryCh <- *delivery
+
+		return nil
+	}
+
+	// unsolicited delivery (live mode): process and acknowledge right away
+	processed := s.processDeliveryV2(delivery)
+	if len(processed) == 0 {
+		return nil
+	}
+
+	return s.sendMessagesReceivedV2(myDID, theirDID, delivery.ID, processed)
+}
+
+func (s *Service) deliverV2(myDID, theirDID, thID string, limit int) error {
+	s.inboxLock.Lock()
+
+	outbox, err := s.getInbox(theirDID)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		s.inboxLock.Unlock()
+
+		return fmt.Errorf("delivery get inbox: %w", err)
+	}
+
+	var msgs []*Message
+
+	if outbox != nil {
+		msgs, err = outbox.DecodeMessages()
+		if err != nil {
+			s.inboxLock.Unlock()
+
+			return fmt.Errorf("delivery decode: %w", err)
+		}
+
+		outbox.LastDeliveredTime = time.Now()
+
+		if remaining := unexpired(msgs, outbox.LastDeliveredTime); len(remaining) != len(msgs) {
+			msgs = remaining
+
+			err = outbox.EncodeMessages(msgs)
+			if err != nil {
+				s.inboxLock.Unlock()
+
+				return fmt.Errorf("delivery encode: %w", err)
+			}
+		}
+
+		err = s.putInbox(theirDID, outbox)
+		if err != nil {
+			s.inboxLock.Unlock()
+
+			return fmt.Errorf("delivery put inbox: %w", err)
+		}
+	}
+
+	s.inboxLock.Unlock()
+
+	if len(msgs) == 0 {
+		if thID == "" {
+			return nil
+		}
+
+		return s.sendStatusV2(myDID, theirDID, thID, "")
+	}
+
+	if limit > 0 && limit < len(msgs) {
+		msgs = msgs[:limit]
+	}
+
+	return s.sendDeliveryV2(myDID, theirDID, thID, msgs)
+}
+
+func (s *Service) sendDeliveryV2(myDID, theirDID, thID string, msgs []*Message) error {
+	delivery := &DeliveryV2{
+		ID:       uuid.New().String(),
+		Type:     DeliveryMsgTypeV2,
+		ThreadID: thID,
+	}
+
+	for _, m := range msgs {
+		delivery.Attachments = append(delivery.Attachments, &decorator.AttachmentV2{
+			ID: m.ID,
+			Data: decorator.AttachmentData{
+				Base64: base64.StdEncoding.EncodeToString(m.Message),
+			},
+		})
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(delivery), myDID, theirDID)
+}
+
+func (s *Service) sendStatusV2(myDID, theirDID, thID, recipientKey string) error {
+	s.inboxLock.Lock()
+
+	outbox, err := s.getInbox(theirDID)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		s.inboxLock.Unlock()
+
+		return fmt.Errorf("error in status request getting inbox: %w", err)
+	}
+
+	var msgs []*Message
+
+	if outbox != nil {
+		msgs, err = outbox.DecodeMessages()
+		if err != nil {
+			s.inboxLock.Unlock()
+
+			return fmt.Errorf("status request decode: %w", err)
+		}
+	}
+
+	s.inboxLock.Unlock()
+
+	msgs = unexpired(msgs, time.Now())
+
+	_, live := s.getLiveDelivery(theirDID)
+
+	resp := &StatusV2{
+		ID:       uuid.New().String(),
+		Type:     StatusMsgTypeV2,
+		ThreadID: thID,
+		Body: StatusV2Body{
+			RecipientKey: recipientKey,
+			MessageCount: len(msgs),
+			LiveDelivery: live,
+		},
+	}
+
+	for i, m := range msgs {
+		resp.Body.TotalBytes += len(m.Message)
+
+		if i == 0 || m.AddedTime.Unix() < resp.Body.OldestReceivedTime {
+			resp.Body.OldestReceivedTime = m.AddedTime.Unix()
+		}
+
+		if m.AddedTime.Unix() > resp.Body.NewestReceivedTime {
+			resp.Body.NewestReceivedTime = m.AddedTime.Unix()
+		}
+	}
+
+	if len(msgs) > 0 {
+		resp.Body.LongestWaitedSeconds = int(time.Now().Unix() - resp.Body.OldestReceivedTime)
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(resp), myDID, theirDID)
+}
+
+func (s *Service) sendMessagesReceivedV2(myDID, theirDID, thID string, msgIDs []string) error {
+	ack := &MessagesReceivedV2{
+		ID:       uuid.New().String(),
+		Type:     MessagesReceivedMsgTypeV2,
+		ThreadID: thID,
+		Body: MessagesReceivedV2Body{
+			MessageIDList: msgIDs,
+		},
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(ack), myDID, theirDID)
+}
+
+func (s *Service) removeMessages(theirDID string, msgIDs []string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	outbox, err := s.getInbox(theirDID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("get inbox: %w", err)
+	}
+
+	msgs, err := outbox.DecodeMessages()
+	if err != nil {
+		return fmt.Errorf("decode messages: %w", err)
+	}
+
+	received := make(map[string]struct{}, len(msgIDs))
+	for _, id := range msgIDs {
+		received[id] = struct{}{}
+	}
+
+	var remaining []*Message
+
+	for _, m := range msgs {
+		if _, ok := received[m.ID]; !ok {
+			remaining = append(remaining, m)
+		}
+	}
+
+	if len(remaining) == len(msgs) {
+		return nil
+	}
+
+	outbox.LastRemovedTime = time.Now()
+
+	err = outbox.EncodeMessages(remaining)
+	if err != nil {
+		return fmt.Errorf("encode messages: %w", err)
+	}
+
+	return s.putInbox(theirDID, outbox)
+}
+
+// processDeliveryV2 hands every delivered message to the inbound message handler and returns the IDs of the
+// messages that were processed successfully.
+func (s *Service) processDeliveryV2(delivery *DeliveryV2) []string {
+	var processed []string
+
+	for _, attachment := range delivery.Attachments {
+		if attachment == nil {
+			continue
+		}
+
+		raw, err := attachment.Data.Fetch()
+		if err != nil {
+			logger.Errorf("error fetching delivered message %s: %s", attachment.ID, err)
+
+			continue
+		}
+
+		err = s.handle(&Message{ID: attachment.ID, Message: raw})
+
+		var rejected *dispatcher.RejectedError
+
+		switch {
+		case errors.As(err, &rejected) && !rejected.Retry:
+			// the message would be rejected again, e.g. it expired while it was queued.
+			logger.Warnf("delivered message %s rejected: %s", attachment.ID, err)
+		case err != nil:
+			logger.Errorf("error handling delivered message %s: %s", attachment.ID, err)
+
+			continue
+		}
+
+		processed = append(processed, attachment.ID)
+	}
+
+	return processed
+}
+
+// StatusRequestV2 requests a Pickup 2.0 status message from the mediator.
+func (s *Service) StatusRequestV2(connectionID string) (*StatusV2, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	statusCh := make(chan StatusV2)
+	s.setStatusV2Ch(msgID, statusCh)
+
+	defer s.setStatusV2Ch(msgID, nil)
+
+	req := &StatusRequestV2{
+		ID:   msgID,
+		Type: StatusRequestMsgTypeV2,
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send status request v2: %w", err)
+	}
+
+	select {
+	case sts := <-statusCh:
+		return &sts, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for status")
+	}
+}
+
+// DeliveryRequest asks the mediator to deliver up to limit waiting messages. Delivered messages are handed to
+// the inbound message handler and the IDs of the successfully processed ones are returned. The messages stay
+// queued on the mediator until they are acknowledged with MessagesReceived.
+func (s *Service) DeliveryRequest(connectionID string, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("invalid limit %d", limit)
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	deliveryCh := make(chan DeliveryV2)
+	s.setDeliveryCh(msgID, deliveryCh)
+
+	defer s.setDeliveryCh(msgID, nil)
+
+	req := &DeliveryRequestV2{
+		ID:   msgID,
+		Type: DeliveryRequestMsgTypeV2,
+		Body: DeliveryRequestV2Body{
+			Limit: limit,
+		},
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send delivery request: %w", err)
+	}
+
+	select {
+	case delivery := <-deliveryCh:
+		return s.processDeliveryV2(&delivery), nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for delivery")
+	}
+}
+
+// MessagesReceived acknowledges the given messages, the mediator then removes them from its queue.
+func (s *Service) MessagesReceived(connectionID string, messageIDs []string) error {
+	if len(messageIDs) == 0 {
+		return errors.New("no message IDs to acknowledge")
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sendMessagesReceivedV2(conn.MyDID, conn.TheirDID, "", messageIDs); err != nil {
+		return fmt.Errorf("send messages received: %w", err)
+	}
+
+	return nil
+}
+
+// LiveDeliveryChange turns live mode on or off. While live mode is on, the mediator delivers queued messages as
+// soon as they arrive, typically over the WebSocket the recipient keeps open with return route 'all'. Messages
+// delivered this way are processed and acknowledged automatically.
+func (s *Service) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	req := &LiveDeliveryChangeV2{
+		ID:   uuid.New().String(),
+		Type: LiveDeliveryChangeMsgTypeV2,
+		Body: LiveDeliveryChangeV2Body{
+			LiveDelivery: liveDelivery,
+		},
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send live delivery change: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getStatusV2Ch(thID string) chan StatusV2 {
+	s.statusV2MapLock.RLock()
+	defer s.statusV2MapLock.RUnlock()
+
+	return s.statusV2Map[thID]
+}
+
+func (s *Service) setStatusV2Ch(thID string, statusCh chan StatusV2) {
+	s.statusV2MapLock.Lock()
+	defer s.statusV2MapLock.Unlock()
+
+	if statusCh == nil {
+		delete(s.statusV2Map, thID)
+	} else {
+		s.statusV2Map[thID] = statusCh
+	}
+}
+
+func (s *Service) getDeliveryCh(thID string) chan DeliveryV2 {
+	s.deliveryMapLock.RLock()
+	defer s.deliveryMapLock.RUnlock()
+
+	return s.deliveryMap[thID]
+}
+
+func (s *Service) setDeliveryCh(thID string, deliveryCh chan DeliveryV2) {
+	s.deliveryMapLock.Lock()
+	defer s.deliveryMapLock.Unlock()
+
+	if deliveryCh == nil {
+		delete(s.deliveryMap, thID)
+	} else {
+		s.deliveryMap[thID] = deliveryCh
+	}
+}
+
+func (s *Service) getLiveDelivery(theirDID string) (string, bool) {
+	s.liveDeliveryLock.RLock()
+	defer s.liveDeliveryLock.RUnlock()
+
+	myDID, ok := s.liveDelivery[theirDID]
+
+	return myDID, ok
+}
+
+func (s *Service) setLiveDelivery(theirDID, myDID string, enabled bool) {
+	s.liveDeliveryLock.Lock()
+	defer s.liveDeliveryLock.Unlock()
+
+	if enabled {
+		s.liveDelivery[theirDID] = myDID
+	} else {
+		delete(s.liveDelivery, theirDID)
+	}
+}
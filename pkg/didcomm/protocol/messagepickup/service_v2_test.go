@@ -0,0 +1,456 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
+	mockdispatcher "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	"github.com/markcryptohash/aries-framework-go/pkg/store/connection"
+)
+
+func TestAcceptV2(t *testing.T) {
+	svc, err := getService()
+	require.NoError(t, err)
+
+	for _, msgType := range []string{
+		StatusRequestMsgTypeV2, StatusMsgTypeV2, DeliveryRequestMsgTypeV2, DeliveryMsgTypeV2,
+		MessagesReceivedMsgTypeV2, LiveDeliveryChangeMsgTypeV2,
+	} {
+		require.True(t, svc.Accept(msgType))
+	}
+}
+
+func TestMediatorSideV2(t *testing.T) {
+	t.Run("status request - empty mailbox", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		msg := service.NewDIDCommMsgMap(&StatusRequestV2{ID: "req-1", Type: StatusRequestMsgTypeV2})
+
+		require.NoError(t, svc.handleStatusRequestV2(msg, MYDID, THEIRDID))
+
+		status := &StatusV2{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, StatusMsgTypeV2, status.Type)
+		require.Equal(t, "req-1", status.ThreadID)
+		require.Equal(t, 0, status.Body.MessageCount)
+		require.False(t, status.Body.LiveDelivery)
+	})
+
+	t.Run("delivery request, messages received and status", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		require.NoError(t, svc.AddMessage([]byte("message-1"), THEIRDID))
+		require.NoError(t, svc.AddMessage([]byte("message-2"), THEIRDID))
+
+		msg := service.NewDIDCommMsgMap(&DeliveryRequestV2{
+			ID:   "req-1",
+			Type: DeliveryRequestMsgTypeV2,
+			Body: DeliveryRequestV2Body{Limit: 1},
+		})
+
+		require.NoError(t, svc.handleDeliveryRequestV2(msg, MYDID, THEIRDID))
+
+		delivery := &DeliveryV2{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Equal(t, DeliveryMsgTypeV2, delivery.Type)
+		require.Equal(t, "req-1", delivery.ThreadID)
+		require.Len(t, delivery.Attachments, 1)
+
+		raw, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "message-1", string(raw))
+
+		// delivered messages stay queued until acknowledged
+		require.NoError(t, svc.handleStatusRequestV2(
+			service.NewDIDCommMsgMap(&StatusRequestV2{ID: "req-2", Type: StatusRequestMsgTypeV2}), MYDID, THEIRDID))
+
+		status := &StatusV2{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, 2, status.Body.MessageCount)
+		require.Equal(t, len("message-1")+len("message-2"), status.Body.TotalBytes)
+		require.NotZero(t, status.Body.OldestReceivedTime)
+
+		ack := service.NewDIDCommMsgMap(&MessagesReceivedV2{
+			ID:   "req-3",
+			Type: MessagesReceivedMsgTypeV2,
+			Body: MessagesReceivedV2Body{MessageIDList: []string{delivery.Attachments[0].ID}},
+		})
+
+		require.NoError(t, svc.handleMessagesReceivedV2(ack, MYDID, THEIRDID))
+
+		status = &StatusV2{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, "req-3", status.ThreadID)
+		require.Equal(t, 1, status.Body.MessageCount)
+	})
+
+	t.Run("expired messages are dropped", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		require.NoError(t, svc.AddMessageWithExpiry([]byte("expired"), THEIRDID, time.Now().Add(-time.Second)))
+		require.NoError(t, svc.AddMessageWithExpiry([]byte("valid"), THEIRDID, time.Now().Add(time.Hour)))
+
+		require.NoError(t, svc.handleStatusRequestV2(
+			service.NewDIDCommMsgMap(&StatusRequestV2{ID: "req-1", Type: StatusRequestMsgTypeV2}), MYDID, THEIRDID))
+
+		status := &StatusV2{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, 1, status.Body.MessageCount)
+
+		msg := service.NewDIDCommMsgMap(&DeliveryRequestV2{
+			ID:   "req-2",
+			Type: DeliveryRequestMsgTypeV2,
+			Body: DeliveryRequestV2Body{Limit: 10},
+		})
+
+		require.NoError(t, svc.handleDeliveryRequestV2(msg, MYDID, THEIRDID))
+
+		delivery := &DeliveryV2{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Len(t, delivery.Attachments, 1)
+
+		raw, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "valid", string(raw))
+
+		outbox, err := svc.getInbox(THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, 1, outbox.MessageCount)
+	})
+
+	t.Run("delivery request - invalid limit", func(t *testing.T) {
+		svc := newServiceV2(t, make(chan service.DIDCommMsgMap, 1))
+
+		msg := service.NewDIDCommMsgMap(&DeliveryRequestV2{ID: "req-1", Type: DeliveryRequestMsgTypeV2})
+
+		err := svc.handleDeliveryRequestV2(msg, MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid limit")
+	})
+
+	t.Run("delivery request - nothing queued", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		msg := service.NewDIDCommMsgMap(&DeliveryRequestV2{
+			ID:   "req-1",
+			Type: DeliveryRequestMsgTypeV2,
+			Body: DeliveryRequestV2Body{Limit: 10},
+		})
+
+		require.NoError(t, svc.handleDeliveryRequestV2(msg, MYDID, THEIRDID))
+
+		reply := <-sent
+		require.Equal(t, StatusMsgTypeV2, reply.Type())
+	})
+
+	t.Run("live delivery", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		require.NoError(t, svc.AddMessage([]byte("queued"), THEIRDID))
+
+		msg := service.NewDIDCommMsgMap(&LiveDeliveryChangeV2{
+			ID:   "req-1",
+			Type: LiveDeliveryChangeMsgTypeV2,
+			Body: LiveDeliveryChangeV2Body{LiveDelivery: true},
+		})
+
+		require.NoError(t, svc.handleLiveDeliveryChangeV2(msg, MYDID, THEIRDID))
+		require.Equal(t, DeliveryMsgTypeV2, (<-sent).Type())
+
+		// new messages are pushed as they arrive, without the messages delivered before
+		for _, m := range []string{"live-1", "live-2"} {
+			require.NoError(t, svc.AddMessage([]byte(m), THEIRDID))
+
+			select {
+			case reply := <-sent:
+				delivery := &DeliveryV2{}
+				require.NoError(t, reply.Decode(delivery))
+				require.Equal(t, DeliveryMsgTypeV2, delivery.Type)
+				require.Len(t, delivery.Attachments, 1)
+
+				raw, err := delivery.Attachments[0].Data.Fetch()
+				require.NoError(t, err)
+				require.Equal(t, m, string(raw))
+			case <-time.After(2 * time.Second):
+				require.Fail(t, "live delivery not sent")
+			}
+		}
+
+		msg = service.NewDIDCommMsgMap(&LiveDeliveryChangeV2{
+			ID:   "req-2",
+			Type: LiveDeliveryChangeMsgTypeV2,
+			Body: LiveDeliveryChangeV2Body{LiveDelivery: false},
+		})
+
+		require.NoError(t, svc.handleLiveDeliveryChangeV2(msg, MYDID, THEIRDID))
+
+		_, live := svc.getLiveDelivery(THEIRDID)
+		require.False(t, live)
+	})
+}
+
+func TestRecipientSideV2(t *testing.T) {
+	t.Run("status request", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		go func() {
+			req := <-sent
+			require.Equal(t, StatusRequestMsgTypeV2, req.Type())
+
+			reply := service.NewDIDCommMsgMap(&StatusV2{
+				ID:       "status-1",
+				Type:     StatusMsgTypeV2,
+				ThreadID: req.ID(),
+				Body:     StatusV2Body{MessageCount: 3, LiveDelivery: true},
+			})
+
+			_, err := svc.HandleInbound(reply, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+		}()
+
+		status, err := svc.StatusRequestV2("conn")
+		require.NoError(t, err)
+		require.Equal(t, 3, status.Body.MessageCount)
+		require.True(t, status.Body.LiveDelivery)
+	})
+
+	t.Run("delivery request", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		go func() {
+			req := <-sent
+
+			request := &DeliveryRequestV2{}
+			require.NoError(t, req.Decode(request))
+			require.Equal(t, 5, request.Body.Limit)
+
+			reply := service.NewDIDCommMsgMap(&DeliveryV2{
+				ID:       "delivery-1",
+				Type:     DeliveryMsgTypeV2,
+				ThreadID: req.ID(),
+				Attachments: []*decorator.AttachmentV2{{
+					ID:   "msg-1",
+					Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte("packed"))},
+				}},
+			})
+
+			_, err := svc.HandleInbound(reply, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+		}()
+
+		ids, err := svc.DeliveryRequest("conn", 5)
+		require.NoError(t, err)
+		require.Equal(t, []string{"msg-1"}, ids)
+	})
+
+	t.Run("delivery request - answered with status", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		go func() {
+			req := <-sent
+
+			reply := service.NewDIDCommMsgMap(&StatusV2{
+				ID:       "status-1",
+				Type:     StatusMsgTypeV2,
+				ThreadID: req.ID(),
+			})
+
+			_, err := svc.HandleInbound(reply, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+		}()
+
+		ids, err := svc.DeliveryRequest("conn", 5)
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+
+	t.Run("delivery request - invalid limit", func(t *testing.T) {
+		svc := newServiceV2(t, make(chan service.DIDCommMsgMap, 1))
+
+		_, err := svc.DeliveryRequest("conn", 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid limit")
+	})
+
+	t.Run("unsolicited delivery is acknowledged", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+
+		delivery := service.NewDIDCommMsgMap(&DeliveryV2{
+			ID:   "delivery-1",
+			Type: DeliveryMsgTypeV2,
+			Attachments: []*decorator.AttachmentV2{{
+				ID:   "msg-1",
+				Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte("packed"))},
+			}},
+		})
+
+		require.NoError(t, svc.handleDeliveryV2(delivery, MYDID, THEIRDID))
+
+		ack := &MessagesReceivedV2{}
+		require.NoError(t, (<-sent).Decode(ack))
+		require.Equal(t, []string{"msg-1"}, ack.Body.MessageIDList)
+	})
+
+	t.Run("rejected delivered messages are acknowledged unless they can be retried", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc := newServiceV2(t, sent)
+		svc.packager = &idPackager{}
+		svc.msgHandler = func(envelope *transport.Envelope) error {
+			msg, err := service.ParseDIDCommMsgMap(envelope.Message)
+			require.NoError(t, err)
+
+			switch msg.ID() {
+			case "expired":
+				return fmt.Errorf("check inbound message: %w", &dispatcher.RejectedError{Code: "message-expired"})
+			case "rate-limited":
+				return &dispatcher.RejectedError{Code: "rate-limited", Retry: true}
+			}
+
+			return nil
+		}
+
+		var attachments []*decorator.AttachmentV2
+
+		for _, m := range []string{"expired", "rate-limited", "valid"} {
+			attachments = append(attachments, &decorator.AttachmentV2{
+				ID:   m,
+				Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(m))},
+			})
+		}
+
+		delivery := service.NewDIDCommMsgMap(&DeliveryV2{ID: "delivery-1", Type: DeliveryMsgTypeV2, Attachments: attachments})
+
+		require.NoError(t, svc.handleDeliveryV2(delivery, MYDID, THEIRDID))
+
+		ack := &MessagesReceivedV2{}
+		require.NoError(t, (<-sent).Decode(ack))
+		require.Equal(t, []string{"expired", "valid"}, ack.Body.MessageIDList)
+	})
+
+	t.Run("messages received and live delivery change", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 2)
+
+		svc := newServiceV2(t, sent)
+
+		require.NoError(t, svc.MessagesReceived("conn", []string{"msg-1"}))
+		require.Equal(t, MessagesReceivedMsgTypeV2, (<-sent).Type())
+
+		require.NoError(t, svc.LiveDeliveryChange("conn", true))
+
+		change := &LiveDeliveryChangeV2{}
+		require.NoError(t, (<-sent).Decode(change))
+		require.True(t, change.Body.LiveDelivery)
+
+		err := svc.MessagesReceived("conn", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc := newServiceV2(t, make(chan service.DIDCommMsgMap, 1))
+
+		_, err := svc.StatusRequestV2("unknown")
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+
+		_, err = svc.DeliveryRequest("unknown", 1)
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+
+		require.ErrorIs(t, svc.MessagesReceived("unknown", []string{"id"}), ErrConnectionNotFound)
+		require.ErrorIs(t, svc.LiveDeliveryChange("unknown", true), ErrConnectionNotFound)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc := newServiceV2(t, make(chan service.DIDCommMsgMap, 1))
+		svc.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		_, err := svc.StatusRequestV2("conn")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send error")
+
+		err = svc.LiveDeliveryChange("conn", false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send error")
+	})
+}
+
+func newServiceV2(t *testing.T, sent chan service.DIDCommMsgMap) *Service {
+	t.Helper()
+
+	provider := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+
+				msgMap, ok := msg.(service.DIDCommMsgMap)
+				require.True(t, ok)
+
+				sent <- msgMap
+
+				return nil
+			},
+		},
+		PackagerValue:              &mockPackager{},
+		InboundMessageHandlerValue: (&mockTransportProvider{}).InboundMessageHandler(),
+	}
+
+	r, err := connection.NewRecorder(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "completed",
+	}))
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	return svc
+}
+
+// idPackager unpacks a message into a message whose ID is the packed message.
+type idPackager struct{}
+
+func (p *idPackager) PackMessage(e *transport.Envelope) ([]byte, error) {
+	return e.Message, nil
+}
+
+func (p *idPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	return &transport.Envelope{Message: []byte(`{"id":"` + string(encMessage) + `"}`)}, nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/component/storageutil/mem"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+	serviceMocks "github.com/markcryptohash/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	presentproofMocks "github.com/markcryptohash/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
+)
+
+func TestService_PleaseAck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newService := func(t *testing.T, messenger service.Messenger) (*Service, chan service.DIDCommAction,
+		chan service.StateMsg) {
+		t.Helper()
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		events := make(chan service.StateMsg, 100)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		return svc, actions, events
+	}
+
+	// present sends a presentation with the given please_ack, it returns the PIID.
+	present := func(t *testing.T, svc *Service, actions chan service.DIDCommAction, pleaseAck *decorator.PleaseAck,
+		opts ...Opt) string {
+		t.Helper()
+
+		request := service.NewDIDCommMsgMap(RequestPresentationV2{Type: RequestPresentationMsgTypeV2})
+		request.SetID(uuid.New().String())
+
+		_, err := svc.HandleInbound(request, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		<-actions
+
+		opts = append(opts, WithPresentation(&PresentationParams{PleaseAck: pleaseAck}))
+		require.NoError(t, svc.ActionContinue(request.ID(), opts...))
+
+		return request.ID()
+	}
+
+	ack := func(piID, status string) service.DIDCommMsgMap {
+		msg := service.NewDIDCommMsgMap(model.Ack{
+			Type:   AckMsgTypeV2,
+			Status: status,
+			Thread: &decorator.Thread{ID: piID},
+		})
+		msg.SetID(uuid.New().String())
+
+		return msg
+	}
+
+	waitEvent := func(t *testing.T, events chan service.StateMsg, stateID string) service.StateMsg {
+		t.Helper()
+
+		for {
+			select {
+			case event := <-events:
+				if event.StateID == stateID {
+					return event
+				}
+			case <-time.After(time.Second):
+				require.FailNow(t, "timeout waiting for event "+stateID)
+			}
+		}
+	}
+
+	stateName := func(t *testing.T, svc *Service, piID string) string {
+		t.Helper()
+
+		data, err := svc.currentInternalData(piID, version2)
+		require.NoError(t, err)
+
+		return data.StateName
+	}
+
+	t.Run("prover receives the acknowledgements of receipt and outcome", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				sent <- msg
+
+				return nil
+			})
+
+		svc, actions, events := newService(t, messenger)
+
+		piID := present(t, svc, actions,
+			decorator.NewPleaseAck(decorator.PleaseAckOnReceipt, decorator.PleaseAckOnOutcome))
+
+		presentation := <-sent
+		require.True(t, presentation.PleaseAck().OnReceipt())
+		require.True(t, presentation.PleaseAck().OnOutcome())
+
+		waitEvent(t, events, stateNamePresentationSent)
+
+		_, err := svc.HandleInbound(ack(piID, model.AckStatusPENDING), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		event := waitEvent(t, events, StateIDAckReceived)
+		require.Equal(t, piID, event.Properties.All()["piid"])
+
+		// the verifier didn't confirm it would acknowledge the outcome, the prover waits for it anyway.
+		require.Equal(t, stateNamePresentationSent, stateName(t, svc, piID))
+
+		_, err = svc.HandleInbound(ack(piID, model.AckStatusOK), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		event = waitEvent(t, events, StateIDAckReceived)
+		require.Equal(t, AckMsgTypeV2, event.Msg.Type())
+		require.Equal(t, StateNameDone, stateName(t, svc, piID))
+
+		require.False(t, svc.acks.Awaiting(piID))
+
+		// receipts which are not awaited are not notified.
+		_, err = svc.HandleInbound(ack(piID, model.AckStatusPENDING), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case event = <-events:
+			require.NotEqual(t, StateIDAckReceived, event.StateID)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("verifier acknowledges the receipt and the outcome", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		sent := make(chan service.DIDCommMsgMap, 2)
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob, gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				sent <- msg
+
+				return nil
+			}).Times(2)
+
+		svc, actions, _ := newService(t, messenger)
+
+		piID := uuid.New().String()
+		require.NoError(t, svc.saveInternalData(piID, &internalData{
+			StateName:       stateNameRequestSent,
+			ProtocolVersion: version2,
+		}))
+
+		msg := service.NewDIDCommMsgMap(PresentationV2{
+			Type:      PresentationMsgTypeV2,
+			ID:        uuid.New().String(),
+			PleaseAck: decorator.NewPleaseAck(decorator.PleaseAckOnReceipt, decorator.PleaseAckOnOutcome),
+		})
+		msg.SetThread(piID, "")
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		receipt := model.Ack{}
+		require.NoError(t, (<-sent).Decode(&receipt))
+		require.Equal(t, model.AckStatusPENDING, receipt.Status)
+
+		<-actions
+
+		require.NoError(t, svc.ActionContinue(piID))
+
+		outcome := model.Ack{}
+		require.NoError(t, (<-sent).Decode(&outcome))
+		require.Equal(t, AckMsgTypeV2, outcome.Type)
+		require.Equal(t, model.AckStatusOK, outcome.Status)
+
+		require.Eventually(t, func() bool {
+			return stateName(t, svc, piID) == StateNameDone
+		}, time.Second, 10*time.Millisecond)
+	})
+}
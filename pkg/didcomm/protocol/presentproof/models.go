@@ -15,7 +15,6 @@ type ProposePresentationV2 struct {
 	ID   string `json:"@id,omitempty"`
 	Type string `json:"@type,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
 	// Formats contains an entry for each proposal~attach array entry, including an optional value of the
 	// attachment @id (if attachments are present) and the verifiable presentation format and version of the attachment.
@@ -41,7 +40,6 @@ type ProposePresentationV3 struct {
 type ProposePresentationV3Body struct {
 	GoalCode string `json:"goal_code,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
 }
 
@@ -50,7 +48,6 @@ type RequestPresentationV2 struct {
 	ID   string `json:"@id,omitempty"`
 	Type string `json:"@type,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
 	// WillConfirm is a field that defaults to "false" to indicate that the verifier will or will not
 	// send a post-presentation confirmation ack message.
@@ -75,7 +72,6 @@ type RequestPresentationV3 struct {
 type RequestPresentationV3Body struct {
 	GoalCode string `json:"goal_code,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
 	// WillConfirm is a field that defaults to "false" to indicate that the verifier will or will not
 	// send a post-presentation confirmation ack message.
@@ -83,18 +79,19 @@ type RequestPresentationV3Body struct {
 }
 
 // PresentationV2 is a response to a RequestPresentationV2 message and contains signed presentations.
-// TODO: Add ~please_ack decorator support for the protocol [Issue #2047].
 type PresentationV2 struct {
 	ID   string `json:"@id,omitempty"`
 	Type string `json:"@type,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
 	Comment string `json:"comment,omitempty"`
 	// Formats contains an entry for each presentations~attach array entry, providing the the value of the attachment
 	// @id and the verifiable presentation format and version of the attachment.
 	Formats []Format `json:"formats,omitempty"`
 	// PresentationsAttach an array of attachments containing the presentation in the requested format(s).
 	PresentationsAttach []decorator.Attachment `json:"presentations~attach,omitempty"`
+	// PleaseAck requests acknowledgements of the presentation, the verifier acknowledges the outcome even if it
+	// didn't confirm it would.
+	PleaseAck *decorator.PleaseAck `json:"~please_ack,omitempty"`
 }
 
 // Format contains the value of the attachment @id and the verifiable credential format of the attachment.
@@ -110,12 +107,13 @@ type PresentationV3 struct {
 	// Attachments is an array of attachments that further define the presentation request being proposed.
 	// This might be used to clarify which formats or format versions are wanted.
 	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
+	// PleaseAck lists the requested acknowledgements of the presentation.
+	PleaseAck []string `json:"please_ack,omitempty"`
 }
 
 // PresentationV3Body represents body for PresentationV3.
 type PresentationV3Body struct {
 	GoalCode string `json:"goal_code,omitempty"`
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string `json:"comment,omitempty"`
 }
@@ -41,7 +41,6 @@ func (p *ProposePresentationV3) notEmpty() bool {
 // ProposePresentationParams holds the parameters for proposing a presentation.
 type ProposePresentationParams struct {
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string
 	// Formats contains an entry for each proposal~attach array entry, including an optional value of the
 	// attachment @id (if attachments are present) and the verifiable presentation format and version of the attachment.
@@ -170,7 +169,6 @@ func (r *rawRequest) isV3() bool {
 // RequestPresentationParams holds the parameters for requesting a presentation.
 type RequestPresentationParams struct {
 	// Comment is a field that provides some human readable information about the proposed presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300]
 	Comment string
 	// WillConfirm is a field that defaults to "false" to indicate that the verifier will or will not
 	// send a post-presentation confirmation ack message.
@@ -305,7 +303,6 @@ func (r *rawPresentation) isV3() bool {
 // PresentationParams holds the parameters for providing a presentation.
 type PresentationParams struct {
 	// Comment is a field that provides some human readable information about the provided presentation.
-	// TODO: Should follow DIDComm conventions for l10n. [Issue #1300].
 	Comment string
 	// Formats contains an entry for each presentations~attach array entry, providing the the value of the attachment
 	// @id and the verifiable presentation format and version of the attachment.
@@ -314,6 +311,8 @@ type PresentationParams struct {
 	Attachments []decorator.GenericAttachment
 	// GoalCode is an optional goal code to indicate the intended use of the provided presentation(s).
 	GoalCode string
+	// PleaseAck requests acknowledgements of the presentation.
+	PleaseAck *decorator.PleaseAck
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -367,6 +366,7 @@ func (p *PresentationParams) AsV2() *PresentationV2 {
 		Comment:             p.Comment,
 		Formats:             p.Formats,
 		PresentationsAttach: decorator.GenericAttachmentsToV1(p.Attachments),
+		PleaseAck:           p.PleaseAck,
 	}
 }
 
@@ -379,6 +379,7 @@ func (p *PresentationParams) AsV3() *PresentationV3 {
 			Comment:  p.Comment,
 		},
 		Attachments: decorator.GenericAttachmentsToV2(p.Attachments),
+		PleaseAck:   p.PleaseAck.AsV2(),
 	}
 }
 
@@ -388,6 +389,7 @@ func (p *PresentationParams) FromV2(v2 *PresentationV2) {
 	p.Formats = v2.Formats
 	p.Attachments = decorator.V1AttachmentsToGeneric(v2.PresentationsAttach)
 	p.GoalCode = ""
+	p.PleaseAck = v2.PleaseAck
 }
 
 // FromV3 initializes this presentation message from a present-proof 3.0 presentation message.
@@ -396,4 +398,5 @@ func (p *PresentationParams) FromV3(v3 *PresentationV3) {
 	p.Formats = nil
 	p.Attachments = decorator.V2AttachmentsToGeneric(v3.Attachments)
 	p.GoalCode = v3.Body.GoalCode
+	p.PleaseAck = decorator.PleaseAckFromV2(v3.PleaseAck)
 }
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -111,6 +112,9 @@ type metaData struct {
 	requestV3             *RequestPresentationV3
 
 	addProofFn func(presentation *verifiable.Presentation) error
+	// acknowledgements requested by the sent message and the time to wait for them.
+	pleaseAck  *decorator.PleaseAck
+	ackTimeout time.Duration
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
@@ -186,6 +190,7 @@ func WithPresentation(pp *PresentationParams) Opt {
 				Comment:             pp.Comment,
 				Formats:             pp.Formats,
 				PresentationsAttach: decorator.GenericAttachmentsToV1(pp.Attachments),
+				PleaseAck:           pp.PleaseAck,
 			}
 		case version3:
 			md.presentationV3 = &PresentationV3{
@@ -195,6 +200,7 @@ func WithPresentation(pp *PresentationParams) Opt {
 					Comment:  pp.Comment,
 				},
 				Attachments: decorator.GenericAttachmentsToV2(pp.Attachments),
+				PleaseAck:   pp.PleaseAck.AsV2(),
 			}
 		}
 	}
@@ -312,6 +318,8 @@ type Service struct {
 	messenger   service.Messenger
 	middleware  Handler
 	initialized bool
+	// acknowledgements requested with the please_ack decorator, by PIID.
+	acks *decorator.AckWaiter
 }
 
 // New returns the presentproof service.
@@ -351,6 +359,7 @@ func (s *Service) Initialize(prov interface{}) error {
 	s.store = store
 	s.callbacks = make(chan *metaData)
 	s.middleware = initialHandler
+	s.acks = decorator.NewAckWaiter()
 
 	// start the listener
 	go s.startInternalListener()
@@ -383,6 +392,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		return "", errors.New("no clients are registered to handle the message")
 	}
 
+	if isReceipt(msgMap) {
+		return s.handleReceipt(msgMap, ctx)
+	}
+
 	md, err := s.buildMetaData(msgMap, inboundMessage)
 	if err != nil {
 		return "", fmt.Errorf("buildMetaData: %w", err)
@@ -391,6 +404,17 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	md.MyDID = ctx.MyDID()
 	md.TheirDID = ctx.TheirDID()
 
+	if canRequestAck(msgMap) && msgMap.PleaseAck().OnReceipt() {
+		if err = s.sendReceipt(md); err != nil {
+			logger.Warnf("failed to acknowledge the receipt of message %s: %s", msgMap.ID(), err)
+		}
+	}
+
+	// a problem report is the negative acknowledgement of the outcome.
+	if msgMap.Type() == ProblemReportMsgTypeV2 || msgMap.Type() == ProblemReportMsgTypeV3 {
+		s.acks.Stop(md.PIID, true)
+	}
+
 	// trigger action event based on message type for inbound messages
 	if canTriggerActionEvents(msgMap) {
 		err = s.saveTransitionalPayload(md.PIID, &(md.transitionalPayload))
@@ -408,7 +432,14 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	}
 
 	// if no action event is triggered, continue the execution
-	return thid, s.handle(md)
+	err = s.handle(md)
+
+	if err == nil && (msgMap.Type() == AckMsgTypeV2 || msgMap.Type() == AckMsgTypeV3) &&
+		s.acks.Stop(md.PIID, true) {
+		s.sendAckEvent(msgMap, StateIDAckReceived, newEventProps(md))
+	}
+
+	return thid, err
 }
 
 // HandleOutbound handles outbound message (presentproof protocol).
@@ -554,7 +585,18 @@ func (s *Service) handle(md *metaData) error {
 			return fmt.Errorf("failed to persist state %s: %w", current.Name(), err)
 		}
 
+		// the acknowledgements can be received before the action returns.
+		awaitingAck := md.pleaseAck != nil
+		if awaitingAck {
+			s.awaitAck(md)
+			md.pleaseAck = nil
+		}
+
 		if err := action(s.messenger); err != nil {
+			if awaitingAck {
+				s.acks.Stop(md.PIID, true)
+			}
+
 			return fmt.Errorf("action %s: %w", md.state.Name(), err)
 		}
 
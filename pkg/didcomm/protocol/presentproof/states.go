@@ -12,6 +12,7 @@ import (
 
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
 )
 
 const (
@@ -325,6 +326,12 @@ func (s *presentationSent) Execute(md *metaData) (state, stateAction, error) {
 		return nil, nil, errors.New("presentation was not provided")
 	}
 
+	if md.presentation != nil {
+		md.pleaseAck = md.presentation.PleaseAck
+	} else {
+		md.pleaseAck = decorator.PleaseAckFromV2(md.presentationV3.PleaseAck)
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
 		if s.V == SpecV3 {
@@ -344,7 +351,8 @@ func (s *presentationSent) Execute(md *metaData) (state, stateAction, error) {
 		)
 	}
 
-	if !s.WillConfirm {
+	// the verifier acknowledges the outcome if it confirmed it would or if the presentation requests it.
+	if !s.WillConfirm && !md.pleaseAck.OnOutcome() {
 		return &done{V: s.V}, action, nil
 	}
 
@@ -370,7 +378,7 @@ func (s *presentationReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *presentationReceived) Execute(md *metaData) (state, stateAction, error) {
-	if !md.AckRequired {
+	if !md.AckRequired && !md.Msg.PleaseAck().OnOutcome() {
 		return &done{V: s.V}, zeroAction, nil
 	}
 
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Ping is sent to test the connection with the other party.
+// https://github.com/markcryptohash/aries-rfcs/tree/master/features/0048-trust-ping#messages
+type Ping struct {
+	Type              string `json:"@type,omitempty"`
+	ID                string `json:"@id,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	ResponseRequested bool   `json:"response_requested"`
+}
+
+// PingResponse is sent in response to a Ping.
+// https://github.com/markcryptohash/aries-rfcs/tree/master/features/0048-trust-ping#messages
+type PingResponse struct {
+	Type    string            `json:"@type,omitempty"`
+	ID      string            `json:"@id,omitempty"`
+	Thread  *decorator.Thread `json:"~thread,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+}
+
+// PingV2 is sent to test the connection with the other party.
+// https://identity.foundation/didcomm-messaging/spec/#trust-ping-protocol-20
+type PingV2 struct {
+	ID   string     `json:"id,omitempty"`
+	Type string     `json:"type,omitempty"`
+	Body PingV2Body `json:"body"`
+}
+
+// PingV2Body is the body of a PingV2 message.
+type PingV2Body struct {
+	ResponseRequested bool `json:"response_requested"`
+}
+
+// PingResponseV2 is sent in response to a PingV2.
+// https://identity.foundation/didcomm-messaging/spec/#trust-ping-protocol-20
+type PingResponseV2 struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	ThreadID string `json:"thid,omitempty"`
+}
@@ -0,0 +1,364 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/markcryptohash/aries-framework-go/pkg/store/connection"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+const (
+	// TrustPing defines the protocol name.
+	TrustPing = "trustping"
+	// Spec defines the Trust Ping 1.0 protocol spec.
+	Spec = "https://didcomm.org/trust_ping/1.0/"
+	// PingMsgType defines the Trust Ping 1.0 ping message type.
+	PingMsgType = Spec + "ping"
+	// PingResponseMsgType defines the Trust Ping 1.0 ping_response message type.
+	PingResponseMsgType = Spec + "ping_response"
+
+	// SpecV2 defines the Trust Ping 2.0 protocol spec.
+	SpecV2 = "https://didcomm.org/trust-ping/2.0/"
+	// PingMsgTypeV2 defines the Trust Ping 2.0 ping message type.
+	PingMsgTypeV2 = SpecV2 + "ping"
+	// PingResponseMsgTypeV2 defines the Trust Ping 2.0 ping-response message type.
+	PingResponseMsgTypeV2 = SpecV2 + "ping-response"
+)
+
+// DefaultTimeout is the time to wait for a ping response when no timeout is given.
+const DefaultTimeout = 10 * time.Second
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrTimeout is returned when no ping response was received in time.
+	ErrTimeout = errors.New("timeout waiting for ping response")
+
+	logger = log.New("aries-framework/trustping")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	SaveConnectionRecord(*connection.Record) error
+}
+
+// PingParams holds the parameters of a ping.
+type PingParams struct {
+	// Comment is an optional comment sent along with the ping.
+	Comment string
+	// ResponseRequested asks the other party to respond. The ping does not wait for a response otherwise.
+	ResponseRequested bool
+	// Timeout is the time to wait for the response, DefaultTimeout is used if not set.
+	Timeout time.Duration
+}
+
+// PingResult holds the outcome of a ping.
+type PingResult struct {
+	ConnectionID string
+	PingID       string
+	// Responded is true when the ping response was received.
+	Responded bool
+	// Latency is the round-trip time of the ping, it is set only if Responded is true.
+	Latency time.Duration
+}
+
+// Service for the trust ping protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connections   connections
+	outbound      dispatcher.Outbound
+	responseMap   map[string]chan time.Time
+	responseMapMu sync.RWMutex
+	initialized   bool
+}
+
+// New returns the trust ping service.
+func New(prov provider) (*Service, error) {
+	svc := Service{}
+
+	err := svc.Initialize(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+// Initialize initializes the Service. If Initialize succeeds, any further call is a no-op.
+func (s *Service) Initialize(p interface{}) error {
+	if s.initialized {
+		return nil
+	}
+
+	prov, ok := p.(provider)
+	if !ok {
+		return fmt.Errorf("expected provider of type `%T`, got type `%T`", provider(nil), p)
+	}
+
+	connectionRecorder, err := connection.NewRecorder(prov)
+	if err != nil {
+		return fmt.Errorf("create connection recorder: %w", err)
+	}
+
+	s.connections = connectionRecorder
+	s.outbound = prov.OutboundDispatcher()
+	s.responseMap = make(map[string]chan time.Time)
+
+	s.initialized = true
+
+	return nil
+}
+
+// HandleInbound handles inbound trust ping messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	// capture the time before any processing so that it doesn't add up to the measured latency
+	received := time.Now()
+
+	// perform action asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case PingMsgType:
+			err = s.handlePing(msg, ctx.MyDID(), ctx.TheirDID())
+		case PingMsgTypeV2:
+			err = s.handlePingV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case PingResponseMsgType, PingResponseMsgTypeV2:
+			s.handlePingResponse(msg, received)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%w)\n", err)
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case PingMsgType, PingResponseMsgType, PingMsgTypeV2, PingResponseMsgTypeV2:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return TrustPing
+}
+
+// Ping sends a trust ping over the given connection. If a response is requested, Ping waits for it and
+// records the round-trip latency on the connection record.
+func (s *Service) Ping(connectionID string, params *PingParams) (*PingResult, error) {
+	if params == nil {
+		params = &PingParams{ResponseRequested: true}
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+	ping := newPing(msgID, conn.DIDCommVersion, params)
+	result := &PingResult{ConnectionID: connectionID, PingID: msgID}
+
+	if !params.ResponseRequested {
+		if err = s.outbound.SendToDID(ping, conn.MyDID, conn.TheirDID); err != nil {
+			return nil, fmt.Errorf("send ping: %w", err)
+		}
+
+		return result, nil
+	}
+
+	// register chan for callback processing, buffered so that a late response never blocks the handler
+	responseCh := make(chan time.Time, 1)
+	s.setResponseCh(msgID, responseCh)
+
+	defer s.setResponseCh(msgID, nil)
+
+	sent := time.Now()
+
+	if err = s.outbound.SendToDID(ping, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send ping: %w", err)
+	}
+
+	timeout := params.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	select {
+	case received := <-responseCh:
+		result.Responded = true
+		result.Latency = received.Sub(sent)
+	case <-time.After(timeout):
+		return nil, ErrTimeout
+	}
+
+	if err = s.saveLastPing(connectionID, &connection.PingRecord{Time: sent, Latency: result.Latency}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func newPing(msgID string, version service.Version, params *PingParams) service.DIDCommMsgMap {
+	if version == service.V2 {
+		return service.NewDIDCommMsgMap(&PingV2{
+			ID:   msgID,
+			Type: PingMsgTypeV2,
+			Body: PingV2Body{ResponseRequested: params.ResponseRequested},
+		})
+	}
+
+	return service.NewDIDCommMsgMap(&Ping{
+		Type:              PingMsgType,
+		ID:                msgID,
+		Comment:           params.Comment,
+		ResponseRequested: params.ResponseRequested,
+	})
+}
+
+func (s *Service) handlePing(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// response is requested unless explicitly disabled
+	ping := &Ping{ResponseRequested: true}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return fmt.Errorf("ping message unmarshal: %w", err)
+	}
+
+	if !ping.ResponseRequested {
+		return nil
+	}
+
+	resp := &PingResponse{
+		Type:   PingResponseMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: msg.ID()},
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(resp), myDID, theirDID)
+}
+
+func (s *Service) handlePingV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// response is requested unless explicitly disabled
+	ping := &PingV2{Body: PingV2Body{ResponseRequested: true}}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return fmt.Errorf("ping message unmarshal: %w", err)
+	}
+
+	if !ping.Body.ResponseRequested {
+		return nil
+	}
+
+	resp := &PingResponseV2{
+		ID:       uuid.New().String(),
+		Type:     PingResponseMsgTypeV2,
+		ThreadID: msg.ID(),
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(resp), myDID, theirDID)
+}
+
+func (s *Service) handlePingResponse(msg service.DIDCommMsg, received time.Time) {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		logger.Warnf("ping response without thread ID: %s", err)
+
+		return
+	}
+
+	responseCh := s.getResponseCh(thID)
+	if responseCh == nil {
+		logger.Debugf("no ping is waiting for response with thread ID %s", thID)
+
+		return
+	}
+
+	select {
+	case responseCh <- received:
+	default:
+		// duplicate response
+	}
+}
+
+func (s *Service) saveLastPing(connectionID string, ping *connection.PingRecord) error {
+	// re-read the record, it might have been updated while waiting for the response
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	conn.LastPing = ping
+
+	if err = s.connections.SaveConnectionRecord(conn); err != nil {
+		return fmt.Errorf("save ping result on connection: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) getResponseCh(msgID string) chan time.Time {
+	s.responseMapMu.RLock()
+	defer s.responseMapMu.RUnlock()
+
+	return s.responseMap[msgID]
+}
+
+func (s *Service) setResponseCh(msgID string, responseCh chan time.Time) {
+	s.responseMapMu.Lock()
+	defer s.responseMapMu.Unlock()
+
+	if responseCh == nil {
+		delete(s.responseMap, msgID)
+	} else {
+		s.responseMap[msgID] = responseCh
+	}
+}
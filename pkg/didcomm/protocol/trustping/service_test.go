@@ -0,0 +1,290 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	mockdispatcher "github.com/markcryptohash/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/markcryptohash/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	"github.com/markcryptohash/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+	connID   = "conn"
+)
+
+func TestService_Initialize(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		prov := newProvider(nil)
+
+		svc := Service{}
+
+		err := svc.Initialize(prov)
+		require.NoError(t, err)
+		require.Equal(t, TrustPing, svc.Name())
+
+		// second init is no-op
+		err = svc.Initialize(prov)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure, not given a valid provider", func(t *testing.T) {
+		svc := Service{}
+
+		err := svc.Initialize("not a provider")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected provider of type")
+	})
+
+	t.Run("failure, store error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("store error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create connection recorder")
+		require.Nil(t, svc)
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc, err := New(newProvider(nil))
+	require.NoError(t, err)
+
+	require.True(t, svc.Accept(PingMsgType))
+	require.True(t, svc.Accept(PingResponseMsgType))
+	require.True(t, svc.Accept(PingMsgTypeV2))
+	require.True(t, svc.Accept(PingResponseMsgTypeV2))
+	require.False(t, svc.Accept("unsupported msg type"))
+
+	_, err = svc.HandleOutbound(nil, "", "")
+	require.EqualError(t, err, "not implemented")
+}
+
+func TestService_Ping(t *testing.T) {
+	for _, version := range []service.Version{service.V1, service.V2} {
+		version := version
+
+		t.Run("ping and wait for response - DIDComm "+string(version), func(t *testing.T) {
+			pinger, responder := newServicePair(t)
+			saveConnection(t, pinger.prov, version)
+
+			result, err := pinger.svc.Ping(connID, &PingParams{ResponseRequested: true, Timeout: time.Second})
+			require.NoError(t, err)
+			require.True(t, result.Responded)
+			require.Equal(t, connID, result.ConnectionID)
+			require.NotEmpty(t, result.PingID)
+			require.True(t, result.Latency > 0)
+
+			require.Equal(t, result.PingID, responder.lastReceived.ID())
+
+			conn, err := pinger.svc.connections.GetConnectionRecord(connID)
+			require.NoError(t, err)
+			require.NotNil(t, conn.LastPing)
+			require.Equal(t, result.Latency, conn.LastPing.Latency)
+		})
+	}
+
+	t.Run("ping without response requested", func(t *testing.T) {
+		pinger, responder := newServicePair(t)
+		saveConnection(t, pinger.prov, service.V1)
+
+		result, err := pinger.svc.Ping(connID, &PingParams{ResponseRequested: false, Comment: "hi"})
+		require.NoError(t, err)
+		require.False(t, result.Responded)
+		require.Zero(t, result.Latency)
+
+		require.Eventually(t, func() bool { return responder.received() == 1 }, time.Second, 10*time.Millisecond)
+		require.Equal(t, 0, pinger.received())
+
+		conn, err := pinger.svc.connections.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.Nil(t, conn.LastPing)
+	})
+
+	t.Run("default params request a response", func(t *testing.T) {
+		pinger, _ := newServicePair(t)
+		saveConnection(t, pinger.prov, service.V1)
+
+		result, err := pinger.svc.Ping(connID, nil)
+		require.NoError(t, err)
+		require.True(t, result.Responded)
+	})
+
+	t.Run("timeout waiting for response", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{})
+		saveConnection(t, prov, service.V1)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Ping(connID, &PingParams{ResponseRequested: true, Timeout: 10 * time.Millisecond})
+		require.ErrorIs(t, err, ErrTimeout)
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		_, err = svc.Ping(connID, nil)
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		saveConnection(t, prov, service.V1)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Ping(connID, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send ping: send error")
+
+		_, err = svc.Ping(connID, &PingParams{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send ping: send error")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("ping with response_requested omitted is responded", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		ping := service.DIDCommMsgMap{"@id": "ping-id", "@type": PingMsgType}
+
+		_, err = svc.HandleInbound(ping, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case resp := <-sent:
+			require.Equal(t, PingResponseMsgType, resp.Type())
+
+			thID, err := resp.ThreadID()
+			require.NoError(t, err)
+			require.Equal(t, "ping-id", thID)
+		case <-time.After(time.Second):
+			require.Fail(t, "ping response was not sent")
+		}
+	})
+
+	t.Run("ping response without waiting ping is ignored", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		resp := service.NewDIDCommMsgMap(&PingResponseV2{ID: "id", Type: PingResponseMsgTypeV2, ThreadID: "unknown"})
+
+		_, err = svc.HandleInbound(resp, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("duplicate ping response doesn't block", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		responseCh := make(chan time.Time, 1)
+		svc.setResponseCh("ping-id", responseCh)
+
+		resp := service.NewDIDCommMsgMap(&PingResponseV2{ID: "id", Type: PingResponseMsgTypeV2, ThreadID: "ping-id"})
+
+		svc.handlePingResponse(resp, time.Now())
+		svc.handlePingResponse(resp, time.Now())
+
+		require.Len(t, responseCh, 1)
+	})
+}
+
+type testAgent struct {
+	prov *mockprovider.Provider
+	svc  *Service
+	msgs chan service.DIDCommMsgMap
+
+	lastReceived service.DIDCommMsgMap
+}
+
+func (a *testAgent) received() int {
+	return len(a.msgs)
+}
+
+// newServicePair creates two services which deliver outbound messages to each other.
+func newServicePair(t *testing.T) (*testAgent, *testAgent) {
+	t.Helper()
+
+	pinger := &testAgent{msgs: make(chan service.DIDCommMsgMap, 10)}
+	responder := &testAgent{msgs: make(chan service.DIDCommMsgMap, 10)}
+
+	deliver := func(to *testAgent) *mockdispatcher.MockOutbound {
+		return &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				msgMap := msg.(service.DIDCommMsgMap)
+				to.lastReceived = msgMap
+				to.msgs <- msgMap
+
+				_, err := to.svc.HandleInbound(msgMap, service.NewDIDCommContext(theirDID, myDID, nil))
+
+				return err
+			},
+		}
+	}
+
+	var err error
+
+	pinger.prov = newProvider(deliver(responder))
+	pinger.svc, err = New(pinger.prov)
+	require.NoError(t, err)
+
+	responder.prov = newProvider(deliver(pinger))
+	responder.svc, err = New(responder.prov)
+	require.NoError(t, err)
+
+	return pinger, responder
+}
+
+func newProvider(outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+}
+
+func saveConnection(t *testing.T, prov *mockprovider.Provider, version service.Version) {
+	t.Helper()
+
+	r, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+		ConnectionID:   connID,
+		MyDID:          MYDID,
+		TheirDID:       THEIRDID,
+		State:          connection.StateNameCompleted,
+		DIDCommVersion: version,
+	}))
+}
@@ -175,9 +175,10 @@ type Context interface{}
 
 // DocResolution did resolution.
 type DocResolution struct {
-	Context          Context
-	DIDDocument      *Doc
-	DocumentMetadata *DocumentMetadata
+	Context            Context
+	DIDDocument        *Doc
+	DocumentMetadata   *DocumentMetadata
+	ResolutionMetadata *ResolutionMetadata
 }
 
 // MethodMetadata method metadata.
@@ -230,14 +231,38 @@ type DocumentMetadata struct {
 	Method *MethodMetadata `json:"method,omitempty"`
 }
 
+// ResolutionMetadata did resolution metadata.
+type ResolutionMetadata struct {
+	// Cached is true if the DID document was served from the resolution cache.
+	Cached bool `json:"cached,omitempty"`
+	// CachedAt is the time the cached DID document was read from the DID method.
+	CachedAt *time.Time `json:"cachedAt,omitempty"`
+}
+
 type rawDocResolution struct {
-	Context          Context         `json:"@context"`
-	DIDDocument      json.RawMessage `json:"didDocument,omitempty"`
-	DocumentMetadata json.RawMessage `json:"didDocumentMetadata,omitempty"`
+	Context            Context         `json:"@context"`
+	DIDDocument        json.RawMessage `json:"didDocument,omitempty"`
+	DocumentMetadata   json.RawMessage `json:"didDocumentMetadata,omitempty"`
+	ResolutionMetadata json.RawMessage `json:"didResolutionMetadata,omitempty"`
+}
+
+// ParseOption is an option of DID document parsing.
+type ParseOption func(opts *parseOpts)
+
+type parseOpts struct {
+	skipValidation bool
+}
+
+// WithoutValidation parses the DID document without validating it against the DID document schema, e.g. to read
+// back documents that were already accepted.
+func WithoutValidation() ParseOption {
+	return func(opts *parseOpts) {
+		opts.skipValidation = true
+	}
 }
 
 // ParseDocumentResolution parse document resolution.
-func ParseDocumentResolution(data []byte) (*DocResolution, error) {
+func ParseDocumentResolution(data []byte, opts ...ParseOption) (*DocResolution, error) {
 	raw := &rawDocResolution{}
 
 	if err := json.Unmarshal(data, raw); err != nil {
@@ -248,7 +273,7 @@ func ParseDocumentResolution(data []byte) (*DocResolution, error) {
 		return nil, ErrDIDDocumentNotExist
 	}
 
-	doc, err := ParseDocument(raw.DIDDocument)
+	doc, err := ParseDocument(raw.DIDDocument, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -261,9 +286,24 @@ func ParseDocumentResolution(data []byte) (*DocResolution, error) {
 		}
 	}
 
+	var resolutionMeta *ResolutionMetadata
+
+	if len(raw.ResolutionMetadata) != 0 {
+		resolutionMeta = &ResolutionMetadata{}
+
+		if err := json.Unmarshal(raw.ResolutionMetadata, resolutionMeta); err != nil {
+			return nil, err
+		}
+	}
+
 	context, _ := parseContext(raw.Context)
 
-	return &DocResolution{Context: context, DIDDocument: doc, DocumentMetadata: docMeta}, nil
+	return &DocResolution{
+		Context:            context,
+		DIDDocument:        doc,
+		DocumentMetadata:   docMeta,
+		ResolutionMetadata: resolutionMeta,
+	}, nil
 }
 
 // Doc DID Document definition.
@@ -475,7 +515,13 @@ func (doc *Doc) UnmarshalJSON(data []byte) error {
 }
 
 // ParseDocument creates an instance of DIDDocument by reading a JSON document from bytes.
-func ParseDocument(data []byte) (*Doc, error) { // nolint:funlen,gocyclo
+func ParseDocument(data []byte, opts ...ParseOption) (*Doc, error) { // nolint:funlen,gocyclo
+	pOpts := &parseOpts{}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
 	raw := &rawDoc{}
 
 	err := json.Unmarshal(data, &raw)
@@ -494,7 +540,7 @@ func ParseDocument(data []byte) (*Doc, error) { // nolint:funlen,gocyclo
 
 	if (doACAPYInterop || serviceType == legacyServiceType) && requiresLegacyHandling(raw) {
 		raw.Context = []string{contextV011}
-	} else {
+	} else if !pOpts.skipValidation {
 		// validate did document
 		err = validate(data, raw.schemaLoader())
 		if err != nil {
@@ -1110,6 +1156,13 @@ func (docResolution *DocResolution) JSONBytes() ([]byte, error) {
 		DocumentMetadata: documentMetadataBytes,
 	}
 
+	if docResolution.ResolutionMetadata != nil {
+		raw.ResolutionMetadata, err = json.Marshal(docResolution.ResolutionMetadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	byteDoc, err := json.Marshal(raw)
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling of document failed: %w", err)
@@ -198,6 +198,24 @@ func TestDocResolution(t *testing.T) {
 		require.Equal(t, "did:example:123", d.DIDDocument.AlsoKnownAs[0])
 		require.Equal(t, true, d.DocumentMetadata.Method.Published)
 		require.Equal(t, "did:ex:123333", d.DocumentMetadata.CanonicalID)
+		require.Nil(t, d.ResolutionMetadata)
+	})
+
+	t.Run("test doc resolution with resolution metadata", func(t *testing.T) {
+		d, err := ParseDocumentResolution([]byte(validDocResolution))
+		require.NoError(t, err)
+
+		cachedAt := time.Now().UTC().Truncate(time.Second)
+		d.ResolutionMetadata = &ResolutionMetadata{Cached: true, CachedAt: &cachedAt}
+
+		bytes, err := d.JSONBytes()
+		require.NoError(t, err)
+
+		d, err = ParseDocumentResolution(bytes)
+		require.NoError(t, err)
+		require.NotNil(t, d.ResolutionMetadata)
+		require.True(t, d.ResolutionMetadata.Cached)
+		require.True(t, cachedAt.Equal(*d.ResolutionMetadata.CachedAt))
 	})
 
 	t.Run("test did doc not exists", func(t *testing.T) {
@@ -444,6 +462,14 @@ func TestInvalid(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, doc)
 	require.Contains(t, err.Error(), "did document not valid")
+
+	doc, err = ParseDocument([]byte(`{"id":"did:example:123"}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "@context is required")
+
+	doc, err = ParseDocument([]byte(`{"id":"did:example:123"}`), WithoutValidation())
+	require.NoError(t, err)
+	require.Equal(t, "did:example:123", doc.ID)
 }
 
 func TestValidWithProof(t *testing.T) {
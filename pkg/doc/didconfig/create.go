@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	diddoc "github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	vcContextV1 = "https://www.w3.org/2018/credentials/v1"
+	vcType      = "VerifiableCredential"
+
+	originProperty = "origin"
+)
+
+// CreateDomainLinkageCredential creates an unsigned Domain Linkage Credential linking the DID with the origin
+// (https://identity.foundation/.well-known/resources/did-configuration/#domain-linkage-credential).
+// The credential is signed either by adding a linked data proof (JSON-LD form) or using
+// CreateDomainLinkageCredentialJWT (JWT form).
+func CreateDomainLinkageCredential(did, origin string, issued, expires time.Time) (*verifiable.Credential, error) {
+	_, err := diddoc.Parse(did)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DID: %w", err)
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid origin: %w", err)
+	}
+
+	if originURL.Scheme == "" || originURL.Host == "" {
+		return nil, fmt.Errorf("origin[%s] must have scheme and host", origin)
+	}
+
+	if !expires.After(issued) {
+		return nil, errors.New("expiration date must be after issuance date")
+	}
+
+	return &verifiable.Credential{
+		Context: []string{vcContextV1, ContextV1},
+		Types:   []string{vcType, domainLinkageCredentialType},
+		Issuer:  verifiable.Issuer{ID: did},
+		Issued:  util.NewTime(issued.UTC()),
+		Expired: util.NewTime(expires.UTC()),
+		Subject: []verifiable.Subject{{
+			ID:           did,
+			CustomFields: verifiable.CustomFields{originProperty: originURL.Scheme + "://" + originURL.Host},
+		}},
+	}, nil
+}
+
+// CreateDomainLinkageCredentialJWT signs the Domain Linkage Credential in the JWT form. Key ID is the
+// verification method of the DID used to verify the JWT.
+func CreateDomainLinkageCredentialJWT(vc *verifiable.Credential, alg verifiable.JWSAlgorithm,
+	signer verifiable.Signer, keyID string) (string, error) {
+	claims, err := vc.JWTClaims(false)
+	if err != nil {
+		return "", fmt.Errorf("create JWT claims of domain linkage credential: %w", err)
+	}
+
+	jws, err := claims.MarshalJWS(alg, signer, keyID)
+	if err != nil {
+		return "", fmt.Errorf("sign domain linkage credential JWT: %w", err)
+	}
+
+	return jws, nil
+}
+
+// CreateDIDConfiguration assembles the DID Configuration resource served at /.well-known/did-configuration.json
+// from the signed Domain Linkage Credentials of one or more DIDs. A linked DID is either a JWT (string) or
+// a credential in the JSON-LD form (*verifiable.Credential or its JSON).
+func CreateDIDConfiguration(linkedDIDs ...interface{}) ([]byte, error) {
+	if len(linkedDIDs) == 0 {
+		return nil, errors.New("at least one domain linkage credential is required")
+	}
+
+	raw := rawDoc{
+		Context:    ContextV1,
+		LinkedDIDs: make([]interface{}, len(linkedDIDs)),
+	}
+
+	for i, linkedDID := range linkedDIDs {
+		switch linkedDID := linkedDID.(type) {
+		case string: // JWT
+			raw.LinkedDIDs[i] = linkedDID
+		case *verifiable.Credential:
+			vcBytes, err := linkedDID.MarshalJSON()
+			if err != nil {
+				return nil, fmt.Errorf("marshal domain linkage credential: %w", err)
+			}
+
+			raw.LinkedDIDs[i] = json.RawMessage(vcBytes)
+		case json.RawMessage: // Linked Data
+			raw.LinkedDIDs[i] = linkedDID
+		case []byte: // Linked Data
+			raw.LinkedDIDs[i] = json.RawMessage(linkedDID)
+		default:
+			return nil, fmt.Errorf("unexpected interface[%T] for linked DID", linkedDID)
+		}
+	}
+
+	didConfig, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("marshal DID configuration: %w", err)
+	}
+
+	return didConfig, nil
+}
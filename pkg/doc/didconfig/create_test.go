@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util/signature"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/verifiable"
+	"github.com/markcryptohash/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/markcryptohash/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestCreateDomainLinkageCredential(t *testing.T) {
+	issued := time.Now()
+	expires := issued.Add(time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		vc, err := CreateDomainLinkageCredential(testDID, testDomain+"/path", issued, expires)
+		require.NoError(t, err)
+		require.Equal(t, testDID, vc.Issuer.ID)
+		require.NoError(t, isValidDomainLinkageCredential(vc, testDID, testDomain))
+	})
+
+	t.Run("error - invalid DID", func(t *testing.T) {
+		vc, err := CreateDomainLinkageCredential("not a DID", testDomain, issued, expires)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid DID")
+		require.Nil(t, vc)
+	})
+
+	t.Run("error - invalid origin", func(t *testing.T) {
+		vc, err := CreateDomainLinkageCredential(testDID, "identity.foundation", issued, expires)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "must have scheme and host")
+		require.Nil(t, vc)
+
+		vc, err = CreateDomainLinkageCredential(testDID, "://", issued, expires)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid origin")
+		require.Nil(t, vc)
+	})
+
+	t.Run("error - expiration date before issuance date", func(t *testing.T) {
+		vc, err := CreateDomainLinkageCredential(testDID, testDomain, issued, issued)
+		require.EqualError(t, err, "expiration date must be after issuance date")
+		require.Nil(t, vc)
+	})
+}
+
+func TestCreateDIDConfiguration(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     ContextV1,
+		Content: json.RawMessage(didConfigCtx),
+	})
+	require.NoError(t, err)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	did, keyID := fingerprint.CreateDIDKey(pubKey)
+	signer := signature.GetEd25519Signer(privKey, pubKey)
+
+	issued := time.Now()
+	expires := issued.Add(time.Hour)
+
+	t.Run("success - JWT", func(t *testing.T) {
+		vc, err := CreateDomainLinkageCredential(did, testDomain, issued, expires)
+		require.NoError(t, err)
+
+		jwt, err := CreateDomainLinkageCredentialJWT(vc, verifiable.EdDSA, signer, keyID)
+		require.NoError(t, err)
+
+		didConfig, err := CreateDIDConfiguration(jwt)
+		require.NoError(t, err)
+
+		require.NoError(t, VerifyDIDAndDomain(didConfig, did, testDomain, WithJSONLDDocumentLoader(loader)))
+	})
+
+	t.Run("success - JSON-LD", func(t *testing.T) {
+		vc, err := CreateDomainLinkageCredential(did, testDomain, issued, expires)
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: verifiable.SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      keyID,
+			Purpose:                 "assertionMethod",
+		}, jsonld.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		didConfig, err := CreateDIDConfiguration(vc)
+		require.NoError(t, err)
+
+		require.NoError(t, VerifyDIDAndDomain(didConfig, did, testDomain, WithJSONLDDocumentLoader(loader)))
+	})
+
+	t.Run("success - multiple DIDs", func(t *testing.T) {
+		pubKey2, privKey2, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		did2, keyID2 := fingerprint.CreateDIDKey(pubKey2)
+
+		var linkedDIDs []interface{}
+
+		for _, k := range []struct {
+			did, keyID string
+			signer     verifiable.Signer
+		}{
+			{did: did, keyID: keyID, signer: signer},
+			{did: did2, keyID: keyID2, signer: signature.GetEd25519Signer(privKey2, pubKey2)},
+		} {
+			vc, err := CreateDomainLinkageCredential(k.did, testDomain, issued, expires)
+			require.NoError(t, err)
+
+			jwt, err := CreateDomainLinkageCredentialJWT(vc, verifiable.EdDSA, k.signer, k.keyID)
+			require.NoError(t, err)
+
+			linkedDIDs = append(linkedDIDs, jwt)
+		}
+
+		linkedDIDs = append(linkedDIDs, json.RawMessage(`{"some":"credential"}`), []byte(`{"other":"credential"}`))
+
+		didConfig, err := CreateDIDConfiguration(linkedDIDs...)
+		require.NoError(t, err)
+
+		raw := rawDoc{}
+		require.NoError(t, json.Unmarshal(didConfig, &raw))
+		require.Equal(t, ContextV1, raw.Context)
+		require.Len(t, raw.LinkedDIDs, 4)
+
+		require.NoError(t, VerifyDIDAndDomain(didConfig, did, testDomain, WithJSONLDDocumentLoader(loader)))
+		require.NoError(t, VerifyDIDAndDomain(didConfig, did2, testDomain, WithJSONLDDocumentLoader(loader)))
+	})
+
+	t.Run("error - no linked DIDs", func(t *testing.T) {
+		didConfig, err := CreateDIDConfiguration()
+		require.EqualError(t, err, "at least one domain linkage credential is required")
+		require.Nil(t, didConfig)
+	})
+
+	t.Run("error - unexpected linked DID", func(t *testing.T) {
+		didConfig, err := CreateDIDConfiguration(1)
+		require.EqualError(t, err, "unexpected interface[int] for linked DID")
+		require.Nil(t, didConfig)
+	})
+}
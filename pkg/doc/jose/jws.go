@@ -30,6 +30,39 @@ type JSONWebSignature struct {
 
 	signature   []byte
 	joseHeaders Headers
+
+	// signatures are set for JWS with multiple signatures or parsed from JWS JSON Serialization.
+	signatures []*JWSSignature
+}
+
+// JWSSignature defines a single signature of JWS JSON Serialization (https://tools.ietf.org/html/rfc7515#section-7.2).
+type JWSSignature struct {
+	ProtectedHeaders   Headers
+	UnprotectedHeaders Headers
+	Signature          []byte
+
+	// VerifyErr is the verification result of the parsed signature, nil if the signature is valid.
+	VerifyErr error
+
+	// protected holds base64 encoded protected headers as they were parsed.
+	protected string
+}
+
+// rawJSONWebSignature defines General and Flattened JWS JSON Serialization.
+type rawJSONWebSignature struct {
+	Payload    string            `json:"payload,omitempty"`
+	Signatures []rawJWSSignature `json:"signatures,omitempty"`
+
+	// Flattened JWS JSON Serialization signature.
+	Protected string  `json:"protected,omitempty"`
+	Header    Headers `json:"header,omitempty"`
+	Signature string  `json:"signature,omitempty"`
+}
+
+type rawJWSSignature struct {
+	Protected string  `json:"protected,omitempty"`
+	Header    Headers `json:"header,omitempty"`
+	Signature string  `json:"signature"`
 }
 
 // SignatureVerifier makes verification of JSON Web Signature.
@@ -110,6 +143,12 @@ type Signer interface {
 	Headers() Headers
 }
 
+// JSONSigner is a Signer of JWS JSON Serialization with its per-signature unprotected headers.
+type JSONSigner struct {
+	Signer
+	UnprotectedHeaders Headers
+}
+
 // NewJWS creates JSON Web Signature.
 func NewJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte, signer Signer) (*JSONWebSignature, error) {
 	headers := mergeHeaders(protectedHeaders, signer.Headers())
@@ -130,8 +169,69 @@ func NewJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte, signer
 	return jws, nil
 }
 
+// NewJWSWithSigners creates JSON Web Signature signed by each of the signers. Protected headers are shared
+// by all the signatures, headers of each signer are added to the protected headers of its signature.
+func NewJWSWithSigners(protectedHeaders Headers, payload []byte, signers ...JSONSigner) (*JSONWebSignature, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no JWS signers")
+	}
+
+	signatures := make([]*JWSSignature, len(signers))
+
+	for i, signer := range signers {
+		signature, err := newJWSSignature(protectedHeaders, payload, signer)
+		if err != nil {
+			return nil, fmt.Errorf("sign JWS with signer %d: %w", i, err)
+		}
+
+		if i > 0 && !sameB64Header(signatures[0].ProtectedHeaders, signature.ProtectedHeaders) {
+			return nil, fmt.Errorf("%s JWS header must be the same for all signatures", HeaderB64Payload)
+		}
+
+		signatures[i] = signature
+	}
+
+	return newJWSFromSignatures(payload, signatures, signatures[0]), nil
+}
+
+func newJWSSignature(protectedHeaders Headers, payload []byte, signer JSONSigner) (*JWSSignature, error) {
+	headers := mergeHeaders(protectedHeaders, signer.Headers())
+
+	err := checkDisjointHeaders(headers, signer.UnprotectedHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := sign(headers, payload, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWSSignature{
+		ProtectedHeaders:   headers,
+		UnprotectedHeaders: signer.UnprotectedHeaders,
+		Signature:          signature,
+	}, nil
+}
+
+// newJWSFromSignatures creates JWS with the given signatures, single signature fields are taken from the primary one.
+func newJWSFromSignatures(payload []byte, signatures []*JWSSignature, primary *JWSSignature) *JSONWebSignature {
+	return &JSONWebSignature{
+		ProtectedHeaders:   primary.ProtectedHeaders,
+		UnprotectedHeaders: primary.UnprotectedHeaders,
+		Payload:            payload,
+		signature:          primary.Signature,
+		joseHeaders:        mergeHeaders(primary.ProtectedHeaders, primary.UnprotectedHeaders),
+		signatures:         signatures,
+	}
+}
+
 // SerializeCompact makes JWS Compact Serialization (https://tools.ietf.org/html/rfc7515#section-7.1)
 func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
+	if len(s.signatures) > 1 {
+		return "", errors.New("JWS Compact Serialization supports a single signature only")
+	}
+
 	byteHeaders, err := json.Marshal(s.joseHeaders)
 	if err != nil {
 		return "", fmt.Errorf("marshal JWS JOSE Headers: %w", err)
@@ -152,6 +252,102 @@ func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 		b64Signature), nil
 }
 
+// SerializeJSON makes General JWS JSON Serialization (https://tools.ietf.org/html/rfc7515#section-7.2.1)
+func (s JSONWebSignature) SerializeJSON(detached bool) (string, error) {
+	signatures := s.Signatures()
+
+	rawJWS := &rawJSONWebSignature{
+		Signatures: make([]rawJWSSignature, len(signatures)),
+	}
+
+	for i, signature := range signatures {
+		rawSignature, err := signature.raw()
+		if err != nil {
+			return "", err
+		}
+
+		rawJWS.Signatures[i] = *rawSignature
+	}
+
+	return s.serializeJSON(rawJWS, signatures[0], detached)
+}
+
+// SerializeFlattened makes Flattened JWS JSON Serialization (https://tools.ietf.org/html/rfc7515#section-7.2.2)
+func (s JSONWebSignature) SerializeFlattened(detached bool) (string, error) {
+	signatures := s.Signatures()
+	if len(signatures) > 1 {
+		return "", errors.New("flattened JWS JSON Serialization supports a single signature only")
+	}
+
+	rawSignature, err := signatures[0].raw()
+	if err != nil {
+		return "", err
+	}
+
+	rawJWS := &rawJSONWebSignature{
+		Protected: rawSignature.Protected,
+		Header:    rawSignature.Header,
+		Signature: rawSignature.Signature,
+	}
+
+	return s.serializeJSON(rawJWS, signatures[0], detached)
+}
+
+func (s JSONWebSignature) serializeJSON(rawJWS *rawJSONWebSignature, signature *JWSSignature,
+	detached bool) (string, error) {
+	if !detached {
+		payload, err := encodePayload(signature.ProtectedHeaders, s.Payload)
+		if err != nil {
+			return "", err
+		}
+
+		rawJWS.Payload = payload
+	}
+
+	jwsBytes, err := json.Marshal(rawJWS)
+	if err != nil {
+		return "", fmt.Errorf("marshal JWS JSON: %w", err)
+	}
+
+	return string(jwsBytes), nil
+}
+
+func (s *JWSSignature) raw() (*rawJWSSignature, error) {
+	protected := s.protected
+
+	if protected == "" {
+		headersBytes, err := json.Marshal(s.ProtectedHeaders)
+		if err != nil {
+			return nil, fmt.Errorf("marshal JWS protected headers: %w", err)
+		}
+
+		protected = base64.RawURLEncoding.EncodeToString(headersBytes)
+	}
+
+	return &rawJWSSignature{
+		Protected: protected,
+		Header:    s.UnprotectedHeaders,
+		Signature: base64.RawURLEncoding.EncodeToString(s.Signature),
+	}, nil
+}
+
+// Signatures returns the signatures of JWS. JWS created with a single signer or parsed from JWS Compact
+// Serialization has one signature.
+func (s JSONWebSignature) Signatures() []*JWSSignature {
+	if len(s.signatures) > 0 {
+		signatures := make([]*JWSSignature, len(s.signatures))
+		copy(signatures, s.signatures)
+
+		return signatures
+	}
+
+	return []*JWSSignature{{
+		ProtectedHeaders:   s.ProtectedHeaders,
+		UnprotectedHeaders: s.UnprotectedHeaders,
+		Signature:          s.Signature(),
+	}}
+}
+
 // Signature returns a copy of JWS signature.
 func (s JSONWebSignature) Signature() []byte {
 	if s.signature == nil {
@@ -178,6 +374,25 @@ func mergeHeaders(h1, h2 Headers) Headers {
 	return h
 }
 
+// checkDisjointHeaders checks that protected and unprotected headers have no common header parameters
+// (https://tools.ietf.org/html/rfc7515#section-7.2.1).
+func checkDisjointHeaders(protected, unprotected Headers) error {
+	for k := range unprotected {
+		if _, ok := protected[k]; ok {
+			return fmt.Errorf("%s JWS header is both protected and unprotected", k)
+		}
+	}
+
+	return nil
+}
+
+func sameB64Header(h1, h2 Headers) bool {
+	b64H1, errH1 := isB64Payload(h1)
+	b64H2, errH2 := isB64Payload(h2)
+
+	return errH1 == nil && errH2 == nil && b64H1 == b64H2
+}
+
 func sign(joseHeaders Headers, payload []byte, signer Signer) ([]byte, error) {
 	err := checkJWSHeaders(joseHeaders)
 	if err != nil {
@@ -200,6 +415,7 @@ func sign(joseHeaders Headers, payload []byte, signer Signer) ([]byte, error) {
 // jwsParseOpts holds options for the JWS Parsing.
 type jwsParseOpts struct {
 	detachedPayload []byte
+	anySignature    bool
 }
 
 // JWSParseOpt is the JWS Parser option.
@@ -212,7 +428,16 @@ func WithJWSDetachedPayload(payload []byte) JWSParseOpt {
 	}
 }
 
-// ParseJWS parses serialized JWS. Currently only JWS Compact Serialization parsing is supported.
+// WithJWSAnySignature option accepts JWS JSON if at least one of its signatures is valid, all the signatures must be
+// valid by default. The verification results of the signatures are available from JSONWebSignature.Signatures().
+func WithJWSAnySignature() JWSParseOpt {
+	return func(opts *jwsParseOpts) {
+		opts.anySignature = true
+	}
+}
+
+// ParseJWS parses serialized JWS of Compact, General JSON or Flattened JSON Serialization.
+// JWS JSON is parsed if all of its signatures are valid, or at least one of them with WithJWSAnySignature.
 func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSONWebSignature, error) {
 	pOpts := &jwsParseOpts{}
 
@@ -221,9 +446,7 @@ func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSO
 	}
 
 	if strings.HasPrefix(jws, "{") {
-		// TODO support JWS JSON serialization format
-		//  https://github.com/markcryptohash/aries-framework-go/issues/1331
-		return nil, errors.New("JWS JSON serialization is not supported")
+		return parseJSON(jws, verifier, pOpts)
 	}
 
 	return parseCompacted(jws, verifier, pOpts)
@@ -275,6 +498,153 @@ func parseCompacted(jwsCompact string, verifier SignatureVerifier, opts *jwsPars
 	}, nil
 }
 
+func parseJSON(jwsJSON string, verifier SignatureVerifier, opts *jwsParseOpts) (*JSONWebSignature, error) {
+	rawJWS := &rawJSONWebSignature{}
+
+	err := json.Unmarshal([]byte(jwsJSON), rawJWS)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal JWS JSON: %w", err)
+	}
+
+	rawSignatures := rawJWS.Signatures
+
+	if rawJWS.Signature != "" {
+		if len(rawSignatures) > 0 {
+			return nil, errors.New("JWS JSON has both general and flattened signatures")
+		}
+
+		rawSignatures = []rawJWSSignature{{
+			Protected: rawJWS.Protected,
+			Header:    rawJWS.Header,
+			Signature: rawJWS.Signature,
+		}}
+	}
+
+	if len(rawSignatures) == 0 {
+		return nil, errors.New("JWS JSON has no signatures")
+	}
+
+	signatures := make([]*JWSSignature, len(rawSignatures))
+	payloads := make([][]byte, len(rawSignatures))
+
+	for i := range rawSignatures {
+		signatures[i], payloads[i], err = parseJSONSignature(&rawSignatures[i], rawJWS.Payload, verifier, opts)
+		if err != nil {
+			return nil, fmt.Errorf("parse JWS signature %d: %w", i, err)
+		}
+	}
+
+	if !opts.anySignature && len(signatures) > 1 {
+		for i, signature := range signatures {
+			if signature.VerifyErr != nil {
+				return nil, fmt.Errorf("JWS signature %d is invalid: %w", i, signature.VerifyErr)
+			}
+		}
+	}
+
+	for i, signature := range signatures {
+		if signature.VerifyErr == nil {
+			return newJWSFromSignatures(payloads[i], signatures, signature), nil
+		}
+	}
+
+	if len(signatures) == 1 {
+		return nil, signatures[0].VerifyErr
+	}
+
+	return nil, fmt.Errorf("none of %d JWS signatures is valid: %w", len(signatures), signatures[0].VerifyErr)
+}
+
+// parseJSONSignature parses the signature of JWS JSON and verifies it. Verification error is set to
+// the returned signature, the error is returned if the signature is malformed.
+func parseJSONSignature(rawSignature *rawJWSSignature, rawPayload string, verifier SignatureVerifier,
+	opts *jwsParseOpts) (*JWSSignature, []byte, error) {
+	protectedHeaders, err := parseJSONProtectedHeaders(rawSignature.Protected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = checkDisjointHeaders(protectedHeaders, rawSignature.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	joseHeaders := mergeHeaders(protectedHeaders, rawSignature.Header)
+
+	err = checkJWSHeaders(joseHeaders)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload, b64Payload, err := parseJSONPayload(protectedHeaders, rawPayload, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(rawSignature.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode base64 signature: %w", err)
+	}
+
+	sInput := []byte(fmt.Sprintf("%s.%s", rawSignature.Protected, b64Payload))
+
+	return &JWSSignature{
+		ProtectedHeaders:   protectedHeaders,
+		UnprotectedHeaders: rawSignature.Header,
+		Signature:          signature,
+		VerifyErr:          verifier.Verify(joseHeaders, payload, sInput, signature),
+		protected:          rawSignature.Protected,
+	}, payload, nil
+}
+
+func parseJSONProtectedHeaders(protected string) (Headers, error) {
+	if protected == "" {
+		return Headers{}, nil
+	}
+
+	headersBytes, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 header: %w", err)
+	}
+
+	var headers Headers
+
+	err = json.Unmarshal(headersBytes, &headers)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal JSON headers: %w", err)
+	}
+
+	return headers, nil
+}
+
+// parseJSONPayload returns JWS JSON payload along with its encoded form used in the signing input.
+func parseJSONPayload(protectedHeaders Headers, rawPayload string, opts *jwsParseOpts) ([]byte, string, error) {
+	if len(opts.detachedPayload) > 0 {
+		b64Payload, err := encodePayload(protectedHeaders, opts.detachedPayload)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return opts.detachedPayload, b64Payload, nil
+	}
+
+	b64, err := isB64Payload(protectedHeaders)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !b64 {
+		return []byte(rawPayload), rawPayload, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(rawPayload)
+	if err != nil {
+		return nil, "", fmt.Errorf("decode base64 payload: %w", err)
+	}
+
+	return payload, rawPayload, nil
+}
+
 func parseCompactedPayload(jwsPayload string, opts *jwsParseOpts) ([]byte, error) {
 	if len(opts.detachedPayload) > 0 {
 		return opts.detachedPayload, nil
@@ -315,25 +685,42 @@ func signingInput(headers Headers, payload []byte) ([]byte, error) {
 		return nil, fmt.Errorf("serialize JWS headers: %w", err)
 	}
 
-	hBase64 := true
+	headersStr := base64.RawURLEncoding.EncodeToString(headersBytes)
 
-	if b64, ok := headers[HeaderB64Payload]; ok {
-		if hBase64, ok = b64.(bool); !ok {
-			return nil, errors.New("invalid b64 header")
-		}
+	payloadStr, err := encodePayload(headers, payload)
+	if err != nil {
+		return nil, err
 	}
 
-	headersStr := base64.RawURLEncoding.EncodeToString(headersBytes)
+	return []byte(fmt.Sprintf("%s.%s", headersStr, payloadStr)), nil
+}
 
-	var payloadStr string
+// encodePayload encodes JWS payload according to the b64 header (https://tools.ietf.org/html/rfc7797#section-3).
+func encodePayload(headers Headers, payload []byte) (string, error) {
+	b64, err := isB64Payload(headers)
+	if err != nil {
+		return "", err
+	}
 
-	if hBase64 {
-		payloadStr = base64.RawURLEncoding.EncodeToString(payload)
-	} else {
-		payloadStr = string(payload)
+	if !b64 {
+		return string(payload), nil
 	}
 
-	return []byte(fmt.Sprintf("%s.%s", headersStr, payloadStr)), nil
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+func isB64Payload(headers Headers) (bool, error) {
+	b64, ok := headers[HeaderB64Payload]
+	if !ok {
+		return true, nil
+	}
+
+	hBase64, ok := b64.(bool)
+	if !ok {
+		return false, errors.New("invalid b64 header")
+	}
+
+	return hBase64, nil
 }
 
 func checkJWSHeaders(headers Headers) error {
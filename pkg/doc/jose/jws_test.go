@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/square/go-jose/v3/json"
 	"github.com/stretchr/testify/require"
 )
 
@@ -191,10 +192,10 @@ func TestParseJWS(t *testing.T) {
 	require.NotNil(t, parsedJWS)
 	require.Equal(t, jws, parsedJWS)
 
-	// Parse not compact JWS format
+	// Parse JWS JSON without signatures
 	parsedJWS, err = ParseJWS(`{"some": "JSON"}`, &testVerifier{})
 	require.Error(t, err)
-	require.EqualError(t, err, "JWS JSON serialization is not supported")
+	require.EqualError(t, err, "JWS JSON has no signatures")
 	require.Nil(t, parsedJWS)
 
 	// Parse invalid compact JWS format
@@ -245,6 +246,294 @@ func TestParseJWS(t *testing.T) {
 	require.Nil(t, parsedJWS)
 }
 
+func TestJSONWebSignature_SerializeJSON(t *testing.T) {
+	payload := []byte("payload")
+
+	jws, err := NewJWSWithSigners(Headers{"typ": "JWT"}, payload,
+		JSONSigner{
+			Signer:             &testSigner{headers: Headers{"alg": "EdDSA"}, signature: []byte("signature1")},
+			UnprotectedHeaders: Headers{"kid": "key1"},
+		},
+		JSONSigner{
+			Signer:             &testSigner{headers: Headers{"alg": "ES256"}, signature: []byte("signature2")},
+			UnprotectedHeaders: Headers{"kid": "key2"},
+		})
+	require.NoError(t, err)
+	require.Len(t, jws.Signatures(), 2)
+	require.Equal(t, []byte("signature1"), jws.Signature())
+
+	jwsJSON, err := jws.SerializeJSON(false)
+	require.NoError(t, err)
+	require.Contains(t, jwsJSON, `"signatures"`)
+	require.Contains(t, jwsJSON, `"payload":"`+base64.RawURLEncoding.EncodeToString(payload)+`"`)
+
+	jwsJSON, err = jws.SerializeJSON(true)
+	require.NoError(t, err)
+	require.NotContains(t, jwsJSON, `"payload"`)
+
+	_, err = jws.SerializeFlattened(false)
+	require.EqualError(t, err, "flattened JWS JSON Serialization supports a single signature only")
+
+	_, err = jws.SerializeCompact(false)
+	require.EqualError(t, err, "JWS Compact Serialization supports a single signature only")
+
+	// single signer JWS
+	jws, err = NewJWS(Headers{"alg": "EdDSA"}, Headers{"kid": "key1"}, payload,
+		&testSigner{headers: Headers{}, signature: []byte("signature")})
+	require.NoError(t, err)
+
+	jwsJSON, err = jws.SerializeJSON(false)
+	require.NoError(t, err)
+	require.Contains(t, jwsJSON, `"signatures"`)
+
+	jwsFlattened, err := jws.SerializeFlattened(false)
+	require.NoError(t, err)
+	require.NotContains(t, jwsFlattened, `"signatures"`)
+	require.Contains(t, jwsFlattened, `"header":{"kid":"key1"}`)
+
+	// b64=false
+	jws, err = NewJWSWithSigners(Headers{"b64": false}, payload, JSONSigner{
+		Signer: &testSigner{headers: Headers{"alg": "EdDSA"}, signature: []byte("signature")},
+	})
+	require.NoError(t, err)
+
+	jwsJSON, err = jws.SerializeJSON(false)
+	require.NoError(t, err)
+	require.Contains(t, jwsJSON, `"payload":"payload"`)
+
+	// no signers
+	jws, err = NewJWSWithSigners(Headers{}, payload)
+	require.EqualError(t, err, "no JWS signers")
+	require.Nil(t, jws)
+
+	// header is both protected and unprotected
+	jws, err = NewJWSWithSigners(Headers{}, payload, JSONSigner{
+		Signer:             &testSigner{headers: Headers{"alg": "EdDSA"}},
+		UnprotectedHeaders: Headers{"alg": "EdDSA"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "alg JWS header is both protected and unprotected")
+	require.Nil(t, jws)
+
+	// different b64 headers
+	jws, err = NewJWSWithSigners(Headers{}, payload,
+		JSONSigner{Signer: &testSigner{headers: Headers{"alg": "EdDSA"}}},
+		JSONSigner{Signer: &testSigner{headers: Headers{"alg": "EdDSA", "b64": false}}})
+	require.EqualError(t, err, "b64 JWS header must be the same for all signatures")
+	require.Nil(t, jws)
+
+	// signer error
+	jws, err = NewJWSWithSigners(Headers{}, payload, JSONSigner{
+		Signer: &testSigner{headers: Headers{"alg": "EdDSA"}, err: errors.New("signer error")},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sign JWS with signer 0")
+	require.Contains(t, err.Error(), "signer error")
+	require.Nil(t, jws)
+}
+
+func TestParseJWS_JSON(t *testing.T) {
+	payload := []byte("payload")
+
+	jws, err := NewJWSWithSigners(Headers{"typ": "JWT"}, payload,
+		JSONSigner{
+			Signer:             &testSigner{headers: Headers{"alg": "EdDSA"}, signature: []byte("signature1")},
+			UnprotectedHeaders: Headers{"kid": "key1"},
+		},
+		JSONSigner{
+			Signer:             &testSigner{headers: Headers{"alg": "ES256"}, signature: []byte("signature2")},
+			UnprotectedHeaders: Headers{"kid": "key2"},
+		})
+	require.NoError(t, err)
+
+	jwsJSON, err := jws.SerializeJSON(false)
+	require.NoError(t, err)
+
+	t.Run("all signatures are valid", func(t *testing.T) {
+		parsedJWS, err := ParseJWS(jwsJSON, &testVerifier{})
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+		require.Equal(t, Headers{"alg": "EdDSA", "typ": "JWT"}, parsedJWS.ProtectedHeaders)
+		require.Equal(t, Headers{"kid": "key1"}, parsedJWS.UnprotectedHeaders)
+
+		signatures := parsedJWS.Signatures()
+		require.Len(t, signatures, 2)
+		require.NoError(t, signatures[0].VerifyErr)
+		require.NoError(t, signatures[1].VerifyErr)
+		require.Equal(t, []byte("signature2"), signatures[1].Signature)
+
+		// serialized back as parsed
+		reserialized, err := parsedJWS.SerializeJSON(false)
+		require.NoError(t, err)
+		require.Equal(t, jwsJSON, reserialized)
+	})
+
+	t.Run("signing input of each signature", func(t *testing.T) {
+		var rawJWS rawJSONWebSignature
+		require.NoError(t, json.Unmarshal([]byte(jwsJSON), &rawJWS))
+
+		verifier := SignatureVerifierFunc(func(joseHeaders Headers, _, signingInput, _ []byte) error {
+			kid, _ := joseHeaders.KeyID()
+			i := map[string]int{"key1": 0, "key2": 1}[kid]
+			require.Equal(t, rawJWS.Signatures[i].Protected+"."+rawJWS.Payload, string(signingInput))
+
+			return nil
+		})
+
+		_, err := ParseJWS(jwsJSON, verifier)
+		require.NoError(t, err)
+	})
+
+	t.Run("some signatures are invalid", func(t *testing.T) {
+		verifier := SignatureVerifierFunc(func(joseHeaders Headers, _, _, _ []byte) error {
+			if kid, _ := joseHeaders.KeyID(); kid == "key1" {
+				return errors.New("bad signature")
+			}
+
+			return nil
+		})
+
+		parsedJWS, err := ParseJWS(jwsJSON, verifier)
+		require.EqualError(t, err, "JWS signature 0 is invalid: bad signature")
+		require.Nil(t, parsedJWS)
+
+		parsedJWS, err = ParseJWS(jwsJSON, verifier, WithJWSAnySignature())
+		require.NoError(t, err)
+		require.Equal(t, Headers{"kid": "key2"}, parsedJWS.UnprotectedHeaders)
+		require.Equal(t, []byte("signature2"), parsedJWS.Signature())
+
+		signatures := parsedJWS.Signatures()
+		require.EqualError(t, signatures[0].VerifyErr, "bad signature")
+		require.NoError(t, signatures[1].VerifyErr)
+	})
+
+	t.Run("all signatures are invalid", func(t *testing.T) {
+		parsedJWS, err := ParseJWS(jwsJSON, &testVerifier{err: errors.New("bad signature")})
+		require.EqualError(t, err, "JWS signature 0 is invalid: bad signature")
+		require.Nil(t, parsedJWS)
+
+		parsedJWS, err = ParseJWS(jwsJSON, &testVerifier{err: errors.New("bad signature")}, WithJWSAnySignature())
+		require.EqualError(t, err, "none of 2 JWS signatures is valid: bad signature")
+		require.Nil(t, parsedJWS)
+	})
+
+	t.Run("detached payload", func(t *testing.T) {
+		jwsDetached, err := jws.SerializeJSON(true)
+		require.NoError(t, err)
+
+		parsedJWS, err := ParseJWS(jwsDetached, &testVerifier{}, WithJWSDetachedPayload(payload))
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+	})
+
+	t.Run("flattened", func(t *testing.T) {
+		jws, err := NewJWS(Headers{"alg": "EdDSA"}, Headers{"kid": "key1"}, payload,
+			&testSigner{headers: Headers{}, signature: []byte("signature")})
+		require.NoError(t, err)
+
+		jwsFlattened, err := jws.SerializeFlattened(false)
+		require.NoError(t, err)
+
+		parsedJWS, err := ParseJWS(jwsFlattened, &testVerifier{})
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+		require.Equal(t, jws.ProtectedHeaders, parsedJWS.ProtectedHeaders)
+		require.Equal(t, jws.UnprotectedHeaders, parsedJWS.UnprotectedHeaders)
+		require.Equal(t, jws.Signature(), parsedJWS.Signature())
+		require.Len(t, parsedJWS.Signatures(), 1)
+
+		parsedJWS, err = ParseJWS(jwsFlattened, &testVerifier{err: errors.New("bad signature")})
+		require.EqualError(t, err, "bad signature")
+		require.Nil(t, parsedJWS)
+	})
+
+	t.Run("unencoded payload", func(t *testing.T) {
+		jws, err := NewJWSWithSigners(Headers{"b64": false}, payload, JSONSigner{
+			Signer: &testSigner{headers: Headers{"alg": "EdDSA"}, signature: []byte("signature")},
+		})
+		require.NoError(t, err)
+
+		jwsUnencoded, err := jws.SerializeFlattened(false)
+		require.NoError(t, err)
+
+		parsedJWS, err := ParseJWS(jwsUnencoded, &testVerifier{})
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+	})
+
+	t.Run("invalid JWS JSON", func(t *testing.T) {
+		b64Headers := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA"}`))
+		b64Payload := base64.RawURLEncoding.EncodeToString(payload)
+		b64Signature := base64.RawURLEncoding.EncodeToString([]byte("signature"))
+
+		tests := []struct {
+			name string
+			jws  string
+			err  string
+		}{
+			{
+				name: "not JSON",
+				jws:  `{"signatures":`,
+				err:  "unmarshal JWS JSON",
+			},
+			{
+				name: "general and flattened signatures",
+				jws: fmt.Sprintf(`{"payload":"%s","signature":"%s","signatures":[{"protected":"%s","signature":"%s"}]}`,
+					b64Payload, b64Signature, b64Headers, b64Signature),
+				err: "JWS JSON has both general and flattened signatures",
+			},
+			{
+				name: "invalid protected headers base64",
+				jws:  fmt.Sprintf(`{"payload":"%s","protected":"XXX=","signature":"%s"}`, b64Payload, b64Signature),
+				err:  "decode base64 header",
+			},
+			{
+				name: "invalid protected headers JSON",
+				jws:  fmt.Sprintf(`{"payload":"%s","protected":"invalid","signature":"%s"}`, b64Payload, b64Signature),
+				err:  "unmarshal JSON headers",
+			},
+			{
+				name: "no alg header",
+				jws:  fmt.Sprintf(`{"payload":"%s","header":{"kid":"key1"},"signature":"%s"}`, b64Payload, b64Signature),
+				err:  "alg JWS header is not defined",
+			},
+			{
+				name: "header is both protected and unprotected",
+				jws: fmt.Sprintf(`{"payload":"%s","protected":"%s","header":{"alg":"EdDSA"},"signature":"%s"}`,
+					b64Payload, b64Headers, b64Signature),
+				err: "alg JWS header is both protected and unprotected",
+			},
+			{
+				name: "invalid payload",
+				jws:  fmt.Sprintf(`{"payload":"XXX=","protected":"%s","signature":"%s"}`, b64Headers, b64Signature),
+				err:  "decode base64 payload",
+			},
+			{
+				name: "invalid signature",
+				jws:  fmt.Sprintf(`{"payload":"%s","protected":"%s","signature":"XXX="}`, b64Payload, b64Headers),
+				err:  "decode base64 signature",
+			},
+			{
+				name: "invalid b64 header",
+				jws: fmt.Sprintf(`{"payload":"%s","protected":"%s","signature":"%s"}`, b64Payload,
+					base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA","b64":"invalid"}`)), b64Signature),
+				err: "invalid b64 header",
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				parsedJWS, err := ParseJWS(tc.jws, &testVerifier{})
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				require.Nil(t, parsedJWS)
+			})
+		}
+	})
+}
+
 func TestIsCompactJWS(t *testing.T) {
 	require.True(t, IsCompactJWS("a.b.c"))
 	require.False(t, IsCompactJWS("a.b"))
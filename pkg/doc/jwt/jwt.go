@@ -256,7 +256,7 @@ func checkHeaders(headers map[string]interface{}) error {
 	}
 
 	typ, ok := headers[jose.HeaderType]
-	if ok && typ != TypeJWT {
+	if ok && !isJWTType(typ) {
 		return errors.New("typ is not JWT")
 	}
 
@@ -268,6 +268,14 @@ func checkHeaders(headers map[string]interface{}) error {
 	return nil
 }
 
+// isJWTType checks if typ is "JWT" or an explicit JWT type like "vc+jwt"
+// (https://tools.ietf.org/html/rfc8725#section-3.11).
+func isJWTType(typ interface{}) bool {
+	typStr, ok := typ.(string)
+
+	return ok && (typStr == TypeJWT || strings.HasSuffix(strings.ToLower(typStr), "+jwt"))
+}
+
 func toMap(i interface{}) (map[string]interface{}, error) {
 	if reflect.ValueOf(i).Kind() == reflect.Map {
 		return i.(map[string]interface{}), nil
@@ -16,6 +16,8 @@ import (
 var (
 	//go:embed third_party/w3.org/credentials_v1.jsonld
 	w3orgCredentials []byte
+	//go:embed third_party/w3.org/credentials_v2.jsonld
+	w3orgCredentialsV2 []byte
 	//go:embed third_party/w3.org/did_v1.jsonld
 	w3orgDID []byte
 	//go:embed third_party/w3c-ccg.github.io/did_v0.11.jsonld
@@ -52,6 +54,10 @@ var (
 	ed255192020 []byte
 	//go:embed third_party/w3c-ccg.github.io/revocationList2021.jsonld
 	revocationList2021 []byte
+	//go:embed third_party/w3c.github.io/data-integrity-v1.jsonld
+	dataIntegrityV1 []byte
+	//go:embed third_party/identity.foundation/did-configuration_v1.jsonld
+	didConfiguration []byte
 )
 
 // Contexts contains JSON-LD contexts embedded into a Go binary.
@@ -61,6 +67,11 @@ var Contexts = []ldcontext.Document{ //nolint:gochecknoglobals
 		DocumentURL: "https://www.w3.org/2018/credentials/v1",
 		Content:     w3orgCredentials,
 	},
+	{
+		URL:         "https://www.w3.org/ns/credentials/v2",
+		DocumentURL: "https://www.w3.org/ns/credentials/v2",
+		Content:     w3orgCredentialsV2,
+	},
 	{
 		URL:         "https://www.w3.org/ns/did/v1",
 		DocumentURL: "https://www.w3.org/ns/did/v1",
@@ -161,4 +172,14 @@ var Contexts = []ldcontext.Document{ //nolint:gochecknoglobals
 		DocumentURL: "https://digitalbazaar.github.io/ed25519-signature-2020-context/contexts/ed25519-signature-2020-v1.jsonld", //nolint: lll
 		Content:     ed255192020,
 	},
+	{
+		URL:         "https://w3id.org/security/data-integrity/v1",
+		DocumentURL: "https://w3c.github.io/vc-data-integrity/contexts/data-integrity/v1.jsonld",
+		Content:     dataIntegrityV1,
+	},
+	{
+		URL:         "https://identity.foundation/.well-known/did-configuration/v1",
+		DocumentURL: "https://identity.foundation/.well-known/did-configuration/v1",
+		Content:     didConfiguration,
+	},
 }
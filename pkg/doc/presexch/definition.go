@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/PaesslerAG/jsonpath"
@@ -42,7 +43,8 @@ const (
 
 	tmpEnding = "tmp_unique_id_"
 
-	credentialSchema = "credentialSchema"
+	credentialSchema  = "credentialSchema"
+	credentialSubject = "credentialSubject"
 
 	// FormatJWT presentation exchange format.
 	FormatJWT = "jwt"
@@ -354,7 +356,8 @@ func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 
 	applicableCredentials, descriptors := merge(format, result)
 
-	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(applicableCredentials...))
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(applicableCredentials...),
+		verifiable.WithDataModelVersion(verifiable.CredentialsDataModelVersion(applicableCredentials...)))
 	if err != nil {
 		return nil, err
 	}
@@ -623,6 +626,17 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 			continue
 		}
 
+		if constraints.LimitDisclosure.isRequired() && credential.SDJWTDisclosures != nil {
+			credential, err = limitSDJWTDisclosures(constraints, credentialSrc, credential, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("limit SD-JWT disclosures: %w", err)
+			}
+
+			result = append(result, credential)
+
+			continue
+		}
+
 		if constraints.LimitDisclosure.isRequired() || predicate {
 			template := credentialSrc
 
@@ -635,14 +649,7 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 			contexts = append(contexts, credential.CustomContext...)
 
 			if constraints.LimitDisclosure.isRequired() {
-				template, err = json.Marshal(map[string]interface{}{
-					"id":                credential.ID,
-					"type":              credential.Types,
-					"@context":          contexts,
-					"issuer":            credential.Issuer,
-					"credentialSubject": toSubject(credential.Subject),
-					"issuanceDate":      credential.Issued,
-				})
+				template, err = json.Marshal(limitedCredentialTemplate(credential, contexts))
 				if err != nil {
 					return nil, err
 				}
@@ -664,6 +671,25 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 	return result, nil
 }
 
+// limitedCredentialTemplate returns the mandatory fields of the credential to build the limited disclosure one.
+func limitedCredentialTemplate(credential *verifiable.Credential, contexts []interface{}) map[string]interface{} {
+	template := map[string]interface{}{
+		"id":                credential.ID,
+		"type":              credential.Types,
+		"@context":          contexts,
+		"issuer":            credential.Issuer,
+		"credentialSubject": toSubject(credential.Subject),
+	}
+
+	if credential.Version() == verifiable.DataModelV2 {
+		template["validFrom"] = credential.ValidFrom
+	} else {
+		template["issuanceDate"] = credential.Issued
+	}
+
+	return template
+}
+
 func frameCreds(frame map[string]interface{}, creds []*verifiable.Credential,
 	opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
 	if frame == nil {
@@ -785,6 +811,64 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 	return credential.GenerateBBSSelectiveDisclosure(doc, []byte(uuid.New().String()), opts...)
 }
 
+// limitSDJWTDisclosures creates a credential disclosing only the credentialSubject claims matched by the
+// constraints fields.
+func limitSDJWTDisclosures(constraints *Constraints, src []byte, credential *verifiable.Credential,
+	opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
+	var claimNames []string
+
+	for _, f := range constraints.Fields {
+		paths, err := jsonpathkeys.ParsePaths(f.Path...)
+		if err != nil {
+			return nil, err
+		}
+
+		eval, err := jsonpathkeys.EvalPathsInReader(bytes.NewReader(src), paths)
+		if err != nil {
+			return nil, err
+		}
+
+		set := map[string]int{}
+
+		for {
+			result, ok := eval.Next()
+			if !ok {
+				break
+			}
+
+			chunks := strings.Split(getPath(result.Keys, set)[1], ".")
+			if len(chunks) < 2 || chunks[0] != credentialSubject {
+				continue
+			}
+
+			claimName := chunks[1]
+
+			// the claim of one of several subjects
+			if _, e := strconv.Atoi(claimName); e == nil && len(chunks) > 2 {
+				claimName = chunks[2]
+			}
+
+			claimNames = append(claimNames, claimName)
+		}
+	}
+
+	vcSDJWT, err := credential.MarshalWithDisclosure(verifiable.DiscloseGivenIfAvailable(claimNames))
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, verifiable.WithDisabledProofCheck())
+
+	limited, err := verifiable.ParseCredential([]byte(vcSDJWT), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	limited.ID = tmpID(limited.ID)
+
+	return limited, nil
+}
+
 func enhanceRevealDoc(explicitPaths map[string]bool, limitedCred, vcBytes []byte) ([]byte, error) {
 	var err error
 
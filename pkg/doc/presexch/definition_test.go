@@ -583,6 +583,90 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 		checkVP(t, vp)
 	})
 
+	t.Run("Limit disclosure SD-JWT", func(t *testing.T) {
+		required := Required
+
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					LimitDisclosure: &required,
+					Fields: []*Field{{
+						Path:   []string{"$.credentialSubject.degree.degreeSchool"},
+						Filter: &Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc := &verifiable.Credential{
+			ID: "https://issuer.oidp.uscis.gov/credentials/83627465",
+			Context: []string{
+				verifiable.ContextURI,
+				"https://www.w3.org/2018/credentials/examples/v1",
+			},
+			Types: []string{
+				"VerifiableCredential",
+				"UniversityDegreeCredential",
+			},
+			Subject: verifiable.Subject{
+				ID: "did:example:b34ca6cd37bbf23",
+				CustomFields: map[string]interface{}{
+					"name":   "Jayden Doe",
+					"spouse": "did:example:c276e12ec21ebfeb1f712ebc6f1",
+					"degree": map[string]interface{}{
+						"degree":       "MIT",
+						"degreeSchool": "MIT school",
+						"type":         "BachelorDegree",
+					},
+				},
+			},
+			Issued: &util.TimeWrapper{
+				Time: time.Now(),
+			},
+			Issuer: verifiable.Issuer{
+				ID: "did:example:489398593",
+			},
+		}
+
+		vcSDJWT, err := vc.MakeSDJWT(signer, verifiable.EdDSA, vc.Issuer.ID+"#keys-1")
+		require.NoError(t, err)
+
+		sdJWTVC, err := verifiable.ParseCredential([]byte(vcSDJWT),
+			verifiable.WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+		require.Len(t, sdJWTVC.SDJWTDisclosures, 3)
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{sdJWTVC}, lddl,
+			verifiable.WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)))
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+		require.Equal(t, 1, len(vp.Credentials()))
+
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+
+		require.Len(t, vc.SDJWTDisclosures, 1)
+		require.Equal(t, "degree", vc.SDJWTDisclosures[0].Name)
+
+		subject := vc.Subject.([]verifiable.Subject)[0]
+		require.Equal(t, "did:example:b34ca6cd37bbf23", subject.ID)
+		require.NotNil(t, subject.CustomFields["degree"])
+		require.Empty(t, subject.CustomFields["name"])
+		require.Empty(t, subject.CustomFields["spouse"])
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+	})
+
 	t.Run("Predicate and limit disclosure BBS+ (no proof)", func(t *testing.T) {
 		required := Required
 
@@ -0,0 +1,282 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sdjwt implements Selective Disclosure for JWTs (SD-JWT).
+// Reference: https://datatracker.ietf.org/doc/html/draft-ietf-oauth-selective-disclosure-jwt-05
+package sdjwt
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+)
+
+const (
+	// SDAlgorithmKey is the claim holding the hash algorithm of the disclosure digests.
+	SDAlgorithmKey = "_sd_alg"
+	// SDKey is the claim holding the digests of the selectively disclosable claims of an object.
+	SDKey = "_sd"
+	// CNFKey is the confirmation claim holding the public key of the holder.
+	CNFKey = "cnf"
+	// SHA256 is the default and only supported hash algorithm of the disclosure digests.
+	SHA256 = "sha-256"
+
+	combinedFormatSeparator = "~"
+	disclosureParts         = 3
+	jwkKey                  = "jwk"
+)
+
+// DisclosureClaim is a decoded disclosure of a selectively disclosable claim.
+type DisclosureClaim struct {
+	Disclosure string
+	Salt       string
+	Name       string
+	Value      interface{}
+}
+
+// CombinedFormat is an SD-JWT with its disclosures and optional holder binding JWT, serialized as
+// <SD-JWT>~<Disclosure 1>~...~<Disclosure N>~<Holder Binding JWT>.
+type CombinedFormat struct {
+	SDJWT         string
+	Disclosures   []string
+	HolderBinding string
+}
+
+// ParseCombinedFormat splits an SD-JWT in combined format into its parts, it does not check them.
+func ParseCombinedFormat(combined string) *CombinedFormat {
+	parts := strings.Split(combined, combinedFormatSeparator)
+
+	cf := &CombinedFormat{SDJWT: parts[0]}
+
+	if len(parts) == 1 {
+		return cf
+	}
+
+	last := parts[len(parts)-1]
+
+	// a holder binding JWT has three parts while a disclosure is a single base64url value
+	switch {
+	case strings.Count(last, ".") == 2:
+		cf.HolderBinding = last
+		cf.Disclosures = parts[1 : len(parts)-1]
+	case last == "":
+		cf.Disclosures = parts[1 : len(parts)-1]
+	default:
+		cf.Disclosures = parts[1:]
+	}
+
+	return cf
+}
+
+// Serialize serializes the SD-JWT in combined format.
+func (cf *CombinedFormat) Serialize() string {
+	parts := append([]string{cf.SDJWT}, cf.Disclosures...)
+
+	return strings.Join(append(parts, cf.HolderBinding), combinedFormatSeparator)
+}
+
+// IsCombinedFormat returns true if s looks like an SD-JWT in combined format.
+func IsCombinedFormat(s string) bool {
+	if !strings.Contains(s, combinedFormatSeparator) {
+		return false
+	}
+
+	return jwt.IsJWS(ParseCombinedFormat(s).SDJWT)
+}
+
+// GetDisclosureClaims decodes disclosures.
+func GetDisclosureClaims(disclosures []string) ([]*DisclosureClaim, error) {
+	claims := make([]*DisclosureClaim, 0, len(disclosures))
+
+	for _, disclosure := range disclosures {
+		claim, err := decodeDisclosure(disclosure)
+		if err != nil {
+			return nil, err
+		}
+
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+// GetHash returns the digest of a disclosure as it appears in the _sd claims of an SD-JWT.
+func GetHash(disclosure string) string {
+	digest := sha256.Sum256([]byte(disclosure))
+
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func decodeDisclosure(disclosure string) (*DisclosureClaim, error) {
+	src, err := base64.RawURLEncoding.DecodeString(disclosure)
+	if err != nil {
+		return nil, fmt.Errorf("decode disclosure: %w", err)
+	}
+
+	var parts []interface{}
+
+	err = json.Unmarshal(src, &parts)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal disclosure: %w", err)
+	}
+
+	if len(parts) != disclosureParts {
+		return nil, fmt.Errorf("disclosure should have %d parts", disclosureParts)
+	}
+
+	salt, ok := parts[0].(string)
+	if !ok {
+		return nil, errors.New("disclosure salt is not a string")
+	}
+
+	name, ok := parts[1].(string)
+	if !ok {
+		return nil, errors.New("disclosure claim name is not a string")
+	}
+
+	return &DisclosureClaim{
+		Disclosure: disclosure,
+		Salt:       salt,
+		Name:       name,
+		Value:      parts[2],
+	}, nil
+}
+
+func checkSDAlgorithm(claims map[string]interface{}) error {
+	alg, ok := claims[SDAlgorithmKey]
+	if !ok {
+		return nil
+	}
+
+	if alg != SHA256 {
+		return fmt.Errorf("unsupported %s: %v", SDAlgorithmKey, alg)
+	}
+
+	return nil
+}
+
+// collectDigests returns the digests of all the _sd claims of v.
+func collectDigests(v interface{}, digests map[string]bool) error {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, nested := range value {
+			if k != SDKey {
+				if err := collectDigests(nested, digests); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			sd, ok := nested.([]interface{})
+			if !ok {
+				return fmt.Errorf("%s claim is not an array", SDKey)
+			}
+
+			for _, d := range sd {
+				digest, ok := d.(string)
+				if !ok {
+					return fmt.Errorf("%s claim digest is not a string", SDKey)
+				}
+
+				if digests[digest] {
+					return fmt.Errorf("duplicate digest %s", digest)
+				}
+
+				digests[digest] = true
+			}
+		}
+	case []interface{}:
+		for _, nested := range value {
+			if err := collectDigests(nested, digests); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDisclosures checks that every disclosure is referenced by a digest of the SD-JWT claims.
+func checkDisclosures(claims map[string]interface{}, disclosures []*DisclosureClaim) error {
+	digests := make(map[string]bool)
+
+	if err := collectDigests(claims, digests); err != nil {
+		return err
+	}
+
+	for _, d := range disclosures {
+		if !digests[GetHash(d.Disclosure)] {
+			return fmt.Errorf("disclosure digest '%s' not found in SD-JWT", GetHash(d.Disclosure))
+		}
+	}
+
+	return nil
+}
+
+// disclose replaces the _sd claims of v with the disclosed claims, the undisclosed digests are dropped.
+func disclose(v interface{}, disclosures map[string]*DisclosureClaim) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+
+		for k, nested := range value {
+			if k == SDKey || k == SDAlgorithmKey {
+				continue
+			}
+
+			result[k] = disclose(nested, disclosures)
+		}
+
+		sd, _ := value[SDKey].([]interface{}) // nolint:errcheck
+
+		for _, d := range sd {
+			digest, _ := d.(string) // nolint:errcheck
+
+			if claim, ok := disclosures[digest]; ok {
+				result[claim.Name] = disclose(claim.Value, disclosures)
+			}
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+
+		for i, nested := range value {
+			result[i] = disclose(nested, disclosures)
+		}
+
+		return result
+	default:
+		return v
+	}
+}
+
+// DiscloseClaims returns claims with the _sd digests of the given disclosures replaced by the disclosed claims.
+func DiscloseClaims(claims map[string]interface{}, disclosures []*DisclosureClaim) (map[string]interface{}, error) {
+	if err := checkSDAlgorithm(claims); err != nil {
+		return nil, err
+	}
+
+	if err := checkDisclosures(claims, disclosures); err != nil {
+		return nil, err
+	}
+
+	byDigest := make(map[string]*DisclosureClaim, len(disclosures))
+
+	for _, d := range disclosures {
+		byDigest[GetHash(d.Disclosure)] = d
+	}
+
+	disclosed, _ := disclose(claims, byDigest).(map[string]interface{}) // nolint:errcheck
+
+	return disclosed, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"errors"
+	"fmt"
+
+	josejwt "github.com/square/go-jose/v3/jwt"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+)
+
+type holderOpts struct {
+	signatureVerifier jose.SignatureVerifier
+	holderBinding     *BindingInfo
+}
+
+// HolderOpt is an SD-JWT holder option.
+type HolderOpt func(opts *holderOpts)
+
+// WithIssuerSignatureVerifier sets the verifier of the issuer signature of the parsed SD-JWT, the signature is not
+// checked by default.
+func WithIssuerSignatureVerifier(signatureVerifier jose.SignatureVerifier) HolderOpt {
+	return func(opts *holderOpts) {
+		opts.signatureVerifier = signatureVerifier
+	}
+}
+
+// BindingPayload is the payload of a holder binding JWT.
+type BindingPayload struct {
+	Nonce    string               `json:"nonce,omitempty"`
+	Audience string               `json:"aud,omitempty"`
+	IssuedAt *josejwt.NumericDate `json:"iat,omitempty"`
+}
+
+// BindingInfo defines the holder binding JWT of a presentation.
+type BindingInfo struct {
+	Payload BindingPayload
+	Signer  jose.Signer
+	Headers jose.Headers
+}
+
+// WithHolderBinding adds a holder binding JWT to the presentation.
+func WithHolderBinding(info *BindingInfo) HolderOpt {
+	return func(opts *holderOpts) {
+		opts.holderBinding = info
+	}
+}
+
+// Parse parses an SD-JWT issued to the holder, and returns the claims of its disclosures.
+func Parse(combinedFormatForIssuance string, opts ...HolderOpt) ([]*DisclosureClaim, error) {
+	hOpts := &holderOpts{signatureVerifier: &noVerifier{}}
+
+	for _, opt := range opts {
+		opt(hOpts)
+	}
+
+	cf := ParseCombinedFormat(combinedFormatForIssuance)
+
+	signedJWT, err := jwt.Parse(cf.SDJWT, jwt.WithSignatureVerifier(hOpts.signatureVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	if err = checkSDAlgorithm(signedJWT.Payload); err != nil {
+		return nil, err
+	}
+
+	claims, err := GetDisclosureClaims(cf.Disclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkDisclosures(signedJWT.Payload, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// CreatePresentation creates the presentation of an SD-JWT issued to the holder, with only the given disclosures.
+func CreatePresentation(combinedFormatForIssuance string, disclosuresToKeep []string,
+	opts ...HolderOpt) (string, error) {
+	hOpts := &holderOpts{}
+
+	for _, opt := range opts {
+		opt(hOpts)
+	}
+
+	cf := ParseCombinedFormat(combinedFormatForIssuance)
+
+	issued := make(map[string]bool, len(cf.Disclosures))
+
+	for _, d := range cf.Disclosures {
+		issued[d] = true
+	}
+
+	for _, d := range disclosuresToKeep {
+		if !issued[d] {
+			return "", errors.New("disclosure is not part of the SD-JWT")
+		}
+	}
+
+	presentation := &CombinedFormat{
+		SDJWT:       cf.SDJWT,
+		Disclosures: disclosuresToKeep,
+	}
+
+	if hOpts.holderBinding != nil {
+		holderBinding, err := createHolderBinding(hOpts.holderBinding)
+		if err != nil {
+			return "", err
+		}
+
+		presentation.HolderBinding = holderBinding
+	}
+
+	return presentation.Serialize(), nil
+}
+
+func createHolderBinding(info *BindingInfo) (string, error) {
+	if info.Signer == nil {
+		return "", errors.New("holder binding signer is not defined")
+	}
+
+	bindingJWT, err := jwt.NewSigned(info.Payload, info.Headers, info.Signer)
+	if err != nil {
+		return "", fmt.Errorf("create holder binding: %w", err)
+	}
+
+	return bindingJWT.Serialize(false)
+}
+
+// noVerifier accepts any signature, the SD-JWT is parsed by its holder before its signature can be checked.
+type noVerifier struct{}
+
+func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
+	return nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+)
+
+const (
+	saltSize = 16
+
+	vcKey                = "vc"
+	credentialSubjectKey = "credentialSubject"
+	idKey                = "id"
+)
+
+// nolint:gochecknoglobals
+var defaultNonSDClaims = []string{"iss", "sub", "exp", "nbf", "iat", "jti", CNFKey}
+
+type newOpts struct {
+	nonSDClaims     map[string]bool
+	holderPublicKey *jwk.JWK
+	getSalt         func() (string, error)
+}
+
+// NewOpt is an SD-JWT issuance option.
+type NewOpt func(opts *newOpts)
+
+// WithNonSelectivelyDisclosableClaims sets the top-level claims which are always disclosed, in addition to the
+// registered JWT claims (iss, sub, exp, nbf, iat, jti and cnf).
+func WithNonSelectivelyDisclosableClaims(claims ...string) NewOpt {
+	return func(opts *newOpts) {
+		for _, c := range claims {
+			opts.nonSDClaims[c] = true
+		}
+	}
+}
+
+// WithHolderPublicKey binds the SD-JWT to the holder public key with the cnf claim, the holder then has to present
+// the SD-JWT with a holder binding JWT signed with the matching private key.
+func WithHolderPublicKey(holderPublicKey *jwk.JWK) NewOpt {
+	return func(opts *newOpts) {
+		opts.holderPublicKey = holderPublicKey
+	}
+}
+
+// WithSaltFnc sets the salt generator of the disclosures, the default generates 128 bits random salts.
+func WithSaltFnc(fnc func() (string, error)) NewOpt {
+	return func(opts *newOpts) {
+		opts.getSalt = fnc
+	}
+}
+
+// SelectiveDisclosureJWT is a signed SD-JWT with the disclosures of all its selectively disclosable claims.
+type SelectiveDisclosureJWT struct {
+	SignedJWT   *jwt.JSONWebToken
+	Disclosures []string
+}
+
+// Serialize serializes the SD-JWT in combined format for issuance.
+func (j *SelectiveDisclosureJWT) Serialize(detached bool) (string, error) {
+	signedJWT, err := j.SignedJWT.Serialize(detached)
+	if err != nil {
+		return "", fmt.Errorf("serialize SD-JWT: %w", err)
+	}
+
+	cf := &CombinedFormat{
+		SDJWT:       signedJWT,
+		Disclosures: j.Disclosures,
+	}
+
+	return cf.Serialize(), nil
+}
+
+// New creates an SD-JWT signed with signer. The top-level claims are selectively disclosable except the registered
+// JWT claims and the claims set with WithNonSelectivelyDisclosableClaims.
+func New(claims interface{}, headers jose.Headers, signer jose.Signer,
+	opts ...NewOpt) (*SelectiveDisclosureJWT, error) {
+	claimsMap, err := toMap(claims)
+	if err != nil {
+		return nil, fmt.Errorf("SD-JWT claims: %w", err)
+	}
+
+	nOpts := getNewOpts(opts)
+
+	sdClaims, disclosures, err := createDisclosures(claimsMap, nOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sign(sdClaims, disclosures, headers, signer, nOpts)
+}
+
+// NewFromVC creates an SD-JWT VC signed with signer from the JWT claims of a verifiable credential. The claims of
+// the credential subjects in the vc claim are selectively disclosable, except their id and the claims set with
+// WithNonSelectivelyDisclosableClaims.
+func NewFromVC(vcClaims map[string]interface{}, headers jose.Headers, signer jose.Signer,
+	opts ...NewOpt) (*SelectiveDisclosureJWT, error) {
+	vc, ok := vcClaims[vcKey].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("vc claim is not defined")
+	}
+
+	nOpts := getNewOpts(append([]NewOpt{WithNonSelectivelyDisclosableClaims(idKey)}, opts...))
+
+	sdClaims := make(map[string]interface{}, len(vcClaims))
+
+	for k, v := range vcClaims {
+		sdClaims[k] = v
+	}
+
+	sdVC := make(map[string]interface{}, len(vc))
+
+	for k, v := range vc {
+		sdVC[k] = v
+	}
+
+	var disclosures []string
+
+	switch subject := vc[credentialSubjectKey].(type) {
+	case map[string]interface{}:
+		sdSubject, subjectDisclosures, err := createDisclosures(subject, nOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		sdVC[credentialSubjectKey] = sdSubject
+		disclosures = subjectDisclosures
+	case []interface{}:
+		sdSubjects := make([]interface{}, len(subject))
+
+		for i, s := range subject {
+			subjectMap, ok := s.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("credentialSubject is not an object")
+			}
+
+			sdSubject, subjectDisclosures, err := createDisclosures(subjectMap, nOpts)
+			if err != nil {
+				return nil, err
+			}
+
+			sdSubjects[i] = sdSubject
+			disclosures = append(disclosures, subjectDisclosures...)
+		}
+
+		sdVC[credentialSubjectKey] = sdSubjects
+	default:
+		return nil, errors.New("credentialSubject is not defined")
+	}
+
+	sdClaims[vcKey] = sdVC
+
+	return sign(sdClaims, disclosures, headers, signer, nOpts)
+}
+
+// CreateDisclosures replaces the selectively disclosable top-level claims with their digests in the _sd claim,
+// and returns the updated claims with the disclosures of the replaced claims.
+func CreateDisclosures(claims map[string]interface{}, opts ...NewOpt) (map[string]interface{}, []string, error) {
+	return createDisclosures(claims, getNewOpts(opts))
+}
+
+func getNewOpts(opts []NewOpt) *newOpts {
+	nOpts := &newOpts{
+		nonSDClaims: make(map[string]bool),
+		getSalt:     generateSalt,
+	}
+
+	for _, c := range defaultNonSDClaims {
+		nOpts.nonSDClaims[c] = true
+	}
+
+	for _, opt := range opts {
+		opt(nOpts)
+	}
+
+	return nOpts
+}
+
+func sign(sdClaims map[string]interface{}, disclosures []string, headers jose.Headers, signer jose.Signer,
+	opts *newOpts) (*SelectiveDisclosureJWT, error) {
+	sdClaims[SDAlgorithmKey] = SHA256
+
+	if opts.holderPublicKey != nil {
+		sdClaims[CNFKey] = map[string]interface{}{jwkKey: opts.holderPublicKey}
+	}
+
+	signedJWT, err := jwt.NewSigned(sdClaims, headers, signer)
+	if err != nil {
+		return nil, fmt.Errorf("sign SD-JWT: %w", err)
+	}
+
+	return &SelectiveDisclosureJWT{SignedJWT: signedJWT, Disclosures: disclosures}, nil
+}
+
+func createDisclosures(claims map[string]interface{}, opts *newOpts) (map[string]interface{}, []string, error) {
+	names := make([]string, 0, len(claims))
+
+	for name := range claims {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	sdClaims := make(map[string]interface{})
+
+	var (
+		disclosures []string
+		digests     []string
+	)
+
+	for _, name := range names {
+		if opts.nonSDClaims[name] {
+			sdClaims[name] = claims[name]
+
+			continue
+		}
+
+		disclosure, err := createDisclosure(name, claims[name], opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		disclosures = append(disclosures, disclosure)
+		digests = append(digests, GetHash(disclosure))
+	}
+
+	if len(digests) > 0 {
+		// the digests are sorted so that their order does not reveal the order of the claims
+		sort.Strings(digests)
+
+		sdClaims[SDKey] = digests
+	}
+
+	return sdClaims, disclosures, nil
+}
+
+func createDisclosure(name string, value interface{}, opts *newOpts) (string, error) {
+	salt, err := opts.getSalt()
+	if err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+
+	disclosureBytes, err := json.Marshal([]interface{}{salt, name, value})
+	if err != nil {
+		return "", fmt.Errorf("marshal disclosure: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(disclosureBytes), nil
+}
+
+func generateSalt() (string, error) {
+	salt := make([]byte, saltSize)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(salt), nil
+}
+
+func toMap(v interface{}) (map[string]interface{}, error) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	josejwt "github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+)
+
+const testIssuer = "https://example.com/issuer"
+
+func TestSDJWT(t *testing.T) {
+	issuerPub, issuerPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderPub, holderPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderJWK, err := jwksupport.JWKFromKey(holderPub)
+	require.NoError(t, err)
+
+	issuerVerifier, err := jwt.NewEd25519Verifier(issuerPub)
+	require.NoError(t, err)
+
+	claims := map[string]interface{}{
+		"iss":         testIssuer,
+		"given_name":  "John",
+		"family_name": "Doe",
+		"address": map[string]interface{}{
+			"country": "US",
+		},
+	}
+
+	issue := func(t *testing.T, opts ...NewOpt) string {
+		t.Helper()
+
+		token, e := New(claims, nil, jwt.NewEd25519Signer(issuerPriv), opts...)
+		require.NoError(t, e)
+		require.Len(t, token.Disclosures, 3)
+
+		combined, e := token.Serialize(false)
+		require.NoError(t, e)
+
+		return combined
+	}
+
+	t.Run("issue and verify all disclosures", func(t *testing.T) {
+		combined := issue(t)
+		require.True(t, IsCombinedFormat(combined))
+
+		disclosed, err := Verify(combined, WithSignatureVerifier(issuerVerifier))
+		require.NoError(t, err)
+		require.Equal(t, claims, disclosed)
+	})
+
+	t.Run("holder selects disclosures", func(t *testing.T) {
+		combined := issue(t)
+
+		disclosures, err := Parse(combined, WithIssuerSignatureVerifier(issuerVerifier))
+		require.NoError(t, err)
+		require.Len(t, disclosures, 3)
+
+		var keep []string
+
+		for _, d := range disclosures {
+			if d.Name == "given_name" {
+				keep = append(keep, d.Disclosure)
+			}
+		}
+
+		presentation, err := CreatePresentation(combined, keep)
+		require.NoError(t, err)
+
+		disclosed, err := Verify(presentation, WithSignatureVerifier(issuerVerifier))
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"iss": testIssuer, "given_name": "John"}, disclosed)
+
+		_, err = CreatePresentation(combined, []string{"unknown"})
+		require.EqualError(t, err, "disclosure is not part of the SD-JWT")
+	})
+
+	t.Run("non selectively disclosable claims", func(t *testing.T) {
+		token, err := New(claims, nil, jwt.NewEd25519Signer(issuerPriv),
+			WithNonSelectivelyDisclosableClaims("address"))
+		require.NoError(t, err)
+		require.Len(t, token.Disclosures, 2)
+
+		combined, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		presentation, err := CreatePresentation(combined, nil)
+		require.NoError(t, err)
+
+		disclosed, err := Verify(presentation, WithSignatureVerifier(issuerVerifier))
+		require.NoError(t, err)
+		require.Equal(t, claims["address"], disclosed["address"])
+		require.NotContains(t, disclosed, "given_name")
+	})
+
+	t.Run("holder binding", func(t *testing.T) {
+		combined := issue(t, WithHolderPublicKey(holderJWK))
+
+		presentation, err := CreatePresentation(combined, ParseCombinedFormat(combined).Disclosures,
+			WithHolderBinding(&BindingInfo{
+				Payload: BindingPayload{
+					Nonce:    "nonce",
+					Audience: "https://example.com/verifier",
+					IssuedAt: josejwt.NewNumericDate(time.Now()),
+				},
+				Signer: jwt.NewEd25519Signer(holderPriv),
+			}))
+		require.NoError(t, err)
+		require.NotEmpty(t, ParseCombinedFormat(presentation).HolderBinding)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithHolderBindingRequired(true),
+			WithExpectedNonceForHolderBinding("nonce"),
+			WithExpectedAudienceForHolderBinding("https://example.com/verifier"))
+		require.NoError(t, err)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedNonceForHolderBinding("other"))
+		require.EqualError(t, err, "holder binding: nonce does not match")
+
+		withoutBinding, err := CreatePresentation(combined, nil)
+		require.NoError(t, err)
+
+		_, err = Verify(withoutBinding, WithSignatureVerifier(issuerVerifier), WithHolderBindingRequired(true))
+		require.EqualError(t, err, "holder binding is required")
+	})
+
+	t.Run("holder binding signed with another key", func(t *testing.T) {
+		combined := issue(t, WithHolderPublicKey(holderJWK))
+
+		presentation, err := CreatePresentation(combined, nil, WithHolderBinding(&BindingInfo{
+			Payload: BindingPayload{Nonce: "nonce"},
+			Signer:  jwt.NewEd25519Signer(issuerPriv),
+		}))
+		require.NoError(t, err)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse holder binding")
+	})
+
+	t.Run("invalid issuer signature", func(t *testing.T) {
+		otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		otherVerifier, err := jwt.NewEd25519Verifier(otherPub)
+		require.NoError(t, err)
+
+		_, err = Verify(issue(t), WithSignatureVerifier(otherVerifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse SD-JWT")
+
+		_, err = Verify(issue(t))
+		require.EqualError(t, err, "signature verifier is not defined")
+	})
+
+	t.Run("disclosure not referenced by the SD-JWT", func(t *testing.T) {
+		combined := issue(t)
+		other := issue(t)
+
+		cf := ParseCombinedFormat(combined)
+		cf.Disclosures = append(cf.Disclosures, ParseCombinedFormat(other).Disclosures[0])
+
+		_, err := Verify(cf.Serialize(), WithSignatureVerifier(issuerVerifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found in SD-JWT")
+	})
+
+	t.Run("invalid disclosure", func(t *testing.T) {
+		cf := ParseCombinedFormat(issue(t))
+		cf.Disclosures = append(cf.Disclosures, "WyJzYWx0IiwgIm5hbWUiXQ")
+
+		_, err := Verify(cf.Serialize(), WithSignatureVerifier(issuerVerifier))
+		require.EqualError(t, err, "disclosure should have 3 parts")
+	})
+}
+
+func TestParseCombinedFormat(t *testing.T) {
+	cf := ParseCombinedFormat("a.b.c~d1~d2~")
+	require.Equal(t, "a.b.c", cf.SDJWT)
+	require.Equal(t, []string{"d1", "d2"}, cf.Disclosures)
+	require.Empty(t, cf.HolderBinding)
+	require.Equal(t, "a.b.c~d1~d2~", cf.Serialize())
+
+	cf = ParseCombinedFormat("a.b.c~d1~e.f.g")
+	require.Equal(t, []string{"d1"}, cf.Disclosures)
+	require.Equal(t, "e.f.g", cf.HolderBinding)
+
+	require.False(t, IsCombinedFormat("a.b.c~d1"))
+	require.False(t, IsCombinedFormat(`{"name":"a.b.c~"}`))
+}
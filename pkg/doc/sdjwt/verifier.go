@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+type verifyOpts struct {
+	signatureVerifier     jose.SignatureVerifier
+	holderBindingRequired bool
+	expectedNonce         string
+	expectedAudience      string
+}
+
+// VerifyOpt is an SD-JWT verifier option.
+type VerifyOpt func(opts *verifyOpts)
+
+// WithSignatureVerifier sets the verifier of the issuer signature of the SD-JWT, it is required.
+func WithSignatureVerifier(signatureVerifier jose.SignatureVerifier) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.signatureVerifier = signatureVerifier
+	}
+}
+
+// WithHolderBindingRequired rejects the presentations without a holder binding JWT.
+func WithHolderBindingRequired(required bool) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.holderBindingRequired = required
+	}
+}
+
+// WithExpectedNonceForHolderBinding sets the nonce expected in the holder binding JWT.
+func WithExpectedNonceForHolderBinding(nonce string) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.expectedNonce = nonce
+	}
+}
+
+// WithExpectedAudienceForHolderBinding sets the audience expected in the holder binding JWT.
+func WithExpectedAudienceForHolderBinding(audience string) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.expectedAudience = audience
+	}
+}
+
+// Verify verifies an SD-JWT presentation and returns its claims, with the disclosed claims in place of their
+// digests and without the undisclosed ones.
+func Verify(combinedFormatForPresentation string, opts ...VerifyOpt) (map[string]interface{}, error) {
+	vOpts := &verifyOpts{}
+
+	for _, opt := range opts {
+		opt(vOpts)
+	}
+
+	if vOpts.signatureVerifier == nil {
+		return nil, errors.New("signature verifier is not defined")
+	}
+
+	cf := ParseCombinedFormat(combinedFormatForPresentation)
+
+	signedJWT, err := jwt.Parse(cf.SDJWT, jwt.WithSignatureVerifier(vOpts.signatureVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	disclosures, err := GetDisclosureClaims(cf.Disclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := DiscloseClaims(signedJWT.Payload, disclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifyHolderBinding(signedJWT.Payload, cf.HolderBinding, vOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func verifyHolderBinding(claims map[string]interface{}, holderBinding string, opts *verifyOpts) error {
+	if holderBinding == "" {
+		if opts.holderBindingRequired {
+			return errors.New("holder binding is required")
+		}
+
+		return nil
+	}
+
+	holderKey, err := getHolderPublicKey(claims)
+	if err != nil {
+		return fmt.Errorf("holder binding: %w", err)
+	}
+
+	v, err := jwt.GetVerifier(&verifier.PublicKey{JWK: holderKey})
+	if err != nil {
+		return fmt.Errorf("holder binding: %w", err)
+	}
+
+	bindingJWT, err := jwt.Parse(holderBinding, jwt.WithSignatureVerifier(v))
+	if err != nil {
+		return fmt.Errorf("parse holder binding: %w", err)
+	}
+
+	var payload BindingPayload
+
+	err = bindingJWT.DecodeClaims(&payload)
+	if err != nil {
+		return fmt.Errorf("decode holder binding: %w", err)
+	}
+
+	if opts.expectedNonce != "" && payload.Nonce != opts.expectedNonce {
+		return errors.New("holder binding: nonce does not match")
+	}
+
+	if opts.expectedAudience != "" && payload.Audience != opts.expectedAudience {
+		return errors.New("holder binding: audience does not match")
+	}
+
+	return nil
+}
+
+func getHolderPublicKey(claims map[string]interface{}) (*jwk.JWK, error) {
+	cnf, ok := claims[CNFKey].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("cnf claim is not defined")
+	}
+
+	jwkObj, ok := cnf[jwkKey]
+	if !ok {
+		return nil, errors.New("jwk is not defined in the cnf claim")
+	}
+
+	jwkBytes, err := json.Marshal(jwkObj)
+	if err != nil {
+		return nil, err
+	}
+
+	var holderKey jwk.JWK
+
+	err = holderKey.UnmarshalJSON(jwkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal cnf jwk: %w", err)
+	}
+
+	return &holderKey, nil
+}
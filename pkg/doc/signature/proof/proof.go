@@ -38,8 +38,11 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldCryptosuite is a key for the cryptosuite of a Data Integrity proof.
+	jsonldCryptosuite = "cryptosuite"
 
 	ed25519Signature2020 = "Ed25519Signature2020"
+	dataIntegrityProof   = "DataIntegrityProof"
 )
 
 // Proof is cryptographic proof of the integrity of the DID Document.
@@ -57,6 +60,8 @@ type Proof struct {
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// Cryptosuite is the cryptographic suite of a DataIntegrityProof (e.g. "eddsa-2022").
+	Cryptosuite string
 }
 
 // NewProof creates new proof.
@@ -113,6 +118,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
 		CapabilityChain:         capabilityChain,
+		Cryptosuite:             stringEntry(emap[jsonldCryptosuite]),
 	}, nil
 }
 
@@ -149,7 +155,7 @@ func decodeBase64(s string) ([]byte, error) {
 
 // DecodeProofValue decodes proofValue basing on proof type.
 func DecodeProofValue(s, proofType string) ([]byte, error) {
-	if proofType == ed25519Signature2020 {
+	if isMultibaseProofType(proofType) {
 		_, value, err := multibase.Decode(s)
 		if err == nil {
 			return value, nil
@@ -161,6 +167,11 @@ func DecodeProofValue(s, proofType string) ([]byte, error) {
 	return decodeBase64(s)
 }
 
+// isMultibaseProofType checks if the proofValue of the proof type is multibase encoded.
+func isMultibaseProofType(proofType string) bool {
+	return proofType == ed25519Signature2020 || proofType == dataIntegrityProof
+}
+
 // stringEntry.
 func stringEntry(entry interface{}) string {
 	if entry == nil {
@@ -215,12 +226,16 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldCapabilityChain] = p.CapabilityChain
 	}
 
+	if p.Cryptosuite != "" {
+		emap[jsonldCryptosuite] = p.Cryptosuite
+	}
+
 	return emap
 }
 
 // EncodeProofValue decodes proofValue basing on proof type.
 func EncodeProofValue(proofValue []byte, proofType string) string {
-	if proofType == ed25519Signature2020 {
+	if isMultibaseProofType(proofType) {
 		encoded, _ := multibase.Encode(multibase.Base58BTC, proofValue) //nolint: errcheck
 		return encoded
 	}
@@ -75,6 +75,20 @@ func TestProof(t *testing.T) {
 	require.Equal(t, []byte(""), p.Nonce)
 	require.Equal(t, proofValueBytes, p.ProofValue)
 
+	// test Data Integrity proof
+	p, err = NewProof(map[string]interface{}{
+		"type":               "DataIntegrityProof",
+		"cryptosuite":        "eddsa-2022",
+		"verificationMethod": "did:example:123456#key1",
+		"created":            "2018-03-15T00:00:00Z",
+		"proofValue":         proofValueMultibase,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "eddsa-2022", p.Cryptosuite)
+	require.Equal(t, proofValueBytes, p.ProofValue)
+	require.Equal(t, proofValueMultibase, p.JSONLdObject()["proofValue"])
+	require.Equal(t, "eddsa-2022", p.JSONLdObject()["cryptosuite"])
+
 	// test created time with milliseconds section
 	p, err = NewProof(map[string]interface{}{
 		"type":               "type",
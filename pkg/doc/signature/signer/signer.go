@@ -39,6 +39,12 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuiteAcceptor is implemented by the signature suites of the Data Integrity proofs, they are selected by
+// the cryptosuite of the proof in addition to its type.
+type cryptosuiteAcceptor interface {
+	AcceptCryptosuite(cryptosuite string) bool
+}
+
 // DocumentSigner implements signing of JSONLD documents.
 type DocumentSigner struct {
 	signatureSuites []SignatureSuite
@@ -56,6 +62,7 @@ type Context struct {
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	Cryptosuite             string                        // required for DataIntegrityProof
 }
 
 // New returns new instance of document verifier.
@@ -92,7 +99,7 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		return err
 	}
 
-	suite, err := signer.getSignatureSuite(context.SignatureType)
+	suite, err := signer.getSignatureSuite(context.SignatureType, context.Cryptosuite)
 	if err != nil {
 		return err
 	}
@@ -114,6 +121,7 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		Challenge:               context.Challenge,
 		ProofPurpose:            context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		Cryptosuite:             context.Cryptosuite,
 	}
 
 	// TODO support custom proof purpose
@@ -150,12 +158,22 @@ func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Pro
 	}
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (signer *DocumentSigner) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type and cryptosuite.
+func (signer *DocumentSigner) getSignatureSuite(signatureType, cryptosuite string) (SignatureSuite, error) {
 	for _, s := range signer.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+		if !s.Accept(signatureType) {
+			continue
+		}
+
+		if ca, ok := s.(cryptosuiteAcceptor); ok && !ca.AcceptCryptosuite(cryptosuite) {
+			continue
 		}
+
+		return s, nil
+	}
+
+	if cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", signatureType, cryptosuite)
 	}
 
 	return nil, fmt.Errorf("signature type %s not supported", signatureType)
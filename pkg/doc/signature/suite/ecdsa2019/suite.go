@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdsa2019 implements the ecdsa-2019 cryptosuite of the DataIntegrityProof type
+// for the Verifiable Credential Data Integrity specification (https://www.w3.org/TR/vc-di-ecdsa/).
+// It uses the RDF Dataset Normalization Algorithm to transform the input document into its canonical form.
+// It uses SHA-256 with P-256 keys and SHA-384 with P-384 keys as the message digest algorithm and
+// ECDSA as the signature algorithm.
+package ecdsa2019
+
+import (
+	"crypto"
+	"crypto/elliptic"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Suite implements ecdsa-2019 cryptosuite for a single curve.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+	curve           elliptic.Curve
+	hash            crypto.Hash
+}
+
+const (
+	// SignatureType is the proof type of the Data Integrity proofs.
+	SignatureType = "DataIntegrityProof"
+	// Cryptosuite is the cryptosuite of the proof.
+	Cryptosuite   = "ecdsa-2019"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of ecdsa-2019 cryptosuite for P-256 keys.
+func New(opts ...suite.Opt) *Suite {
+	return newSuite(elliptic.P256(), crypto.SHA256, opts...)
+}
+
+// NewP384 an instance of ecdsa-2019 cryptosuite for P-384 keys.
+func NewP384(opts ...suite.Opt) *Suite {
+	return newSuite(elliptic.P384(), crypto.SHA384, opts...)
+}
+
+func newSuite(curve elliptic.Curve, hash crypto.Hash, opts ...suite.Opt) *Suite {
+	s := &Suite{
+		jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg),
+		curve:           curve,
+		hash:            hash,
+	}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// ecdsa-2019 cryptosuite uses RDF Dataset Normalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest, SHA-256 for P-256 and SHA-384 for P-384.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	h := s.hash.New()
+	h.Write(doc) // nolint:errcheck,gosec
+
+	return h.Sum(nil)
+}
+
+// Accept will accept only DataIntegrityProof type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// AcceptCryptosuite will accept only ecdsa-2019 cryptosuite.
+func (s *Suite) AcceptCryptosuite(cryptosuite string) bool {
+	return cryptosuite == Cryptosuite
+}
+
+// AcceptPublicKey will accept only the keys of the curve of the suite, either as JWK or as compressed or
+// uncompressed point.
+func (s *Suite) AcceptPublicKey(pubKey *verifier.PublicKey) bool {
+	if pubKey == nil {
+		return false
+	}
+
+	if pubKey.JWK != nil {
+		return pubKey.JWK.Crv == s.curve.Params().Name
+	}
+
+	byteLen := (s.curve.Params().BitSize + 7) / 8 // nolint:gomnd
+
+	return len(pubKey.Value) == 1+byteLen || len(pubKey.Value) == 1+2*byteLen
+}
@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsa2019
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	require.Len(t, New().GetDigest([]byte("test doc")), 32)
+	require.Len(t, NewP384().GetDigest([]byte("test doc")), 48)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("DataIntegrityProof"))
+	require.False(t, ss.Accept("EcdsaSecp256k1Signature2019"))
+
+	require.True(t, ss.AcceptCryptosuite("ecdsa-2019"))
+	require.False(t, ss.AcceptCryptosuite("eddsa-2022"))
+}
+
+func TestSignatureSuite_AcceptPublicKey(t *testing.T) {
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	p256, p384 := New(), NewP384()
+
+	compressed := &verifier.PublicKey{
+		Value: elliptic.MarshalCompressed(elliptic.P256(), p256Key.X, p256Key.Y),
+	}
+	require.True(t, p256.AcceptPublicKey(compressed))
+	require.False(t, p384.AcceptPublicKey(compressed))
+
+	uncompressed := &verifier.PublicKey{
+		Value: elliptic.Marshal(elliptic.P384(), p384Key.X, p384Key.Y),
+	}
+	require.False(t, p256.AcceptPublicKey(uncompressed))
+	require.True(t, p384.AcceptPublicKey(uncompressed))
+
+	withJWK := &verifier.PublicKey{JWK: &jwk.JWK{Kty: "EC", Crv: "P-384"}}
+	require.False(t, p256.AcceptPublicKey(withJWK))
+	require.True(t, p384.AcceptPublicKey(withJWK))
+
+	require.False(t, p256.AcceptPublicKey(nil))
+}
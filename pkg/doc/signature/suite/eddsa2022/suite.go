@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eddsa2022 implements the eddsa-2022 cryptosuite of the DataIntegrityProof type
+// for the Verifiable Credential Data Integrity specification (https://www.w3.org/TR/vc-di-eddsa/).
+// It uses the RDF Dataset Normalization Algorithm to transform the input document into its canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm and
+// Ed25519 [ED25519] as the signature algorithm.
+package eddsa2022
+
+import (
+	"crypto/sha256"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements eddsa-2022 cryptosuite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureType is the proof type of the Data Integrity proofs.
+	SignatureType = "DataIntegrityProof"
+	// Cryptosuite is the cryptosuite of the proof.
+	Cryptosuite   = "eddsa-2022"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of eddsa-2022 cryptosuite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// eddsa-2022 cryptosuite uses RDF Dataset Normalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only DataIntegrityProof type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// AcceptCryptosuite will accept only eddsa-2022 cryptosuite.
+func (s *Suite) AcceptCryptosuite(cryptosuite string) bool {
+	return cryptosuite == Cryptosuite
+}
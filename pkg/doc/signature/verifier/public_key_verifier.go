@@ -332,6 +332,10 @@ func (sv *ECDSASignatureVerifier) createJWK(pubKeyBytes []byte) (*jwk.JWK, error
 	curve := sv.ec.curve
 
 	x, y := elliptic.Unmarshal(curve, pubKeyBytes)
+	if x == nil {
+		x, y = elliptic.UnmarshalCompressed(curve, pubKeyBytes)
+	}
+
 	if x == nil {
 		return nil, errors.New("invalid public key")
 	}
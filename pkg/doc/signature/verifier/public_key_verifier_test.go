@@ -353,6 +353,18 @@ func TestNewECDSAES256SignatureVerifier(t *testing.T) {
 		require.NoError(t, verifyError)
 	})
 
+	t.Run("verify with compressed public key bytes", func(t *testing.T) {
+		x, y := elliptic.Unmarshal(elliptic.P256(), signer.PublicKeyBytes())
+		require.NotNil(t, x)
+
+		verifyError := v.Verify(&PublicKey{
+			Type:  "Multikey",
+			Value: elliptic.MarshalCompressed(elliptic.P256(), x, y),
+		}, msg, msgSig)
+
+		require.NoError(t, verifyError)
+	})
+
 	t.Run("invalid public key", func(t *testing.T) {
 		verifyError := v.Verify(&PublicKey{
 			Type:  "JwsVerificationKey2020",
@@ -34,6 +34,18 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuiteAcceptor is implemented by the signature suites of the Data Integrity proofs, they are selected by
+// the cryptosuite of the proof in addition to its type.
+type cryptosuiteAcceptor interface {
+	AcceptCryptosuite(cryptosuite string) bool
+}
+
+// publicKeyAcceptor is implemented by the signature suites which depend on the type of the verification key, like
+// the ecdsa-2019 cryptosuite whose hash algorithm is selected by the curve of the key.
+type publicKeyAcceptor interface {
+	AcceptPublicKey(pubKey *PublicKey) bool
+}
+
 // PublicKey contains a result of public key resolution.
 type PublicKey struct {
 	Type  string
@@ -96,7 +108,7 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 			return err
 		}
 
-		suite, err := dv.getSignatureSuite(p.Type)
+		suite, err := dv.getSignatureSuite(p, publicKey)
 		if err != nil {
 			return err
 		}
@@ -120,15 +132,29 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 	return nil
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type, cryptosuite and verification key.
+func (dv *DocumentVerifier) getSignatureSuite(p *proof.Proof, publicKey *PublicKey) (SignatureSuite, error) {
 	for _, s := range dv.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+		if !s.Accept(p.Type) {
+			continue
+		}
+
+		if ca, ok := s.(cryptosuiteAcceptor); ok && !ca.AcceptCryptosuite(p.Cryptosuite) {
+			continue
 		}
+
+		if pka, ok := s.(publicKeyAcceptor); ok && !pka.AcceptPublicKey(publicKey) {
+			continue
+		}
+
+		return s, nil
+	}
+
+	if p.Cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", p.Type, p.Cryptosuite)
 	}
 
-	return nil, fmt.Errorf("signature type %s not supported", signatureType)
+	return nil, fmt.Errorf("signature type %s not supported", p.Type)
 }
 
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
@@ -14,18 +14,24 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/piprate/json-gold/ld"
+	gojose "github.com/square/go-jose/v3"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/verifier"
 	jsonutil "github.com/markcryptohash/aries-framework-go/pkg/doc/util/json"
 	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
 	kmsapi "github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 // TODO https://github.com/square/go-jose/issues/263 support ES256K
@@ -121,6 +127,9 @@ func SingleKey(pubKey []byte, pubKeyType string) PublicKeyFetcher {
 	}
 }
 
+// multikeyType is the verification method type of the multicodec encoded keys used by the Data Integrity proofs.
+const multikeyType = "Multikey"
+
 // VDRKeyResolver resolves DID in order to find public keys for VC verification using vdr.Registry.
 // A source of DID could be issuer of VC or holder of VP. It can be also obtained from
 // JWS "issuer" claim or "verificationMethod" of Linked Data Proof.
@@ -142,6 +151,10 @@ func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 	for _, verifications := range docResolution.DIDDocument.VerificationMethods() {
 		for _, verification := range verifications {
 			if strings.Contains(verification.VerificationMethod.ID, keyID) {
+				if verification.VerificationMethod.Type == multikeyType {
+					return multikeyToPublicKey(verification.VerificationMethod.ID, verification.VerificationMethod.Value)
+				}
+
 				return &verifier.PublicKey{
 					Type:  verification.VerificationMethod.Type,
 					Value: verification.VerificationMethod.Value,
@@ -154,6 +167,43 @@ func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
 }
 
+// multikeyToPublicKey decodes the multicodec prefixed key of a Multikey verification method, as used by the
+// Data Integrity cryptosuites.
+func multikeyToPublicKey(id string, value []byte) (*verifier.PublicKey, error) {
+	code, n := binary.Uvarint(value)
+	if n <= 0 {
+		return nil, fmt.Errorf("multikey %s: invalid multicodec prefix", id)
+	}
+
+	var curve elliptic.Curve
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		return &verifier.PublicKey{Type: multikeyType, Value: value[n:]}, nil
+	case fingerprint.P256PubKeyMultiCodec:
+		curve = elliptic.P256()
+	case fingerprint.P384PubKeyMultiCodec:
+		curve = elliptic.P384()
+	default:
+		return nil, fmt.Errorf("multikey %s: unsupported multicodec 0x%x", id, code)
+	}
+
+	x, y := elliptic.UnmarshalCompressed(curve, value[n:])
+	if x == nil {
+		return nil, fmt.Errorf("multikey %s: invalid %s public key", id, curve.Params().Name)
+	}
+
+	return &verifier.PublicKey{
+		Type:  multikeyType,
+		Value: elliptic.Marshal(curve, x, y),
+		JWK: &jwk.JWK{
+			JSONWebKey: gojose.JSONWebKey{Key: &ecdsa.PublicKey{Curve: curve, X: x, Y: y}},
+			Kty:        "EC",
+			Crv:        curve.Params().Name,
+		},
+	}, nil
+}
+
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
 func (r *VDRKeyResolver) PublicKeyFetcher() PublicKeyFetcher {
 	return r.resolvePublicKey
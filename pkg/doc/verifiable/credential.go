@@ -22,6 +22,7 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
 	docjsonld "github.com/markcryptohash/aries-framework-go/pkg/doc/jsonld"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/sdjwt"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/util"
 	jsonutil "github.com/markcryptohash/aries-framework-go/pkg/doc/util/json"
@@ -504,6 +505,8 @@ type Credential struct {
 	Issuer         Issuer
 	Issued         *util.TimeWrapper
 	Expired        *util.TimeWrapper
+	ValidFrom      *util.TimeWrapper
+	ValidUntil     *util.TimeWrapper
 	Proofs         []Proof
 	Status         *TypedID
 	Schemas        []TypedID
@@ -512,6 +515,10 @@ type Credential struct {
 	RefreshService []TypedID
 	JWT            string
 
+	// SDJWTDisclosures and SDJWTHolderBinding are set when the credential is an SD-JWT.
+	SDJWTDisclosures   []*sdjwt.DisclosureClaim
+	SDJWTHolderBinding string
+
 	CustomFields CustomFields
 }
 
@@ -523,6 +530,8 @@ type rawCredential struct {
 	Subject        json.RawMessage   `json:"credentialSubject,omitempty"`
 	Issued         *util.TimeWrapper `json:"issuanceDate,omitempty"`
 	Expired        *util.TimeWrapper `json:"expirationDate,omitempty"`
+	ValidFrom      *util.TimeWrapper `json:"validFrom,omitempty"`
+	ValidUntil     *util.TimeWrapper `json:"validUntil,omitempty"`
 	Proof          json.RawMessage   `json:"proof,omitempty"`
 	Status         *TypedID          `json:"credentialStatus,omitempty"`
 	Issuer         json.RawMessage   `json:"issuer,omitempty"`
@@ -579,6 +588,7 @@ type credentialOpts struct {
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
 	defaultSchema         string
+	statusListFetcher     StatusListCredentialFetcher
 
 	jsonldCredentialOpts
 }
@@ -651,6 +661,7 @@ func WithBaseContextExtendedValidation(customContexts, customTypes []string) Cre
 		}
 
 		opts.allowedCustomContexts[baseContext] = true
+		opts.allowedCustomContexts[baseContextV2] = true
 
 		opts.allowedCustomTypes = make(map[string]bool)
 		for _, context := range customTypes {
@@ -796,6 +807,10 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 	// Apply options.
 	vcOpts := getCredentialOpts(opts)
 
+	return parseCredential(vcData, vcOpts)
+}
+
+func parseCredential(vcData []byte, vcOpts *credentialOpts) (*Credential, error) {
 	// Decode credential (e.g. from JWT).
 	vcDataDecoded, externalJWT, err := decodeRaw(vcData, vcOpts)
 	if err != nil {
@@ -822,6 +837,20 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 
 	vc.JWT = externalJWT
 
+	if sdjwt.IsCombinedFormat(externalJWT) {
+		err = vc.setSDJWT(externalJWT)
+		if err != nil {
+			return nil, fmt.Errorf("decode SD-JWT disclosures: %w", err)
+		}
+	}
+
+	if vcOpts.statusListFetcher != nil && vc.Status != nil && vc.Status.Type == StatusList2021EntryType {
+		err = vc.checkStatusList2021(vcOpts)
+		if err != nil {
+			return nil, fmt.Errorf("check credential status: %w", err)
+		}
+	}
+
 	return vc, nil
 }
 
@@ -859,7 +888,7 @@ func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts
 		return errors.New("violated type constraint: not base only type defined")
 	}
 
-	if len(vc.Context) > 1 || vc.Context[0] != baseContext {
+	if len(vc.Context) > 1 || vc.Context[0] != vc.Version().BaseContext() {
 		return errors.New("violated @context constraint: not base only @context defined")
 	}
 
@@ -887,7 +916,7 @@ func (vc *Credential) validateJSONLD(vcBytes []byte, vcOpts *credentialOpts) err
 		docjsonld.WithDocumentLoader(vcOpts.jsonldCredentialOpts.jsonldDocumentLoader),
 		docjsonld.WithExternalContext(vcOpts.jsonldCredentialOpts.externalContext),
 		docjsonld.WithStrictValidation(vcOpts.strictValidation),
-		docjsonld.WithStrictContextURIPosition(baseContext),
+		docjsonld.WithStrictContextURIPosition(vc.Version().BaseContext()),
 	)
 }
 
@@ -987,6 +1016,8 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		Issuer:         issuer,
 		Issued:         raw.Issued,
 		Expired:        raw.Expired,
+		ValidFrom:      raw.ValidFrom,
+		ValidUntil:     raw.ValidUntil,
 		Proofs:         proofs,
 		Status:         raw.Status,
 		Schemas:        schemas,
@@ -1048,6 +1079,19 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, string, error) {
 		externalVCStr = jwtHolder.JWT
 	}
 
+	if sdjwt.IsCombinedFormat(externalVCStr) { // External proof, is checked by SD-JWT.
+		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
+			return nil, "", errors.New("public key fetcher is not defined")
+		}
+
+		vcDecodedBytes, err := decodeCredSDJWT(externalVCStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher)
+		if err != nil {
+			return nil, "", fmt.Errorf("SD-JWT decoding: %w", err)
+		}
+
+		return vcDecodedBytes, externalVCStr, nil
+	}
+
 	if jwt.IsJWS(externalVCStr) { // External proof, is checked by JWS.
 		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
 			return nil, "", errors.New("public key fetcher is not defined")
@@ -1197,53 +1241,64 @@ func subjectMapToRaw(subject map[string]interface{}) (json.RawMessage, error) {
 }
 
 func (vc *Credential) validateJSONSchema(data []byte, opts *credentialOpts) error {
-	return validateCredentialUsingJSONSchema(data, vc.Schemas, opts)
+	return validateCredentialUsingJSONSchema(data, vc.Schemas, vc.Version(), opts)
 }
 
-func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *credentialOpts) error {
+func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, version DataModelVersion,
+	opts *credentialOpts) error {
 	// Validate that the Verifiable Credential conforms to the serialization of the Verifiable Credential data model
 	// (https://w3c.github.io/vc-data-model/#example-1-a-simple-example-of-a-verifiable-credential)
-	schemaLoader, err := getSchemaLoader(schemas, opts)
+	schemaLoaders, err := getSchemaLoaders(schemas, version, opts)
 	if err != nil {
 		return err
 	}
 
 	loader := gojsonschema.NewStringLoader(string(data))
 
-	result, err := gojsonschema.Validate(schemaLoader, loader)
-	if err != nil {
-		return fmt.Errorf("validation of verifiable credential: %w", err)
-	}
+	for _, schemaLoader := range schemaLoaders {
+		result, validateErr := gojsonschema.Validate(schemaLoader, loader)
+		if validateErr != nil {
+			return fmt.Errorf("validation of verifiable credential: %w", validateErr)
+		}
 
-	if !result.Valid() {
-		errMsg := describeSchemaValidationError(result, "verifiable credential")
-		return errors.New(errMsg)
+		if !result.Valid() {
+			errMsg := describeSchemaValidationError(result, "verifiable credential")
+			return errors.New(errMsg)
+		}
 	}
 
 	return nil
 }
 
-func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSONLoader, error) {
+// getSchemaLoaders returns loaders of all supported credential schemas, the credential has to conform to each of them.
+func getSchemaLoaders(schemas []TypedID, version DataModelVersion,
+	opts *credentialOpts) ([]gojsonschema.JSONLoader, error) {
 	if opts.disabledCustomSchema {
-		return defaultSchemaLoaderWithOpts(opts), nil
+		return []gojsonschema.JSONLoader{defaultSchemaLoaderWithOpts(version, opts)}, nil
 	}
 
+	var loaders []gojsonschema.JSONLoader
+
 	for _, schema := range schemas {
 		switch schema.Type {
-		case jsonSchema2018Type:
+		case jsonSchema2018Type, jsonSchemaType:
 			customSchemaData, err := getJSONSchema(schema.ID, opts)
 			if err != nil {
 				return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
 			}
 
-			return gojsonschema.NewBytesLoader(customSchemaData), nil
+			loaders = append(loaders, gojsonschema.NewBytesLoader(customSchemaData))
 		default:
 			logger.Warnf("unsupported credential schema: %s. Using default schema for validation", schema.Type)
 		}
 	}
 
-	// If no custom schema is chosen, use default one
-	return defaultSchemaLoaderWithOpts(opts), nil
+	if len(loaders) == 0 {
+		// If no custom schema is chosen, use default one
+		loaders = append(loaders, defaultSchemaLoaderWithOpts(version, opts))
+	}
+
+	return loaders, nil
 }
 
 type schemaOpts struct {
@@ -1262,13 +1317,15 @@ func WithDisableRequiredField(fieldName string) SchemaOpt {
 
 // JSONSchemaLoader creates default schema with the option to disable the check of specific properties.
 func JSONSchemaLoader(opts ...SchemaOpt) string {
-	defaultRequired := []string{
+	return jsonSchema(DefaultSchemaTemplate, []string{
 		schemaPropertyType,
 		schemaPropertyCredentialSubject,
 		schemaPropertyIssuer,
 		schemaPropertyIssuanceDate,
-	}
+	}, opts)
+}
 
+func jsonSchema(template string, defaultRequired []string, opts []SchemaOpt) string {
 	dsOpts := &schemaOpts{}
 	for _, opt := range opts {
 		opt(dsOpts)
@@ -1291,14 +1348,18 @@ func JSONSchemaLoader(opts ...SchemaOpt) string {
 		}
 	}
 
-	return fmt.Sprintf(DefaultSchemaTemplate, required)
+	return fmt.Sprintf(template, required)
 }
 
-func defaultSchemaLoaderWithOpts(opts *credentialOpts) gojsonschema.JSONLoader {
+func defaultSchemaLoaderWithOpts(version DataModelVersion, opts *credentialOpts) gojsonschema.JSONLoader {
 	if opts.defaultSchema != "" {
 		return gojsonschema.NewStringLoader(opts.defaultSchema)
 	}
 
+	if version == DataModelV2 {
+		return defaultSchemaLoaderV2()
+	}
+
 	return defaultSchemaLoader()
 }
 
@@ -1469,6 +1530,8 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		TermsOfUse:     rawTermsOfUse,
 		Issued:         vc.Issued,
 		Expired:        vc.Expired,
+		ValidFrom:      vc.ValidFrom,
+		ValidUntil:     vc.ValidUntil,
 		JWT:            vc.JWT,
 		CustomFields:   vc.CustomFields,
 	}
@@ -1517,6 +1580,10 @@ func (vc *Credential) MarshalJSON() ([]byte, error) {
 	if vc.JWT != "" {
 		// If vc.JWT exists, marshal only the JWT, since all other values should be unchanged
 		// from when the JWT was parsed.
+		if vc.SDJWTDisclosures != nil {
+			return []byte("\"" + vc.combinedSDJWT(vc.SDJWTHolderBinding) + "\""), nil
+		}
+
 		return []byte("\"" + vc.JWT + "\""), nil
 	}
 
@@ -7,7 +7,7 @@ package verifiable
 
 // MarshalJWS serializes JWT into signed form (JWS).
 func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jcc, signatureAlg, signer, keyID)
+	return marshalJWS(jcc, signatureAlg, signer, keyID, jcc.jwtType())
 }
 
 func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) (*JWTCredClaims, error) {
@@ -21,6 +21,9 @@ const (
 	vcIssuanceDateField   = "issuanceDate"
 	vcIDField             = "id"
 	vcExpirationDateField = "expirationDate"
+	vcValidFromField      = "validFrom"
+	vcValidUntilField     = "validUntil"
+	vcContextField        = "@context"
 	vcIssuerField         = "issuer"
 	vcIssuerIDField       = "id"
 )
@@ -42,14 +45,17 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 
 	// currently jwt encoding supports only single subject (by the spec)
 	jwtClaims := &jwt.Claims{
-		Issuer:    vc.Issuer.ID,                           // iss
-		NotBefore: josejwt.NewNumericDate(vc.Issued.Time), // nbf
-		ID:        vc.ID,                                  // jti
-		Subject:   subjectID,                              // sub
+		Issuer:  vc.Issuer.ID, // iss
+		ID:      vc.ID,        // jti
+		Subject: subjectID,    // sub
 	}
 
-	if vc.Expired != nil {
-		jwtClaims.Expiry = josejwt.NewNumericDate(vc.Expired.Time) // exp
+	if validFrom := vc.validFrom(); validFrom != nil {
+		jwtClaims.NotBefore = josejwt.NewNumericDate(validFrom.Time) // nbf
+	}
+
+	if validUntil := vc.validUntil(); validUntil != nil {
+		jwtClaims.Expiry = josejwt.NewNumericDate(validUntil.Time) // exp
 	}
 
 	var raw *rawCredential
@@ -59,6 +65,8 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 		vcCopy.Expired = nil
 		vcCopy.Issuer.ID = ""
 		vcCopy.Issued = nil
+		vcCopy.ValidFrom = nil
+		vcCopy.ValidUntil = nil
 		vcCopy.ID = ""
 
 		raw, err = vcCopy.raw()
@@ -116,15 +124,21 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 		refineVCIssuerFromJWTClaims(vcMap, iss)
 	}
 
+	if jti := claims.ID; jti != "" {
+		vcMap[vcIDField] = jti
+	}
+
+	if rawDataModelVersion(vcMap[vcContextField]) == DataModelV2 {
+		refineVCValidityPeriodFromJWTClaims(vcMap, claims)
+
+		return
+	}
+
 	if nbf := claims.NotBefore; nbf != nil {
 		nbfTime := nbf.Time().UTC()
 		vcMap[vcIssuanceDateField] = nbfTime.Format(time.RFC3339)
 	}
 
-	if jti := claims.ID; jti != "" {
-		vcMap[vcIDField] = jti
-	}
-
 	if iat := claims.IssuedAt; iat != nil {
 		iatTime := iat.Time().UTC()
 		vcMap[vcIssuanceDateField] = iatTime.Format(time.RFC3339)
@@ -136,6 +150,25 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	}
 }
 
+// refineVCValidityPeriodFromJWTClaims maps "nbf" and "exp" claims to validFrom and validUntil of Data Model 2.0.
+func refineVCValidityPeriodFromJWTClaims(vcMap map[string]interface{}, claims *jwt.Claims) {
+	if nbf := claims.NotBefore; nbf != nil {
+		vcMap[vcValidFromField] = nbf.Time().UTC().Format(time.RFC3339)
+	}
+
+	if exp := claims.Expiry; exp != nil {
+		vcMap[vcValidUntilField] = exp.Time().UTC().Format(time.RFC3339)
+	}
+}
+
+func (jcc *JWTCredClaims) jwtType() string {
+	if rawDataModelVersion(jcc.VC[vcContextField]) == DataModelV2 {
+		return jwtTypeCredential
+	}
+
+	return ""
+}
+
 func refineVCIssuerFromJWTClaims(vcMap map[string]interface{}, iss string) {
 	// Issuer of Verifiable Credential could be either string (id) or struct (with "id" field).
 	if _, exists := vcMap[vcIssuerField]; !exists {
@@ -6,7 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -28,9 +30,11 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ecdsa2019"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/eddsa2022"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/markcryptohash/aries-framework-go/pkg/doc/signature/verifier"
 	jsonutil "github.com/markcryptohash/aries-framework-go/pkg/doc/util/json"
@@ -104,6 +108,126 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2020(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_DataIntegrityProof(t *testing.T) {
+	t.Run("eddsa-2022", func(t *testing.T) {
+		r := require.New(t)
+
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		r.NoError(err)
+
+		vc := newDataIntegrityTestCredential(t)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			Cryptosuite:             "eddsa-2022",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   eddsa2022.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:123456#key1",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 1)
+		r.Equal("eddsa-2022", vc.Proofs[0]["cryptosuite"])
+		r.True(strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+
+		vcBytes, err := json.Marshal(vc)
+		r.NoError(err)
+
+		multikey := append([]byte{0xed, 0x01}, signer.PublicKeyBytes()...)
+
+		vcWithLdp, err := parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(func(_, keyID string) (*sigverifier.PublicKey, error) {
+				return multikeyToPublicKey(keyID, multikey)
+			}))
+		r.NoError(err)
+		r.Equal(vc, vcWithLdp)
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		r.NoError(err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), "Multikey")))
+		r.Error(err)
+		r.Contains(err.Error(), "check embedded proof")
+	})
+
+	t.Run("ecdsa-2019", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			keyType   kms.KeyType
+			multicode []byte
+			newSuite  func(opts ...suite.Opt) *ecdsa2019.Suite
+		}{
+			{name: "P-256", keyType: kms.ECDSAP256TypeIEEEP1363, multicode: []byte{0x80, 0x24}, newSuite: ecdsa2019.New},
+			{name: "P-384", keyType: kms.ECDSAP384TypeIEEEP1363, multicode: []byte{0x81, 0x24}, newSuite: ecdsa2019.NewP384},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				r := require.New(t)
+
+				signer, err := newCryptoSigner(tc.keyType)
+				r.NoError(err)
+
+				vc := newDataIntegrityTestCredential(t)
+
+				err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+					SignatureType:           "DataIntegrityProof",
+					Cryptosuite:             "ecdsa-2019",
+					SignatureRepresentation: SignatureProofValue,
+					Suite:                   tc.newSuite(suite.WithSigner(signer)),
+					VerificationMethod:      "did:example:123456#key1",
+				}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+				r.NoError(err)
+
+				vcBytes, err := json.Marshal(vc)
+				r.NoError(err)
+
+				ecPubKey, ok := signer.PublicKey().(*ecdsa.PublicKey)
+				r.True(ok)
+
+				compressed := elliptic.MarshalCompressed(ecPubKey.Curve, ecPubKey.X, ecPubKey.Y)
+				multikey := append(append([]byte{}, tc.multicode...), compressed...)
+
+				vcWithLdp, err := parseTestCredential(t, vcBytes,
+					WithPublicKeyFetcher(func(_, keyID string) (*sigverifier.PublicKey, error) {
+						return multikeyToPublicKey(keyID, multikey)
+					}))
+				r.NoError(err)
+				r.Equal(vc, vcWithLdp)
+			})
+		}
+	})
+
+	t.Run("unsupported cryptosuite", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc := newDataIntegrityTestCredential(t)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			Cryptosuite:             "ecdsa-2019",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   eddsa2022.New(suite.WithSigner(signer)),
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature type DataIntegrityProof with cryptosuite ecdsa-2019 not supported")
+	})
+}
+
+func newDataIntegrityTestCredential(t *testing.T) *Credential {
+	t.Helper()
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.Context = append(vc.Context, "https://w3id.org/security/data-integrity/v1")
+
+	return vc
+}
+
 //nolint:lll
 func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 	r := require.New(t)
@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/sdjwt"
+	jsonutil "github.com/markcryptohash/aries-framework-go/pkg/doc/util/json"
+)
+
+// MakeSDJWT serializes the credential into an SD-JWT in combined format, with the claims of its credential
+// subjects selectively disclosable.
+func (vc *Credential) MakeSDJWT(signer Signer, signatureAlg JWSAlgorithm, keyID string,
+	opts ...sdjwt.NewOpt) (string, error) {
+	jwtClaims, err := vc.JWTClaims(false)
+	if err != nil {
+		return "", fmt.Errorf("make SD-JWT: %w", err)
+	}
+
+	claims, err := jsonutil.ToMap(jwtClaims)
+	if err != nil {
+		return "", fmt.Errorf("make SD-JWT: %w", err)
+	}
+
+	algName, err := signatureAlg.name()
+	if err != nil {
+		return "", err
+	}
+
+	headers := map[string]interface{}{
+		jose.HeaderKeyID: keyID,
+	}
+
+	sdJWT, err := sdjwt.NewFromVC(claims, headers, getJWTSigner(signer, algName), opts...)
+	if err != nil {
+		return "", fmt.Errorf("make SD-JWT: %w", err)
+	}
+
+	return sdJWT.Serialize(false)
+}
+
+type marshalDisclosureOpts struct {
+	discloseAll   bool
+	required      []string
+	ifAvailable   []string
+	holderBinding *sdjwt.BindingInfo
+}
+
+// MarshalDisclosureOption is an option of MarshalWithDisclosure.
+type MarshalDisclosureOption func(opts *marshalDisclosureOpts)
+
+// DiscloseAll discloses all the selectively disclosable claims of the credential.
+func DiscloseAll() MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.discloseAll = true
+	}
+}
+
+// DiscloseGivenRequired discloses the given claims, they must be selectively disclosable claims of the credential.
+func DiscloseGivenRequired(claimNames []string) MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.required = append(opts.required, claimNames...)
+	}
+}
+
+// DiscloseGivenIfAvailable discloses the given claims which are selectively disclosable claims of the credential.
+func DiscloseGivenIfAvailable(claimNames []string) MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.ifAvailable = append(opts.ifAvailable, claimNames...)
+	}
+}
+
+// DisclosureHolderBinding adds a holder binding JWT to the disclosed credential.
+func DisclosureHolderBinding(info *sdjwt.BindingInfo) MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.holderBinding = info
+	}
+}
+
+// MarshalWithDisclosure serializes an SD-JWT credential into the combined format for presentation, with the
+// selected disclosures only.
+func (vc *Credential) MarshalWithDisclosure(opts ...MarshalDisclosureOption) (string, error) {
+	if vc.JWT == "" || vc.SDJWTDisclosures == nil {
+		return "", errors.New("credential is not an SD-JWT")
+	}
+
+	mOpts := &marshalDisclosureOpts{}
+
+	for _, opt := range opts {
+		opt(mOpts)
+	}
+
+	byName := make(map[string][]string)
+
+	for _, d := range vc.SDJWTDisclosures {
+		byName[d.Name] = append(byName[d.Name], d.Disclosure)
+	}
+
+	var disclosures []string
+
+	if mOpts.discloseAll {
+		for _, d := range vc.SDJWTDisclosures {
+			disclosures = append(disclosures, d.Disclosure)
+		}
+	}
+
+	for _, name := range mOpts.required {
+		if _, ok := byName[name]; !ok {
+			return "", fmt.Errorf("claim '%s' is not selectively disclosable", name)
+		}
+	}
+
+	for _, name := range append(mOpts.required, mOpts.ifAvailable...) {
+		if !mOpts.discloseAll {
+			disclosures = append(disclosures, byName[name]...)
+		}
+
+		delete(byName, name)
+	}
+
+	var holderOpts []sdjwt.HolderOpt
+
+	if mOpts.holderBinding != nil {
+		holderOpts = append(holderOpts, sdjwt.WithHolderBinding(mOpts.holderBinding))
+	}
+
+	return sdjwt.CreatePresentation(vc.combinedSDJWT(""), disclosures, holderOpts...)
+}
+
+// combinedSDJWT serializes the credential SD-JWT with all its disclosures.
+func (vc *Credential) combinedSDJWT(holderBinding string) string {
+	cf := &sdjwt.CombinedFormat{
+		SDJWT:         vc.JWT,
+		HolderBinding: holderBinding,
+	}
+
+	for _, d := range vc.SDJWTDisclosures {
+		cf.Disclosures = append(cf.Disclosures, d.Disclosure)
+	}
+
+	return cf.Serialize()
+}
+
+// setSDJWT keeps the SD-JWT and the disclosures of a credential parsed from the SD-JWT combined format.
+func (vc *Credential) setSDJWT(combined string) error {
+	cf := sdjwt.ParseCombinedFormat(combined)
+
+	disclosures, err := sdjwt.GetDisclosureClaims(cf.Disclosures)
+	if err != nil {
+		return err
+	}
+
+	vc.JWT = cf.SDJWT
+	vc.SDJWTDisclosures = disclosures
+	vc.SDJWTHolderBinding = cf.HolderBinding
+
+	return nil
+}
+
+func decodeCredSDJWT(combined string, checkProof bool, fetcher PublicKeyFetcher) ([]byte, error) {
+	return decodeCredJWT(combined, func(string) (*JWTCredClaims, error) {
+		var verifier jose.SignatureVerifier
+
+		if checkProof {
+			verifier = jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
+		} else {
+			verifier = &noVerifier{}
+		}
+
+		claims, err := sdjwt.Verify(combined, sdjwt.WithSignatureVerifier(verifier))
+		if err != nil {
+			return nil, err
+		}
+
+		var credClaims JWTCredClaims
+
+		// claims hold JSON numbers of the JWT package, decode them with the JSON package of the JWT package.
+		err = (&jwt.JSONWebToken{Payload: claims}).DecodeClaims(&credClaims)
+		if err != nil {
+			return nil, err
+		}
+
+		return &credClaims, nil
+	})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jwt"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/sdjwt"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util"
+	kmsapi "github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+func TestCredential_MakeSDJWT(t *testing.T) {
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	keyFetcher := SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)
+
+	vc := newSDJWTTestCredential()
+
+	combined, err := vc.MakeSDJWT(signer, EdDSA, vc.Issuer.ID+"#keys-1")
+	require.NoError(t, err)
+	require.Len(t, sdjwt.ParseCombinedFormat(combined).Disclosures, 2)
+
+	t.Run("parse with all disclosures", func(t *testing.T) {
+		parsed, err := parseTestCredential(t, []byte(combined), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.Len(t, parsed.SDJWTDisclosures, 2)
+		require.Equal(t, sdjwt.ParseCombinedFormat(combined).SDJWT, parsed.JWT)
+
+		subject := parsed.Subject.([]Subject)[0]
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject.ID)
+		require.Equal(t, "Jayden Doe", subject.CustomFields["name"])
+		require.NotNil(t, subject.CustomFields["degree"])
+
+		parsedBytes, err := json.Marshal(parsed)
+		require.NoError(t, err)
+		require.Equal(t, "\""+combined+"\"", string(parsedBytes))
+	})
+
+	t.Run("parse with selected disclosures", func(t *testing.T) {
+		parsed, err := parseTestCredential(t, []byte(combined), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+
+		presented, err := parsed.MarshalWithDisclosure(DiscloseGivenRequired([]string{"name"}))
+		require.NoError(t, err)
+
+		disclosed, err := parseTestCredential(t, []byte(presented), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.Len(t, disclosed.SDJWTDisclosures, 1)
+
+		subject := disclosed.Subject.([]Subject)[0]
+		require.Equal(t, "Jayden Doe", subject.CustomFields["name"])
+		require.NotContains(t, subject.CustomFields, "degree")
+
+		_, err = parsed.MarshalWithDisclosure(DiscloseGivenRequired([]string{"unknown"}))
+		require.EqualError(t, err, "claim 'unknown' is not selectively disclosable")
+
+		presented, err = parsed.MarshalWithDisclosure(DiscloseGivenIfAvailable([]string{"unknown"}))
+		require.NoError(t, err)
+		require.Empty(t, sdjwt.ParseCombinedFormat(presented).Disclosures)
+
+		presented, err = parsed.MarshalWithDisclosure(DiscloseAll())
+		require.NoError(t, err)
+		require.Len(t, sdjwt.ParseCombinedFormat(presented).Disclosures, 2)
+	})
+
+	t.Run("holder binding", func(t *testing.T) {
+		holderPub, holderPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		holderJWK, err := jwksupport.JWKFromKey(holderPub)
+		require.NoError(t, err)
+
+		bound, err := vc.MakeSDJWT(signer, EdDSA, vc.Issuer.ID+"#keys-1", sdjwt.WithHolderPublicKey(holderJWK))
+		require.NoError(t, err)
+
+		parsed, err := parseTestCredential(t, []byte(bound), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+
+		presented, err := parsed.MarshalWithDisclosure(DiscloseAll(), DisclosureHolderBinding(&sdjwt.BindingInfo{
+			Payload: sdjwt.BindingPayload{Nonce: "nonce"},
+			Signer:  jwt.NewEd25519Signer(holderPriv),
+		}))
+		require.NoError(t, err)
+
+		disclosed, err := parseTestCredential(t, []byte(presented), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.NotEmpty(t, disclosed.SDJWTHolderBinding)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(combined),
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kmsapi.ED25519)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "SD-JWT decoding")
+
+		_, err = parseTestCredential(t, []byte(combined))
+		require.EqualError(t, err, "decode new credential: public key fetcher is not defined")
+
+		_, err = parseTestCredential(t, []byte(combined), WithDisabledProofCheck())
+		require.NoError(t, err)
+	})
+
+	t.Run("not an SD-JWT", func(t *testing.T) {
+		_, err := vc.MarshalWithDisclosure(DiscloseAll())
+		require.EqualError(t, err, "credential is not an SD-JWT")
+	})
+}
+
+func newSDJWTTestCredential() *Credential {
+	return &Credential{
+		Context: []string{baseContext, "https://www.w3.org/2018/credentials/examples/v1"},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{vcType},
+		Issuer:  Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Now().UTC().Truncate(time.Second)),
+		Subject: []Subject{{
+			ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: CustomFields{
+				"name": "Jayden Doe",
+				"degree": map[string]interface{}{
+					"type": "BachelorDegree",
+					"name": "Bachelor of Science and Arts",
+				},
+			},
+		}},
+	}
+}
@@ -148,7 +148,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = "https://www.w3.org/2018/credentials/v1"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -159,7 +159,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = "https://www.w3.org/2018/credentials/v2"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context: @context does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -171,7 +171,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context is required")
 	})
@@ -186,7 +186,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -200,7 +200,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context.0: @context.0 does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -216,7 +216,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context.0: @context.0 does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -229,7 +229,7 @@ func TestValidateVerCredID(t *testing.T) {
 	raw.ID = "not valid credential ID URL"
 	bytes, err := json.Marshal(raw)
 	require.NoError(t, err)
-	err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+	err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "id: Does not match format 'uri'")
 }
@@ -242,7 +242,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = []string{}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "Array must have at least 1 items")
 	})
@@ -254,7 +254,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = []string{"NotVerifiableCredential"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "Does not match pattern '^VerifiableCredential$")
 	})
@@ -266,7 +266,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = "VerifiableCredential"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -278,7 +278,7 @@ func TestValidateVerCredType(t *testing.T) {
 			raw.Type = []string{"UniversityDegreeCredentail", "VerifiableCredential"}
 			bytes, err := json.Marshal(raw)
 			require.NoError(t, err)
-			err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+			err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 			require.NoError(t, err)
 		})
 }
@@ -291,7 +291,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		raw.Subject = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSubject is required")
 	})
@@ -303,7 +303,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(singleCredentialSubject), &raw.Subject))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -314,7 +314,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(multipleCredentialSubjects), &raw.Subject))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -327,7 +327,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		raw.Subject = invalidNumericSubject
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSubject: Invalid type.")
 	})
@@ -341,7 +341,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		raw.Issuer = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer is required")
 	})
@@ -358,7 +358,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -369,7 +369,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(issuerAsObject), &raw.Issuer))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -385,7 +385,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer: Invalid type")
 	})
@@ -402,7 +402,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer: Does not match format 'uri'")
 	})
@@ -423,7 +423,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		bytes, err := json.Marshal(raw)
 
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer.id: Does not match format 'uri'")
 	})
@@ -437,7 +437,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		raw.Issued = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuanceDate is required")
 	})
@@ -450,7 +450,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuanceDate: Does not match format 'date-time'")
 	})
@@ -463,7 +463,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	}
 }
@@ -486,7 +486,7 @@ func TestValidateVerCredProof(t *testing.T) {
 		raw.Proof = proofBytes
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 	t.Run("test verifiable credential with empty proof", func(t *testing.T) {
@@ -496,7 +496,7 @@ func TestValidateVerCredProof(t *testing.T) {
 		raw.Proof = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 }
@@ -509,7 +509,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		raw.Expired = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -521,7 +521,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "expirationDate: Does not match format 'date-time'")
 	})
@@ -534,7 +534,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	}
 }
@@ -547,7 +547,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -558,7 +558,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{Type: "CredentialStatusList2017"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus: id is required")
 	})
@@ -570,7 +570,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{ID: "https://example.edu/status/24"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus: type is required")
 	})
@@ -582,7 +582,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{ID: "invalid URL", Type: "CredentialStatusList2017"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus.id: Does not match format 'uri'")
 	})
@@ -596,7 +596,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -607,7 +607,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{Type: "JsonSchemaValidator2018"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema: id is required")
 	})
@@ -619,7 +619,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{ID: "https://example.org/examples/degree.json"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema: type is required")
 	})
@@ -631,7 +631,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{ID: "invalid URL", Type: "JsonSchemaValidator2018"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema.id: Does not match format 'uri'")
 	})
@@ -645,7 +645,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		raw.RefreshService = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -656,7 +656,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{Type: "ManualRefreshService2018"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService: id is required")
 	})
@@ -668,7 +668,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{ID: "https://example.edu/refresh/3732"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService: type is required")
 	})
@@ -680,7 +680,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{ID: "invalid URL", Type: "ManualRefreshService2018"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService.id: Does not match format 'uri'")
 	})
@@ -849,6 +849,7 @@ func TestWithBaseContextExtendedValidation(t *testing.T) {
 
 	require.Equal(t, map[string]bool{
 		"https://www.w3.org/2018/credentials/v1":          true,
+		"https://www.w3.org/ns/credentials/v2":            true,
 		"https://www.w3.org/2018/credentials/examples/v1": true,
 	},
 		opts.allowedCustomContexts)
@@ -15,9 +15,11 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ecdsa2019"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/eddsa2022"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/verifier"
 )
@@ -29,6 +31,7 @@ const (
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
 	bbsBlsSignature2020         = "BbsBlsSignature2020"
 	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
+	dataIntegrityProof          = "DataIntegrityProof"
 )
 
 func getProofType(proofMap map[string]interface{}) (string, error) {
@@ -40,7 +43,7 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 	proofTypeStr := safeStringValue(proofType)
 	switch proofTypeStr {
 	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
-		bbsBlsSignature2020, bbsBlsSignatureProof2020, ed25519Signature2020:
+		bbsBlsSignature2020, bbsBlsSignatureProof2020, ed25519Signature2020, dataIntegrityProof:
 		return proofTypeStr, nil
 	default:
 		return "", fmt.Errorf("unsupported proof type: %s", proofType)
@@ -140,6 +143,8 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 
 				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
 					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+			case dataIntegrityProof:
+				ldpSuites = append(ldpSuites, getDataIntegritySuites(proofs[i])...)
 			}
 		}
 	}
@@ -147,6 +152,25 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	return ldpSuites, nil
 }
 
+// getDataIntegritySuites returns the suites of the cryptosuite of a DataIntegrityProof.
+func getDataIntegritySuites(proof map[string]interface{}) []verifier.SignatureSuite {
+	cryptosuite, _ := proof["cryptosuite"].(string) // nolint:errcheck
+
+	switch cryptosuite {
+	case eddsa2022.Cryptosuite:
+		return []verifier.SignatureSuite{
+			eddsa2022.New(suite.WithVerifier(eddsa2022.NewPublicKeyVerifier())),
+		}
+	case ecdsa2019.Cryptosuite:
+		return []verifier.SignatureSuite{
+			ecdsa2019.New(suite.WithVerifier(ecdsa2019.NewPublicKeyVerifier())),
+			ecdsa2019.NewP384(suite.WithVerifier(ecdsa2019.NewP384PublicKeyVerifier())),
+		}
+	}
+
+	return nil
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
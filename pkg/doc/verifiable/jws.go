@@ -49,7 +49,8 @@ func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
 }
 
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
-func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
+// The "typ" header is set only if typ is not empty.
+func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID, typ string) (string, error) {
 	algName, err := signatureAlg.name()
 	if err != nil {
 		return "", err
@@ -59,6 +60,10 @@ func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer,
 		jose.HeaderKeyID: keyID,
 	}
 
+	if typ != "" {
+		headers[jose.HeaderType] = typ
+	}
+
 	token, err := jwt.NewSigned(jwtClaims, headers, getJWTSigner(signer, algName))
 	if err != nil {
 		return "", err
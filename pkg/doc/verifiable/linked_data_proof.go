@@ -62,6 +62,7 @@ type LinkedDataProofContext struct {
 	Challenge               string                  // optional
 	Domain                  string                  // optional
 	Purpose                 string                  // optional
+	Cryptosuite             string                  // required for DataIntegrityProof
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
 }
@@ -139,6 +140,7 @@ func mapContext(context *LinkedDataProofContext) *signer.Context {
 		Challenge:               context.Challenge,
 		Domain:                  context.Domain,
 		Purpose:                 context.Purpose,
+		Cryptosuite:             context.Cryptosuite,
 		CapabilityChain:         context.CapabilityChain,
 	}
 }
@@ -413,7 +413,7 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, err
 	}
 
-	err = validateVP(vpDataDecoded, vpOpts)
+	err = validateVP(vpDataDecoded, rawDataModelVersion(vpRaw.Context), vpOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -550,8 +550,8 @@ func mapOpts(vpOpts *presentationOpts) *credentialOpts {
 	}
 }
 
-func validateVP(data []byte, opts *presentationOpts) error {
-	err := validateVPJSONSchema(data)
+func validateVP(data []byte, version DataModelVersion, opts *presentationOpts) error {
+	err := validateVPJSONSchema(data, version)
 	if err != nil {
 		return err
 	}
@@ -567,10 +567,15 @@ func validateVPJSONLD(vpBytes []byte, opts *presentationOpts) error {
 	)
 }
 
-func validateVPJSONSchema(data []byte) error {
+func validateVPJSONSchema(data []byte, version DataModelVersion) error {
+	schemaLoader := basePresentationSchemaLoader
+	if version == DataModelV2 {
+		schemaLoader = basePresentationSchemaV2Loader
+	}
+
 	loader := gojsonschema.NewStringLoader(string(data))
 
-	result, err := gojsonschema.Validate(basePresentationSchemaLoader, loader)
+	result, err := gojsonschema.Validate(schemaLoader, loader)
 	if err != nil {
 		return fmt.Errorf("validation of verifiable credential: %w", err)
 	}
@@ -31,6 +31,14 @@ func (jpc *JWTPresClaims) refineFromJWTClaims() {
 	}
 }
 
+func (jpc *JWTPresClaims) jwtType() string {
+	if jpc.Presentation != nil && rawDataModelVersion(jpc.Presentation.Context) == DataModelV2 {
+		return jwtTypePresentation
+	}
+
+	return ""
+}
+
 // newJWTPresClaims creates JWT Claims of VP with an option to minimize certain fields put into "vp" claim.
 func newJWTPresClaims(vp *Presentation, audience []string, minimizeVP bool) (*JWTPresClaims, error) {
 	// currently jwt encoding supports only single subject.([]Subject) (by the spec)
@@ -0,0 +1,394 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util"
+)
+
+// Reference: https://w3c-ccg.github.io/vc-status-list-2021/
+const (
+	// StatusList2021Context is the JSON-LD context of the StatusList2021 credentials and entries.
+	StatusList2021Context = "https://w3id.org/vc/status-list/2021/v1"
+	// StatusList2021CredentialType is the type of the credentials publishing a status list.
+	StatusList2021CredentialType = "StatusList2021Credential"
+	// StatusList2021Type is the type of the subject of a status list credential.
+	StatusList2021Type = "StatusList2021"
+	// StatusList2021EntryType is the type of the credentialStatus of the credentials referring to a status list.
+	StatusList2021EntryType = "StatusList2021Entry"
+
+	// StatusPurposeRevocation is the purpose of the status lists of revoked credentials, revocation is permanent.
+	StatusPurposeRevocation = "revocation"
+	// StatusPurposeSuspension is the purpose of the status lists of suspended credentials, suspension is reversible.
+	StatusPurposeSuspension = "suspension"
+
+	statusPurposeField        = "statusPurpose"
+	statusListIndexField      = "statusListIndex"
+	statusListCredentialField = "statusListCredential"
+	encodedListField          = "encodedList"
+	typeField                 = "type"
+
+	// the minimum size of the bitstring is 16KB to provide herd privacy.
+	minStatusListSize = 16 * 1024 * 8
+	bitsPerByte       = 8
+
+	// maxStatusListCredentialSize bounds the size of the fetched status list credentials and maxStatusListBytes the
+	// size of the decompressed bitstrings, a bitstring of 16MB holds the statuses of 128M credentials.
+	maxStatusListCredentialSize = 32 * 1024 * 1024
+	maxStatusListBytes          = 16 * 1024 * 1024
+)
+
+var (
+	// ErrCredentialRevoked is returned when the status of a credential is set in its revocation status list.
+	ErrCredentialRevoked = errors.New("credential is revoked")
+	// ErrCredentialSuspended is returned when the status of a credential is set in its suspension status list.
+	ErrCredentialSuspended = errors.New("credential is suspended")
+)
+
+// StatusListCredentialFetcher fetches the status list credential published at statusListCredential, the
+// credential can be returned in any format accepted by ParseCredential.
+type StatusListCredentialFetcher func(statusListCredential string) ([]byte, error)
+
+// NewHTTPStatusListCredentialFetcher returns a fetcher downloading the status list credentials with client. The
+// client should have a timeout, the status of the credentials is checked while they are parsed.
+func NewHTTPStatusListCredentialFetcher(client *http.Client) StatusListCredentialFetcher {
+	return func(statusListCredential string) ([]byte, error) {
+		resp, err := client.Get(statusListCredential) //nolint:noctx
+		if err != nil {
+			return nil, fmt.Errorf("fetch status list credential: %w", err)
+		}
+
+		defer func() {
+			if e := resp.Body.Close(); e != nil {
+				logger.Warnf("failed to close response body: %s", e)
+			}
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetch status list credential: unexpected response status %d", resp.StatusCode)
+		}
+
+		vcBytes, err := readAtMost(resp.Body, maxStatusListCredentialSize)
+		if err != nil {
+			return nil, fmt.Errorf("fetch status list credential: %w", err)
+		}
+
+		return vcBytes, nil
+	}
+}
+
+// WithStatusList2021Check enables the check of the StatusList2021Entry credentialStatus, the status list
+// credential is fetched with fetcher and verified with the options of the checked credential. Credentials with
+// their status set are rejected with ErrCredentialRevoked or ErrCredentialSuspended.
+func WithStatusList2021Check(fetcher StatusListCredentialFetcher) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusListFetcher = fetcher
+	}
+}
+
+// NewStatusList2021Credential creates an unsigned status list credential published at id, with all the statuses
+// of the list unset. The size of the list is rounded up to the 16KB minimum. The credential must be signed by
+// its issuer before it is published.
+func NewStatusList2021Credential(id, issuer, purpose string, size int) (*Credential, error) {
+	if purpose != StatusPurposeRevocation && purpose != StatusPurposeSuspension {
+		return nil, fmt.Errorf("unsupported status purpose: %s", purpose)
+	}
+
+	if size < minStatusListSize {
+		size = minStatusListSize
+	}
+
+	encodedList, err := encodeStatusList(make([]byte, (size+bitsPerByte-1)/bitsPerByte))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		Context: []string{baseContext, StatusList2021Context},
+		ID:      id,
+		Types:   []string{vcType, StatusList2021CredentialType},
+		Issuer:  Issuer{ID: issuer},
+		Issued:  util.NewTime(time.Now().UTC()),
+		Subject: []Subject{{
+			ID: id + "#list",
+			CustomFields: CustomFields{
+				typeField:          StatusList2021Type,
+				statusPurposeField: purpose,
+				encodedListField:   encodedList,
+			},
+		}},
+	}, nil
+}
+
+// NewStatusList2021Entry creates the credentialStatus of a credential whose status is kept at index in the status
+// list credential published at statusListCredential.
+func NewStatusList2021Entry(statusListCredential, purpose string, index int) *TypedID {
+	return &TypedID{
+		ID:   fmt.Sprintf("%s#%d", statusListCredential, index),
+		Type: StatusList2021EntryType,
+		CustomFields: CustomFields{
+			statusPurposeField:        purpose,
+			statusListIndexField:      strconv.Itoa(index),
+			statusListCredentialField: statusListCredential,
+		},
+	}
+}
+
+// SetStatusList2021 sets or clears the status at index in the status list credential vc. The proofs of vc are
+// removed as they are not valid anymore, vc must be signed again before it is published.
+func SetStatusList2021(vc *Credential, index int, status bool) error {
+	subject, err := statusListSubject(vc)
+	if err != nil {
+		return err
+	}
+
+	bitstring, err := decodeStatusList(subject.CustomFields[encodedListField])
+	if err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(bitstring)*bitsPerByte {
+		return fmt.Errorf("status list index %d is out of range", index)
+	}
+
+	mask := byte(1 << (bitsPerByte - 1 - index%bitsPerByte))
+
+	if status {
+		bitstring[index/bitsPerByte] |= mask
+	} else {
+		bitstring[index/bitsPerByte] &^= mask
+	}
+
+	encodedList, err := encodeStatusList(bitstring)
+	if err != nil {
+		return err
+	}
+
+	subject.CustomFields[encodedListField] = encodedList
+
+	vc.Proofs = nil
+	vc.JWT = ""
+
+	return nil
+}
+
+// checkStatusList2021 rejects vc if its status is set in the status list credential of its StatusList2021Entry.
+func (vc *Credential) checkStatusList2021(vcOpts *credentialOpts) error { // nolint:gocyclo
+	purpose, ok := vc.Status.CustomFields[statusPurposeField].(string)
+	if !ok {
+		return errors.New("status list entry: statusPurpose is not defined")
+	}
+
+	statusListCredential, ok := vc.Status.CustomFields[statusListCredentialField].(string)
+	if !ok || statusListCredential == "" {
+		return errors.New("status list entry: statusListCredential is not defined")
+	}
+
+	index, err := statusListIndex(vc.Status.CustomFields[statusListIndexField])
+	if err != nil {
+		return fmt.Errorf("status list entry: %w", err)
+	}
+
+	statusVC, err := fetchStatusListCredential(statusListCredential, vcOpts)
+	if err != nil {
+		return err
+	}
+
+	if statusVC.Issuer.ID != vc.Issuer.ID {
+		return errors.New("status list credential: issuer does not match the credential issuer")
+	}
+
+	subject, err := statusListSubject(statusVC)
+	if err != nil {
+		return fmt.Errorf("status list credential: %w", err)
+	}
+
+	if subject.CustomFields[statusPurposeField] != purpose {
+		return errors.New("status list credential: statusPurpose does not match the credential status")
+	}
+
+	bitstring, err := decodeStatusList(subject.CustomFields[encodedListField])
+	if err != nil {
+		return fmt.Errorf("status list credential: %w", err)
+	}
+
+	if index >= len(bitstring)*bitsPerByte {
+		return fmt.Errorf("status list entry: statusListIndex %d is out of range", index)
+	}
+
+	if bitstring[index/bitsPerByte]&(1<<(bitsPerByte-1-index%bitsPerByte)) == 0 {
+		return nil
+	}
+
+	if purpose == StatusPurposeSuspension {
+		return ErrCredentialSuspended
+	}
+
+	return ErrCredentialRevoked
+}
+
+// fetchStatusListCredential fetches and parses a status list credential, its proof is checked like the proof of
+// the credential referring to it.
+func fetchStatusListCredential(statusListCredential string, vcOpts *credentialOpts) (*Credential, error) {
+	raw, err := vcOpts.statusListFetcher(statusListCredential)
+	if err != nil {
+		return nil, fmt.Errorf("status list credential: %w", err)
+	}
+
+	statusOpts := *vcOpts
+	statusOpts.statusListFetcher = nil
+
+	statusVC, err := parseCredential(raw, &statusOpts)
+	if err != nil {
+		return nil, fmt.Errorf("status list credential: %w", err)
+	}
+
+	if !vcOpts.disabledProofCheck && len(statusVC.Proofs) == 0 && statusVC.JWT == "" {
+		return nil, errors.New("status list credential: proof is not defined")
+	}
+
+	if !containsType(statusVC.Types, StatusList2021CredentialType) {
+		return nil, fmt.Errorf("status list credential: type %s is not defined", StatusList2021CredentialType)
+	}
+
+	if validUntil := statusVC.validUntil(); validUntil != nil && validUntil.Time.Before(time.Now()) {
+		return nil, errors.New("status list credential: credential has expired")
+	}
+
+	return statusVC, nil
+}
+
+func statusListSubject(vc *Credential) (*Subject, error) {
+	if s, ok := vc.Subject.(Subject); ok {
+		// keep the updates of the subject
+		vc.Subject = []Subject{s}
+	}
+
+	var subject *Subject
+
+	switch s := vc.Subject.(type) {
+	case []Subject:
+		if len(s) == 1 {
+			subject = &s[0]
+		}
+	case *Subject:
+		subject = s
+	}
+
+	if subject == nil || subject.CustomFields[typeField] != StatusList2021Type {
+		return nil, fmt.Errorf("credentialSubject of type %s is not defined", StatusList2021Type)
+	}
+
+	return subject, nil
+}
+
+func statusListIndex(value interface{}) (int, error) {
+	var (
+		index int
+		err   error
+	)
+
+	switch v := value.(type) {
+	case string:
+		index, err = strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid statusListIndex: %w", err)
+		}
+	case float64:
+		index = int(v)
+	default:
+		return 0, errors.New("statusListIndex is not defined")
+	}
+
+	if index < 0 {
+		return 0, fmt.Errorf("invalid statusListIndex: %d", index)
+	}
+
+	return index, nil
+}
+
+func encodeStatusList(bitstring []byte) (string, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(bitstring); err != nil {
+		return "", fmt.Errorf("compress status list: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("compress status list: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeStatusList decodes a GZIP compressed bitstring, encoded either in base64url or in standard base64.
+func decodeStatusList(encodedList interface{}) ([]byte, error) {
+	encoded, ok := encodedList.(string)
+	if !ok || encoded == "" {
+		return nil, errors.New("encodedList is not defined")
+	}
+
+	encoded = strings.TrimRight(encoded, "=")
+
+	compressed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		compressed, err = base64.RawStdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode encodedList: %w", err)
+		}
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	bitstring, err := readAtMost(r, maxStatusListBytes)
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	return bitstring, nil
+}
+
+// readAtMost reads r until EOF, it fails if more than limit bytes are read.
+func readAtMost(r io.Reader, limit int64) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("more than %d bytes", limit)
+	}
+
+	return data, nil
+}
+
+func containsType(types []string, t string) bool {
+	for _, vcType := range types {
+		if vcType == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,298 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/markcryptohash/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util"
+	kmsapi "github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+const (
+	statusListIssuer = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+	statusListURL    = "https://example.com/credentials/status/3"
+)
+
+func TestStatusList2021(t *testing.T) {
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	keyFetcher := SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)
+
+	sign := func(t *testing.T, vc *Credential) {
+		t.Helper()
+
+		err := vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SignatureRepresentation: SignatureJWS,
+			VerificationMethod:      statusListIssuer + "#key-1",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+	}
+
+	newStatusList := func(t *testing.T, purpose string, indexes ...int) *Credential {
+		t.Helper()
+
+		statusVC, err := NewStatusList2021Credential(statusListURL, statusListIssuer, purpose, 0)
+		require.NoError(t, err)
+
+		for _, index := range indexes {
+			require.NoError(t, SetStatusList2021(statusVC, index, true))
+		}
+
+		sign(t, statusVC)
+
+		return statusVC
+	}
+
+	fetcherOf := func(t *testing.T, statusVC *Credential) StatusListCredentialFetcher {
+		t.Helper()
+
+		statusVCBytes := statusVC.byteJSON(t)
+
+		return func(statusListCredential string) ([]byte, error) {
+			require.Equal(t, statusListURL, statusListCredential)
+
+			return statusVCBytes, nil
+		}
+	}
+
+	parse := func(t *testing.T, index int, purpose string, fetcher StatusListCredentialFetcher) error {
+		t.Helper()
+
+		vc := newStatusListTestCredential(index, purpose)
+
+		_, err := parseTestCredential(t, vc.byteJSON(t),
+			WithPublicKeyFetcher(keyFetcher),
+			WithStatusList2021Check(fetcher))
+
+		return err
+	}
+
+	t.Run("credential is not revoked", func(t *testing.T) {
+		fetcher := fetcherOf(t, newStatusList(t, StatusPurposeRevocation, 5))
+
+		require.NoError(t, parse(t, 6, StatusPurposeRevocation, fetcher))
+	})
+
+	t.Run("credential is revoked", func(t *testing.T) {
+		fetcher := fetcherOf(t, newStatusList(t, StatusPurposeRevocation, 5))
+
+		err := parse(t, 5, StatusPurposeRevocation, fetcher)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrCredentialRevoked))
+	})
+
+	t.Run("credential is suspended", func(t *testing.T) {
+		fetcher := fetcherOf(t, newStatusList(t, StatusPurposeSuspension, 5))
+
+		err := parse(t, 5, StatusPurposeSuspension, fetcher)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrCredentialSuspended))
+	})
+
+	t.Run("status is not checked without the option", func(t *testing.T) {
+		vc := newStatusListTestCredential(5, StatusPurposeRevocation)
+
+		_, err := parseTestCredential(t, vc.byteJSON(t), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+	})
+
+	t.Run("status is cleared", func(t *testing.T) {
+		statusVC := newStatusList(t, StatusPurposeSuspension, 5)
+		require.NoError(t, SetStatusList2021(statusVC, 5, false))
+		require.Empty(t, statusVC.Proofs)
+
+		sign(t, statusVC)
+
+		require.NoError(t, parse(t, 5, StatusPurposeSuspension, fetcherOf(t, statusVC)))
+	})
+
+	t.Run("status list credential is not signed", func(t *testing.T) {
+		statusVC, err := NewStatusList2021Credential(statusListURL, statusListIssuer, StatusPurposeRevocation, 0)
+		require.NoError(t, err)
+
+		err = parse(t, 5, StatusPurposeRevocation, fetcherOf(t, statusVC))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status list credential: proof is not defined")
+	})
+
+	t.Run("status list credential with invalid proof", func(t *testing.T) {
+		statusVC := newStatusList(t, StatusPurposeRevocation)
+		statusVC.Subject.([]Subject)[0].CustomFields[statusPurposeField] = StatusPurposeSuspension
+
+		err := parse(t, 5, StatusPurposeSuspension, fetcherOf(t, statusVC))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+	})
+
+	t.Run("status purpose mismatch", func(t *testing.T) {
+		fetcher := fetcherOf(t, newStatusList(t, StatusPurposeRevocation))
+
+		err := parse(t, 5, StatusPurposeSuspension, fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "statusPurpose does not match the credential status")
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		statusVC, err := NewStatusList2021Credential(statusListURL, "did:example:other", StatusPurposeRevocation, 0)
+		require.NoError(t, err)
+
+		sign(t, statusVC)
+
+		err = parse(t, 5, StatusPurposeRevocation, fetcherOf(t, statusVC))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "issuer does not match the credential issuer")
+	})
+
+	t.Run("status list index out of range", func(t *testing.T) {
+		fetcher := fetcherOf(t, newStatusList(t, StatusPurposeRevocation))
+
+		err := parse(t, minStatusListSize, StatusPurposeRevocation, fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is out of range")
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		err := parse(t, 5, StatusPurposeRevocation, func(string) ([]byte, error) {
+			return nil, errors.New("fetch error")
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fetch error")
+	})
+
+	t.Run("invalid status list entry", func(t *testing.T) {
+		fetcher := fetcherOf(t, newStatusList(t, StatusPurposeRevocation))
+
+		for field, value := range map[string]interface{}{
+			statusPurposeField:        nil,
+			statusListCredentialField: "",
+			statusListIndexField:      "x",
+		} {
+			vc := newStatusListTestCredential(5, StatusPurposeRevocation)
+			vc.Status.CustomFields[field] = value
+
+			_, err := parseTestCredential(t, vc.byteJSON(t),
+				WithPublicKeyFetcher(keyFetcher),
+				WithStatusList2021Check(fetcher))
+			require.Error(t, err, field)
+			require.Contains(t, err.Error(), "status list entry", field)
+		}
+	})
+}
+
+func TestSetStatusList2021(t *testing.T) {
+	t.Run("unsupported purpose", func(t *testing.T) {
+		_, err := NewStatusList2021Credential(statusListURL, statusListIssuer, "other", 0)
+		require.EqualError(t, err, "unsupported status purpose: other")
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		statusVC, err := NewStatusList2021Credential(statusListURL, statusListIssuer, StatusPurposeRevocation,
+			2*minStatusListSize)
+		require.NoError(t, err)
+
+		require.NoError(t, SetStatusList2021(statusVC, 2*minStatusListSize-1, true))
+		require.EqualError(t, SetStatusList2021(statusVC, 2*minStatusListSize, true),
+			fmt.Sprintf("status list index %d is out of range", 2*minStatusListSize))
+		require.Error(t, SetStatusList2021(statusVC, -1, true))
+	})
+
+	t.Run("not a status list credential", func(t *testing.T) {
+		err := SetStatusList2021(&Credential{Subject: "did:example:123"}, 0, true)
+		require.EqualError(t, err, "credentialSubject of type StatusList2021 is not defined")
+	})
+
+	t.Run("invalid encoded list", func(t *testing.T) {
+		statusVC, err := NewStatusList2021Credential(statusListURL, statusListIssuer, StatusPurposeRevocation, 0)
+		require.NoError(t, err)
+
+		statusVC.Subject.([]Subject)[0].CustomFields[encodedListField] = "!!!"
+		require.Error(t, SetStatusList2021(statusVC, 0, true))
+
+		statusVC.Subject.([]Subject)[0].CustomFields[encodedListField] = "H4sI"
+		require.Error(t, SetStatusList2021(statusVC, 0, true))
+	})
+
+	t.Run("encoded list too large", func(t *testing.T) {
+		statusVC, err := NewStatusList2021Credential(statusListURL, statusListIssuer, StatusPurposeRevocation, 0)
+		require.NoError(t, err)
+
+		encoded, err := encodeStatusList(make([]byte, maxStatusListBytes+1))
+		require.NoError(t, err)
+
+		statusVC.Subject.([]Subject)[0].CustomFields[encodedListField] = encoded
+
+		err = SetStatusList2021(statusVC, 0, true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), fmt.Sprintf("more than %d bytes", maxStatusListBytes))
+	})
+}
+
+func TestNewHTTPStatusListCredentialFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status/3" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, err := w.Write([]byte("status list"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPStatusListCredentialFetcher(server.Client())
+
+	raw, err := fetcher(server.URL + "/status/3")
+	require.NoError(t, err)
+	require.Equal(t, "status list", string(raw))
+
+	_, err = fetcher(server.URL + "/status/4")
+	require.EqualError(t, err, "fetch status list credential: unexpected response status 404")
+
+	_, err = fetcher("http://invalid host")
+	require.Error(t, err)
+}
+
+func TestReadAtMost(t *testing.T) {
+	data, err := readAtMost(strings.NewReader("status list"), 11)
+	require.NoError(t, err)
+	require.Equal(t, "status list", string(data))
+
+	_, err = readAtMost(strings.NewReader("status list"), 10)
+	require.EqualError(t, err, "more than 10 bytes")
+
+	_, err = readAtMost(iotest.ErrReader(errors.New("read error")), 10)
+	require.EqualError(t, err, "read error")
+}
+
+func newStatusListTestCredential(index int, purpose string) *Credential {
+	return &Credential{
+		Context: []string{baseContext, StatusList2021Context},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{vcType},
+		Issuer:  Issuer{ID: statusListIssuer},
+		Issued:  util.NewTime(time.Now().UTC()),
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Status:  NewStatusList2021Entry(statusListURL, purpose, index),
+	}
+}
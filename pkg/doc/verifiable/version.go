@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util"
+)
+
+const (
+	// https://www.w3.org/TR/vc-data-model-2.0/#base-context
+	baseContextV2 = ContextURIV2
+
+	// https://www.w3.org/TR/vc-data-model-2.0/#json-schema
+	jsonSchemaType = "JsonSchema"
+)
+
+// Media types of the Verifiable Credentials Data Model 2.0
+// (https://www.w3.org/TR/vc-data-model-2.0/#media-types).
+const (
+	// CredentialMediaTypeLDJSON is a media type of Verifiable Credential secured using embedded proof.
+	CredentialMediaTypeLDJSON = "application/vc+ld+json"
+
+	// CredentialMediaTypeJWT is a media type of Verifiable Credential secured as JWT.
+	CredentialMediaTypeJWT = "application/vc+jwt"
+
+	// PresentationMediaTypeLDJSON is a media type of Verifiable Presentation secured using embedded proof.
+	PresentationMediaTypeLDJSON = "application/vp+ld+json"
+
+	// PresentationMediaTypeJWT is a media type of Verifiable Presentation secured as JWT.
+	PresentationMediaTypeJWT = "application/vp+jwt"
+)
+
+// JWT "typ" header values of Verifiable Credential and Presentation of Data Model 2.0.
+const (
+	jwtTypeCredential   = "vc+jwt"
+	jwtTypePresentation = "vp+jwt"
+)
+
+// DataModelVersion defines a version of Verifiable Credentials Data Model.
+type DataModelVersion int
+
+const (
+	// DataModelV1 is Verifiable Credentials Data Model v1.1 (https://www.w3.org/TR/vc-data-model).
+	DataModelV1 DataModelVersion = iota + 1
+
+	// DataModelV2 is Verifiable Credentials Data Model v2.0 (https://www.w3.org/TR/vc-data-model-2.0).
+	DataModelV2
+)
+
+// String returns the version in "major.minor" form.
+func (v DataModelVersion) String() string {
+	if v == DataModelV2 {
+		return "2.0"
+	}
+
+	return "1.1"
+}
+
+// BaseContext returns the base @context URI of the Data Model version.
+func (v DataModelVersion) BaseContext() string {
+	if v == DataModelV2 {
+		return baseContextV2
+	}
+
+	return baseContext
+}
+
+// dataModelVersion detects Data Model version by the base context, which must be the first one.
+// Documents without known base context are treated as v1.1 ones to be rejected by v1.1 validation.
+func dataModelVersion(contexts []string) DataModelVersion {
+	if len(contexts) > 0 && contexts[0] == baseContextV2 {
+		return DataModelV2
+	}
+
+	return DataModelV1
+}
+
+// rawDataModelVersion detects Data Model version by the raw @context value.
+func rawDataModelVersion(rawContext interface{}) DataModelVersion {
+	if contexts, ok := rawContext.([]string); ok {
+		return dataModelVersion(contexts)
+	}
+
+	contexts, _, err := decodeContext(rawContext)
+	if err != nil {
+		return DataModelV1
+	}
+
+	return dataModelVersion(contexts)
+}
+
+// Version returns Data Model version the Credential conforms to.
+func (vc *Credential) Version() DataModelVersion {
+	return dataModelVersion(vc.Context)
+}
+
+// validFrom returns the beginning of the Credential validity period:
+// validFrom of Data Model 2.0 or issuanceDate of Data Model 1.1.
+func (vc *Credential) validFrom() *util.TimeWrapper {
+	if vc.Version() == DataModelV2 {
+		return vc.ValidFrom
+	}
+
+	return vc.Issued
+}
+
+// validUntil returns the end of the Credential validity period:
+// validUntil of Data Model 2.0 or expirationDate of Data Model 1.1.
+func (vc *Credential) validUntil() *util.TimeWrapper {
+	if vc.Version() == DataModelV2 {
+		return vc.ValidUntil
+	}
+
+	return vc.Expired
+}
+
+// MediaType returns a media type of the Credential as defined by Data Model 2.0.
+func (vc *Credential) MediaType() string {
+	if vc.JWT != "" {
+		return CredentialMediaTypeJWT
+	}
+
+	return CredentialMediaTypeLDJSON
+}
+
+// Version returns Data Model version the Presentation conforms to.
+func (vp *Presentation) Version() DataModelVersion {
+	return dataModelVersion(vp.Context)
+}
+
+// MediaType returns a media type of the Presentation as defined by Data Model 2.0.
+func (vp *Presentation) MediaType() string {
+	if vp.JWT != "" {
+		return PresentationMediaTypeJWT
+	}
+
+	return PresentationMediaTypeLDJSON
+}
+
+// WithDataModelVersion sets the base context of the new Presentation to the one of the given Data Model version.
+func WithDataModelVersion(version DataModelVersion) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		p.Context = []string{version.BaseContext()}
+
+		return nil
+	}
+}
+
+// CredentialsDataModelVersion returns Data Model version a Presentation enclosing the credentials should conform to:
+// DataModelV2 if all the credentials are of Data Model 2.0 and DataModelV1 otherwise.
+func CredentialsDataModelVersion(credentials ...*Credential) DataModelVersion {
+	if len(credentials) == 0 {
+		return DataModelV1
+	}
+
+	for _, vc := range credentials {
+		if vc.Version() != DataModelV2 {
+			return DataModelV1
+		}
+	}
+
+	return DataModelV2
+}
+
+// defaultSchemaTemplateV2 is DefaultSchemaTemplate with v2.0 base context and validity period properties.
+var defaultSchemaTemplateV2 = strings.NewReplacer( //nolint:gochecknoglobals
+	baseContext, baseContextV2,
+	`"issuanceDate"`, `"validFrom"`,
+	`"expirationDate"`, `"validUntil"`,
+).Replace(DefaultSchemaTemplate)
+
+//nolint:gochecknoglobals
+var basePresentationSchemaV2Loader = gojsonschema.NewStringLoader(
+	strings.ReplaceAll(basePresentationSchema, baseContext, baseContextV2))
+
+// JSONSchemaLoaderV2 creates default schema of Data Model 2.0 with the option to disable the check
+// of specific properties.
+func JSONSchemaLoaderV2(opts ...SchemaOpt) string {
+	return jsonSchema(defaultSchemaTemplateV2, []string{
+		schemaPropertyType,
+		schemaPropertyCredentialSubject,
+		schemaPropertyIssuer,
+	}, opts)
+}
+
+func defaultSchemaLoaderV2() gojsonschema.JSONLoader {
+	return gojsonschema.NewStringLoader(JSONSchemaLoaderV2())
+}
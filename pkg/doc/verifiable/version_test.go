@@ -0,0 +1,287 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+const v2Credential = `
+{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": [
+    "VerifiableCredential",
+    "ExampleAlumniCredential"
+  ],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "validFrom": "2010-01-01T19:23:24Z",
+  "validUntil": "2030-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "alumniOf": "Example University"
+  }
+}
+`
+
+func TestParseCredential_DataModelV2(t *testing.T) {
+	t.Run("parse credential of Data Model 2.0", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(v2Credential))
+		require.NoError(t, err)
+
+		require.Equal(t, DataModelV2, vc.Version())
+		require.Equal(t, "2.0", vc.Version().String())
+		require.Equal(t, CredentialMediaTypeLDJSON, vc.MediaType())
+		require.Nil(t, vc.Issued)
+		require.Nil(t, vc.Expired)
+		require.NotNil(t, vc.ValidFrom)
+		require.True(t, vc.ValidFrom.Time.Equal(time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC)))
+		require.NotNil(t, vc.ValidUntil)
+		require.True(t, vc.ValidUntil.Time.Equal(time.Date(2030, 1, 1, 19, 23, 24, 0, time.UTC)))
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		vcMap := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+		require.Equal(t, "2010-01-01T19:23:24Z", vcMap["validFrom"])
+		require.Equal(t, "2030-01-01T19:23:24Z", vcMap["validUntil"])
+		require.NotContains(t, vcMap, "issuanceDate")
+	})
+
+	t.Run("parse credential of Data Model 1.1", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		require.Equal(t, DataModelV1, vc.Version())
+		require.Equal(t, "1.1", vc.Version().String())
+		require.Nil(t, vc.ValidFrom)
+	})
+
+	t.Run("validFrom is optional", func(t *testing.T) {
+		vcMap := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(v2Credential), &vcMap))
+		delete(vcMap, "validFrom")
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Nil(t, vc.ValidFrom)
+	})
+
+	t.Run("base context validation", func(t *testing.T) {
+		vcMap := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(v2Credential), &vcMap))
+		vcMap["type"] = "VerifiableCredential"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes, WithBaseContextValidation())
+		require.NoError(t, err)
+		require.Equal(t, DataModelV2, vc.Version())
+	})
+
+	t.Run("no extra context is allowed by base context validation", func(t *testing.T) {
+		vcMap := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(v2Credential), &vcMap))
+		vcMap["@context"] = []interface{}{ContextURIV2, ContextURI}
+		vcMap["type"] = "VerifiableCredential"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithBaseContextValidation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "violated @context constraint")
+	})
+
+	t.Run("invalid validFrom", func(t *testing.T) {
+		vc, err := parseTestCredential(t,
+			[]byte(strings.Replace(v2Credential, "2010-01-01T19:23:24Z", "not a date", 1)))
+		require.Error(t, err)
+		require.Nil(t, vc)
+	})
+}
+
+func TestJSONSchemaLoaderV2(t *testing.T) {
+	schema := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal([]byte(JSONSchemaLoaderV2()), &schema))
+	require.Equal(t, []interface{}{"@context", "type", "credentialSubject", "issuer"}, schema["required"])
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	require.True(t, ok)
+	require.Contains(t, properties, "validFrom")
+	require.Contains(t, properties, "validUntil")
+	require.NotContains(t, properties, "issuanceDate")
+	require.Contains(t, JSONSchemaLoaderV2(), ContextURIV2)
+
+	require.NoError(t, json.Unmarshal([]byte(JSONSchemaLoaderV2(WithDisableRequiredField("issuer"))), &schema))
+	require.Equal(t, []interface{}{"@context", "type", "credentialSubject"}, schema["required"])
+}
+
+func TestCustomCredentialJsonSchema(t *testing.T) {
+	newSchemaServer := func(requiredField string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			rawMap := make(map[string]interface{})
+			require.NoError(t, json.Unmarshal([]byte(JSONSchemaLoaderV2(WithDisableRequiredField("issuer"))), &rawMap))
+
+			required, success := rawMap["required"].([]interface{})
+			require.True(t, success)
+			rawMap["required"] = append(required, requiredField)
+
+			bytes, err := json.Marshal(rawMap)
+			require.NoError(t, err)
+
+			res.WriteHeader(http.StatusOK)
+			_, err = res.Write(bytes)
+			require.NoError(t, err)
+		}))
+	}
+
+	referenceNumberServer := newSchemaServer("referenceNumber")
+	defer referenceNumberServer.Close()
+
+	serialNumberServer := newSchemaServer("serialNumber")
+	defer serialNumberServer.Close()
+
+	vcMap := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal([]byte(v2Credential), &vcMap))
+	vcMap["credentialSchema"] = []interface{}{
+		map[string]interface{}{"id": referenceNumberServer.URL, "type": "JsonSchema"},
+		map[string]interface{}{"id": serialNumberServer.URL, "type": "JsonSchemaValidator2018"},
+	}
+	vcMap["referenceNumber"] = 83294847
+
+	t.Run("credential is validated against each of credential schemas", func(t *testing.T) {
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "serialNumber is required")
+		require.Nil(t, vc)
+	})
+
+	t.Run("credential conforms to all credential schemas", func(t *testing.T) {
+		vcMap["serialNumber"] = 100
+		defer delete(vcMap, "serialNumber")
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Len(t, vc.Schemas, 2)
+		require.Equal(t, "JsonSchema", vc.Schemas[0].Type)
+	})
+}
+
+func TestCredentialJWT_DataModelV2(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(v2Credential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	require.True(t, jwtClaims.NotBefore.Time().Equal(vc.ValidFrom.Time))
+	require.True(t, jwtClaims.Expiry.Time().Equal(vc.ValidUntil.Time))
+	require.NotContains(t, jwtClaims.VC, "validFrom")
+	require.NotContains(t, jwtClaims.VC, "validUntil")
+
+	jws, err := jwtClaims.MarshalJWS(EdDSA, signer, "any")
+	require.NoError(t, err)
+
+	headersBytes, err := base64.RawURLEncoding.DecodeString(strings.Split(jws, ".")[0])
+	require.NoError(t, err)
+
+	headers := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(headersBytes, &headers))
+	require.Equal(t, "vc+jwt", headers["typ"])
+
+	vcFromJWT, err := parseTestCredential(t, []byte(jws),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	require.Equal(t, DataModelV2, vcFromJWT.Version())
+	require.Equal(t, CredentialMediaTypeJWT, vcFromJWT.MediaType())
+	require.Nil(t, vcFromJWT.Issued)
+	require.True(t, vcFromJWT.ValidFrom.Time.Equal(vc.ValidFrom.Time))
+	require.True(t, vcFromJWT.ValidUntil.Time.Equal(vc.ValidUntil.Time))
+
+	t.Run("v1.1 credential has no explicit JWT type", func(t *testing.T) {
+		v1VC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		v1Claims, err := v1VC.JWTClaims(true)
+		require.NoError(t, err)
+		require.Empty(t, v1Claims.jwtType())
+	})
+}
+
+func TestPresentation_DataModelV2(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(v2Credential))
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithCredentials(vc), WithDataModelVersion(CredentialsDataModelVersion(vc)))
+	require.NoError(t, err)
+	require.Equal(t, []string{ContextURIV2}, vp.Context)
+	require.Equal(t, DataModelV2, vp.Version())
+	require.Equal(t, PresentationMediaTypeLDJSON, vp.MediaType())
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	parsedVP, err := newTestPresentation(t, vpBytes, WithPresDisabledProofCheck())
+	require.NoError(t, err)
+	require.Equal(t, DataModelV2, parsedVP.Version())
+	require.Len(t, parsedVP.Credentials(), 1)
+
+	vpClaims, err := vp.JWTClaims(nil, true)
+	require.NoError(t, err)
+	require.Equal(t, "vp+jwt", vpClaims.jwtType())
+
+	t.Run("presentation of mixed credentials is of Data Model 1.1", func(t *testing.T) {
+		v1VC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		require.Equal(t, DataModelV1, CredentialsDataModelVersion(vc, v1VC))
+		require.Equal(t, DataModelV2, CredentialsDataModelVersion(vc, vc))
+		require.Equal(t, DataModelV1, CredentialsDataModelVersion())
+
+		vp, err := NewPresentation(WithCredentials(vc, v1VC),
+			WithDataModelVersion(CredentialsDataModelVersion(vc, v1VC)))
+		require.NoError(t, err)
+		require.Equal(t, []string{ContextURI}, vp.Context)
+		require.Empty(t, vpClaimsType(t, vp))
+	})
+}
+
+func vpClaimsType(t *testing.T, vp *Presentation) string {
+	t.Helper()
+
+	vpClaims, err := vp.JWTClaims(nil, true)
+	require.NoError(t, err)
+
+	return vpClaims.jwtType()
+}
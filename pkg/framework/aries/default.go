@@ -20,6 +20,7 @@ import (
 	legacyAnonCrypt "github.com/markcryptohash/aries-framework-go/pkg/didcomm/packer/legacy/anoncrypt"
 	legacyAuthCrypt "github.com/markcryptohash/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
@@ -30,6 +31,7 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/outofband"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose"
@@ -85,7 +87,8 @@ func defFrameworkOpts(frameworkOpts *Aries) error { //nolint:gocyclo
 	// - Introduce depends on OutOfBand
 	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
 		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newLegacyConnectionSvc(), newOutOfBandSvc(),
-		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc(), newOutOfBandV2Svc())
+		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc(), newOutOfBandV2Svc(),
+		newTrustPingSvc(), newDiscoverFeaturesSvc())
 
 	if frameworkOpts.secretLock == nil && frameworkOpts.kmsCreator == nil {
 		err = createDefSecretLock(frameworkOpts)
@@ -198,6 +201,22 @@ func newOutOfBandSvc() api.ProtocolSvcCreator {
 	}
 }
 
+func newTrustPingSvc() api.ProtocolSvcCreator {
+	return api.ProtocolSvcCreator{
+		Create: func(prv api.Provider) (dispatcher.ProtocolService, error) {
+			return &trustping.Service{}, nil
+		},
+	}
+}
+
+func newDiscoverFeaturesSvc() api.ProtocolSvcCreator {
+	return api.ProtocolSvcCreator{
+		Create: func(prv api.Provider) (dispatcher.ProtocolService, error) {
+			return &discoverfeatures.Service{}, nil
+		},
+	}
+}
+
 func newOutOfBandV2Svc() api.ProtocolSvcCreator {
 	return api.ProtocolSvcCreator{
 		Create: func(prv api.Provider) (dispatcher.ProtocolService, error) {
@@ -222,7 +241,7 @@ func newOutOfBandV2Svc() api.ProtocolSvcCreator {
 func setDefaultKMSCryptOpts(frameworkOpts *Aries) error {
 	if frameworkOpts.kmsCreator == nil {
 		frameworkOpts.kmsCreator = func(provider kms.Provider) (kms.KeyManager, error) {
-			return localkms.New(defaultMasterKeyURI, provider)
+			return localkms.New(DefaultMasterKeyURI, provider)
 		}
 	}
 
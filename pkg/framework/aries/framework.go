@@ -8,6 +8,7 @@ package aries
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/google/uuid"
@@ -35,15 +36,17 @@ import (
 	ldstore "github.com/markcryptohash/aries-framework-go/pkg/store/ld"
 	"github.com/markcryptohash/aries-framework-go/pkg/store/verifiable"
 	"github.com/markcryptohash/aries-framework-go/pkg/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/vdr/jwk"
 	"github.com/markcryptohash/aries-framework-go/pkg/vdr/key"
 	"github.com/markcryptohash/aries-framework-go/pkg/vdr/peer"
 	"github.com/markcryptohash/aries-framework-go/spi/storage"
 )
 
-const (
-	defaultEndpoint     = "didcomm:transport/queue"
-	defaultMasterKeyURI = "local-lock://default/master/key/"
-)
+const defaultEndpoint = "didcomm:transport/queue"
+
+// DefaultMasterKeyURI is the primary key URI of the default KMS, the keys it stores are encrypted with this URI as
+// associated data.
+const DefaultMasterKeyURI = "local-lock://default/master/key/"
 
 // Aries provides access to the context being managed by the framework. The context can be used to create aries clients.
 type Aries struct {
@@ -69,6 +72,8 @@ type Aries struct {
 	packers                    []packer.Packer
 	vdrRegistry                vdrapi.Registry
 	vdr                        []vdrapi.VDR
+	vdrCacheOpts               []vdr.CacheOption
+	vdrCache                   bool
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
 	contextStore               ldstore.ContextStore
@@ -82,6 +87,8 @@ type Aries struct {
 	mediaTypeProfiles          []string
 	inboundEnvelopeHandler     inbound.MessageHandler
 	didRotator                 middleware.DIDCommMessageMiddleware
+	outboxRetryParams          *outbound.RetryParams
+	inboundGuard               dispatcher.InboundGuard
 }
 
 // Option configures the framework.
@@ -266,6 +273,16 @@ func WithVDR(v vdrapi.VDR) Option {
 	}
 }
 
+// WithDIDResolutionCache enables caching of DID resolution results in the VDR registry.
+func WithDIDResolutionCache(cacheOpts ...vdr.CacheOption) Option {
+	return func(opts *Aries) error {
+		opts.vdrCache = true
+		opts.vdrCacheOpts = append(opts.vdrCacheOpts, cacheOpts...)
+
+		return nil
+	}
+}
+
 // WithMessageServiceProvider injects a message service provider to the Aries framework.
 // Message service provider returns list of message services which can be used to provide custom handle
 // functionality based on incoming messages type and purpose.
@@ -362,6 +379,33 @@ func WithMediaTypeProfiles(mediaTypeProfiles []string) Option {
 	}
 }
 
+// WithOutbox enables the persistent outbound message queue: messages that cannot be delivered are stored and
+// retried in the background with the given retry parameters (defaults are used if params is nil).
+func WithOutbox(params *outbound.RetryParams) Option {
+	return func(opts *Aries) error {
+		if params == nil {
+			params = outbound.DefaultRetryParams()
+		}
+
+		if params.MaxAttempts < 1 {
+			return fmt.Errorf("invalid outbox max attempts : %d", params.MaxAttempts)
+		}
+
+		opts.outboxRetryParams = params
+
+		return nil
+	}
+}
+
+// WithInboundGuard checks the inbound messages with guard before they are dispatched to a service, e.g. an
+// inbound.Guard limiting the rate of messages per sender and rejecting replayed messages.
+func WithInboundGuard(guard dispatcher.InboundGuard) Option {
+	return func(opts *Aries) error {
+		opts.inboundGuard = guard
+		return nil
+	}
+}
+
 // WithServiceMsgTypeTargets injects service msg type to target mappings in the context.
 func WithServiceMsgTypeTargets(msgTypeTargets ...dispatcher.MessageTypeTarget) Option {
 	return func(opts *Aries) error {
@@ -401,6 +445,7 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithServiceMsgTypeTargets(a.servicesMsgTypeTargets...),
 		context.WithDIDRotator(&a.didRotator),
 		context.WithInboundEnvelopeHandler(&a.inboundEnvelopeHandler),
+		context.WithInboundGuard(a.inboundGuard),
 	)
 }
 
@@ -412,6 +457,13 @@ func (a *Aries) Messenger() service.Messenger {
 
 // Close frees resources being maintained by the framework.
 func (a *Aries) Close() error {
+	// stop the outbox retries before closing the stores they use
+	if closer, ok := a.outboundDispatcher.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("failed to close the outbound dispatcher: %w", err)
+		}
+	}
+
 	if a.storeProvider != nil {
 		err := a.storeProvider.Close()
 		if err != nil {
@@ -516,6 +568,13 @@ func createVDR(frameworkOpts *Aries) error {
 	k := key.New()
 	opts = append(opts, vdr.WithVDR(k))
 
+	j := jwk.New(jwk.WithKMS(ctx.KMS()))
+	opts = append(opts, vdr.WithVDR(j))
+
+	if frameworkOpts.vdrCache {
+		opts = append(opts, vdr.WithResolutionCache(frameworkOpts.vdrCacheOpts...))
+	}
+
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
 	return nil
@@ -580,7 +639,13 @@ func createOutboundDispatcher(frameworkOpts *Aries) error {
 		return fmt.Errorf("context creation failed: %w", err)
 	}
 
-	frameworkOpts.outboundDispatcher, err = outbound.NewOutbound(ctx)
+	var opts []outbound.Option
+
+	if frameworkOpts.outboxRetryParams != nil {
+		opts = append(opts, outbound.WithOutbox(frameworkOpts.outboxRetryParams))
+	}
+
+	frameworkOpts.outboundDispatcher, err = outbound.NewOutbound(ctx, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to init outbound dispatcher: %w", err)
 	}
@@ -727,6 +792,7 @@ func loadServices(frameworkOpts *Aries) error { // nolint:funlen
 		context.WithInboundEnvelopeHandler(&frameworkOpts.inboundEnvelopeHandler),
 		context.WithServiceMsgTypeTargets(frameworkOpts.servicesMsgTypeTargets...),
 		context.WithDIDRotator(&frameworkOpts.didRotator),
+		context.WithInboundGuard(frameworkOpts.inboundGuard),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
@@ -26,12 +26,15 @@ import (
 	"github.com/markcryptohash/aries-framework-go/pkg/common/model"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher/inbound"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/dispatcher/outbound"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/packer"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/transport"
 	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/markcryptohash/aries-framework-go/pkg/framework/context"
 	mocks "github.com/markcryptohash/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	didStoreMocks "github.com/markcryptohash/aries-framework-go/pkg/internal/gomocks/store/did"
@@ -157,6 +160,32 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - with DID resolution cache", func(t *testing.T) {
+		reads := 0
+		vdr := &mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				reads++
+
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+		}
+
+		aries, err := New(WithVDR(vdr), WithInboundTransport(&mockInboundTransport{}), WithDIDResolutionCache())
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = ctx.VDRegistry().Resolve("did:example:123")
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 1, reads)
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test error create vdr", func(t *testing.T) {
 		sp := storage.NewMockStoreProvider()
 		sp.FailNamespace = peer.StoreNamespace
@@ -206,6 +235,19 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - did:jwk resolved by default vdr", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		// nolint:lll
+		didJWK := "did:jwk:eyJrdHkiOiJPS1AiLCJjcnYiOiJYMjU1MTkiLCJ1c2UiOiJlbmMiLCJ4IjoiM3A3YmZYdDl3YlRUVzJIQzdPUTFOei1EUThoYmVHZE5yZngtRkctSUswOCJ9"
+
+		docResolution, err := aries.vdrRegistry.Resolve(didJWK)
+		require.NoError(t, err)
+		require.Equal(t, didJWK, docResolution.DIDDocument.ID)
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test protocol svc - with default protocol", func(t *testing.T) {
 		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
 		require.NoError(t, err)
@@ -714,6 +756,29 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, transport.MediaTypeV1EncryptedEnvelope, aries.mediaTypeProfiles[1])
 	})
 
+	t.Run("test new with outbox", func(t *testing.T) {
+		aries, err := New(WithOutbox(nil))
+		require.NoError(t, err)
+		require.Equal(t, outbound.DefaultRetryParams(), aries.outboxRetryParams)
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithOutbox(&outbound.RetryParams{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid outbox max attempts")
+	})
+
+	t.Run("test new with inbound guard", func(t *testing.T) {
+		guard := inbound.NewGuard(inbound.WithRateLimit(1, 1))
+
+		aries, err := New(WithInboundGuard(guard))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, guard, ctx.InboundGuard())
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("failure while creating KMS Aries provider wrapper", func(t *testing.T) {
 		mockStoreProvider := &storage.MockStoreProvider{
 			FailNamespace: kms.AriesWrapperStoreName,
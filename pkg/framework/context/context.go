@@ -69,6 +69,7 @@ type Provider struct {
 	getDIDsBackOffDuration     time.Duration
 	inboundEnvelopeHandler     InboundEnvelopeHandler
 	didRotator                 *middleware.DIDCommMessageMiddleware
+	inboundGuard               dispatcher.InboundGuard
 	connectionRecorder         *connection.Recorder
 }
 
@@ -322,6 +323,11 @@ func (p *Provider) InboundMessenger() service.InboundMessenger {
 	return p.messenger
 }
 
+// InboundGuard returns the guard checking the inbound messages, nil if they are not checked.
+func (p *Provider) InboundGuard() dispatcher.InboundGuard {
+	return p.inboundGuard
+}
+
 // ProviderOption configures the framework.
 type ProviderOption func(opts *Provider) error
 
@@ -357,6 +363,14 @@ func WithDIDRotator(didRotator *middleware.DIDCommMessageMiddleware) ProviderOpt
 	}
 }
 
+// WithInboundGuard injects a guard checking the inbound messages into the context.
+func WithInboundGuard(guard dispatcher.InboundGuard) ProviderOption {
+	return func(opts *Provider) error {
+		opts.inboundGuard = guard
+		return nil
+	}
+}
+
 // WithOutboundDispatcher injects an outbound dispatcher into the context.
 func WithOutboundDispatcher(outboundDispatcher dispatcher.Outbound) ProviderOption {
 	return func(opts *Provider) error {
@@ -9,6 +9,7 @@ package kms
 import (
 	"errors"
 	"io"
+	"time"
 
 	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
 )
@@ -72,10 +73,61 @@ type KeyManager interface {
 	ImportPrivateKey(privKey interface{}, kt KeyType, opts ...PrivateKeyOpts) (string, interface{}, error)
 }
 
+// KeyLifecycleManager is implemented by the KeyManagers able to enumerate, label and retire the keys they store.
+// Callers should type assert a KeyManager to find out whether it is supported.
+type KeyLifecycleManager interface {
+	// ListKeys returns the information of all the keys tracked by the KeyManager.
+	ListKeys() ([]*KeyInfo, error)
+	// GetKeyInfo returns the information of the key referenced by keyID.
+	// Returns an error wrapping ErrKeyNotFound if the key does not exist.
+	GetKeyInfo(keyID string) (*KeyInfo, error)
+	// SetKeyMetadata replaces the metadata tags of the key referenced by keyID.
+	SetKeyMetadata(keyID string, metadata map[string]string) error
+	// DisableKey disables the key referenced by keyID, the key is kept in the store but the KeyManager refuses to
+	// use it, its operations return an error wrapping ErrKeyDisabled.
+	DisableKey(keyID string) error
+	// DeleteKey permanently deletes the key referenced by keyID.
+	DeleteKey(keyID string) error
+}
+
+// KeyBackupManager is implemented by the KeyManagers able to export their keys in an encrypted backup and to restore
+// them. Callers should type assert a KeyManager to find out whether it is supported.
+type KeyBackupManager interface {
+	// Backup exports the keys in an archive encrypted with a key derived from passphrase.
+	// keyIDs lists keys to export in addition to the keys the KeyManager keeps track of.
+	Backup(passphrase string, keyIDs ...string) ([]byte, error)
+
+	// Restore imports the keys of an archive created by Backup, the keys keep their IDs.
+	Restore(backup []byte, passphrase string) error
+}
+
+// KeyRewrapper is implemented by the KeyManagers protecting the stored keys with a secret lock that can be replaced,
+// typically to rotate a master key. Callers should type assert a KeyManager to find out whether it is supported.
+type KeyRewrapper interface {
+	// RewrapKeys re-encrypts the stored keys with newLock, which the KeyManager uses from then on. keyIDs lists keys
+	// the KeyManager can't find in its store. It fails without re-encrypting any key if a stored key can't be
+	// decrypted.
+	RewrapKeys(newLock secretlock.Service, keyIDs ...string) error
+}
+
+// KeyInfo describes a key managed by a KeyLifecycleManager. RotatedTo is set once the key is rotated to the ID of
+// the key replacing it.
+type KeyInfo struct {
+	ID        string            `json:"id"`
+	Type      KeyType           `json:"type,omitempty"`
+	CreatedAt *time.Time        `json:"createdAt,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Disabled  bool              `json:"disabled,omitempty"`
+	RotatedTo string            `json:"rotatedTo,omitempty"`
+}
+
 // ErrKeyNotFound is an error type that a KMS expects from the Store.Get method if no key stored under the given
 // key ID could be found.
 var ErrKeyNotFound = errors.New("key not found")
 
+// ErrKeyDisabled is returned by a KeyManager when a disabled key is requested.
+var ErrKeyDisabled = errors.New("key is disabled")
+
 // Store defines the storage capability required by a KeyManager Provider.
 type Store interface {
 	// Put stores the given key under the given keysetID.
@@ -89,6 +141,13 @@ type Store interface {
 	Delete(keysetID string) error
 }
 
+// KeysetLister is implemented by the Stores able to list the keysets they hold. Callers should type assert a Store
+// to find out whether it is supported.
+type KeysetLister interface {
+	// KeysetIDs returns the IDs of the keysets in the store.
+	KeysetIDs() ([]string, error)
+}
+
 // Provider for KeyManager builder/constructor.
 type Provider interface {
 	StorageProvider() Store
@@ -16,12 +16,15 @@ import (
 // AriesWrapperStoreName is the store name used when creating a KMS store using kms.NewAriesProviderWrapper.
 const AriesWrapperStoreName = "kmsdb"
 
+// keysetTagName tags the keysets put in the store, so they can be listed.
+const keysetTagName = "keyset"
+
 type ariesProviderKMSStoreWrapper struct {
 	store storage.Store
 }
 
 func (a *ariesProviderKMSStoreWrapper) Put(keysetID string, key []byte) error {
-	return a.store.Put(keysetID, key)
+	return a.store.Put(keysetID, key, storage.Tag{Name: keysetTagName})
 }
 
 func (a *ariesProviderKMSStoreWrapper) Get(keysetID string) ([]byte, error) {
@@ -42,14 +45,64 @@ func (a *ariesProviderKMSStoreWrapper) Delete(keysetID string) error {
 	return a.store.Delete(keysetID)
 }
 
+// KeysetIDs returns the IDs of the keysets put in the store. Keysets put before the store tagged them for listing
+// are not returned.
+func (a *ariesProviderKMSStoreWrapper) KeysetIDs() ([]string, error) {
+	iter, err := a.store.Query(keysetTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query keysets: %w", err)
+	}
+
+	ids, err := iteratorKeys(iter)
+	if err != nil {
+		_ = iter.Close() // nolint: errcheck
+
+		return nil, fmt.Errorf("failed to list keysets: %w", err)
+	}
+
+	return ids, iter.Close()
+}
+
+func iteratorKeys(iter storage.Iterator) ([]string, error) {
+	var keys []string
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	for more {
+		var key string
+
+		key, err = iter.Key()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
 // NewAriesProviderWrapper returns an implementation of the kms.Store interface that wraps an
-// Aries provider implementation, allowing it to be used with a KMS.
+// Aries provider implementation, allowing it to be used with a KMS. The returned Store is also a KeysetLister.
 func NewAriesProviderWrapper(provider storage.Provider) (Store, error) {
 	store, err := provider.OpenStore(AriesWrapperStoreName)
 	if err != nil {
 		return nil, err
 	}
 
+	err = provider.SetStoreConfig(AriesWrapperStoreName, storage.StoreConfiguration{TagNames: []string{keysetTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
 	storeWrapper := ariesProviderKMSStoreWrapper{store: store}
 
 	return &storeWrapper, nil
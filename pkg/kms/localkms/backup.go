@@ -0,0 +1,334 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/subtle/random"
+	"github.com/google/tink/go/tink"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local/masterlock/pbkdf2"
+)
+
+const (
+	// BackupVersion is the version of the backup archive format written by Backup.
+	BackupVersion = 1
+
+	backupKDF           = "PBKDF2-SHA256"
+	backupIterations    = 100000
+	maxBackupIterations = 10000000
+	backupSaltLen       = 16
+	backupKeyURI        = keywrapper.LocalKeyURIPrefix + "backup"
+)
+
+// backupArchive is the backup format: the payload is encrypted with a key derived from the passphrase with the KDF
+// parameters of the archive, which are authenticated along with the payload.
+type backupArchive struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`
+	Payload    string `json:"payload,omitempty"`
+}
+
+type backupPayload struct {
+	Keys []*backupKey `json:"keys"`
+}
+
+// backupKey is a key of the backup, its keyset is encrypted with the backup key.
+type backupKey struct {
+	Info   *kms.KeyInfo    `json:"info"`
+	Keyset json.RawMessage `json:"keyset"`
+}
+
+// Backup exports the keys stored by LocalKMS, along with their information, in an archive encrypted with a key
+// derived from passphrase (PBKDF2 with SHA-256 and a random salt). The keys recorded in the key information of
+// LocalKMS are exported along with keyIDs, which must list the keys stored before key information was recorded.
+// Disabled keys are exported too.
+func (l *LocalKMS) Backup(passphrase string, keyIDs ...string) ([]byte, error) {
+	return l.backup(passphrase, keyIDs, true)
+}
+
+// BackupKeys is like Backup but it only exports the keys referenced by keyIDs, typically the keys of one of the
+// users sharing the store of LocalKMS.
+func (l *LocalKMS) BackupKeys(passphrase string, keyIDs ...string) ([]byte, error) {
+	return l.backup(passphrase, keyIDs, false)
+}
+
+func (l *LocalKMS) backup(passphrase string, keyIDs []string, allKeys bool) ([]byte, error) {
+	archive := &backupArchive{
+		Version:    BackupVersion,
+		KDF:        backupKDF,
+		Iterations: backupIterations,
+		Salt:       base64.RawURLEncoding.EncodeToString(random.GetRandomBytes(backupSaltLen)),
+	}
+
+	backupLock, backupAEAD, err := archive.lock(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("backup: %w", err)
+	}
+
+	payload, err := l.exportKeys(backupAEAD, keyIDs, allKeys)
+	if err != nil {
+		return nil, fmt.Errorf("backup: %w", err)
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to marshal payload: %w", err)
+	}
+
+	encrypted, err := backupLock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext:                   string(payloadBytes),
+		AdditionalAuthenticatedData: archive.header(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backup: failed to encrypt payload: %w", err)
+	}
+
+	archive.Payload = encrypted.Ciphertext
+
+	return json.Marshal(archive)
+}
+
+// Restore imports the keys of a backup created by Backup with the same passphrase. The keys keep their ID and
+// information, nothing is imported if one of them is already stored or if one of them can't be stored.
+func (l *LocalKMS) Restore(backup []byte, passphrase string) error {
+	archive := &backupArchive{}
+
+	err := json.Unmarshal(backup, archive)
+	if err != nil {
+		return fmt.Errorf("restore: failed to unmarshal backup archive: %w", err)
+	}
+
+	backupLock, backupAEAD, err := archive.lock(passphrase)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	decrypted, err := backupLock.Decrypt("", &secretlock.DecryptRequest{
+		Ciphertext:                  archive.Payload,
+		AdditionalAuthenticatedData: archive.header(),
+	})
+	if err != nil {
+		return fmt.Errorf("restore: integrity check failed, invalid passphrase or corrupted backup: %w", err)
+	}
+
+	payload := &backupPayload{}
+
+	err = json.Unmarshal([]byte(decrypted.Plaintext), payload)
+	if err != nil {
+		return fmt.Errorf("restore: failed to unmarshal payload: %w", err)
+	}
+
+	err = l.importKeys(payload, backupAEAD)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	return nil
+}
+
+func (l *LocalKMS) exportKeys(backupAEAD tink.AEAD, keyIDs []string, allKeys bool) (*backupPayload, error) {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
+	l.keyInfoMutex.RLock()
+	defer l.keyInfoMutex.RUnlock()
+
+	if allKeys {
+		storedIDs, err := storedKeySetIDs(l.store)
+		if err != nil {
+			return nil, err
+		}
+
+		keyIDs = appendMissing(storedIDs, keyIDs...)
+	} else {
+		keyIDs = appendMissing(nil, keyIDs...)
+	}
+
+	payload := &backupPayload{}
+
+	for _, keyID := range keyIDs {
+		key, e := l.exportKey(keyID, backupAEAD)
+		if e != nil {
+			return nil, e
+		}
+
+		if key == nil {
+			continue
+		}
+
+		payload.Keys = append(payload.Keys, key)
+	}
+
+	return payload, nil
+}
+
+// exportKey decrypts the keyset of the key referenced by keyID with the key of LocalKMS and encrypts it with the
+// backup key. Rotated keys are not exported.
+func (l *LocalKMS) exportKey(keyID string, backupAEAD tink.AEAD) (*backupKey, error) {
+	info, err := l.lookupKeyInfo(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := l.store.Get(keyID)
+	if errors.Is(err, kms.ErrKeyNotFound) && info.RotatedTo != "" {
+		// the keyset of a rotated key is part of the keyset of the key replacing it.
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key '%s': %w", keyID, err)
+	}
+
+	kh, err := keyset.Read(keyset.NewJSONReader(bytes.NewReader(data)), l.primaryKeyEnvAEAD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key '%s': %w", keyID, err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = kh.Write(keyset.NewJSONWriter(buf), backupAEAD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key '%s': %w", keyID, err)
+	}
+
+	return &backupKey{Info: info, Keyset: buf.Bytes()}, nil
+}
+
+func (l *LocalKMS) importKeys(payload *backupPayload, backupAEAD tink.AEAD) error {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
+	l.keyInfoMutex.Lock()
+	defer l.keyInfoMutex.Unlock()
+
+	keysets := make(map[string][]byte, len(payload.Keys))
+
+	// check all the keys before storing any of them.
+	for _, key := range payload.Keys {
+		keysetBytes, e := l.reencryptBackupKey(key, backupAEAD)
+		if e != nil {
+			return e
+		}
+
+		if _, ok := keysets[key.Info.ID]; ok {
+			return fmt.Errorf("key '%s' is duplicated in backup", key.Info.ID)
+		}
+
+		keysets[key.Info.ID] = keysetBytes
+	}
+
+	for i, key := range payload.Keys {
+		err := l.store.Put(key.Info.ID, keysets[key.Info.ID])
+		if err == nil {
+			err = writeKeyInfo(l.store, key.Info, l.primaryKeyURI)
+		}
+
+		if err != nil {
+			// the restore is all or nothing, the keys already stored are removed.
+			l.deleteImportedKeys(payload.Keys[:i+1])
+
+			return fmt.Errorf("failed to put key '%s': %w", key.Info.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteImportedKeys deletes the keysets and the information of keys, failures are only logged since the import
+// already failed.
+func (l *LocalKMS) deleteImportedKeys(keys []*backupKey) {
+	for _, key := range keys {
+		for _, id := range []string{key.Info.ID, keyInfoIDPrefix + key.Info.ID} {
+			err := l.store.Delete(id)
+			if err != nil && !errors.Is(err, kms.ErrKeyNotFound) {
+				logger.Warnf("failed to delete '%s' of the failed restore: %s", id, err)
+			}
+		}
+	}
+}
+
+// reencryptBackupKey decrypts the keyset of key with the backup key and encrypts it with the key of LocalKMS.
+func (l *LocalKMS) reencryptBackupKey(key *backupKey, backupAEAD tink.AEAD) ([]byte, error) {
+	if key.Info == nil || key.Info.ID == "" || isReservedID(key.Info.ID) {
+		return nil, errors.New("invalid key ID in backup")
+	}
+
+	_, err := l.store.Get(key.Info.ID)
+	if err == nil {
+		return nil, fmt.Errorf("key '%s' already exists", key.Info.ID)
+	}
+
+	if !errors.Is(err, kms.ErrKeyNotFound) {
+		return nil, fmt.Errorf("failed to get key '%s': %w", key.Info.ID, err)
+	}
+
+	kh, err := keyset.Read(keyset.NewJSONReader(bytes.NewReader(key.Keyset)), backupAEAD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key '%s': %w", key.Info.ID, err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = kh.Write(keyset.NewJSONWriter(buf), l.primaryKeyEnvAEAD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key '%s': %w", key.Info.ID, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// lock derives the backup key from passphrase with the KDF parameters of the archive. It returns the lock encrypting
+// the payload and the AEAD encrypting the keysets.
+func (a *backupArchive) lock(passphrase string) (secretlock.Service, tink.AEAD, error) {
+	if a.Version != BackupVersion {
+		return nil, nil, fmt.Errorf("backup version %d is not supported", a.Version)
+	}
+
+	if a.KDF != backupKDF {
+		return nil, nil, fmt.Errorf("backup KDF '%s' is not supported", a.KDF)
+	}
+
+	if a.Iterations <= 0 || a.Iterations > maxBackupIterations {
+		return nil, nil, fmt.Errorf("invalid backup KDF iterations %d", a.Iterations)
+	}
+
+	salt, err := base64.RawURLEncoding.DecodeString(a.Salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode backup salt: %w", err)
+	}
+
+	backupLock, err := pbkdf2.NewMasterLock(passphrase, sha256.New, a.Iterations, salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create backup lock: %w", err)
+	}
+
+	backupAEAD, err := newKeyEnvelopeAEAD(backupLock, backupKeyURI)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return backupLock, backupAEAD, nil
+}
+
+// header returns the archive parameters authenticated with the payload.
+func (a *backupArchive) header() string {
+	return fmt.Sprintf("%d.%s.%d.%s", a.Version, a.KDF, a.Iterations, a.Salt)
+}
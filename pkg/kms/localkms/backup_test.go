@@ -0,0 +1,336 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/noop"
+)
+
+const testBackupPassphrase = "backup passphrase"
+
+func TestBackupAndRestore(t *testing.T) {
+	var _ kms.KeyBackupManager = (*LocalKMS)(nil)
+
+	t.Run("restore into a fresh KMS with another master key", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: newTestMasterLock(t, "old")})
+		require.NoError(t, err)
+
+		aesID, _, err := k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+		require.NoError(t, k.SetKeyMetadata(aesID, map[string]string{"label": "a"}))
+
+		edID, edPubKey, err := k.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+		require.NoError(t, k.DisableKey(edID))
+
+		legacyID, _, err := k.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		// a key stored before key information was recorded.
+		delete(store.keys, keyInfoIDPrefix+legacyID)
+
+		// the rotated key is not exported, its keyset is part of the keyset of the key replacing it.
+		aesID, _, err = k.Rotate(kms.AES256GCMType, aesID)
+		require.NoError(t, err)
+
+		backup, err := k.Backup(testBackupPassphrase)
+		require.NoError(t, err)
+
+		archive := &backupArchive{}
+		require.NoError(t, json.Unmarshal(backup, archive))
+		require.Equal(t, BackupVersion, archive.Version)
+		require.Equal(t, backupKDF, archive.KDF)
+
+		restored, err := New(testMasterKeyURI, &mockProvider{
+			storage:    newInMemoryKMSStore(),
+			secretLock: newTestMasterLock(t, "new"),
+		})
+		require.NoError(t, err)
+		require.NoError(t, restored.Restore(backup, testBackupPassphrase))
+
+		keys, err := restored.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+
+		info, err := restored.GetKeyInfo(aesID)
+		require.NoError(t, err)
+		require.Equal(t, "a", info.Metadata["label"])
+		require.Equal(t, kms.AES256GCMType, info.Type)
+
+		_, err = restored.Get(aesID)
+		require.NoError(t, err)
+
+		_, err = restored.Get(edID)
+		require.True(t, errors.Is(err, kms.ErrKeyDisabled))
+
+		info, err = restored.GetKeyInfo(edID)
+		require.NoError(t, err)
+		require.True(t, info.Disabled)
+
+		restoredPubKey, err := restoredExportPubKey(restored, edID)
+		require.NoError(t, err)
+		require.Equal(t, edPubKey, restoredPubKey)
+
+		_, err = restored.Get(legacyID)
+		require.NoError(t, err)
+
+		err = restored.Restore(backup, testBackupPassphrase)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already exists")
+	})
+
+	t.Run("backup of an empty KMS", func(t *testing.T) {
+		k := newTestKMS(t)
+
+		backup, err := k.Backup(testBackupPassphrase)
+		require.NoError(t, err)
+
+		require.NoError(t, newTestKMS(t).Restore(backup, testBackupPassphrase))
+	})
+
+	t.Run("backup of selected keys", func(t *testing.T) {
+		k := newTestKMS(t)
+
+		selectedID, _, err := k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		otherID, _, err := k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		backup, err := k.BackupKeys(testBackupPassphrase, selectedID, selectedID)
+		require.NoError(t, err)
+
+		restored := newTestKMS(t)
+		require.NoError(t, restored.Restore(backup, testBackupPassphrase))
+
+		keys, err := restored.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, selectedID, keys[0].ID)
+
+		_, err = restored.Get(otherID)
+		require.Error(t, err)
+
+		_, err = k.BackupKeys(testBackupPassphrase, "unknown")
+		require.Error(t, err)
+	})
+
+	t.Run("error - invalid passphrase or tampered backup", func(t *testing.T) {
+		k := newTestKMS(t)
+
+		_, _, err := k.Create(kms.AES128GCMType)
+		require.NoError(t, err)
+
+		_, err = k.Backup("")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "passphrase is empty")
+
+		backup, err := k.Backup(testBackupPassphrase)
+		require.NoError(t, err)
+
+		err = newTestKMS(t).Restore(backup, "wrong passphrase")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "integrity check failed")
+
+		tamper := func(update func(archive *backupArchive)) []byte {
+			archive := &backupArchive{}
+			require.NoError(t, json.Unmarshal(backup, archive))
+
+			update(archive)
+
+			tampered, e := json.Marshal(archive)
+			require.NoError(t, e)
+
+			return tampered
+		}
+
+		for _, tc := range []struct {
+			name   string
+			update func(archive *backupArchive)
+			errMsg string
+		}{
+			{
+				name:   "iterations",
+				update: func(a *backupArchive) { a.Iterations++ },
+				errMsg: "integrity check failed",
+			},
+			{
+				name:   "payload",
+				update: func(a *backupArchive) { a.Payload = a.Payload[:len(a.Payload)-4] + "AAAA" },
+				errMsg: "integrity check failed",
+			},
+			{
+				name:   "version",
+				update: func(a *backupArchive) { a.Version = 2 },
+				errMsg: "backup version 2 is not supported",
+			},
+			{
+				name:   "KDF",
+				update: func(a *backupArchive) { a.KDF = "scrypt" },
+				errMsg: "backup KDF 'scrypt' is not supported",
+			},
+			{
+				name:   "invalid iterations",
+				update: func(a *backupArchive) { a.Iterations = 0 },
+				errMsg: "invalid backup KDF iterations",
+			},
+			{
+				name:   "salt",
+				update: func(a *backupArchive) { a.Salt = "!" },
+				errMsg: "failed to decode backup salt",
+			},
+		} {
+			err = newTestKMS(t).Restore(tamper(tc.update), testBackupPassphrase)
+			require.Error(t, err, tc.name)
+			require.Contains(t, err.Error(), tc.errMsg, tc.name)
+		}
+
+		err = newTestKMS(t).Restore([]byte("{"), testBackupPassphrase)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal backup archive")
+	})
+
+	t.Run("error - invalid backup content", func(t *testing.T) {
+		k := newTestKMS(t)
+
+		for _, tc := range []struct {
+			name    string
+			payload *backupPayload
+			errMsg  string
+		}{
+			{
+				name:    "reserved key ID",
+				payload: &backupPayload{Keys: []*backupKey{{Info: &kms.KeyInfo{ID: keyInfoIDPrefix + "k1"}}}},
+				errMsg:  "invalid key ID in backup",
+			},
+			{
+				name:    "missing key info",
+				payload: &backupPayload{Keys: []*backupKey{{}}},
+				errMsg:  "invalid key ID in backup",
+			},
+			{
+				name:    "invalid keyset",
+				payload: &backupPayload{Keys: []*backupKey{{Info: &kms.KeyInfo{ID: "k1"}, Keyset: []byte("{}")}}},
+				errMsg:  "failed to decrypt key 'k1'",
+			},
+		} {
+			archive := &backupArchive{Version: BackupVersion, KDF: backupKDF, Iterations: 1, Salt: "c2FsdA"}
+
+			backupLock, _, err := archive.lock(testBackupPassphrase)
+			require.NoError(t, err)
+
+			payloadBytes, err := json.Marshal(tc.payload)
+			require.NoError(t, err)
+
+			archive.Payload = encryptTestPayload(t, backupLock, archive, payloadBytes)
+
+			backup, err := json.Marshal(archive)
+			require.NoError(t, err)
+
+			err = k.Restore(backup, testBackupPassphrase)
+			require.Error(t, err, tc.name)
+			require.Contains(t, err.Error(), tc.errMsg, tc.name)
+		}
+
+		keys, err := k.ListKeys()
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("error - store failures", func(t *testing.T) {
+		errGet := errors.New("get error")
+
+		k, err := New(testMasterKeyURI, &mockProvider{storage: &mockStore{errGet: errGet}, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		_, err = k.BackupKeys(testBackupPassphrase, "k1")
+		require.True(t, errors.Is(err, errGet))
+
+		_, err = k.Backup(testBackupPassphrase)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store can't list its keysets")
+
+		_, err = newTestKMS(t).Backup(testBackupPassphrase, "unknown")
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+	})
+
+	t.Run("error - failed restore doesn't leave keys behind", func(t *testing.T) {
+		k := newTestKMS(t)
+
+		for i := 0; i < 3; i++ {
+			_, _, err := k.Create(kms.AES256GCMType)
+			require.NoError(t, err)
+		}
+
+		backup, err := k.Backup(testBackupPassphrase)
+		require.NoError(t, err)
+
+		// the first key and the keyset of the second key are stored, storing the information of the second key fails.
+		store := &failingPutStore{inMemoryKMSStore: newInMemoryKMSStore(), putsLeft: 3}
+
+		restored, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		err = restored.Restore(backup, testBackupPassphrase)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "put error")
+		require.Empty(t, store.keys)
+
+		keys, err := restored.ListKeys()
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+}
+
+func newTestKMS(t *testing.T) *LocalKMS {
+	t.Helper()
+
+	k, err := New(testMasterKeyURI, &mockProvider{storage: newInMemoryKMSStore(), secretLock: &noop.NoLock{}})
+	require.NoError(t, err)
+
+	return k
+}
+
+func encryptTestPayload(t *testing.T, lock secretlock.Service, archive *backupArchive, payload []byte) string {
+	t.Helper()
+
+	encrypted, err := lock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext:                   string(payload),
+		AdditionalAuthenticatedData: archive.header(),
+	})
+	require.NoError(t, err)
+
+	return encrypted.Ciphertext
+}
+
+func restoredExportPubKey(k *LocalKMS, keyID string) ([]byte, error) {
+	// disabled keys can't be exported, enable the key by editing its information.
+	info, err := readKeyInfo(k.store, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Disabled = false
+
+	err = writeKeyInfo(k.store, info, k.primaryKeyURI)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, _, err := k.ExportPubKeyBytes(keyID)
+
+	return pubKey, err
+}
@@ -185,6 +185,9 @@ func (b *CryptoBox) SealOpen(cipherText, myPub []byte) ([]byte, error) {
 
 // exportEncPrivKeyBytes temporary support function for crypto_box to be used with legacyPacker only.
 func (l *LocalKMS) exportEncPrivKeyBytes(id string) ([]byte, error) {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
 	kh, err := l.getKeySet(id)
 	if err != nil {
 		return nil, err
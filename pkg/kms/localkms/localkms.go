@@ -13,10 +13,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/google/tink/go/aead"
 	"github.com/google/tink/go/keyset"
 
+	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
 	cryptoapi "github.com/markcryptohash/aries-framework-go/pkg/crypto"
 	"github.com/markcryptohash/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/markcryptohash/aries-framework-go/pkg/kms"
@@ -37,32 +39,34 @@ const (
 
 var errInvalidKeyType = errors.New("key type is not supported")
 
+var logger = log.New("aries-framework/kms/localkms")
+
 // package localkms is the default KMS service implementation of pkg/kms.KeyManager. It uses Tink keys to support the
 // default Crypto implementation, pkg/crypto/tinkcrypto, and stores these keys in the format understood by Tink. It also
 // uses a secretLock service to protect private key material in the storage.
 
 // LocalKMS implements kms.KeyManager to provide key management capabilities using a local db.
 // It uses an underlying secret lock service (default local secretLock) to wrap (encrypt) keys
-// prior to storing them.
+// prior to storing them. It also implements kms.KeyLifecycleManager.
 type LocalKMS struct {
 	secretLock        secretlock.Service
 	primaryKeyURI     string
 	store             kms.Store
 	primaryKeyEnvAEAD *aead.KMSEnvelopeAEAD
+	// envAEADMutex guards the secret lock replaced by RewrapKeys, it must be acquired before keyInfoMutex.
+	envAEADMutex sync.RWMutex
+	keyInfoMutex sync.RWMutex
 }
 
 // New will create a new (local) KMS service.
 func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
 	secretLock := p.SecretLock()
 
-	kw, err := keywrapper.New(secretLock, primaryKeyURI)
+	keyEnvelopeAEAD, err := newKeyEnvelopeAEAD(secretLock, primaryKeyURI)
 	if err != nil {
-		return nil, fmt.Errorf("new: failed to create new keywrapper: %w", err)
+		return nil, fmt.Errorf("new: %w", err)
 	}
 
-	// create a KMSEnvelopeAEAD instance to wrap/unwrap keys managed by LocalKMS
-	keyEnvelopeAEAD := aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), kw)
-
 	return &LocalKMS{
 			store:             p.StorageProvider(),
 			secretLock:        secretLock,
@@ -72,6 +76,16 @@ func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
 		nil
 }
 
+// newKeyEnvelopeAEAD creates a KMSEnvelopeAEAD instance to wrap/unwrap keys managed by LocalKMS.
+func newKeyEnvelopeAEAD(secretLock secretlock.Service, primaryKeyURI string) (*aead.KMSEnvelopeAEAD, error) {
+	kw, err := keywrapper.New(secretLock, primaryKeyURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new keywrapper: %w", err)
+	}
+
+	return aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), kw), nil
+}
+
 // HealthCheck check kms.
 func (l *LocalKMS) HealthCheck() error {
 	return nil
@@ -79,10 +93,13 @@ func (l *LocalKMS) HealthCheck() error {
 
 // Create a new key/keyset/key handle for the type kt
 // Returns:
-//  - keyID of the handle
-//  - handle instance (to private key)
-//  - error if failure
+//   - keyID of the handle
+//   - handle instance (to private key)
+//   - error if failure
 func (l *LocalKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interface{}, error) {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
 	if kt == "" {
 		return "", nil, fmt.Errorf("failed to create new key, missing key type")
 	}
@@ -102,24 +119,35 @@ func (l *LocalKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interfac
 		return "", nil, fmt.Errorf("create: failed to store keyset: %w", err)
 	}
 
+	err = l.recordKeyInfo(keyID, kt, "")
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to record key info: %w", err)
+	}
+
 	return keyID, kh, nil
 }
 
 // Get key handle for the given keyID
 // Returns:
-//  - handle instance (to private key)
-//  - error if failure
+//   - handle instance (to private key)
+//   - error if failure
 func (l *LocalKMS) Get(keyID string) (interface{}, error) {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
 	return l.getKeySet(keyID)
 }
 
 // Rotate a key referenced by keyID and return a new handle of a keyset including old key and
 // new key with type kt. It also returns the updated keyID as the first return value
 // Returns:
-//  - new KeyID
-//  - handle instance (to private key)
-//  - error if failure
+//   - new KeyID
+//   - handle instance (to private key)
+//   - error if failure
 func (l *LocalKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (string, interface{}, error) {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
 	kh, err := l.getKeySet(keyID)
 	if err != nil {
 		return "", nil, fmt.Errorf("rotate: failed to getKeySet: %w", err)
@@ -152,6 +180,11 @@ func (l *LocalKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (st
 		return "", nil, fmt.Errorf("rotate: failed to store keySet: %w", err)
 	}
 
+	err = l.recordKeyInfo(newID, kt, keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: failed to record key info: %w", err)
+	}
+
 	return newID, updatedKH, nil
 }
 
@@ -205,6 +238,11 @@ func writeToStore(store kms.Store, buf *bytes.Buffer, opts ...kms.PrivateKeyOpts
 }
 
 func (l *LocalKMS) getKeySet(id string) (*keyset.Handle, error) {
+	err := l.checkKeyEnabled(id)
+	if err != nil {
+		return nil, fmt.Errorf("getKeySet: %w", err)
+	}
+
 	localDBReader := newReader(l.store, id)
 
 	jsonKeysetReader := keyset.NewJSONReader(localDBReader)
@@ -222,9 +260,12 @@ func (l *LocalKMS) getKeySet(id string) (*keyset.Handle, error) {
 // ExportPubKeyBytes will fetch a key referenced by id then gets its public key in raw bytes and returns it.
 // The key must be an asymmetric key.
 // Returns:
-//  - marshalled public key []byte
-//  - error if it fails to export the public key bytes
+//   - marshalled public key []byte
+//   - error if it fails to export the public key bytes
 func (l *LocalKMS) ExportPubKeyBytes(id string) ([]byte, kms.KeyType, error) {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
 	kh, err := l.getKeySet(id)
 	if err != nil {
 		return nil, "", fmt.Errorf("exportPubKeyBytes: failed to get keyset handle: %w", err)
@@ -280,9 +321,9 @@ func (l *LocalKMS) exportPubKeyBytes(kh *keyset.Handle) ([]byte, kms.KeyType, er
 // CreateAndExportPubKeyBytes will create a key of type kt and export its public key in raw bytes and returns it.
 // The key must be an asymmetric key.
 // Returns:
-//  - keyID of the new handle created.
-//  - marshalled public key []byte
-//  - error if it fails to export the public key bytes
+//   - keyID of the new handle created.
+//   - marshalled public key []byte
+//   - error if it fails to export the public key bytes
 func (l *LocalKMS) CreateAndExportPubKeyBytes(kt kms.KeyType, opts ...kms.KeyOpts) (string, []byte, error) {
 	kid, _, err := l.Create(kt, opts...)
 	if err != nil {
@@ -312,21 +353,41 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...km
 // 'opts' allows setting the keysetID of the imported key using WithKeyID() option. If the ID is already used,
 // then an error is returned.
 // Returns:
-//  - keyID of the handle
-//  - handle instance (to private key)
-//  - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
+//   - keyID of the handle
+//   - handle instance (to private key)
+//   - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
 func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	l.envAEADMutex.RLock()
+	defer l.envAEADMutex.RUnlock()
+
+	var (
+		keyID string
+		kh    interface{}
+		err   error
+	)
+
 	switch pk := privKey.(type) {
 	case *ecdsa.PrivateKey:
-		return l.importECDSAKey(pk, kt, opts...)
+		keyID, kh, err = l.importECDSAKey(pk, kt, opts...)
 	case ed25519.PrivateKey:
-		return l.importEd25519Key(pk, kt, opts...)
+		keyID, kh, err = l.importEd25519Key(pk, kt, opts...)
 	case *bbs12381g2pub.PrivateKey:
-		return l.importBBSKey(pk, kt, opts...)
+		keyID, kh, err = l.importBBSKey(pk, kt, opts...)
 	default:
 		return "", nil, fmt.Errorf("import private key does not support this key type or key is public")
 	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = l.recordKeyInfo(keyID, kt, "")
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: failed to record key info: %w", err)
+	}
+
+	return keyID, kh, nil
 }
 
 func (l *LocalKMS) generateKID(kh *keyset.Handle, kt kms.KeyType) (string, error) {
@@ -0,0 +1,302 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+const (
+	// reservedIDPrefix prefixes the IDs of the store entries LocalKMS uses internally. They can't collide with
+	// generated key IDs as ':' is not in the base64URL alphabet.
+	reservedIDPrefix = "localkms:"
+
+	// keyInfoIDPrefix prefixes the key ID to get the ID of the store entry holding the information of the key.
+	keyInfoIDPrefix = reservedIDPrefix + "keyinfo:"
+)
+
+// isReservedID returns true if id is the ID of a store entry LocalKMS uses internally.
+func isReservedID(id string) bool {
+	return strings.HasPrefix(id, reservedIDPrefix)
+}
+
+// ListKeys returns the information of the keys stored by LocalKMS, sorted by ID. Rotated keys are listed along with
+// the keys replacing them, keys stored before key information was recorded only have their ID set. The store must be
+// a kms.KeysetLister.
+func (l *LocalKMS) ListKeys() ([]*kms.KeyInfo, error) {
+	l.keyInfoMutex.RLock()
+	defer l.keyInfoMutex.RUnlock()
+
+	ids, err := storedKeySetIDs(l.store)
+	if err != nil {
+		return nil, fmt.Errorf("listKeys: %w", err)
+	}
+
+	infos := make([]*kms.KeyInfo, 0, len(ids))
+
+	for _, keyID := range ids {
+		info, e := readKeyInfo(l.store, keyID)
+		if e != nil {
+			return nil, fmt.Errorf("listKeys: %w", e)
+		}
+
+		if info == nil {
+			info = &kms.KeyInfo{ID: keyID}
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// GetKeyInfo returns the information of the key referenced by keyID.
+func (l *LocalKMS) GetKeyInfo(keyID string) (*kms.KeyInfo, error) {
+	l.keyInfoMutex.RLock()
+	defer l.keyInfoMutex.RUnlock()
+
+	info, err := l.lookupKeyInfo(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("getKeyInfo: %w", err)
+	}
+
+	return info, nil
+}
+
+// SetKeyMetadata replaces the metadata tags of the key referenced by keyID.
+func (l *LocalKMS) SetKeyMetadata(keyID string, metadata map[string]string) error {
+	err := l.updateKeyInfo(keyID, func(info *kms.KeyInfo) {
+		info.Metadata = metadata
+	})
+	if err != nil {
+		return fmt.Errorf("setKeyMetadata: %w", err)
+	}
+
+	return nil
+}
+
+// DisableKey disables the key referenced by keyID, Get, Rotate and ExportPubKeyBytes return an error wrapping
+// kms.ErrKeyDisabled for a disabled key.
+func (l *LocalKMS) DisableKey(keyID string) error {
+	err := l.updateKeyInfo(keyID, func(info *kms.KeyInfo) {
+		info.Disabled = true
+	})
+	if err != nil {
+		return fmt.Errorf("disableKey: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteKey permanently deletes the key referenced by keyID and its information.
+func (l *LocalKMS) DeleteKey(keyID string) error {
+	l.keyInfoMutex.Lock()
+	defer l.keyInfoMutex.Unlock()
+
+	_, err := l.lookupKeyInfo(keyID)
+	if err != nil {
+		return fmt.Errorf("deleteKey: %w", err)
+	}
+
+	err = l.store.Delete(keyID)
+	if err != nil {
+		return fmt.Errorf("deleteKey: failed to delete key '%s': %w", keyID, err)
+	}
+
+	err = l.store.Delete(keyInfoIDPrefix + keyID)
+	if err != nil {
+		return fmt.Errorf("deleteKey: failed to delete key info of '%s': %w", keyID, err)
+	}
+
+	return nil
+}
+
+// recordKeyInfo records the information of a newly stored key. When the key replaces a previous one (rotation), the
+// metadata of the previous key is carried over and the previous key remains listed, referring to the new key.
+func (l *LocalKMS) recordKeyInfo(keyID string, kt kms.KeyType, previousKeyID string) error {
+	l.keyInfoMutex.Lock()
+	defer l.keyInfoMutex.Unlock()
+
+	createdAt := time.Now().UTC()
+
+	info := &kms.KeyInfo{ID: keyID, Type: kt, CreatedAt: &createdAt}
+
+	if previousKeyID != "" {
+		previous, err := readKeyInfo(l.store, previousKeyID)
+		if err != nil {
+			return err
+		}
+
+		if previous == nil {
+			previous = &kms.KeyInfo{ID: previousKeyID, Type: kt}
+		}
+
+		info.Metadata = previous.Metadata
+		previous.RotatedTo = keyID
+
+		err = writeKeyInfo(l.store, previous, l.primaryKeyURI)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeKeyInfo(l.store, info, l.primaryKeyURI)
+}
+
+// checkKeyEnabled returns an error wrapping kms.ErrKeyDisabled if the key referenced by keyID is disabled.
+func (l *LocalKMS) checkKeyEnabled(keyID string) error {
+	info, err := readKeyInfo(l.store, keyID)
+	if err != nil {
+		return err
+	}
+
+	if info != nil && info.Disabled {
+		return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDisabled)
+	}
+
+	return nil
+}
+
+func (l *LocalKMS) updateKeyInfo(keyID string, update func(info *kms.KeyInfo)) error {
+	l.keyInfoMutex.Lock()
+	defer l.keyInfoMutex.Unlock()
+
+	info, err := l.lookupKeyInfo(keyID)
+	if err != nil {
+		return err
+	}
+
+	// the key keeps its owner, the keys of the other key managers sharing the store are not claimed.
+	owner, err := readKeyOwner(l.store, keyID)
+	if err != nil {
+		return err
+	}
+
+	update(info)
+
+	return writeKeyInfo(l.store, info, owner)
+}
+
+// lookupKeyInfo returns the information of the key referenced by keyID. Keys stored before key information was
+// recorded only have their ID set.
+func (l *LocalKMS) lookupKeyInfo(keyID string) (*kms.KeyInfo, error) {
+	if keyID == "" || isReservedID(keyID) {
+		return nil, fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyNotFound)
+	}
+
+	info, err := readKeyInfo(l.store, keyID)
+	if err != nil || info != nil {
+		return info, err
+	}
+
+	_, err = l.store.Get(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key '%s': %w", keyID, err)
+	}
+
+	return &kms.KeyInfo{ID: keyID}, nil
+}
+
+// storedKeySetIDs returns the IDs of the keys listed by store, sorted, including the keys only known by their
+// information.
+func storedKeySetIDs(store kms.Store) ([]string, error) {
+	lister, ok := store.(kms.KeysetLister)
+	if !ok {
+		return nil, errors.New("store can't list its keysets")
+	}
+
+	listedIDs, err := lister.KeysetIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keysets: %w", err)
+	}
+
+	var ids []string
+
+	for _, id := range listedIDs {
+		switch {
+		case strings.HasPrefix(id, keyInfoIDPrefix):
+			ids = append(ids, strings.TrimPrefix(id, keyInfoIDPrefix))
+		case !isReservedID(id):
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return appendMissing(nil, ids...), nil
+}
+
+// readKeyInfo returns the information recorded for the key referenced by keyID, nil if there is none.
+func readKeyInfo(store kms.Store, keyID string) (*kms.KeyInfo, error) {
+	infoBytes, err := store.Get(keyInfoIDPrefix + keyID)
+	if errors.Is(err, kms.ErrKeyNotFound) || err == nil && len(infoBytes) == 0 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key info of '%s': %w", keyID, err)
+	}
+
+	info := &kms.KeyInfo{}
+
+	err = json.Unmarshal(infoBytes, info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key info of '%s': %w", keyID, err)
+	}
+
+	return info, nil
+}
+
+// storedKeyInfo is the key information as stored, along with the primary key URI of the LocalKMS owning the key.
+type storedKeyInfo struct {
+	*kms.KeyInfo
+	PrimaryKeyURI string `json:"primaryKeyURI,omitempty"`
+}
+
+// readKeyOwner returns the primary key URI of the LocalKMS owning the key referenced by keyID, empty if it is not
+// recorded, typically for the keys stored before their owner was recorded.
+func readKeyOwner(store kms.Store, keyID string) (string, error) {
+	infoBytes, err := store.Get(keyInfoIDPrefix + keyID)
+	if errors.Is(err, kms.ErrKeyNotFound) || err == nil && len(infoBytes) == 0 {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get key info of '%s': %w", keyID, err)
+	}
+
+	info := &storedKeyInfo{}
+
+	err = json.Unmarshal(infoBytes, info)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal key info of '%s': %w", keyID, err)
+	}
+
+	return info.PrimaryKeyURI, nil
+}
+
+// writeKeyInfo stores the information of a key owned by the LocalKMS of primary key URI owner.
+func writeKeyInfo(store kms.Store, info *kms.KeyInfo, owner string) error {
+	infoBytes, err := json.Marshal(&storedKeyInfo{KeyInfo: info, PrimaryKeyURI: owner})
+	if err != nil {
+		return fmt.Errorf("failed to marshal key info of '%s': %w", info.ID, err)
+	}
+
+	err = store.Put(keyInfoIDPrefix+info.ID, infoBytes)
+	if err != nil {
+		return fmt.Errorf("failed to put key info of '%s': %w", info.ID, err)
+	}
+
+	return nil
+}
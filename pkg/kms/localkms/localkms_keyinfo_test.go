@@ -0,0 +1,249 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestLocalKMS_KeyLifecycle(t *testing.T) {
+	var _ kms.KeyLifecycleManager = (*LocalKMS)(nil)
+
+	newKMS := func(t *testing.T, store kms.Store) *LocalKMS {
+		t.Helper()
+
+		k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		return k
+	}
+
+	t.Run("list, get, set metadata, disable and delete keys", func(t *testing.T) {
+		k := newKMS(t, newInMemoryKMSStore())
+
+		keys, err := k.ListKeys()
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		aesID, _, err := k.Create(kms.AES128GCMType)
+		require.NoError(t, err)
+
+		edID, _, err := k.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		importedID, _, err := k.ImportPrivateKey(privKey, kms.ED25519Type, kms.WithKeyID("imported"))
+		require.NoError(t, err)
+		require.Equal(t, "imported", importedID)
+
+		keys, err = k.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+
+		info, err := k.GetKeyInfo(edID)
+		require.NoError(t, err)
+		require.Equal(t, edID, info.ID)
+		require.Equal(t, kms.ED25519Type, info.Type)
+		require.NotNil(t, info.CreatedAt)
+		require.False(t, info.Disabled)
+
+		require.NoError(t, k.SetKeyMetadata(aesID, map[string]string{"purpose": "wallet"}))
+
+		info, err = k.GetKeyInfo(aesID)
+		require.NoError(t, err)
+		require.Equal(t, "wallet", info.Metadata["purpose"])
+
+		require.NoError(t, k.DisableKey(edID))
+
+		info, err = k.GetKeyInfo(edID)
+		require.NoError(t, err)
+		require.True(t, info.Disabled)
+
+		_, err = k.Get(edID)
+		require.True(t, errors.Is(err, kms.ErrKeyDisabled))
+
+		_, _, err = k.ExportPubKeyBytes(edID)
+		require.True(t, errors.Is(err, kms.ErrKeyDisabled))
+
+		_, _, err = k.Rotate(kms.ED25519Type, edID)
+		require.True(t, errors.Is(err, kms.ErrKeyDisabled))
+
+		require.NoError(t, k.DeleteKey(edID))
+
+		_, err = k.GetKeyInfo(edID)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		_, err = k.Get(edID)
+		require.Error(t, err)
+
+		keys, err = k.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+	})
+
+	t.Run("rotate carries metadata over and keeps the rotated key listed", func(t *testing.T) {
+		k := newKMS(t, newInMemoryKMSStore())
+
+		keyID, _, err := k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		require.NoError(t, k.SetKeyMetadata(keyID, map[string]string{"label": "a"}))
+
+		newID, _, err := k.Rotate(kms.AES256GCMType, keyID)
+		require.NoError(t, err)
+
+		info, err := k.GetKeyInfo(keyID)
+		require.NoError(t, err)
+		require.Equal(t, newID, info.RotatedTo)
+		require.Equal(t, "a", info.Metadata["label"])
+
+		info, err = k.GetKeyInfo(newID)
+		require.NoError(t, err)
+		require.Equal(t, "a", info.Metadata["label"])
+		require.Empty(t, info.RotatedTo)
+
+		keys, err := k.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		require.NoError(t, k.DeleteKey(keyID))
+
+		keys, err = k.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+	})
+
+	t.Run("keys stored without key info", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		k := newKMS(t, store)
+
+		keyID, _, err := k.Create(kms.AES128GCMType)
+		require.NoError(t, err)
+
+		delete(store.keys, keyInfoIDPrefix+keyID)
+
+		keys, err := k.ListKeys()
+		require.NoError(t, err)
+		require.Equal(t, []*kms.KeyInfo{{ID: keyID}}, keys)
+
+		info, err := k.GetKeyInfo(keyID)
+		require.NoError(t, err)
+		require.Equal(t, &kms.KeyInfo{ID: keyID}, info)
+
+		require.NoError(t, k.SetKeyMetadata(keyID, map[string]string{"label": "a"}))
+
+		keys, err = k.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, "a", keys[0].Metadata["label"])
+
+		require.NoError(t, k.DeleteKey(keyID))
+		require.Empty(t, store.keys)
+	})
+
+	t.Run("key manager instances sharing a store", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		k1 := newKMS(t, store)
+		k2 := newKMS(t, store)
+
+		aesID, _, err := k1.Create(kms.AES128GCMType)
+		require.NoError(t, err)
+
+		edID, _, err := k2.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		require.NoError(t, k1.SetKeyMetadata(aesID, map[string]string{"label": "a"}))
+		require.NoError(t, k2.DisableKey(edID))
+
+		for _, k := range []*LocalKMS{k1, k2} {
+			keys, err := k.ListKeys()
+			require.NoError(t, err)
+			require.Len(t, keys, 2)
+
+			info, err := k.GetKeyInfo(aesID)
+			require.NoError(t, err)
+			require.Equal(t, "a", info.Metadata["label"])
+
+			_, err = k.Get(edID)
+			require.True(t, errors.Is(err, kms.ErrKeyDisabled))
+		}
+	})
+
+	t.Run("error - unknown or reserved key", func(t *testing.T) {
+		k := newKMS(t, newInMemoryKMSStore())
+
+		keyID, _, err := k.Create(kms.AES128GCMType)
+		require.NoError(t, err)
+
+		for _, id := range []string{"unknown", keyInfoIDPrefix + keyID, rewrapJournalID, ""} {
+			_, err = k.GetKeyInfo(id)
+			require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+			err = k.SetKeyMetadata(id, nil)
+			require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+			err = k.DisableKey(id)
+			require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+			err = k.DeleteKey(id)
+			require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+		}
+
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, _, err = k.ImportPrivateKey(privKey, kms.ED25519Type, kms.WithKeyID(keyInfoIDPrefix+"imported"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is reserved")
+	})
+
+	t.Run("error - store failures", func(t *testing.T) {
+		errGet := errors.New("get error")
+		k := newKMS(t, &mockStore{errGet: errGet})
+
+		_, err := k.ListKeys()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store can't list its keysets")
+
+		_, err = k.GetKeyInfo("k1")
+		require.True(t, errors.Is(err, errGet))
+
+		err = k.SetKeyMetadata("k1", nil)
+		require.True(t, errors.Is(err, errGet))
+
+		err = k.DeleteKey("k1")
+		require.True(t, errors.Is(err, errGet))
+
+		_, err = k.Get("k1")
+		require.True(t, errors.Is(err, errGet))
+
+		store := newInMemoryKMSStore()
+		store.keys[keyInfoIDPrefix+"k1"] = []byte("{")
+		k = newKMS(t, store)
+
+		_, err = k.ListKeys()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal key info")
+
+		// the keyset is stored but its information isn't.
+		k = newKMS(t, &failingPutStore{inMemoryKMSStore: newInMemoryKMSStore(), putsLeft: 1})
+
+		_, _, err = k.Create(kms.AES128GCMType)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create: failed to record key info")
+	})
+}
@@ -69,6 +69,16 @@ func (i *inMemoryKMSStore) Delete(keysetID string) error {
 	return nil
 }
 
+func (i *inMemoryKMSStore) KeysetIDs() ([]string, error) {
+	ids := make([]string, 0, len(i.keys))
+
+	for keysetID := range i.keys {
+		ids = append(ids, keysetID)
+	}
+
+	return ids, nil
+}
+
 type mockStore struct {
 	errPut error
 	errGet error
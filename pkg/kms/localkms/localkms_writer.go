@@ -70,6 +70,10 @@ func (l *storeWriter) Write(p []byte) (int, error) {
 }
 
 func (l *storeWriter) verifyRequestedID() (string, error) {
+	if isReservedID(l.requestedKeysetID) {
+		return "", fmt.Errorf("requested ID '%s' is reserved, cannot write keyset", l.requestedKeysetID)
+	}
+
 	_, err := l.storage.Get(l.requestedKeysetID)
 	if errors.Is(err, kms.ErrKeyNotFound) {
 		return l.requestedKeysetID, nil
@@ -0,0 +1,290 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/tink"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+)
+
+// rewrapJournalID is the reserved ID of the store entry listing the keys left to re-encrypt by an interrupted rewrap.
+const rewrapJournalID = "localkms:rewrapjournal"
+
+type rewrapJournal struct {
+	Pending []string `json:"pending"`
+}
+
+// Rewrap re-encrypts the keys stored in store with newLock instead of oldLock, it is meant to rotate the master key
+// of a local secret lock while no LocalKMS is using store (see LocalKMS.RewrapKeys otherwise). store must be a
+// kms.KeysetLister: the keysets it lists which are owned by the LocalKMS of primaryKeyURI are re-encrypted, along
+// with keyIDs, which must list the keys the store doesn't list.
+//
+// The store may be shared with other key managers, e.g. the LocalKMS of the wallet users, their keys are skipped.
+// The keys stored before their owner was recorded are skipped if they can be decrypted with neither oldLock nor
+// newLock, they are logged. Rewrap refuses to start if any of the other keys can be decrypted with neither oldLock
+// nor newLock, rather than leave it behind, or if none of the keys can be decrypted.
+//
+// The keys left to re-encrypt are journaled in store: if Rewrap is interrupted, calling it again with the same locks
+// resumes it. Keys already encrypted with newLock are skipped.
+func Rewrap(store kms.Store, primaryKeyURI string, oldLock, newLock secretlock.Service, keyIDs ...string) error {
+	oldAEAD, err := newKeyEnvelopeAEAD(oldLock, primaryKeyURI)
+	if err != nil {
+		return fmt.Errorf("rewrap: old lock: %w", err)
+	}
+
+	newAEAD, err := newKeyEnvelopeAEAD(newLock, primaryKeyURI)
+	if err != nil {
+		return fmt.Errorf("rewrap: new lock: %w", err)
+	}
+
+	err = rewrap(store, primaryKeyURI, oldAEAD, newAEAD, keyIDs)
+	if err != nil {
+		return fmt.Errorf("rewrap: %w", err)
+	}
+
+	return nil
+}
+
+// RewrapKeys re-encrypts the stored keys with newLock (see Rewrap) and uses newLock from then on. Key operations are
+// blocked until all the keys are re-encrypted. If it fails, the keys already re-encrypted can't be used until
+// RewrapKeys is called again with the same lock and succeeds.
+func (l *LocalKMS) RewrapKeys(newLock secretlock.Service, keyIDs ...string) error {
+	newAEAD, err := newKeyEnvelopeAEAD(newLock, l.primaryKeyURI)
+	if err != nil {
+		return fmt.Errorf("rewrapKeys: %w", err)
+	}
+
+	l.envAEADMutex.Lock()
+	defer l.envAEADMutex.Unlock()
+
+	// prevent keys from being deleted while they are re-encrypted.
+	l.keyInfoMutex.Lock()
+	defer l.keyInfoMutex.Unlock()
+
+	err = rewrap(l.store, l.primaryKeyURI, l.primaryKeyEnvAEAD, newAEAD, keyIDs)
+	if err != nil {
+		return fmt.Errorf("rewrapKeys: %w", err)
+	}
+
+	l.secretLock = newLock
+	l.primaryKeyEnvAEAD = newAEAD
+
+	return nil
+}
+
+func rewrap(store kms.Store, primaryKeyURI string, oldAEAD, newAEAD tink.AEAD, keyIDs []string) error {
+	journal, err := readRewrapJournal(store)
+	if err != nil {
+		return err
+	}
+
+	storedIDs, err := storedKeySetIDs(store)
+	if err != nil {
+		return err
+	}
+
+	journal.Pending, err = ownKeySets(store, primaryKeyURI, appendMissing(journal.Pending, storedIDs...), keyIDs,
+		oldAEAD, newAEAD)
+	if err != nil {
+		return err
+	}
+
+	for len(journal.Pending) > 0 {
+		// persist the keys left before re-encrypting the next one, so an interrupted rewrap can be resumed.
+		err = writeRewrapJournal(store, journal)
+		if err != nil {
+			return err
+		}
+
+		keyID := journal.Pending[0]
+
+		err = rewrapKeySet(store, keyID, oldAEAD, newAEAD)
+		if err != nil {
+			return fmt.Errorf("key '%s': %w", keyID, err)
+		}
+
+		journal.Pending = journal.Pending[1:]
+	}
+
+	err = store.Delete(rewrapJournalID)
+	if err != nil {
+		return fmt.Errorf("failed to delete rewrap journal: %w", err)
+	}
+
+	return nil
+}
+
+// ownKeySets returns the keysets of storedIDs owned by the LocalKMS of primaryKeyURI, along with keyIDs. It returns
+// an error if any of them can be decrypted with neither oldAEAD nor newAEAD, it would be left behind by the rewrap.
+// The keysets whose owner is not recorded are skipped if they can't be decrypted, they are logged.
+func ownKeySets(store kms.Store, primaryKeyURI string, storedIDs, keyIDs []string, oldAEAD,
+	newAEAD tink.AEAD) ([]string, error) {
+	var own, unreadable, skipped []string
+
+	listed := make(map[string]bool, len(keyIDs))
+
+	for _, keyID := range keyIDs {
+		listed[keyID] = true
+	}
+
+	for _, keyID := range appendMissing(storedIDs, keyIDs...) {
+		if isReservedID(keyID) {
+			return nil, fmt.Errorf("key '%s': reserved ID: %w", keyID, kms.ErrKeyNotFound)
+		}
+
+		owner, err := readKeyOwner(store, keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		if owner != "" && owner != primaryKeyURI && !listed[keyID] {
+			// key of another key manager sharing the store.
+			continue
+		}
+
+		data, err := store.Get(keyID)
+		if errors.Is(err, kms.ErrKeyNotFound) {
+			// keep the key in the journal, it was deleted after the rewrap started or is only known by its info.
+			own = append(own, keyID)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get keyset '%s': %w", keyID, err)
+		}
+
+		switch {
+		case canDecrypt(data, oldAEAD) || canDecrypt(data, newAEAD):
+			own = append(own, keyID)
+		case owner == "" && !listed[keyID]:
+			skipped = append(skipped, keyID)
+		default:
+			unreadable = append(unreadable, keyID)
+		}
+	}
+
+	if len(unreadable) > 0 {
+		return nil, fmt.Errorf("refusing to rewrap, keysets %s can't be decrypted", strings.Join(unreadable, ", "))
+	}
+
+	if len(skipped) > 0 {
+		if len(own) == 0 {
+			return nil, fmt.Errorf("refusing to rewrap, none of the keysets %s can be decrypted",
+				strings.Join(skipped, ", "))
+		}
+
+		logger.Warnf("keysets %s are not rewrapped, they can't be decrypted, they are likely keysets of other "+
+			"key managers sharing the store", strings.Join(skipped, ", "))
+	}
+
+	return own, nil
+}
+
+func canDecrypt(data []byte, keyAEAD tink.AEAD) bool {
+	_, err := keyset.Read(keyset.NewJSONReader(bytes.NewReader(data)), keyAEAD)
+
+	return err == nil
+}
+
+func rewrapKeySet(store kms.Store, keyID string, oldAEAD, newAEAD tink.AEAD) error {
+	data, err := store.Get(keyID)
+	if errors.Is(err, kms.ErrKeyNotFound) {
+		// the key was deleted after the rewrap started.
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get keyset: %w", err)
+	}
+
+	kh, err := keyset.Read(keyset.NewJSONReader(bytes.NewReader(data)), oldAEAD)
+	if err != nil {
+		// the keyset was re-encrypted before the rewrap was interrupted.
+		if canDecrypt(data, newAEAD) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to decrypt keyset: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = kh.Write(keyset.NewJSONWriter(buf), newAEAD)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt keyset: %w", err)
+	}
+
+	err = store.Put(keyID, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to put keyset: %w", err)
+	}
+
+	return nil
+}
+
+func appendMissing(ids []string, more ...string) []string {
+	known := make(map[string]struct{}, len(ids))
+
+	for _, id := range ids {
+		known[id] = struct{}{}
+	}
+
+	for _, id := range more {
+		if _, ok := known[id]; ok {
+			continue
+		}
+
+		known[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func readRewrapJournal(store kms.Store) (*rewrapJournal, error) {
+	journal := &rewrapJournal{}
+
+	journalBytes, err := store.Get(rewrapJournalID)
+	if errors.Is(err, kms.ErrKeyNotFound) || err == nil && len(journalBytes) == 0 {
+		return journal, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rewrap journal: %w", err)
+	}
+
+	err = json.Unmarshal(journalBytes, journal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rewrap journal: %w", err)
+	}
+
+	return journal, nil
+}
+
+func writeRewrapJournal(store kms.Store, journal *rewrapJournal) error {
+	journalBytes, err := json.Marshal(journal)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rewrap journal: %w", err)
+	}
+
+	err = store.Put(rewrapJournalID, journalBytes)
+	if err != nil {
+		return fmt.Errorf("failed to put rewrap journal: %w", err)
+	}
+
+	return nil
+}
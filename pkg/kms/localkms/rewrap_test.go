@@ -0,0 +1,291 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local/masterlock"
+)
+
+func TestRewrap(t *testing.T) {
+	var _ kms.KeyRewrapper = (*LocalKMS)(nil)
+
+	oldLock := newTestMasterLock(t, "old passphrase")
+	newLock := newTestMasterLock(t, "new passphrase")
+
+	t.Run("offline rewrap of recorded and legacy keys", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+
+		// a key stored before key information was recorded.
+		delete(store.keys, keyInfoIDPrefix+keyIDs[0])
+
+		require.NoError(t, Rewrap(store, testMasterKeyURI, oldLock, newLock))
+		require.NotContains(t, store.keys, rewrapJournalID)
+
+		requireKeysReadable(t, store, newLock, keyIDs...)
+
+		k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: oldLock})
+		require.NoError(t, err)
+
+		_, err = k.Get(keyIDs[1])
+		require.Error(t, err)
+	})
+
+	t.Run("interrupted rewrap is resumed", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+
+		// the journal and the first key are written before the second put of the journal fails.
+		failingStore := &failingPutStore{inMemoryKMSStore: store, putsLeft: 2}
+
+		err := Rewrap(failingStore, testMasterKeyURI, oldLock, newLock)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to put rewrap journal")
+		require.Contains(t, store.keys, rewrapJournalID)
+
+		journal, err := readRewrapJournal(store)
+		require.NoError(t, err)
+		require.Len(t, journal.Pending, len(keyIDs))
+
+		require.NoError(t, Rewrap(store, testMasterKeyURI, oldLock, newLock))
+		require.NotContains(t, store.keys, rewrapJournalID)
+
+		requireKeysReadable(t, store, newLock, keyIDs...)
+	})
+
+	t.Run("online rewrap", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+
+		k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: oldLock})
+		require.NoError(t, err)
+
+		require.NoError(t, k.RewrapKeys(newLock))
+
+		for _, keyID := range keyIDs {
+			_, err = k.Get(keyID)
+			require.NoError(t, err)
+		}
+
+		newID, _, err := k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		requireKeysReadable(t, store, newLock, append(keyIDs, newID)...)
+	})
+
+	t.Run("keys deleted after the rewrap started are skipped", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+
+		require.NoError(t, writeRewrapJournal(store, &rewrapJournal{Pending: []string{"deleted"}}))
+		require.NoError(t, Rewrap(store, testMasterKeyURI, oldLock, newLock))
+
+		requireKeysReadable(t, store, newLock, keyIDs...)
+	})
+
+	t.Run("keys the store doesn't list are re-encrypted if given", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+
+		// a key neither listed by the store nor recorded in the key information.
+		unlistedStore := &unlistingStore{inMemoryKMSStore: store, unlisted: keyIDs[0]}
+		delete(store.keys, keyInfoIDPrefix+keyIDs[0])
+
+		require.NoError(t, Rewrap(unlistedStore, testMasterKeyURI, oldLock, newLock, keyIDs[0]))
+
+		requireKeysReadable(t, store, newLock, keyIDs...)
+	})
+
+	t.Run("keys of other key managers sharing the store are skipped", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+
+		otherLock := newTestMasterLock(t, "other passphrase")
+
+		other, err := New("local-lock://other/primary/key/", &mockProvider{storage: store, secretLock: otherLock})
+		require.NoError(t, err)
+
+		otherIDs := make([]string, 0, 2)
+
+		for _, kt := range []kms.KeyType{kms.AES128GCMType, kms.ED25519Type} {
+			keyID, _, e := other.Create(kt)
+			require.NoError(t, e)
+
+			otherIDs = append(otherIDs, keyID)
+		}
+
+		// a key of the other key manager stored before its owner was recorded.
+		delete(store.keys, keyInfoIDPrefix+otherIDs[1])
+
+		k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: oldLock})
+		require.NoError(t, err)
+
+		require.NoError(t, k.RewrapKeys(newLock))
+
+		requireKeysReadable(t, store, newLock, keyIDs...)
+		requireKeysReadable(t, store, otherLock, otherIDs...)
+
+		for _, keyID := range otherIDs {
+			_, err = other.Get(keyID)
+			require.NoError(t, err)
+		}
+
+		// the offline rewrap skips them too.
+		require.NoError(t, Rewrap(store, testMasterKeyURI, newLock, oldLock))
+
+		requireKeysReadable(t, store, oldLock, keyIDs...)
+		requireKeysReadable(t, store, otherLock, otherIDs...)
+	})
+
+	t.Run("error - wrong old lock", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+
+		err := Rewrap(store, testMasterKeyURI, newTestMasterLock(t, "wrong"), newLock)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "refusing to rewrap")
+		require.NotContains(t, store.keys, rewrapJournalID)
+		requireKeysReadable(t, store, oldLock, keyIDs...)
+
+		k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: oldLock})
+		require.NoError(t, err)
+
+		err = k.RewrapKeys(newLock, "unknown", rewrapJournalID)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		// keys stored before their owner was recorded.
+		for _, keyID := range keyIDs {
+			delete(store.keys, keyInfoIDPrefix+keyID)
+		}
+
+		err = Rewrap(store, testMasterKeyURI, newTestMasterLock(t, "wrong"), newLock)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "none of the keysets")
+		requireKeysReadable(t, store, oldLock, keyIDs...)
+	})
+
+	t.Run("error - a stored key can't be decrypted", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		keyIDs := createTestKeys(t, store, oldLock)
+		foreignIDs := createTestKeys(t, store, newTestMasterLock(t, "foreign"))
+
+		err := Rewrap(store, testMasterKeyURI, oldLock, newLock)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "refusing to rewrap")
+		require.Contains(t, err.Error(), foreignIDs[0])
+		require.NotContains(t, store.keys, rewrapJournalID)
+		requireKeysReadable(t, store, oldLock, keyIDs...)
+	})
+
+	t.Run("error - store can't list its keysets", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		createTestKeys(t, store, oldLock)
+
+		err := Rewrap(struct{ kms.Store }{store}, testMasterKeyURI, oldLock, newLock)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store can't list its keysets")
+	})
+
+	t.Run("error - invalid primary key URI", func(t *testing.T) {
+		err := Rewrap(newInMemoryKMSStore(), "bad-uri", oldLock, newLock)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rewrap: old lock")
+	})
+
+	t.Run("error - store failures", func(t *testing.T) {
+		errGet := errors.New("get error")
+
+		err := Rewrap(&mockStore{errGet: errGet}, testMasterKeyURI, oldLock, newLock)
+		require.True(t, errors.Is(err, errGet))
+
+		store := newInMemoryKMSStore()
+		store.keys[rewrapJournalID] = []byte("{")
+
+		err = Rewrap(store, testMasterKeyURI, oldLock, newLock)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal rewrap journal")
+
+	})
+}
+
+func newTestMasterLock(t *testing.T, passphrase string) secretlock.Service {
+	t.Helper()
+
+	lock, err := masterlock.New(masterlock.HKDF, passphrase)
+	require.NoError(t, err)
+
+	return lock
+}
+
+func createTestKeys(t *testing.T, store kms.Store, lock secretlock.Service) []string {
+	t.Helper()
+
+	k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: lock})
+	require.NoError(t, err)
+
+	var keyIDs []string
+
+	for _, kt := range []kms.KeyType{kms.AES128GCMType, kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363} {
+		keyID, _, err := k.Create(kt)
+		require.NoError(t, err)
+
+		keyIDs = append(keyIDs, keyID)
+	}
+
+	return keyIDs
+}
+
+func requireKeysReadable(t *testing.T, store kms.Store, lock secretlock.Service, keyIDs ...string) {
+	t.Helper()
+
+	k, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: lock})
+	require.NoError(t, err)
+
+	for _, keyID := range keyIDs {
+		_, err = k.Get(keyID)
+		require.NoError(t, err)
+	}
+}
+
+type unlistingStore struct {
+	*inMemoryKMSStore
+	unlisted string
+}
+
+func (u *unlistingStore) KeysetIDs() ([]string, error) {
+	var ids []string
+
+	for keysetID := range u.keys {
+		if keysetID != u.unlisted {
+			ids = append(ids, keysetID)
+		}
+	}
+
+	return ids, nil
+}
+
+type failingPutStore struct {
+	*inMemoryKMSStore
+	putsLeft int
+}
+
+func (f *failingPutStore) Put(keysetID string, key []byte) error {
+	if f.putsLeft == 0 {
+		return errors.New("put error")
+	}
+
+	f.putsLeft--
+
+	return f.inMemoryKMSStore.Put(keysetID, key)
+}
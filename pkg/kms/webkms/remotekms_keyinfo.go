@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+type listKeysResp struct {
+	Keys []*kms.KeyInfo `json:"keys"`
+}
+
+type setKeyMetadataReq struct {
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ListKeys remotely fetches the information of all the keys of the keystore.
+func (r *RemoteKMS) ListKeys() ([]*kms.KeyInfo, error) {
+	destination := r.keystoreURL + "/keys"
+
+	resp, err := r.getHTTPRequest(destination)
+	if err != nil {
+		return nil, fmt.Errorf("posting GET ListKeys failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "ListKeys")
+
+	var httpResp listKeysResp
+
+	err = readResponse(resp, &httpResp, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("list keys failed [%s, %w]", destination, err)
+	}
+
+	return httpResp.Keys, nil
+}
+
+// GetKeyInfo remotely fetches the information of the key referenced by keyID.
+func (r *RemoteKMS) GetKeyInfo(keyID string) (*kms.KeyInfo, error) {
+	destination := r.buildKIDURL(keyID) + "/info"
+
+	resp, err := r.getHTTPRequest(destination)
+	if err != nil {
+		return nil, fmt.Errorf("posting GET GetKeyInfo failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "GetKeyInfo")
+
+	httpResp := &kms.KeyInfo{}
+
+	err = readResponse(resp, httpResp, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("get key info failed [%s, %w]", destination, err)
+	}
+
+	return httpResp, nil
+}
+
+// SetKeyMetadata remotely replaces the metadata tags of the key referenced by keyID.
+func (r *RemoteKMS) SetKeyMetadata(keyID string, metadata map[string]string) error {
+	destination := r.buildKIDURL(keyID) + "/metadata"
+
+	marshaledReq, err := r.marshalFunc(&setKeyMetadataReq{Metadata: metadata})
+	if err != nil {
+		return fmt.Errorf("failed to marshal SetKeyMetadata request [%s, %w]", destination, err)
+	}
+
+	resp, err := r.putHTTPRequest(destination, marshaledReq)
+	if err != nil {
+		return fmt.Errorf("posting PUT SetKeyMetadata failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "SetKeyMetadata")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("set key metadata failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
+// DisableKey remotely disables the key referenced by keyID.
+func (r *RemoteKMS) DisableKey(keyID string) error {
+	destination := r.buildKIDURL(keyID) + "/disable"
+
+	resp, err := r.postHTTPRequest(destination, []byte("{}"))
+	if err != nil {
+		return fmt.Errorf("posting DisableKey failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "DisableKey")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("disable key failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
+// DeleteKey remotely deletes the key referenced by keyID.
+func (r *RemoteKMS) DeleteKey(keyID string) error {
+	destination := r.buildKIDURL(keyID)
+
+	resp, err := r.doHTTPRequest(http.MethodDelete, destination, nil)
+	if err != nil {
+		return fmt.Errorf("posting DELETE DeleteKey failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "DeleteKey")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("delete key failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
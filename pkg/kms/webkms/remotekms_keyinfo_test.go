@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+const testKeystoreURL = "https://kms.example.com/v1/keystores/" + defaultKeyStoreID
+
+func TestRemoteKMS_KeyLifecycle(t *testing.T) {
+	var _ kms.KeyLifecycleManager = (*RemoteKMS)(nil)
+
+	t.Run("list keys", func(t *testing.T) {
+		client := &mockHTTPClient{response: `{"keys":[{"id":"k1","type":"ED25519","metadata":{"label":"a"}}]}`}
+
+		keys, err := New(testKeystoreURL, client).ListKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, "k1", keys[0].ID)
+		require.Equal(t, kms.ED25519Type, keys[0].Type)
+		require.Equal(t, "a", keys[0].Metadata["label"])
+
+		require.Equal(t, http.MethodGet, client.request.Method)
+		require.Equal(t, testKeystoreURL+"/keys", client.request.URL.String())
+	})
+
+	t.Run("get key info", func(t *testing.T) {
+		client := &mockHTTPClient{response: `{"id":"k1","disabled":true}`}
+
+		info, err := New(testKeystoreURL, client).GetKeyInfo("k1")
+		require.NoError(t, err)
+		require.Equal(t, "k1", info.ID)
+		require.True(t, info.Disabled)
+
+		require.Equal(t, http.MethodGet, client.request.Method)
+		require.Equal(t, testKeystoreURL+"/keys/k1/info", client.request.URL.String())
+	})
+
+	t.Run("set key metadata", func(t *testing.T) {
+		client := &mockHTTPClient{}
+
+		err := New(testKeystoreURL, client).SetKeyMetadata("k1", map[string]string{"label": "a"})
+		require.NoError(t, err)
+
+		require.Equal(t, http.MethodPut, client.request.Method)
+		require.Equal(t, testKeystoreURL+"/keys/k1/metadata", client.request.URL.String())
+		require.JSONEq(t, `{"metadata":{"label":"a"}}`, string(client.body))
+	})
+
+	t.Run("disable key", func(t *testing.T) {
+		client := &mockHTTPClient{}
+
+		require.NoError(t, New(testKeystoreURL, client).DisableKey("k1"))
+		require.Equal(t, http.MethodPost, client.request.Method)
+		require.Equal(t, testKeystoreURL+"/keys/k1/disable", client.request.URL.String())
+	})
+
+	t.Run("delete key", func(t *testing.T) {
+		client := &mockHTTPClient{}
+
+		require.NoError(t, New(testKeystoreURL, client).DeleteKey("k1"))
+		require.Equal(t, http.MethodDelete, client.request.Method)
+		require.Equal(t, testKeystoreURL+"/keys/k1", client.request.URL.String())
+	})
+
+	t.Run("API errors", func(t *testing.T) {
+		client := &mockHTTPClient{status: http.StatusNotFound, response: `{"errMessage": "key not found"}`}
+		remoteKMS := New(testKeystoreURL, client)
+
+		_, err := remoteKMS.ListKeys()
+		require.Contains(t, err.Error(), "list keys failed")
+		require.Contains(t, err.Error(), "key not found")
+
+		_, err = remoteKMS.GetKeyInfo("k1")
+		require.Contains(t, err.Error(), "get key info failed")
+
+		err = remoteKMS.SetKeyMetadata("k1", nil)
+		require.Contains(t, err.Error(), "set key metadata failed")
+
+		err = remoteKMS.DisableKey("k1")
+		require.Contains(t, err.Error(), "disable key failed")
+
+		err = remoteKMS.DeleteKey("k1")
+		require.Contains(t, err.Error(), "delete key failed")
+	})
+
+	t.Run("HTTP client errors", func(t *testing.T) {
+		remoteKMS := New(testKeystoreURL, &mockHTTPClient{err: errors.New("connection refused")})
+
+		_, err := remoteKMS.ListKeys()
+		require.Contains(t, err.Error(), "connection refused")
+
+		_, err = remoteKMS.GetKeyInfo("k1")
+		require.Contains(t, err.Error(), "connection refused")
+
+		err = remoteKMS.SetKeyMetadata("k1", nil)
+		require.Contains(t, err.Error(), "connection refused")
+
+		err = remoteKMS.DisableKey("k1")
+		require.Contains(t, err.Error(), "connection refused")
+
+		err = remoteKMS.DeleteKey("k1")
+		require.Contains(t, err.Error(), "connection refused")
+	})
+
+	t.Run("set key metadata marshal failure", func(t *testing.T) {
+		remoteKMS := New(testKeystoreURL, &mockHTTPClient{})
+		remoteKMS.marshalFunc = failingMarshal
+
+		err := remoteKMS.SetKeyMetadata("k1", nil)
+		require.Contains(t, err.Error(), "failed to marshal SetKeyMetadata request")
+	})
+}
+
+type mockHTTPClient struct {
+	status   int
+	response string
+	err      error
+	request  *http.Request
+	body     []byte
+}
+
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	m.request = req
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		m.body = body
+	}
+
+	status := m.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	response := m.response
+	if response == "" {
+		response = "{}"
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+		Header:     http.Header{"Content-Type": []string{ContentType}},
+	}, nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// MockDiscoverFeaturesSvc mock discover features service.
+type MockDiscoverFeaturesSvc struct {
+	service.DIDComm
+	ProtocolName string
+	QueryErr     error
+	QueryFunc    func(connectionID string, params *discoverfeatures.QueryParams) ([]*discoverfeatures.Disclosure, error)
+}
+
+// Initialize service.
+func (m *MockDiscoverFeaturesSvc) Initialize(interface{}) error {
+	return nil
+}
+
+// Name return service name.
+func (m *MockDiscoverFeaturesSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return discoverfeatures.DiscoverFeatures
+}
+
+// Accept checks whether the service can handle the message type.
+func (m *MockDiscoverFeaturesSvc) Accept(msgType string) bool {
+	return false
+}
+
+// Query perform Query.
+func (m *MockDiscoverFeaturesSvc) Query(connectionID string,
+	params *discoverfeatures.QueryParams) ([]*discoverfeatures.Disclosure, error) {
+	if m.QueryErr != nil {
+		return nil, m.QueryErr
+	}
+
+	if m.QueryFunc != nil {
+		return m.QueryFunc(connectionID, params)
+	}
+
+	return []*discoverfeatures.Disclosure{}, nil
+}
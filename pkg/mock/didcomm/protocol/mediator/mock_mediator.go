@@ -25,11 +25,14 @@ type MockMediatorSvc struct {
 	RouterEndpoint     string
 	RoutingKeys        []string
 	ConfigErr          error
+	ConfigFunc         func(connID string) (*mediator.Config, error)
 	AddKeyErr          error
 	UnregisterErr      error
 	Connections        []string
 	GetConnectionsErr  error
 	AddKeyFunc         func(string) error
+	Keylist            *mediator.KeylistV2Body
+	KeylistQueryErr    error
 }
 
 // Initialize service.
@@ -87,14 +90,20 @@ func (m *MockMediatorSvc) Unregister(connID string) error {
 	return m.UnregisterErr
 }
 
-// AddKey adds agents recKey to the router.
-func (m *MockMediatorSvc) AddKey(connID, recKey string) error {
+// AddKey adds agents recKeys to the router, AddKeyFunc is called for each key.
+func (m *MockMediatorSvc) AddKey(connID string, recKeys ...string) error {
 	if m.AddKeyErr != nil {
 		return m.AddKeyErr
 	}
 
-	if m.AddKeyFunc != nil {
-		return m.AddKeyFunc(recKey)
+	if m.AddKeyFunc == nil {
+		return nil
+	}
+
+	for _, recKey := range recKeys {
+		if err := m.AddKeyFunc(recKey); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -106,6 +115,10 @@ func (m *MockMediatorSvc) Config(connID string) (*mediator.Config, error) {
 		return nil, m.ConfigErr
 	}
 
+	if m.ConfigFunc != nil {
+		return m.ConfigFunc(connID)
+	}
+
 	// default, route not registered error
 	if m.RouterEndpoint == "" || m.RoutingKeys == nil {
 		return nil, mediator.ErrRouterNotRegistered
@@ -122,3 +135,16 @@ func (m *MockMediatorSvc) GetConnections(...mediator.ConnectionOption) ([]string
 
 	return m.Connections, nil
 }
+
+// KeylistQuery queries the recipient DIDs registered with the router.
+func (m *MockMediatorSvc) KeylistQuery(connID string, limit, offset int) (*mediator.KeylistV2Body, error) {
+	if m.KeylistQueryErr != nil {
+		return nil, m.KeylistQueryErr
+	}
+
+	if m.Keylist != nil {
+		return m.Keylist, nil
+	}
+
+	return &mediator.KeylistV2Body{}, nil
+}
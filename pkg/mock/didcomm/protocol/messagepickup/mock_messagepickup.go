@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package messagepickup
 
 import (
+	"time"
+
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 )
@@ -22,10 +24,20 @@ type MockMessagePickupSvc struct {
 	HandleInboundFunc  func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
 	HandleOutboundFunc func(_ service.DIDCommMsg, _, _ string) (string, error)
 	AddMessageFunc     func(message []byte, theirDID string) error
+	AddMessageExpFunc  func(message []byte, theirDID string, expiresTime time.Time) error
 	AddMessageErr      error
 	AcceptFunc         func(msgType string) bool
 	NoopErr            error
 	NoopFunc           func(connectionID string) error
+
+	StatusRequestV2Err     error
+	StatusRequestV2Func    func(connectionID string) (*messagepickup.StatusV2, error)
+	DeliveryRequestErr     error
+	DeliveryRequestFunc    func(connectionID string, limit int) ([]string, error)
+	MessagesReceivedErr    error
+	MessagesReceivedFunc   func(connectionID string, messageIDs []string) error
+	LiveDeliveryChangeErr  error
+	LiveDeliveryChangeFunc func(connectionID string, liveDelivery bool) error
 }
 
 // Initialize service.
@@ -86,6 +98,15 @@ func (m *MockMessagePickupSvc) Accept(msgType string) bool {
 	return true
 }
 
+// AddMessageWithExpiry perform AddMessageWithExpiry.
+func (m *MockMessagePickupSvc) AddMessageWithExpiry(message []byte, theirDID string, expiresTime time.Time) error {
+	if m.AddMessageExpFunc != nil {
+		return m.AddMessageExpFunc(message, theirDID, expiresTime)
+	}
+
+	return m.AddMessage(message, theirDID)
+}
+
 // AddMessage perform AddMessage.
 func (m *MockMessagePickupSvc) AddMessage(message []byte, theirDID string) error {
 	if m.AddMessageErr != nil {
@@ -111,3 +132,55 @@ func (m *MockMessagePickupSvc) Noop(connectionID string) error {
 
 	return nil
 }
+
+// StatusRequestV2 perform StatusRequestV2.
+func (m *MockMessagePickupSvc) StatusRequestV2(connectionID string) (*messagepickup.StatusV2, error) {
+	if m.StatusRequestV2Err != nil {
+		return nil, m.StatusRequestV2Err
+	}
+
+	if m.StatusRequestV2Func != nil {
+		return m.StatusRequestV2Func(connectionID)
+	}
+
+	return &messagepickup.StatusV2{}, nil
+}
+
+// DeliveryRequest perform DeliveryRequest.
+func (m *MockMessagePickupSvc) DeliveryRequest(connectionID string, limit int) ([]string, error) {
+	if m.DeliveryRequestErr != nil {
+		return nil, m.DeliveryRequestErr
+	}
+
+	if m.DeliveryRequestFunc != nil {
+		return m.DeliveryRequestFunc(connectionID, limit)
+	}
+
+	return nil, nil
+}
+
+// MessagesReceived perform MessagesReceived.
+func (m *MockMessagePickupSvc) MessagesReceived(connectionID string, messageIDs []string) error {
+	if m.MessagesReceivedErr != nil {
+		return m.MessagesReceivedErr
+	}
+
+	if m.MessagesReceivedFunc != nil {
+		return m.MessagesReceivedFunc(connectionID, messageIDs)
+	}
+
+	return nil
+}
+
+// LiveDeliveryChange perform LiveDeliveryChange.
+func (m *MockMessagePickupSvc) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	if m.LiveDeliveryChangeErr != nil {
+		return m.LiveDeliveryChangeErr
+	}
+
+	if m.LiveDeliveryChangeFunc != nil {
+		return m.LiveDeliveryChangeFunc(connectionID, liveDelivery)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/markcryptohash/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// MockTrustPingSvc mock trustping service.
+type MockTrustPingSvc struct {
+	service.DIDComm
+	ProtocolName string
+	PingErr      error
+	PingFunc     func(connectionID string, params *trustping.PingParams) (*trustping.PingResult, error)
+}
+
+// Initialize service.
+func (m *MockTrustPingSvc) Initialize(interface{}) error {
+	return nil
+}
+
+// Name return service name.
+func (m *MockTrustPingSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return trustping.TrustPing
+}
+
+// Accept checks whether the service can handle the message type.
+func (m *MockTrustPingSvc) Accept(msgType string) bool {
+	return false
+}
+
+// Ping perform Ping.
+func (m *MockTrustPingSvc) Ping(connectionID string, params *trustping.PingParams) (*trustping.PingResult, error) {
+	if m.PingErr != nil {
+		return nil, m.PingErr
+	}
+
+	if m.PingFunc != nil {
+		return m.PingFunc(connectionID, params)
+	}
+
+	return &trustping.PingResult{ConnectionID: connectionID, PingID: "ping-id", Responded: params.ResponseRequested}, nil
+}
@@ -20,7 +20,8 @@ import (
 	"github.com/markcryptohash/aries-framework-go/spi/storage"
 )
 
-// KeyManager mocks a local Key Management Service + ExportableKeyManager.
+// KeyManager mocks a local Key Management Service + ExportableKeyManager + KeyLifecycleManager + KeyRewrapper
+// + KeyBackupManager.
 type KeyManager struct {
 	CreateKeyID              string
 	CreateKeyValue           *keyset.Handle
@@ -42,6 +43,17 @@ type KeyManager struct {
 	ImportPrivateKeyErr      error
 	ImportPrivateKeyID       string
 	ImportPrivateKeyValue    *keyset.Handle
+	ListKeysValue            []*kmsservice.KeyInfo
+	ListKeysErr              error
+	GetKeyInfoValue          *kmsservice.KeyInfo
+	GetKeyInfoErr            error
+	SetKeyMetadataErr        error
+	DisableKeyErr            error
+	DeleteKeyErr             error
+	RewrapKeysErr            error
+	BackupValue              []byte
+	BackupErr                error
+	RestoreErr               error
 }
 
 // Create a new mock ey/keyset/key handle for the type kt.
@@ -115,6 +127,54 @@ func (k *KeyManager) ImportPrivateKey(privKey interface{}, keyType kmsservice.Ke
 	return k.ImportPrivateKeyID, k.ImportPrivateKeyValue, nil
 }
 
+// ListKeys returns the mocked key infos.
+func (k *KeyManager) ListKeys() ([]*kmsservice.KeyInfo, error) {
+	if k.ListKeysErr != nil {
+		return nil, k.ListKeysErr
+	}
+
+	return k.ListKeysValue, nil
+}
+
+// GetKeyInfo returns the mocked key info.
+func (k *KeyManager) GetKeyInfo(keyID string) (*kmsservice.KeyInfo, error) {
+	if k.GetKeyInfoErr != nil {
+		return nil, k.GetKeyInfoErr
+	}
+
+	return k.GetKeyInfoValue, nil
+}
+
+// SetKeyMetadata emulates setting the metadata of a key.
+func (k *KeyManager) SetKeyMetadata(keyID string, metadata map[string]string) error {
+	return k.SetKeyMetadataErr
+}
+
+// DisableKey emulates disabling a key.
+func (k *KeyManager) DisableKey(keyID string) error {
+	return k.DisableKeyErr
+}
+
+// DeleteKey emulates deleting a key.
+func (k *KeyManager) DeleteKey(keyID string) error {
+	return k.DeleteKeyErr
+}
+
+// RewrapKeys emulates re-encrypting the stored keys with a new secret lock.
+func (k *KeyManager) RewrapKeys(newLock secretlock.Service, keyIDs ...string) error {
+	return k.RewrapKeysErr
+}
+
+// Backup emulates exporting the keys in an encrypted backup.
+func (k *KeyManager) Backup(passphrase string, keyIDs ...string) ([]byte, error) {
+	return k.BackupValue, k.BackupErr
+}
+
+// Restore emulates importing the keys of an encrypted backup.
+func (k *KeyManager) Restore(backup []byte, passphrase string) error {
+	return k.RestoreErr
+}
+
 func createMockKeyHandle(ks *tinkpb.Keyset) (*keyset.Handle, error) {
 	primaryKey := ks.Key[0]
 
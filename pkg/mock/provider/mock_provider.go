@@ -53,6 +53,8 @@ type Provider struct {
 	GetDIDsMaxRetriesValue            uint64
 	DIDRotatorValue                   middleware.DIDCommMessageMiddleware
 	MessengerValue                    service.Messenger
+	ServiceMsgTypeTargetsValue        []dispatcher.MessageTypeTarget
+	InboundGuardValue                 dispatcher.InboundGuard
 }
 
 // Messenger return messenger.
@@ -80,6 +82,11 @@ func (p *Provider) AllServices() []dispatcher.ProtocolService {
 	return out
 }
 
+// ServiceMsgTypeTargets return the service message type targets.
+func (p *Provider) ServiceMsgTypeTargets() []dispatcher.MessageTypeTarget {
+	return p.ServiceMsgTypeTargetsValue
+}
+
 // GetDIDsBackOffDuration return backoff duration for getting DIDs.
 func (p *Provider) GetDIDsBackOffDuration() time.Duration {
 	return p.GetDIDsBackoffDurationValue
@@ -207,3 +214,8 @@ func (p *Provider) KeyAgreementType() kms.KeyType {
 func (p *Provider) DIDRotator() *middleware.DIDCommMessageMiddleware {
 	return &p.DIDRotatorValue
 }
+
+// InboundGuard returns the inbound guard.
+func (p *Provider) InboundGuard() dispatcher.InboundGuard {
+	return p.InboundGuardValue
+}
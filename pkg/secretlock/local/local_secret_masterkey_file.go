@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package local
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+)
+
+const (
+	newMasterKeyLen = 32
+
+	// pendingMasterKeySuffix is appended to the path of a master key file to get the path of the master key
+	// replacing it during a rotation.
+	pendingMasterKeySuffix = ".new"
+)
+
+// NewServiceFromPath creates a new instance of local secret lock service using the master key stored in `path`,
+// protected by secLock if not nil (see NewService). If the file doesn't exist, a new random master key is created
+// and written to `path` first.
+func NewServiceFromPath(path string, secLock secretlock.Service) (secretlock.Service, error) {
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		err = CreateMasterKeyFile(path, secLock)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	masterKeyReader, err := MasterKeyFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewService(masterKeyReader, secLock)
+}
+
+// CreateMasterKeyFile writes a new random master key to `path`, encrypted with secLock if not nil or base64URL
+// encoded otherwise. It fails if the file already exists.
+func CreateMasterKeyFile(path string, secLock secretlock.Service) error {
+	masterKey := random.GetRandomBytes(newMasterKeyLen)
+
+	var content string
+
+	if secLock != nil {
+		encResponse, err := secLock.Encrypt("", &secretlock.EncryptRequest{Plaintext: string(masterKey)})
+		if err != nil {
+			return fmt.Errorf("encrypt master key: %w", err)
+		}
+
+		content = encResponse.Ciphertext
+	} else {
+		content = base64.URLEncoding.EncodeToString(masterKey)
+	}
+
+	masterKeyFile, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = masterKeyFile.WriteString(content)
+	if err == nil {
+		err = masterKeyFile.Sync()
+	}
+
+	if err != nil {
+		_ = masterKeyFile.Close() // nolint: errcheck
+
+		return fmt.Errorf("write master key: %w", err)
+	}
+
+	err = masterKeyFile.Close()
+	if err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+// RotateMasterKeyFile replaces the master key stored in `path` with a new random master key protected by secLock if
+// not nil. The new master key is written to `path` + ".new" first, then rewrap is called with its secret lock
+// service to re-encrypt the data protected by the current master key. The new master key replaces the file at
+// `path` atomically once rewrap succeeds, it is kept in `path` + ".new" otherwise so that calling
+// RotateMasterKeyFile again resumes the rotation with the same master key. If the process stops before the file is
+// replaced, ResumeMasterKeyRotation must be called before the master key is used again.
+func RotateMasterKeyFile(path string, secLock secretlock.Service, rewrap func(newLock secretlock.Service) error) error {
+	pendingPath := path + pendingMasterKeySuffix
+
+	newLock, err := NewServiceFromPath(pendingPath, secLock)
+	if err != nil {
+		return fmt.Errorf("new master key: %w", err)
+	}
+
+	err = rewrap(newLock)
+	if err != nil {
+		return err
+	}
+
+	return replaceMasterKeyFile(pendingPath, path)
+}
+
+// ResumeMasterKeyRotation completes the rotation of the master key stored in `path` if RotateMasterKeyFile was
+// interrupted, typically by a crash, before the new master key replaced the file. Some data may already be protected
+// by the new master key kept in `path` + ".new", so rewrap is called with the secret lock services of the current
+// and of the new master key to re-encrypt the remaining data, then the new master key replaces the file. The pending
+// master key is removed if it can't be read while the current one can, the rotation was interrupted before any data
+// was re-encrypted. Nothing is done if no rotation is pending.
+func ResumeMasterKeyRotation(path string, secLock secretlock.Service,
+	rewrap func(oldLock, newLock secretlock.Service) error) error {
+	pendingPath := path + pendingMasterKeySuffix
+
+	_, err := os.Stat(pendingPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("pending master key: %w", err)
+	}
+
+	oldLock, err := serviceFromExistingPath(path, secLock)
+	if err != nil {
+		return fmt.Errorf("master key: %w", err)
+	}
+
+	newLock, err := serviceFromExistingPath(pendingPath, secLock)
+	if err != nil {
+		logger.Warnf("removing unreadable pending master key %s: %s", pendingPath, err)
+
+		err = os.Remove(pendingPath)
+		if err != nil {
+			return fmt.Errorf("remove pending master key: %w", err)
+		}
+
+		return syncDir(filepath.Dir(path))
+	}
+
+	err = rewrap(oldLock, newLock)
+	if err != nil {
+		return err
+	}
+
+	return replaceMasterKeyFile(pendingPath, path)
+}
+
+func serviceFromExistingPath(path string, secLock secretlock.Service) (secretlock.Service, error) {
+	masterKeyReader, err := MasterKeyFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewService(masterKeyReader, secLock)
+}
+
+// replaceMasterKeyFile atomically replaces the master key file at path with the one at pendingPath.
+func replaceMasterKeyFile(pendingPath, path string) error {
+	err := os.Rename(pendingPath, path)
+	if err != nil {
+		return fmt.Errorf("replace master key: %w", err)
+	}
+
+	err = syncDir(filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("replace master key: %w", err)
+	}
+
+	return nil
+}
+
+// syncDir flushes the entries of the directory dir, so that the master key files it holds survive a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(filepath.Clean(dir))
+	if err != nil {
+		return err
+	}
+
+	err = d.Sync()
+	if err != nil {
+		_ = d.Close() // nolint: errcheck
+
+		return fmt.Errorf("sync directory %s: %w", dir, err)
+	}
+
+	return d.Close()
+}
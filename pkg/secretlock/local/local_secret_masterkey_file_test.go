@@ -0,0 +1,255 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package local
+
+import (
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mocksecretlock "github.com/markcryptohash/aries-framework-go/pkg/mock/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+)
+
+func TestNewServiceFromPath(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		secLock func(t *testing.T) secretlock.Service
+	}{
+		{
+			name:    "unprotected master key",
+			secLock: func(*testing.T) secretlock.Service { return nil },
+		},
+		{
+			name: "protected master key",
+			secLock: func(t *testing.T) secretlock.Service {
+				lock, e := hkdf.NewMasterLock("passphrase", sha256.New, nil)
+				require.NoError(t, e)
+
+				return lock
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "masterkey")
+
+			lock, err := NewServiceFromPath(path, tc.secLock(t))
+			require.NoError(t, err)
+
+			encrypted, err := lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: "secret"})
+			require.NoError(t, err)
+
+			// the master key file created above is reused
+			lock, err = NewServiceFromPath(path, tc.secLock(t))
+			require.NoError(t, err)
+
+			decrypted, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypted.Ciphertext})
+			require.NoError(t, err)
+			require.Equal(t, "secret", decrypted.Plaintext)
+
+			err = CreateMasterKeyFile(path, tc.secLock(t))
+			require.True(t, errors.Is(err, os.ErrExist))
+		})
+	}
+
+	t.Run("error - invalid path", func(t *testing.T) {
+		lock, err := NewServiceFromPath(filepath.Join(t.TempDir(), "missing", "masterkey"), nil)
+		require.Error(t, err)
+		require.Nil(t, lock)
+	})
+
+	t.Run("error - encrypt master key", func(t *testing.T) {
+		err := CreateMasterKeyFile(filepath.Join(t.TempDir(), "masterkey"),
+			&mocksecretlock.MockSecretLock{ValEncrypt: "", ErrEncrypt: errors.New("encrypt error")})
+		require.EqualError(t, err, "encrypt master key: encrypt error")
+	})
+}
+
+func TestRotateMasterKeyFile(t *testing.T) {
+	encrypt := func(t *testing.T, lock secretlock.Service) string {
+		t.Helper()
+
+		encrypted, err := lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: "secret"})
+		require.NoError(t, err)
+
+		return encrypted.Ciphertext
+	}
+
+	t.Run("the new master key replaces the file once rewrapped", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "masterkey")
+
+		oldLock, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		ciphertext := encrypt(t, oldLock)
+
+		err = RotateMasterKeyFile(path, nil, func(newLock secretlock.Service) error {
+			ciphertext = encrypt(t, newLock)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = os.Stat(path + pendingMasterKeySuffix)
+		require.True(t, errors.Is(err, os.ErrNotExist))
+
+		lock, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		decrypted, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: ciphertext})
+		require.NoError(t, err)
+		require.Equal(t, "secret", decrypted.Plaintext)
+	})
+
+	t.Run("a failed rewrap is resumed with the same master key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "masterkey")
+
+		oldLock, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		var ciphertext string
+
+		errRewrap := errors.New("rewrap error")
+
+		err = RotateMasterKeyFile(path, nil, func(newLock secretlock.Service) error {
+			ciphertext = encrypt(t, newLock)
+
+			return errRewrap
+		})
+		require.True(t, errors.Is(err, errRewrap))
+
+		// the configured master key is kept.
+		lock, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		_, err = lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypt(t, oldLock)})
+		require.NoError(t, err)
+
+		err = RotateMasterKeyFile(path, nil, func(newLock secretlock.Service) error {
+			decrypted, e := newLock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: ciphertext})
+			require.NoError(t, e)
+			require.Equal(t, "secret", decrypted.Plaintext)
+
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - invalid path", func(t *testing.T) {
+		err := RotateMasterKeyFile(filepath.Join(t.TempDir(), "missing", "masterkey"), nil,
+			func(secretlock.Service) error { return nil })
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new master key")
+	})
+}
+
+func TestResumeMasterKeyRotation(t *testing.T) {
+	rewrapNothing := func(secretlock.Service, secretlock.Service) error { return nil }
+
+	t.Run("nothing to resume", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "masterkey")
+
+		_, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		err = ResumeMasterKeyRotation(path, nil, func(secretlock.Service, secretlock.Service) error {
+			return errors.New("unexpected rewrap")
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("rotation interrupted before the file was replaced is completed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "masterkey")
+
+		oldLock, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		// the process stopped after the data was rewrapped, before the new master key replaced the file.
+		newLock, err := NewServiceFromPath(path+pendingMasterKeySuffix, nil)
+		require.NoError(t, err)
+
+		encrypted, err := newLock.Encrypt("", &secretlock.EncryptRequest{Plaintext: "secret"})
+		require.NoError(t, err)
+
+		err = ResumeMasterKeyRotation(path, nil, func(o, n secretlock.Service) error {
+			_, e := o.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypted.Ciphertext})
+			require.Error(t, e)
+
+			_, e = n.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypted.Ciphertext})
+			require.NoError(t, e)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = os.Stat(path + pendingMasterKeySuffix)
+		require.True(t, errors.Is(err, os.ErrNotExist))
+
+		lock, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		decrypted, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypted.Ciphertext})
+		require.NoError(t, err)
+		require.Equal(t, "secret", decrypted.Plaintext)
+
+		_, err = oldLock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypted.Ciphertext})
+		require.Error(t, err)
+	})
+
+	t.Run("failed rewrap keeps the pending master key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "masterkey")
+
+		_, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, CreateMasterKeyFile(path+pendingMasterKeySuffix, nil))
+
+		errRewrap := errors.New("rewrap error")
+
+		err = ResumeMasterKeyRotation(path, nil, func(secretlock.Service, secretlock.Service) error {
+			return errRewrap
+		})
+		require.True(t, errors.Is(err, errRewrap))
+
+		_, err = os.Stat(path + pendingMasterKeySuffix)
+		require.NoError(t, err)
+	})
+
+	t.Run("unreadable pending master key is rolled back", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "masterkey")
+
+		_, err := NewServiceFromPath(path, nil)
+		require.NoError(t, err)
+
+		// the process stopped while the new master key was written.
+		require.NoError(t, os.WriteFile(path+pendingMasterKeySuffix, []byte("partial"), 0o600))
+
+		require.NoError(t, ResumeMasterKeyRotation(path, nil, rewrapNothing))
+
+		_, err = os.Stat(path + pendingMasterKeySuffix)
+		require.True(t, errors.Is(err, os.ErrNotExist))
+	})
+
+	t.Run("error - unreadable master key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "masterkey")
+
+		require.NoError(t, CreateMasterKeyFile(path+pendingMasterKeySuffix, nil))
+
+		err := ResumeMasterKeyRotation(path, nil, rewrapNothing)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "master key")
+
+		_, err = os.Stat(path + pendingMasterKeySuffix)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,40 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package masterlock
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock/local/masterlock/pbkdf2"
+)
+
+// package masterlock creates the master locks of sub packages hkdf and pbkdf2 by name, it is useful when the
+// master lock is selected through configuration.
+
+const (
+	// HKDF is the name of the hkdf master lock.
+	HKDF = "hkdf"
+	// PBKDF2 is the name of the pbkdf2 master lock.
+	PBKDF2 = "pbkdf2"
+
+	pbkdf2Iterations = 100000
+)
+
+// New creates the master lock named lockType (HKDF if empty) protecting the master key with passphrase, the key is
+// derived with SHA-256 and without salt.
+func New(lockType, passphrase string) (secretlock.Service, error) {
+	switch lockType {
+	case HKDF, "":
+		return hkdf.NewMasterLock(passphrase, sha256.New, nil)
+	case PBKDF2:
+		return pbkdf2.NewMasterLock(passphrase, sha256.New, pbkdf2Iterations, nil)
+	default:
+		return nil, fmt.Errorf("master lock type '%s' is not supported", lockType)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package masterlock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/secretlock"
+)
+
+func TestNew(t *testing.T) {
+	for _, lockType := range []string{"", HKDF, PBKDF2} {
+		lock, err := New(lockType, "passphrase")
+		require.NoError(t, err)
+
+		encrypted, err := lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: "secret"})
+		require.NoError(t, err)
+
+		decrypted, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypted.Ciphertext})
+		require.NoError(t, err)
+		require.Equal(t, "secret", decrypted.Plaintext)
+
+		// the master key is derived from the passphrase only
+		other, err := New(lockType, "passphrase")
+		require.NoError(t, err)
+
+		decrypted, err = other.Decrypt("", &secretlock.DecryptRequest{Ciphertext: encrypted.Ciphertext})
+		require.NoError(t, err)
+		require.Equal(t, "secret", decrypted.Plaintext)
+	}
+
+	t.Run("error - unsupported lock type", func(t *testing.T) {
+		lock, err := New("unknown", "passphrase")
+		require.EqualError(t, err, "master lock type 'unknown' is not supported")
+		require.Nil(t, lock)
+	})
+
+	t.Run("error - empty passphrase", func(t *testing.T) {
+		_, err := New(HKDF, "")
+		require.Error(t, err)
+
+		_, err = New(PBKDF2, "")
+		require.Error(t, err)
+	})
+}
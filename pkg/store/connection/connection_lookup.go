@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
 	"github.com/markcryptohash/aries-framework-go/pkg/common/model"
@@ -53,6 +54,26 @@ type DIDRotationRecord struct {
 	FromPrior string `json:"fromPrior,omitempty"`
 }
 
+// PingRecord holds the outcome of the latest trust ping over a connection.
+type PingRecord struct {
+	Time    time.Time     `json:"time"`
+	Latency time.Duration `json:"latency"`
+}
+
+// FeatureRecord is a feature the other party of a connection disclosed with the discover features protocol.
+type FeatureRecord struct {
+	FeatureType string   `json:"featureType"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
+
+// FeaturesRecord holds the features disclosed by the other party of a connection.
+type FeaturesRecord struct {
+	// Time is the time of the latest disclosure.
+	Time     time.Time        `json:"time"`
+	Features []*FeatureRecord `json:"features"`
+}
+
 // Record contain info about did exchange connection.
 // nolint:lll
 type Record struct {
@@ -75,6 +96,8 @@ type Record struct {
 	DIDCommVersion          didcomm.Version
 	PeerDIDInitialState     string
 	MyDIDRotation           *DIDRotationRecord `json:"myDIDRotation,omitempty"`
+	LastPing                *PingRecord        `json:"lastPing,omitempty"`
+	DisclosedFeatures       *FeaturesRecord    `json:"disclosedFeatures,omitempty"`
 }
 
 // NewLookup returns new connection lookup instance.
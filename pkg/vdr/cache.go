@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/common/log"
+	diddoc "github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/markcryptohash/aries-framework-go/spi/storage"
+)
+
+const (
+	defaultCacheSize   = 1000
+	defaultCacheTTL    = 5 * time.Minute
+	defaultNotFoundTTL = 30 * time.Second
+)
+
+var logger = log.New("aries-framework/vdr")
+
+// CacheOption is a DID resolution cache option.
+type CacheOption func(opts *resolutionCache)
+
+// WithCacheSize sets the maximum number of DIDs kept in memory, least recently used ones are evicted first.
+func WithCacheSize(size int) CacheOption {
+	return func(opts *resolutionCache) {
+		opts.size = size
+	}
+}
+
+// WithCacheTTL sets the time resolved DID documents are cached for.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(opts *resolutionCache) {
+		opts.ttl = ttl
+	}
+}
+
+// WithMethodCacheTTL sets the time DID documents of the given DID method are cached for. Zero TTL disables caching
+// of the DID method.
+func WithMethodCacheTTL(method string, ttl time.Duration) CacheOption {
+	return func(opts *resolutionCache) {
+		opts.methodTTL[method] = ttl
+	}
+}
+
+// WithNotFoundTTL sets the time not found DIDs are cached for. Zero TTL disables negative caching.
+func WithNotFoundTTL(ttl time.Duration) CacheOption {
+	return func(opts *resolutionCache) {
+		opts.notFoundTTL = ttl
+	}
+}
+
+// WithCacheStore persists cached resolutions in the given store, so that they survive restarts.
+func WithCacheStore(store storage.Store) CacheOption {
+	return func(opts *resolutionCache) {
+		opts.store = store
+	}
+}
+
+// WithResolutionCache enables caching of DID resolution results. Resolutions requested with DID method options
+// bypass the cache, and cached DIDs are invalidated when updated or deactivated through the registry.
+func WithResolutionCache(opts ...CacheOption) Option {
+	return func(r *Registry) {
+		r.cache = newResolutionCache(opts...)
+	}
+}
+
+// resolutionCache is in-memory LRU cache of DID resolutions with optional persistence.
+type resolutionCache struct {
+	size        int
+	ttl         time.Duration
+	methodTTL   map[string]time.Duration
+	notFoundTTL time.Duration
+	store       storage.Store
+	lru         gcache.Cache
+}
+
+// cacheEntry holds the JSON of the resolution, each get parses a new copy so that callers can't alter the cached
+// resolution.
+type cacheEntry struct {
+	Resolution json.RawMessage `json:"resolution,omitempty"`
+	NotFound   bool            `json:"notFound,omitempty"`
+	CachedAt   time.Time       `json:"cachedAt"`
+	ExpiresAt  time.Time       `json:"expiresAt"`
+}
+
+func newResolutionCache(opts ...CacheOption) *resolutionCache {
+	c := &resolutionCache{
+		size:        defaultCacheSize,
+		ttl:         defaultCacheTTL,
+		methodTTL:   make(map[string]time.Duration),
+		notFoundTTL: defaultNotFoundTTL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.size <= 0 {
+		c.size = defaultCacheSize
+	}
+
+	c.lru = gcache.New(c.size).LRU().Build()
+
+	return c
+}
+
+// get returns cached resolution of the DID. Found is false if the DID is not cached, otherwise either
+// the resolution or vdrapi.ErrNotFound is returned.
+func (c *resolutionCache) get(did string) (*diddoc.DocResolution, bool, error) {
+	entry := c.getEntry(did)
+	if entry == nil {
+		return nil, false, nil
+	}
+
+	if entry.NotFound {
+		return nil, true, vdrapi.ErrNotFound
+	}
+
+	// the resolution was accepted when it was cached, it is not validated again
+	docResolution, err := diddoc.ParseDocumentResolution(entry.Resolution, diddoc.WithoutValidation())
+	if err != nil {
+		return nil, true, fmt.Errorf("parse cached resolution of %s: %w", did, err)
+	}
+
+	cachedAt := entry.CachedAt
+	docResolution.ResolutionMetadata = &diddoc.ResolutionMetadata{Cached: true, CachedAt: &cachedAt}
+
+	return docResolution, true, nil
+}
+
+func (c *resolutionCache) getEntry(did string) *cacheEntry {
+	value, err := c.lru.Get(did)
+	if err == nil {
+		entry, ok := value.(*cacheEntry)
+		if ok {
+			return entry
+		}
+	}
+
+	if c.store == nil {
+		return nil
+	}
+
+	entry, err := c.loadEntry(did)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Warnf("failed to load cached resolution of %s: %s", did, err)
+		}
+
+		return nil
+	}
+
+	ttl := time.Until(entry.ExpiresAt)
+	if ttl <= 0 {
+		c.remove(did)
+
+		return nil
+	}
+
+	c.setMemory(did, entry, ttl)
+
+	return entry
+}
+
+func (c *resolutionCache) loadEntry(did string) (*cacheEntry, error) {
+	entryBytes, err := c.store.Get(did)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &cacheEntry{}
+
+	err = json.Unmarshal(entryBytes, entry)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal cache entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// put caches the resolution of the DID.
+func (c *resolutionCache) put(did, method string, docResolution *diddoc.DocResolution) {
+	ttl := c.methodCacheTTL(method)
+	if ttl <= 0 {
+		return
+	}
+
+	resolutionBytes, err := docResolution.JSONBytes()
+	if err != nil {
+		logger.Warnf("failed to marshal resolution of %s: %s", did, err)
+
+		return
+	}
+
+	c.set(did, &cacheEntry{Resolution: resolutionBytes}, ttl)
+}
+
+// putNotFound caches that the DID does not exist.
+func (c *resolutionCache) putNotFound(did, method string) {
+	if c.notFoundTTL <= 0 || c.methodCacheTTL(method) <= 0 {
+		return
+	}
+
+	c.set(did, &cacheEntry{NotFound: true}, c.notFoundTTL)
+}
+
+func (c *resolutionCache) set(did string, entry *cacheEntry, ttl time.Duration) {
+	entry.CachedAt = time.Now()
+	entry.ExpiresAt = entry.CachedAt.Add(ttl)
+
+	c.setMemory(did, entry, ttl)
+
+	if c.store == nil {
+		return
+	}
+
+	err := c.saveEntry(did, entry)
+	if err != nil {
+		logger.Warnf("failed to persist cached resolution of %s: %s", did, err)
+	}
+}
+
+func (c *resolutionCache) setMemory(did string, entry *cacheEntry, ttl time.Duration) {
+	// gcache returns error only if the loader function fails, which is not used
+	_ = c.lru.SetWithExpire(did, entry, ttl)
+}
+
+func (c *resolutionCache) saveEntry(did string, entry *cacheEntry) error {
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal cache entry: %w", err)
+	}
+
+	return c.store.Put(did, entryBytes)
+}
+
+// remove invalidates the cached resolution of the DID.
+func (c *resolutionCache) remove(did string) {
+	c.lru.Remove(did)
+
+	if c.store == nil {
+		return
+	}
+
+	err := c.store.Delete(did)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		logger.Warnf("failed to delete cached resolution of %s: %s", did, err)
+	}
+}
+
+func (c *resolutionCache) methodCacheTTL(method string) time.Duration {
+	if ttl, ok := c.methodTTL[method]; ok {
+		return ttl
+	}
+
+	return c.ttl
+}
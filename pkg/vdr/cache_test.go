@@ -0,0 +1,389 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockstorage "github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/markcryptohash/aries-framework-go/pkg/mock/vdr"
+)
+
+const (
+	cachedDID  = "did:example:123"
+	cachedDID2 = "did:example:456"
+)
+
+type countingVDR struct {
+	mockvdr.MockVDR
+	reads int
+}
+
+func newCountingVDR(t *testing.T, notFound bool) *countingVDR {
+	t.Helper()
+
+	v := &countingVDR{}
+	v.AcceptValue = true
+	v.ReadFunc = func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+		v.reads++
+
+		if notFound {
+			return nil, vdrapi.ErrNotFound
+		}
+
+		doc, err := did.ParseDocument([]byte(`{"@context":["https://www.w3.org/ns/did/v1"],"id":"` + didID + `"}`))
+		require.NoError(t, err)
+
+		return &did.DocResolution{DIDDocument: doc, DocumentMetadata: &did.DocumentMetadata{VersionID: "1"}}, nil
+	}
+
+	return v
+}
+
+func TestRegistry_ResolutionCache(t *testing.T) {
+	t.Run("resolution is cached", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		docResolution, err := registry.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Nil(t, docResolution.ResolutionMetadata)
+
+		docResolution, err = registry.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, cachedDID, docResolution.DIDDocument.ID)
+		require.Equal(t, "1", docResolution.DocumentMetadata.VersionID)
+		require.NotNil(t, docResolution.ResolutionMetadata)
+		require.True(t, docResolution.ResolutionMetadata.Cached)
+		require.NotNil(t, docResolution.ResolutionMetadata.CachedAt)
+
+		require.Equal(t, 1, v.reads)
+	})
+
+	t.Run("cached resolution is not altered by callers", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		for i := 0; i < 3; i++ {
+			docResolution, err := registry.Resolve(cachedDID)
+			require.NoError(t, err)
+			require.Equal(t, cachedDID, docResolution.DIDDocument.ID)
+			require.Empty(t, docResolution.DIDDocument.Service)
+			require.Equal(t, "1", docResolution.DocumentMetadata.VersionID)
+
+			docResolution.DIDDocument.ID = "did:example:altered"
+			docResolution.DIDDocument.Service = append(docResolution.DIDDocument.Service, did.Service{ID: "altered"})
+			docResolution.DocumentMetadata.VersionID = "2"
+		}
+
+		require.Equal(t, 1, v.reads)
+	})
+
+	t.Run("resolution with options is not cached", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		for i := 0; i < 2; i++ {
+			docResolution, err := registry.Resolve(cachedDID, vdrapi.WithOption("versionId", "1"))
+			require.NoError(t, err)
+			require.Nil(t, docResolution.ResolutionMetadata)
+		}
+
+		require.Equal(t, 2, v.reads)
+	})
+
+	t.Run("cached resolution expires", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache(WithCacheTTL(50*time.Millisecond)))
+
+		_, err := registry.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		docResolution, err := registry.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Nil(t, docResolution.ResolutionMetadata)
+		require.Equal(t, 2, v.reads)
+	})
+
+	t.Run("caching is disabled for DID method", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache(WithMethodCacheTTL("example", 0)))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(cachedDID)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, v.reads)
+	})
+
+	t.Run("not found DID is cached", func(t *testing.T) {
+		v := newCountingVDR(t, true)
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(cachedDID)
+			require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		}
+
+		require.Equal(t, 1, v.reads)
+	})
+
+	t.Run("negative caching is disabled", func(t *testing.T) {
+		v := newCountingVDR(t, true)
+		registry := New(WithVDR(v), WithResolutionCache(WithNotFoundTTL(0)))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(cachedDID)
+			require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		}
+
+		require.Equal(t, 2, v.reads)
+	})
+
+	t.Run("read error is not cached", func(t *testing.T) {
+		reads := 0
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				reads++
+
+				return nil, errors.New("read error")
+			},
+		}), WithResolutionCache())
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(cachedDID)
+			require.Error(t, err)
+		}
+
+		require.Equal(t, 2, reads)
+	})
+
+	t.Run("least recently used DID is evicted", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache(WithCacheSize(1)))
+
+		for _, didID := range []string{cachedDID, cachedDID2, cachedDID} {
+			_, err := registry.Resolve(didID)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 3, v.reads)
+	})
+}
+
+func TestRegistry_ResolutionCacheInvalidation(t *testing.T) {
+	t.Run("update invalidates cached DID", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		_, err := registry.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		require.NoError(t, registry.Update(&did.Doc{ID: cachedDID}))
+
+		_, err = registry.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads)
+	})
+
+	t.Run("failed update invalidates cached DID", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		v.UpdateFunc = func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+			return errors.New("update error")
+		}
+
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		_, err := registry.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		require.Error(t, registry.Update(&did.Doc{ID: cachedDID}))
+
+		_, err = registry.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads)
+	})
+
+	t.Run("deactivate invalidates cached DID", func(t *testing.T) {
+		v := newCountingVDR(t, false)
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		_, err := registry.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		require.NoError(t, registry.Deactivate(cachedDID))
+
+		_, err = registry.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads)
+	})
+
+	t.Run("create invalidates not found DID", func(t *testing.T) {
+		v := newCountingVDR(t, true)
+		v.CreateFunc = func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			return &did.DocResolution{DIDDocument: didDoc}, nil
+		}
+
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		_, err := registry.Resolve(cachedDID)
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+
+		_, err = registry.Create("example", &did.Doc{ID: cachedDID})
+		require.NoError(t, err)
+
+		_, err = registry.Resolve(cachedDID)
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		require.Equal(t, 2, v.reads)
+	})
+}
+
+func TestRegistry_PersistentResolutionCache(t *testing.T) {
+	t.Run("cached resolution survives restart", func(t *testing.T) {
+		store, err := mockstorage.NewMockStoreProvider().OpenStore("vdrcache")
+		require.NoError(t, err)
+
+		v := newCountingVDR(t, false)
+
+		_, err = New(WithVDR(v), WithResolutionCache(WithCacheStore(store))).Resolve(cachedDID)
+		require.NoError(t, err)
+
+		docResolution, err := New(WithVDR(v), WithResolutionCache(WithCacheStore(store))).Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, cachedDID, docResolution.DIDDocument.ID)
+		require.True(t, docResolution.ResolutionMetadata.Cached)
+		require.Equal(t, 1, v.reads)
+	})
+
+	t.Run("not found DID survives restart", func(t *testing.T) {
+		store, err := mockstorage.NewMockStoreProvider().OpenStore("vdrcache")
+		require.NoError(t, err)
+
+		v := newCountingVDR(t, true)
+
+		for i := 0; i < 2; i++ {
+			_, err = New(WithVDR(v), WithResolutionCache(WithCacheStore(store))).Resolve(cachedDID)
+			require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		}
+
+		require.Equal(t, 1, v.reads)
+	})
+
+	t.Run("expired resolution is removed from store", func(t *testing.T) {
+		store, err := mockstorage.NewMockStoreProvider().OpenStore("vdrcache")
+		require.NoError(t, err)
+
+		v := newCountingVDR(t, false)
+
+		_, err = New(WithVDR(v), WithResolutionCache(WithCacheStore(store), WithCacheTTL(time.Millisecond))).
+			Resolve(cachedDID)
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+
+		_, err = New(WithVDR(v), WithResolutionCache(WithCacheStore(store), WithMethodCacheTTL("example", 0))).
+			Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, v.reads)
+
+		_, err = store.Get(cachedDID)
+		require.Error(t, err)
+	})
+
+	t.Run("invalidation removes resolution from store", func(t *testing.T) {
+		store, err := mockstorage.NewMockStoreProvider().OpenStore("vdrcache")
+		require.NoError(t, err)
+
+		registry := New(WithVDR(newCountingVDR(t, false)), WithResolutionCache(WithCacheStore(store)))
+
+		_, err = registry.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		_, err = store.Get(cachedDID)
+		require.NoError(t, err)
+
+		require.NoError(t, registry.Deactivate(cachedDID))
+
+		_, err = store.Get(cachedDID)
+		require.Error(t, err)
+	})
+
+	t.Run("store errors don't fail resolution", func(t *testing.T) {
+		store := &mockstorage.MockStore{
+			Store:  make(map[string]mockstorage.DBEntry),
+			ErrPut: errors.New("put error"),
+			ErrGet: errors.New("get error"),
+		}
+
+		v := newCountingVDR(t, false)
+
+		docResolution, err := New(WithVDR(v), WithResolutionCache(WithCacheStore(store))).Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, cachedDID, docResolution.DIDDocument.ID)
+	})
+
+	t.Run("cached resolution is not validated again", func(t *testing.T) {
+		v := &countingVDR{}
+		v.AcceptValue = true
+		v.ReadFunc = func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			v.reads++
+
+			return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+		}
+
+		registry := New(WithVDR(v), WithResolutionCache())
+
+		for i := 0; i < 2; i++ {
+			docResolution, err := registry.Resolve(cachedDID)
+			require.NoError(t, err)
+			require.Equal(t, cachedDID, docResolution.DIDDocument.ID)
+		}
+
+		require.Equal(t, 1, v.reads)
+	})
+
+	t.Run("unparsable cached resolution is reported", func(t *testing.T) {
+		store, err := mockstorage.NewMockStoreProvider().OpenStore("vdrcache")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put(cachedDID, []byte(`{"resolution":{},"expiresAt":"`+
+			time.Now().Add(time.Hour).Format(time.RFC3339Nano)+`"}`)))
+
+		v := newCountingVDR(t, false)
+
+		_, err = New(WithVDR(v), WithResolutionCache(WithCacheStore(store))).Resolve(cachedDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse cached resolution")
+		require.Equal(t, 0, v.reads)
+
+		_, err = store.Get(cachedDID)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid cache entry is ignored", func(t *testing.T) {
+		store, err := mockstorage.NewMockStoreProvider().OpenStore("vdrcache")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put(cachedDID, []byte("not a cache entry")))
+
+		v := newCountingVDR(t, false)
+
+		_, err = New(WithVDR(v), WithResolutionCache(WithCacheStore(store))).Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 1, v.reads)
+	})
+}
@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	josejwk "github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/util/jwkkid"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+const (
+	schemaResV1                = "https://w3id.org/did-resolution/v1"
+	schemaDIDV1                = "https://www.w3.org/ns/did/v1"
+	schemaJWS2020V1            = "https://w3id.org/security/suites/jws-2020/v1"
+	jsonWebKey2020             = "JsonWebKey2020"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	keyFragment                = "#0"
+	useSignature               = "sig"
+	useEncryption              = "enc"
+)
+
+// Create new DID document for didDoc.
+// Either didDoc must contain a JsonWebKey2020 or Ed25519VerificationKey2018 VerificationMethod or opts must contain
+// KeyType value of kms.KeyType to create a new key with the KMS of the VDR.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	createDIDOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(createDIDOpts)
+	}
+
+	var (
+		j   *josejwk.JWK
+		err error
+	)
+
+	switch {
+	case len(didDoc.VerificationMethod) > 0:
+		j, err = jwkFromVerificationMethod(&didDoc.VerificationMethod[0])
+	case createDIDOpts.Values[KeyType] != nil:
+		j, err = v.createKey(createDIDOpts.Values[KeyType])
+	default:
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	didJWK, err := createDID(j)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := createDoc(didJWK, j)
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+func jwkFromVerificationMethod(vm *did.VerificationMethod) (*josejwk.JWK, error) {
+	switch vm.Type {
+	case jsonWebKey2020:
+		if vm.JSONWebKey() == nil {
+			return nil, fmt.Errorf("verification method %s has no JSON Web Key", vm.ID)
+		}
+
+		return vm.JSONWebKey(), nil
+	case ed25519VerificationKey2018:
+		return jwksupport.JWKFromKey(ed25519.PublicKey(vm.Value))
+	default:
+		return nil, fmt.Errorf("not supported public key type: %s", vm.Type)
+	}
+}
+
+// createKey creates a new key with the KMS, the "use" of the key is set from its key type.
+func (v *VDR) createKey(keyTypeOpt interface{}) (*josejwk.JWK, error) {
+	keyType, ok := keyTypeOpt.(kms.KeyType)
+	if !ok {
+		return nil, fmt.Errorf("keyType option is not a kms.KeyType")
+	}
+
+	if v.kms == nil {
+		return nil, errors.New("kms is not set, a verification method must be provided")
+	}
+
+	_, pubKeyBytes, err := v.kms.CreateAndExportPubKeyBytes(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("create %s key: %w", keyType, err)
+	}
+
+	var j *josejwk.JWK
+
+	if keyType == kms.ED25519Type {
+		j, err = jwksupport.JWKFromKey(ed25519.PublicKey(pubKeyBytes))
+	} else {
+		j, err = jwkkid.BuildJWK(pubKeyBytes, keyType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("build JWK of %s key: %w", keyType, err)
+	}
+
+	switch keyType {
+	case kms.X25519ECDHKWType, kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
+		j.Use = useEncryption
+	default:
+		j.Use = useSignature
+	}
+
+	return j, nil
+}
+
+// createDID encodes the public JWK into a did:jwk DID.
+func createDID(j *josejwk.JWK) (string, error) {
+	// the kid is not part of the DID, it is derived from it
+	public := *j
+	public.KeyID = ""
+
+	jwkBytes, err := public.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("marshal JWK: %w", err)
+	}
+
+	if err = checkPublicKey(jwkBytes); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("did:%s:%s", DIDMethod, base64.RawURLEncoding.EncodeToString(jwkBytes)), nil
+}
+
+// checkPublicKey checks that a marshalled JWK does not contain private key material.
+func checkPublicKey(jwkBytes []byte) error {
+	var fields map[string]interface{}
+
+	if err := json.Unmarshal(jwkBytes, &fields); err != nil {
+		return fmt.Errorf("unmarshal JWK: %w", err)
+	}
+
+	if _, ok := fields["d"]; ok {
+		return errors.New("JWK of did:jwk must not contain a private key")
+	}
+
+	return nil
+}
+
+// createDoc creates the DID document of a did:jwk, its verification relationships depend on the "use" of the key.
+func createDoc(didJWK string, j *josejwk.JWK) (*did.Doc, error) {
+	vm, err := did.NewVerificationMethodFromJWK(didJWK+keyFragment, jsonWebKey2020, didJWK, j)
+	if err != nil {
+		return nil, fmt.Errorf("create verification method: %w", err)
+	}
+
+	// Created/Updated time
+	t := time.Now()
+
+	doc := &did.Doc{
+		Context:            []string{schemaDIDV1, schemaJWS2020V1},
+		ID:                 didJWK,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Created:            &t,
+		Updated:            &t,
+	}
+
+	if j.Use != useEncryption {
+		doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+		doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+		doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+		doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+	}
+
+	if j.Use != useSignature {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+	}
+
+	return doc, nil
+}
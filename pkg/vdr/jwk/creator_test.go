@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	mockkms "github.com/markcryptohash/aries-framework-go/pkg/mock/kms"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("create from Ed25519VerificationKey2018", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", ed25519VerificationKey2018, "", pubKey),
+		}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:jwk:"))
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, doc.ID+"#0", doc.VerificationMethod[0].ID)
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.Equal(t, []byte(pubKey), doc.VerificationMethod[0].Value)
+
+		// no "use" in the JWK, the key is used for all the verification relationships
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+
+		resolved, err := New().Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc.VerificationMethod[0].Value, resolved.DIDDocument.VerificationMethod[0].Value)
+	})
+
+	t.Run("create from JsonWebKey2020", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		j, err := jwksupport.JWKFromKey(&privKey.PublicKey)
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+
+		resolved, err := New().Read(docResolution.DIDDocument.ID)
+		require.NoError(t, err)
+		require.Equal(t, "P-256", resolved.DIDDocument.VerificationMethod[0].JSONWebKey().Crv)
+	})
+
+	t.Run("create with kms key", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		v := New(WithKMS(&mockkms.KeyManager{CrAndExportPubKeyValue: pubKey}))
+
+		docResolution, err := v.Create(&did.Doc{}, vdrapi.WithOption(KeyType, kms.ED25519Type))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "sig", doc.VerificationMethod[0].JSONWebKey().Use)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Empty(t, doc.KeyAgreement)
+	})
+
+	t.Run("create with kms key fails", func(t *testing.T) {
+		_, err := New().Create(&did.Doc{}, vdrapi.WithOption(KeyType, kms.ED25519Type))
+		require.EqualError(t, err, "kms is not set, a verification method must be provided")
+
+		_, err = New().Create(&did.Doc{}, vdrapi.WithOption(KeyType, "ED25519"))
+		require.EqualError(t, err, "keyType option is not a kms.KeyType")
+
+		v := New(WithKMS(&mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("kms error")}))
+
+		_, err = v.Create(&did.Doc{}, vdrapi.WithOption(KeyType, kms.ED25519Type))
+		require.EqualError(t, err, "create ED25519 key: kms error")
+	})
+
+	t.Run("verification method is missing or not supported", func(t *testing.T) {
+		_, err := New().Create(&did.Doc{})
+		require.EqualError(t, err, "verification method is empty")
+
+		_, err = New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", "Bls12381G2Key2020", "", []byte("key")),
+		}})
+		require.EqualError(t, err, "not supported public key type: Bls12381G2Key2020")
+	})
+}
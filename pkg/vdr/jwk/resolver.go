@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	josejwk "github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// Read expands did:jwk value to a DID document.
+func (v *VDR) Read(didJWK string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didJWK)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: failed to parse DID: %w", err)
+	}
+
+	if parsed.Method != DIDMethod {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method: %s", parsed.Method)
+	}
+
+	jwkBytes, err := base64.RawURLEncoding.DecodeString(parsed.MethodSpecificID)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %w", err)
+	}
+
+	if err = checkPublicKey(jwkBytes); err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	var j josejwk.JWK
+
+	if err = j.UnmarshalJSON(jwkBytes); err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	didDoc, err := createDoc(didJWK, &j)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// did:jwk examples of https://github.com/quartzjer/did-jwk/blob/main/spec.md
+	// nolint:lll
+	didJWKP256 = "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9"
+	// nolint:lll
+	didJWKX25519 = "did:jwk:eyJrdHkiOiJPS1AiLCJjcnYiOiJYMjU1MTkiLCJ1c2UiOiJlbmMiLCJ4IjoiM3A3YmZYdDl3YlRUVzJIQzdPUTFOei1EUThoYmVHZE5yZngtRkctSUswOCJ9"
+)
+
+func TestRead(t *testing.T) {
+	t.Run("P-256 key without use", func(t *testing.T) {
+		docResolution, err := New().Read(didJWKP256)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didJWKP256, doc.ID)
+		require.Equal(t, []string{schemaDIDV1, schemaJWS2020V1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, didJWKP256+"#0", doc.VerificationMethod[0].ID)
+		require.Equal(t, didJWKP256, doc.VerificationMethod[0].Controller)
+		require.Equal(t, "P-256", doc.VerificationMethod[0].JSONWebKey().Crv)
+
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+	})
+
+	t.Run("X25519 encryption key", func(t *testing.T) {
+		docResolution, err := New().Read(didJWKX25519)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "X25519", doc.VerificationMethod[0].JSONWebKey().Crv)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Empty(t, doc.AssertionMethod)
+		require.Empty(t, doc.Authentication)
+	})
+
+	t.Run("invalid DIDs", func(t *testing.T) {
+		_, err := New().Read("did:jwk")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse DID")
+
+		_, err = New().Read("did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK")
+		require.EqualError(t, err, "jwk vdr Read: invalid did:jwk method: key")
+
+		_, err = New().Read("did:jwk:invalid.base64")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:jwk method ID")
+
+		_, err = New().Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(`{"kty":"unknown"}`)))
+		require.Error(t, err)
+
+		privateJWK := `{"kty":"OKP","crv":"X25519","x":"3p7bfXt9wbTTW2HC7OQ1Nz-DQ8hbeGdNrfx-FG-IK08","d":"secret"}`
+
+		_, err = New().Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(privateJWK)))
+		require.EqualError(t, err, "jwk vdr Read: JWK of did:jwk must not contain a private key")
+	})
+}
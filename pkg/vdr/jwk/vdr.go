@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package jwk implements the did:jwk method (https://github.com/quartzjer/did-jwk/blob/main/spec.md), a DID method
+// whose DID is the base64url encoded JSON Web Key of its single verification method.
+package jwk
+
+import (
+	"fmt"
+
+	diddoc "github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+)
+
+const (
+	// DIDMethod did method.
+	DIDMethod = "jwk"
+	// KeyType option to create a new kms key for DIDDocs with empty VerificationMethod.
+	KeyType = "keyType"
+)
+
+// VDR implements did:jwk method support.
+type VDR struct {
+	kms kms.KeyManager
+}
+
+// Option configures the did:jwk VDR.
+type Option func(opts *VDR)
+
+// WithKMS sets the key manager used to create the keys of the new did:jwk DIDs.
+func WithKMS(km kms.KeyManager) Option {
+	return func(opts *VDR) {
+		opts.kms = km
+	}
+}
+
+// New returns new instance of VDR that works with did:jwk method.
+func New(opts ...Option) *VDR {
+	v := &VDR{}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Accept accepts did:jwk method.
+func (v *VDR) Accept(method string) bool {
+	return method == DIDMethod
+}
+
+// Close frees resources being maintained by VDR.
+func (v *VDR) Close() error {
+	return nil
+}
+
+// Update did doc.
+func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc.
+func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
@@ -0,0 +1,37 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+var _ vdr.VDR = (*VDR)(nil) // verify interface compliance
+
+func TestAccept(t *testing.T) {
+	v := New()
+	require.True(t, v.Accept("jwk"))
+	require.False(t, v.Accept("key"))
+}
+
+func TestUpdate(t *testing.T) {
+	err := New().Update(nil)
+	require.EqualError(t, err, "not supported")
+}
+
+func TestDeactivate(t *testing.T) {
+	err := New().Deactivate("")
+	require.EqualError(t, err, "not supported")
+}
+
+func TestClose(t *testing.T) {
+	require.NoError(t, New().Close())
+}
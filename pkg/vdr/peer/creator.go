@@ -37,6 +37,18 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 		opt(docOpts)
 	}
 
+	if docOpts.Values[NumAlgoOpt] != nil {
+		docResolution, err := createStatic(didDoc, docOpts)
+		if err != nil {
+			return nil, fmt.Errorf("create peer DID : %w", err)
+		}
+
+		// numalgo 1 DIDs are built and stored below
+		if docResolution != nil {
+			return docResolution, nil
+		}
+	}
+
 	store := false
 
 	storeOpt := docOpts.Values["store"]
@@ -76,6 +88,58 @@ func build(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution,
 		return nil, err
 	}
 
+	service, err := buildServices(didDoc, docOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Created/Updated time
+	t := time.Now()
+
+	assertion := []did.Verification{{
+		VerificationMethod: mainVM[0],
+		Relationship:       did.AssertionMethod,
+	}}
+
+	authentication := []did.Verification{{
+		VerificationMethod: mainVM[0],
+		Relationship:       did.Authentication,
+	}}
+
+	var keyAgreement []did.Verification
+
+	verificationMethods := mainVM
+
+	if keyAgreementVM != nil {
+		verificationMethods = append(verificationMethods, keyAgreementVM...)
+
+		for _, ka := range keyAgreementVM {
+			keyAgreement = append(keyAgreement, did.Verification{
+				VerificationMethod: ka,
+				Relationship:       did.KeyAgreement,
+			})
+		}
+	}
+
+	didDoc, err = NewDoc(
+		verificationMethods,
+		did.WithService(service),
+		did.WithCreatedTime(t),
+		did.WithUpdatedTime(t),
+		did.WithAuthentication(authentication),
+		did.WithAssertion(assertion),
+		did.WithKeyAgreement(keyAgreement),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{DIDDocument: didDoc}, nil
+}
+
+// buildServices applies the default service type and endpoint options and sets the DIDComm recipient keys.
+//nolint: gocyclo,gocognit
+func buildServices(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) ([]did.Service, error) {
 	// Service model to be included only if service type is provided through opts
 	var service []did.Service
 
@@ -114,7 +178,7 @@ func build(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution,
 					sp = model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{}})
 				} else {
 					for _, ep := range epArrayEntry {
-						err = sp.UnmarshalJSON([]byte(ep))
+						err := sp.UnmarshalJSON([]byte(ep))
 						if err != nil {
 							if strings.EqualFold(err.Error(), "endpoint data is not supported") {
 								// if unmarshall failed, then use as string.
@@ -140,48 +204,7 @@ func build(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution,
 		service = append(service, didDoc.Service[i])
 	}
 
-	// Created/Updated time
-	t := time.Now()
-
-	assertion := []did.Verification{{
-		VerificationMethod: mainVM[0],
-		Relationship:       did.AssertionMethod,
-	}}
-
-	authentication := []did.Verification{{
-		VerificationMethod: mainVM[0],
-		Relationship:       did.Authentication,
-	}}
-
-	var keyAgreement []did.Verification
-
-	verificationMethods := mainVM
-
-	if keyAgreementVM != nil {
-		verificationMethods = append(verificationMethods, keyAgreementVM...)
-
-		for _, ka := range keyAgreementVM {
-			keyAgreement = append(keyAgreement, did.Verification{
-				VerificationMethod: ka,
-				Relationship:       did.KeyAgreement,
-			})
-		}
-	}
-
-	didDoc, err = NewDoc(
-		verificationMethods,
-		did.WithService(service),
-		did.WithCreatedTime(t),
-		did.WithUpdatedTime(t),
-		did.WithAuthentication(authentication),
-		did.WithAssertion(assertion),
-		did.WithKeyAgreement(keyAgreement),
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &did.DocResolution{DIDDocument: didDoc}, nil
+	return service, nil
 }
 
 // stringEntry.
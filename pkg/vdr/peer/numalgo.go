@@ -0,0 +1,462 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/common/model"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	// NumAlgoOpt is the Create option selecting the numalgo of the new peer DID, one of NumAlgo0, NumAlgo1 or
+	// NumAlgo2. NumAlgo1 is used when the option is not set.
+	NumAlgoOpt = "numalgo"
+
+	// NumAlgo0 creates a peer DID made of a single inception key, it resolves without being stored.
+	// Reference: https://identity.foundation/peer-did-method-spec/#method-0-inception-key-without-doc
+	NumAlgo0 = "0"
+	// NumAlgo1 creates a peer DID from the hash of its genesis document, the document is kept in the peer store.
+	NumAlgo1 = numAlgo
+	// NumAlgo2 creates a peer DID made of multiple inception keys and services, it resolves without being stored.
+	// Reference: https://identity.foundation/peer-did-method-spec/#method-2-multiple-inception-key-without-doc
+	NumAlgo2 = "2"
+
+	purposeAssertion            = 'A'
+	purposeEncryption           = 'E'
+	purposeVerification         = 'V'
+	purposeCapabilityInvocation = 'I'
+	purposeCapabilityDelegation = 'D'
+	purposeService              = 'S'
+
+	abbreviatedDIDCommV2ServiceType = "dm"
+)
+
+// abbreviatedService is the JSON form of a service encoded in a numalgo 2 peer DID.
+type abbreviatedService struct {
+	ID              string          `json:"id,omitempty"`
+	Type            string          `json:"t"`
+	ServiceEndpoint json.RawMessage `json:"s"`
+	RoutingKeys     []string        `json:"r,omitempty"`
+	Accept          []string        `json:"a,omitempty"`
+}
+
+// abbreviatedEndpoint is the JSON form of a DIDComm V2 service endpoint encoded in a numalgo 2 peer DID.
+type abbreviatedEndpoint struct {
+	URI         string   `json:"uri"`
+	RoutingKeys []string `json:"r,omitempty"`
+	Accept      []string `json:"a,omitempty"`
+}
+
+// createStatic creates a numalgo 0 or numalgo 2 peer DID, it returns a nil resolution for numalgo 1.
+func createStatic(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution, error) {
+	var numAlgoOpt string
+
+	switch v := docOpts.Values[NumAlgoOpt].(type) {
+	case string:
+		numAlgoOpt = v
+	case int:
+		numAlgoOpt = fmt.Sprint(v)
+	default:
+		return nil, fmt.Errorf("numalgo opt not string")
+	}
+
+	switch numAlgoOpt {
+	case NumAlgo0:
+		return createNumAlgo0(didDoc)
+	case NumAlgo1:
+		return nil, nil
+	case NumAlgo2:
+		return createNumAlgo2(didDoc, docOpts)
+	default:
+		return nil, fmt.Errorf("unsupported numalgo: %s", numAlgoOpt)
+	}
+}
+
+// isStaticDID returns true for the peer DIDs that carry their keys in the DID itself.
+func isStaticDID(didID string) bool {
+	return strings.HasPrefix(didID, peerPrefix+NumAlgo0) || strings.HasPrefix(didID, peerPrefix+NumAlgo2)
+}
+
+// resolveStatic builds the DID document of a numalgo 0 or numalgo 2 peer DID.
+func resolveStatic(didID string) (*did.Doc, error) {
+	if strings.HasPrefix(didID, peerPrefix+NumAlgo0) {
+		return resolveNumAlgo0(didID)
+	}
+
+	return resolveNumAlgo2(didID)
+}
+
+// createNumAlgo0 creates a peer DID from the first verification method of didDoc, or its first key agreement
+// if it has no verification method.
+func createNumAlgo0(didDoc *did.Doc) (*did.DocResolution, error) {
+	var inceptionKey *did.VerificationMethod
+
+	switch {
+	case len(didDoc.VerificationMethod) > 0:
+		inceptionKey = &didDoc.VerificationMethod[0]
+	case len(didDoc.KeyAgreement) > 0:
+		inceptionKey = &didDoc.KeyAgreement[0].VerificationMethod
+	default:
+		return nil, fmt.Errorf("verification method and key agreement are empty, at least one should be set")
+	}
+
+	fp, err := keyFingerprint(inceptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := resolveNumAlgo0(peerPrefix + NumAlgo0 + fp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+func resolveNumAlgo0(didID string) (*did.Doc, error) {
+	fp := strings.TrimPrefix(didID, peerPrefix+NumAlgo0)
+
+	pubKey, code, err := fingerprint.PubKeyFromFingerprint(fp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid numalgo 0 peer DID: %w", err)
+	}
+
+	vm, err := vmFromFingerprint(didID+"#"+fp, didID, code, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &did.Doc{
+		Context:            []string{did.ContextV1},
+		ID:                 didID,
+		VerificationMethod: []did.VerificationMethod{*vm},
+	}
+
+	if code == fingerprint.X25519PubKeyMultiCodec {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+
+		return doc, nil
+	}
+
+	doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+	doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+	doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+	doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+
+	return doc, nil
+}
+
+// createNumAlgo2 creates a peer DID encoding the verification methods of didDoc as verification keys, its key
+// agreements as encryption keys and its services in their abbreviated form.
+func createNumAlgo2(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution, error) {
+	if len(didDoc.VerificationMethod) == 0 && len(didDoc.KeyAgreement) == 0 {
+		return nil, fmt.Errorf("verification method and key agreement are empty, at least one should be set")
+	}
+
+	services, err := buildServices(didDoc, docOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := []string{peerPrefix + NumAlgo2}
+
+	for i := range didDoc.VerificationMethod {
+		fp, e := keyFingerprint(&didDoc.VerificationMethod[i])
+		if e != nil {
+			return nil, e
+		}
+
+		elements = append(elements, string(purposeVerification)+fp)
+	}
+
+	for i := range didDoc.KeyAgreement {
+		fp, e := keyFingerprint(&didDoc.KeyAgreement[i].VerificationMethod)
+		if e != nil {
+			return nil, e
+		}
+
+		elements = append(elements, string(purposeEncryption)+fp)
+	}
+
+	for i := range services {
+		encoded, e := encodeService(&services[i])
+		if e != nil {
+			return nil, e
+		}
+
+		elements = append(elements, string(purposeService)+encoded)
+	}
+
+	doc, err := resolveNumAlgo2(strings.Join(elements, "."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// nolint: gocyclo
+func resolveNumAlgo2(didID string) (*did.Doc, error) {
+	elements := strings.Split(strings.TrimPrefix(didID, peerPrefix+NumAlgo2), ".")
+	if len(elements) < 2 || elements[0] != "" {
+		return nil, fmt.Errorf("invalid numalgo 2 peer DID: %s", didID)
+	}
+
+	doc := &did.Doc{
+		Context: []string{did.ContextV1},
+		ID:      didID,
+	}
+
+	var encodedServices []string
+
+	for _, element := range elements[1:] {
+		if element == "" {
+			return nil, fmt.Errorf("invalid numalgo 2 peer DID: empty element")
+		}
+
+		purpose, value := element[0], element[1:]
+
+		if purpose == purposeService {
+			encodedServices = append(encodedServices, value)
+
+			continue
+		}
+
+		pubKey, code, err := fingerprint.PubKeyFromFingerprint(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid numalgo 2 peer DID: %w", err)
+		}
+
+		vm, err := vmFromFingerprint(fmt.Sprintf("#key-%d", len(doc.VerificationMethod)+1), didID, code, pubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.VerificationMethod = append(doc.VerificationMethod, *vm)
+
+		switch purpose {
+		case purposeAssertion:
+			doc.AssertionMethod = append(doc.AssertionMethod, *did.NewReferencedVerification(vm, did.AssertionMethod))
+		case purposeEncryption:
+			doc.KeyAgreement = append(doc.KeyAgreement, *did.NewReferencedVerification(vm, did.KeyAgreement))
+		case purposeVerification:
+			doc.Authentication = append(doc.Authentication, *did.NewReferencedVerification(vm, did.Authentication))
+		case purposeCapabilityInvocation:
+			doc.CapabilityInvocation = append(doc.CapabilityInvocation,
+				*did.NewReferencedVerification(vm, did.CapabilityInvocation))
+		case purposeCapabilityDelegation:
+			doc.CapabilityDelegation = append(doc.CapabilityDelegation,
+				*did.NewReferencedVerification(vm, did.CapabilityDelegation))
+		default:
+			return nil, fmt.Errorf("invalid numalgo 2 peer DID: unsupported purpose code '%c'", purpose)
+		}
+	}
+
+	for i, encoded := range encodedServices {
+		svc, err := decodeService(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid numalgo 2 peer DID: %w", err)
+		}
+
+		if svc.ID == "" {
+			svc.ID = "#service"
+
+			if i > 0 {
+				svc.ID = fmt.Sprintf("#service-%d", i)
+			}
+		}
+
+		applyStaticDIDCommKeys(svc, doc)
+
+		doc.Service = append(doc.Service, *svc)
+	}
+
+	return doc, nil
+}
+
+// encodeService returns the base64url encoding of the abbreviated JSON form of svc, the recipient keys are
+// not encoded as they are derived from the keys of the DID on resolution.
+func encodeService(svc *did.Service) (string, error) {
+	uri, _ := svc.ServiceEndpoint.URI() // nolint:errcheck
+
+	abbreviated := &abbreviatedService{
+		Type:        svc.Type,
+		RoutingKeys: svc.RoutingKeys,
+		Accept:      svc.Accept,
+	}
+
+	var (
+		endpoint interface{} = uri
+		err      error
+	)
+
+	if svc.Type == vdrapi.DIDCommV2ServiceType {
+		abbreviated.Type = abbreviatedDIDCommV2ServiceType
+
+		if svc.ServiceEndpoint.Type() == model.DIDCommV2 {
+			ep := &abbreviatedEndpoint{URI: uri}
+			ep.Accept, _ = svc.ServiceEndpoint.Accept()           // nolint:errcheck
+			ep.RoutingKeys, _ = svc.ServiceEndpoint.RoutingKeys() // nolint:errcheck
+
+			endpoint = ep
+		}
+	}
+
+	abbreviated.ServiceEndpoint, err = json.Marshal(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("marshal service endpoint: %w", err)
+	}
+
+	src, err := json.Marshal(abbreviated)
+	if err != nil {
+		return "", fmt.Errorf("marshal service: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(src), nil
+}
+
+// decodeService decodes an abbreviated service, the endpoint may either be a plain URI with the routing keys and
+// accepted profiles at the service level or an object holding them.
+func decodeService(encoded string) (*did.Service, error) {
+	src, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode service: %w", err)
+	}
+
+	abbreviated := &abbreviatedService{}
+
+	err = json.Unmarshal(src, abbreviated)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal service: %w", err)
+	}
+
+	ep := &abbreviatedEndpoint{}
+
+	if strings.HasPrefix(strings.TrimSpace(string(abbreviated.ServiceEndpoint)), "{") {
+		err = json.Unmarshal(abbreviated.ServiceEndpoint, ep)
+	} else {
+		err = json.Unmarshal(abbreviated.ServiceEndpoint, &ep.URI)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal service endpoint: %w", err)
+	}
+
+	svc := &did.Service{
+		ID:   abbreviated.ID,
+		Type: abbreviated.Type,
+	}
+
+	if svc.Type == abbreviatedDIDCommV2ServiceType || svc.Type == vdrapi.DIDCommV2ServiceType {
+		svc.Type = vdrapi.DIDCommV2ServiceType
+
+		if ep.Accept == nil {
+			ep.Accept = abbreviated.Accept
+		}
+
+		if ep.RoutingKeys == nil {
+			ep.RoutingKeys = abbreviated.RoutingKeys
+		}
+
+		svc.ServiceEndpoint = model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{
+			{URI: ep.URI, Accept: ep.Accept, RoutingKeys: ep.RoutingKeys},
+		})
+
+		return svc, nil
+	}
+
+	svc.ServiceEndpoint = model.NewDIDCommV1Endpoint(ep.URI)
+	svc.RoutingKeys = append(abbreviated.RoutingKeys, ep.RoutingKeys...)
+	svc.Accept = append(abbreviated.Accept, ep.Accept...)
+
+	return svc, nil
+}
+
+// applyStaticDIDCommKeys sets the recipient keys of the DIDComm services of a resolved numalgo 2 peer DID.
+func applyStaticDIDCommKeys(svc *did.Service, doc *did.Doc) {
+	switch svc.Type {
+	case vdrapi.DIDCommV2ServiceType:
+		svc.RecipientKeys = []string{}
+
+		for _, ka := range doc.KeyAgreement {
+			svc.RecipientKeys = append(svc.RecipientKeys, ka.VerificationMethod.ID)
+		}
+	case vdrapi.DIDCommServiceType:
+		if len(doc.Authentication) > 0 {
+			didKey, _ := fingerprint.CreateDIDKey(doc.Authentication[0].VerificationMethod.Value)
+			svc.RecipientKeys = []string{didKey}
+		}
+	case vdrapi.LegacyServiceType:
+		if len(doc.Authentication) > 0 {
+			svc.RecipientKeys = []string{base58.Encode(doc.Authentication[0].VerificationMethod.Value)}
+		}
+	}
+}
+
+// keyFingerprint returns the multicodec fingerprint of a verification method public key.
+func keyFingerprint(vm *did.VerificationMethod) (string, error) {
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, vm.Value), nil
+	case x25519KeyAgreementKey2019:
+		return fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, vm.Value), nil
+	case jsonWebKey2020:
+		_, keyID, err := fingerprint.CreateDIDKeyByJwk(vm.JSONWebKey())
+		if err != nil {
+			return "", fmt.Errorf("key fingerprint: %w", err)
+		}
+
+		return keyID[strings.Index(keyID, "#")+1:], nil
+	default:
+		return "", fmt.Errorf("not supported public key type: %s", vm.Type)
+	}
+}
+
+// vmFromFingerprint builds the verification method of a public key decoded from a multicodec fingerprint.
+func vmFromFingerprint(id, controller string, code uint64, pubKey []byte) (*did.VerificationMethod, error) {
+	var curve elliptic.Curve
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, ed25519VerificationKey2018, controller, pubKey), nil
+	case fingerprint.X25519PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, x25519KeyAgreementKey2019, controller, pubKey), nil
+	case fingerprint.P256PubKeyMultiCodec:
+		curve = elliptic.P256()
+	case fingerprint.P384PubKeyMultiCodec:
+		curve = elliptic.P384()
+	case fingerprint.P521PubKeyMultiCodec:
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported key multicodec code [0x%x]", code)
+	}
+
+	x, y := elliptic.UnmarshalCompressed(curve, pubKey)
+	if x == nil {
+		return nil, errors.New("error unmarshalling key bytes")
+	}
+
+	j, err := jwksupport.JWKFromKey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+	if err != nil {
+		return nil, fmt.Errorf("error creating JWK %w", err)
+	}
+
+	return did.NewVerificationMethodFromJWK(id, jsonWebKey2020, controller, j)
+}
@@ -0,0 +1,278 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/common/model"
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	"github.com/markcryptohash/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/markcryptohash/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNumAlgo0(t *testing.T) {
+	sProvider := storage.NewMockStoreProvider()
+	km := newKMS(t, sProvider)
+
+	v, err := New(sProvider)
+	require.NoError(t, err)
+
+	t.Run("create and resolve ed25519 inception key", func(t *testing.T) {
+		signingKey, _ := getSigningAndKeyAgreementKey(t, false, km)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{signingKey}},
+			vdr.WithOption(NumAlgoOpt, NumAlgo0))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:0z6Mk"))
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, signingKey.Value, doc.VerificationMethod[0].Value)
+		require.Equal(t, doc.ID, doc.VerificationMethod[0].Controller)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Empty(t, doc.KeyAgreement)
+
+		// numalgo 0 DIDs are not stored
+		_, err = v.Get(doc.ID)
+		require.Error(t, err)
+
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc, resolved.DIDDocument)
+	})
+
+	t.Run("create and resolve x25519 inception key", func(t *testing.T) {
+		_, keyAgreement := getSigningAndKeyAgreementKey(t, false, km)
+
+		docResolution, err := v.Create(&did.Doc{KeyAgreement: []did.Verification{keyAgreement}},
+			vdr.WithOption(NumAlgoOpt, 0))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:0z6LS"))
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Empty(t, doc.Authentication)
+		require.Equal(t, keyAgreement.VerificationMethod.Value, doc.KeyAgreement[0].VerificationMethod.Value)
+	})
+
+	t.Run("create and resolve P-256 inception key", func(t *testing.T) {
+		signingKey, _ := getSigningAndKeyAgreementKey(t, true, km)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{signingKey}},
+			vdr.WithOption(NumAlgoOpt, NumAlgo0))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:0zDn"))
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.Equal(t, signingKey.JSONWebKey().Crv, doc.VerificationMethod[0].JSONWebKey().Crv)
+
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, resolved.DIDDocument.ID)
+	})
+
+	t.Run("create without keys", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{}, vdr.WithOption(NumAlgoOpt, NumAlgo0))
+		require.EqualError(t, err, "create peer DID : verification method and key agreement are empty, at "+
+			"least one should be set")
+	})
+
+	t.Run("create with unsupported key type", func(t *testing.T) {
+		vm := getSigningKey()
+		vm.Type = "undefined"
+
+		_, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{vm}},
+			vdr.WithOption(NumAlgoOpt, NumAlgo0))
+		require.EqualError(t, err, "create peer DID : not supported public key type: undefined")
+	})
+
+	t.Run("resolve invalid fingerprint", func(t *testing.T) {
+		_, err := v.Read("did:peer:0zinvalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid numalgo 0 peer DID")
+	})
+}
+
+func TestNumAlgo2(t *testing.T) {
+	sProvider := storage.NewMockStoreProvider()
+	km := newKMS(t, sProvider)
+
+	v, err := New(sProvider)
+	require.NoError(t, err)
+
+	t.Run("create and resolve DIDComm V2 peer DID", func(t *testing.T) {
+		signingKey, keyAgreement := getSigningAndKeyAgreementKey(t, false, km)
+
+		docResolution, err := v.Create(&did.Doc{
+			VerificationMethod: []did.VerificationMethod{signingKey},
+			KeyAgreement:       []did.Verification{keyAgreement},
+			Service: []did.Service{{
+				Type: vdr.DIDCommV2ServiceType,
+				ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{
+					URI:         "https://example.com/endpoint",
+					Accept:      []string{"didcomm/v2"},
+					RoutingKeys: []string{"did:example:mediator#key-1"},
+				}}),
+			}},
+		}, vdr.WithOption(NumAlgoOpt, NumAlgo2))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:2.Vz6Mk"))
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Equal(t, "#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, signingKey.Value, doc.VerificationMethod[0].Value)
+		require.Equal(t, "#key-2", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, keyAgreement.VerificationMethod.Value, doc.KeyAgreement[0].VerificationMethod.Value)
+		require.Len(t, doc.Authentication, 1)
+
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, "#service", doc.Service[0].ID)
+		require.Equal(t, vdr.DIDCommV2ServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{"#key-2"}, doc.Service[0].RecipientKeys)
+
+		uri, err := doc.Service[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/endpoint", uri)
+
+		routingKeys, err := doc.Service[0].ServiceEndpoint.RoutingKeys()
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:mediator#key-1"}, routingKeys)
+
+		// numalgo 2 DIDs are not stored
+		_, err = v.Get(doc.ID)
+		require.Error(t, err)
+
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc, resolved.DIDDocument)
+	})
+
+	t.Run("create with default service endpoint", func(t *testing.T) {
+		signingKey, keyAgreement := getSigningAndKeyAgreementKey(t, true, km)
+
+		docResolution, err := v.Create(&did.Doc{
+			VerificationMethod: []did.VerificationMethod{signingKey},
+			KeyAgreement:       []did.Verification{keyAgreement},
+			Service:            []did.Service{{}, {Type: vdr.DIDCommServiceType}},
+		},
+			vdr.WithOption(NumAlgoOpt, NumAlgo2),
+			vdr.WithOption(DefaultServiceType, vdr.DIDCommV2ServiceType),
+			vdr.WithOption(DefaultServiceEndpoint, "https://example.com/endpoint"))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.Len(t, doc.Service, 2)
+		require.Equal(t, "#service-1", doc.Service[1].ID)
+		require.Len(t, doc.Service[1].RecipientKeys, 1)
+
+		for _, svc := range doc.Service {
+			uri, err := svc.ServiceEndpoint.URI()
+			require.NoError(t, err)
+			require.Equal(t, "https://example.com/endpoint", uri)
+		}
+	})
+
+	t.Run("resolve service with flat endpoint", func(t *testing.T) {
+		signingKey, keyAgreement := getSigningAndKeyAgreementKey(t, false, km)
+
+		kaFP, err := keyFingerprint(&keyAgreement.VerificationMethod)
+		require.NoError(t, err)
+
+		signingFP, err := keyFingerprint(&signingKey)
+		require.NoError(t, err)
+
+		svc := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"dm","s":"https://example.com/endpoint",` +
+			`"r":["did:example:somemediator#somekey"],"a":["didcomm/v2","didcomm/aip2;env=rfc587"]}`))
+
+		resolved, err := v.Read("did:peer:2.E" + kaFP + ".V" + signingFP + ".S" + svc)
+		require.NoError(t, err)
+
+		doc := resolved.DIDDocument
+		require.Equal(t, "#key-1", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, "#key-2", doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, vdr.DIDCommV2ServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{"#key-1"}, doc.Service[0].RecipientKeys)
+
+		accept, err := doc.Service[0].ServiceEndpoint.Accept()
+		require.NoError(t, err)
+		require.Equal(t, []string{"didcomm/v2", "didcomm/aip2;env=rfc587"}, accept)
+	})
+
+	t.Run("resolve capability and assertion keys", func(t *testing.T) {
+		signingKey, _ := getSigningAndKeyAgreementKey(t, false, km)
+
+		fp, err := keyFingerprint(&signingKey)
+		require.NoError(t, err)
+
+		resolved, err := v.Read("did:peer:2.A" + fp + ".I" + fp + ".D" + fp)
+		require.NoError(t, err)
+
+		doc := resolved.DIDDocument
+		require.Len(t, doc.VerificationMethod, 3)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+	})
+
+	t.Run("resolve errors", func(t *testing.T) {
+		signingKey, _ := getSigningAndKeyAgreementKey(t, false, km)
+
+		fp, err := keyFingerprint(&signingKey)
+		require.NoError(t, err)
+
+		for _, didID := range []string{
+			"did:peer:2",
+			"did:peer:2x.V" + fp,
+			"did:peer:2.V" + fp + "..S",
+			"did:peer:2.X" + fp,
+			"did:peer:2.Vzinvalid",
+			"did:peer:2.V" + fp + ".S!!!",
+			"did:peer:2.V" + fp + ".S" + base64.RawURLEncoding.EncodeToString([]byte("{")),
+			"did:peer:2.V" + fp + ".S" + base64.RawURLEncoding.EncodeToString([]byte(`{"t":"dm","s":1}`)),
+		} {
+			_, err = v.Read(didID)
+			require.Error(t, err, didID)
+			require.Contains(t, err.Error(), "resolving peer DID failed", didID)
+		}
+	})
+}
+
+func TestCreateNumAlgoOption(t *testing.T) {
+	v, err := New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	t.Run("numalgo 1 is stored", func(t *testing.T) {
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdr.WithOption(NumAlgoOpt, NumAlgo1))
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(docResolution.DIDDocument.ID, "did:peer:1"))
+
+		_, err = v.Get(docResolution.DIDDocument.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported numalgo", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdr.WithOption(NumAlgoOpt, "3"))
+		require.EqualError(t, err, "create peer DID : unsupported numalgo: 3")
+	})
+
+	t.Run("numalgo option not string", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdr.WithOption(NumAlgoOpt, true))
+		require.EqualError(t, err, "create peer DID : numalgo opt not string")
+	})
+}
@@ -15,6 +15,16 @@ import (
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
 func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	// numalgo 0 and numalgo 2 DIDs are resolved from the keys and services encoded in the DID
+	if isStaticDID(didID) {
+		doc, err := resolveStatic(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolving peer DID failed: %w", err)
+		}
+
+		return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+	}
+
 	// get the document from the store
 	doc, err := v.Get(didID)
 	if err != nil {
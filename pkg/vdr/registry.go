@@ -24,6 +24,7 @@ type Registry struct {
 	vdr                []vdrapi.VDR
 	defServiceEndpoint string
 	defServiceType     string
+	cache              *resolutionCache
 }
 
 // New return new instance of vdr.
@@ -51,16 +52,34 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 		return nil, err
 	}
 
+	// resolutions with options (e.g. specific version) are not cached
+	useCache := r.cache != nil && len(opts) == 0
+
+	if useCache {
+		didDocResolution, found, cacheErr := r.cache.get(did)
+		if found {
+			return didDocResolution, cacheErr
+		}
+	}
+
 	// Obtain the DID Document
 	didDocResolution, err := method.Read(did, opts...)
 	if err != nil {
 		if errors.Is(err, vdrapi.ErrNotFound) {
+			if useCache {
+				r.cache.putNotFound(did, didMethod)
+			}
+
 			return nil, err
 		}
 
 		return nil, fmt.Errorf("did method read failed failed: %w", err)
 	}
 
+	if useCache {
+		r.cache.put(did, didMethod, didDocResolution)
+	}
+
 	return didDocResolution, nil
 }
 
@@ -77,6 +96,8 @@ func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) er
 		return err
 	}
 
+	defer r.invalidate(didDoc.ID)
+
 	return method.Update(didDoc, opts...)
 }
 
@@ -93,6 +114,8 @@ func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error
 		return err
 	}
 
+	defer r.invalidate(did)
+
 	return method.Deactivate(did, opts...)
 }
 
@@ -115,6 +138,11 @@ func (r *Registry) Create(didMethod string, did *diddoc.Doc,
 		return nil, err
 	}
 
+	// the DID might be cached as not found
+	if didDocResolution != nil && didDocResolution.DIDDocument != nil {
+		r.invalidate(didDocResolution.DIDDocument.ID)
+	}
+
 	return didDocResolution, nil
 }
 
@@ -143,6 +171,13 @@ func (r *Registry) Close() error {
 	return nil
 }
 
+// invalidate removes the DID from the resolution cache.
+func (r *Registry) invalidate(did string) {
+	if r.cache != nil {
+		r.cache.remove(did)
+	}
+}
+
 func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
 	for _, v := range r.vdr {
 		if v.Accept(method) {
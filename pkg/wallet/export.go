@@ -0,0 +1,459 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	gojose "github.com/square/go-jose/v3"
+
+	"github.com/markcryptohash/aries-framework-go/pkg/doc/did"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms"
+	"github.com/markcryptohash/aries-framework-go/pkg/kms/localkms"
+)
+
+// exported wallet constants.
+const (
+	credentialsContext  = "https://www.w3.org/2018/credentials/v1"
+	walletContext       = "https://w3id.org/wallet/v1"
+	credentialType      = "VerifiableCredential"
+	encryptedWalletType = "EncryptedWallet"
+	uuidURNPrefix       = "urn:uuid:"
+
+	// PBES2 iterations of the exported wallets and their upper bound when imported.
+	defaultPBES2Count    = 100000
+	defaultMaxPBES2Count = 10000000
+)
+
+// exportContentTypes are the types of the exported wallet contents, in import order: the collections come first so
+// that the other contents can be mapped to them when imported.
+// nolint:gochecknoglobals
+var exportContentTypes = []ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection}
+
+// encryptedWallet is a locked wallet, the export format of the universal wallet.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#EncryptedWallet
+type encryptedWallet struct {
+	Context           []string                `json:"@context"`
+	ID                string                  `json:"id"`
+	Type              []string                `json:"type"`
+	Issuer            string                  `json:"issuer"`
+	IssuanceDate      string                  `json:"issuanceDate"`
+	CredentialSubject *encryptedWalletSubject `json:"credentialSubject"`
+}
+
+type encryptedWalletSubject struct {
+	ID string `json:"id"`
+
+	// JWE encrypting the wallet contents with a key derived from the export passphrase.
+	EncryptedWalletContents json.RawMessage `json:"encryptedWalletContents"`
+}
+
+// walletContents are the contents of an exported wallet, before encryption.
+type walletContents struct {
+	Contents []*exportedContent `json:"contents"`
+
+	// backup of the wallet keys created by the wallet key manager, encrypted with the export passphrase.
+	Keys json.RawMessage `json:"keys,omitempty"`
+
+	// IDs of the keys of the backup.
+	KeyIDs []string `json:"keyIDs,omitempty"`
+}
+
+type exportedContent struct {
+	ContentType  ContentType     `json:"contentType"`
+	CollectionID string          `json:"collectionID,omitempty"`
+	Content      json.RawMessage `json:"content,omitempty"`
+
+	// contents which are not JSON, typically JWT credentials.
+	RawContent string `json:"rawContent,omitempty"`
+}
+
+// keyBackupManager is implemented by the key managers able to export the keys of a wallet user only.
+type keyBackupManager interface {
+	kms.KeyLifecycleManager
+	BackupKeys(passphrase string, keyIDs ...string) ([]byte, error)
+}
+
+// exportContents returns the wallet contents along with the collection they belong to.
+func (c *Wallet) exportContents(authToken string) ([]*exportedContent, error) {
+	collections, err := c.contents.GetAll(authToken, Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	// collection IDs by content type and content ID.
+	mappings := make(map[ContentType]map[string]string)
+
+	for _, collectionID := range sortedIDs(collections) {
+		for _, ct := range exportContentTypes {
+			mapped, e := c.contents.GetAllByCollection(authToken, collectionID, ct)
+			if e != nil {
+				return nil, fmt.Errorf("failed to get contents of collection '%s': %w", collectionID, e)
+			}
+
+			if mappings[ct] == nil {
+				mappings[ct] = make(map[string]string)
+			}
+
+			for contentID := range mapped {
+				mappings[ct][contentID] = collectionID
+			}
+		}
+	}
+
+	var contents []*exportedContent
+
+	for _, ct := range exportContentTypes {
+		all, e := c.contents.GetAll(authToken, ct)
+		if e != nil {
+			return nil, fmt.Errorf("failed to get %s contents: %w", ct, e)
+		}
+
+		for _, contentID := range sortedIDs(all) {
+			content := &exportedContent{ContentType: ct, CollectionID: mappings[ct][contentID]}
+
+			if json.Valid(all[contentID]) {
+				content.Content = all[contentID]
+			} else {
+				content.RawContent = string(all[contentID])
+			}
+
+			contents = append(contents, content)
+		}
+	}
+
+	return contents, nil
+}
+
+// importContents saves the contents of an exported wallet, the contents already saved are removed if one of them
+// can't be saved.
+func (c *Wallet) importContents(authToken string, contents []*exportedContent) error {
+	for _, content := range contents {
+		if content.ContentType == Key {
+			return errors.New("keys can only be imported from the key backup of the exported wallet")
+		}
+	}
+
+	for i, content := range contents {
+		err := c.contents.Save(authToken, content.ContentType, content.raw(), AddByCollection(content.CollectionID))
+		if err != nil {
+			c.removeContents(authToken, contents[:i])
+
+			return fmt.Errorf("failed to import %s content: %w", content.ContentType, err)
+		}
+	}
+
+	return nil
+}
+
+// removeContents removes the imported contents, failures are logged.
+func (c *Wallet) removeContents(authToken string, contents []*exportedContent) {
+	for _, content := range contents {
+		contentID, err := importedContentID(content.ContentType, content.raw())
+		if err == nil {
+			err = c.contents.Remove(authToken, contentID, content.ContentType)
+		}
+
+		if err != nil {
+			logger.Warnf("failed to remove imported %s content: %s", content.ContentType, err)
+		}
+	}
+}
+
+func (e *exportedContent) raw() []byte {
+	if len(e.Content) > 0 {
+		return e.Content
+	}
+
+	return []byte(e.RawContent)
+}
+
+// importedContentID returns the ID of a saved content.
+func importedContentID(ct ContentType, raw []byte) (string, error) {
+	if ct != DIDResolutionResponse {
+		return getContentID(raw)
+	}
+
+	docRes, err := did.ParseDocumentResolution(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return docRes.DIDDocument.ID, nil
+}
+
+// exportKeys returns a backup of the keys of user and their IDs. Nothing is exported if the key manager can't
+// back up the keys of a user, typically when the keys are kept by a remote key server.
+func exportKeys(keyManager kms.KeyManager, user, passphrase string) (json.RawMessage, []string, error) {
+	backupManager, ok := keyManager.(keyBackupManager)
+	if !ok {
+		logger.Debugf("wallet keys are not exported, key manager %T does not support key backup", keyManager)
+
+		return nil, nil, nil
+	}
+
+	infos, err := backupManager.ListKeys()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	var keyIDs []string
+
+	for _, info := range infos {
+		// the keyset of a rotated key is part of the keyset of the key replacing it.
+		if info.Metadata[walletUserKeyTag] == user && info.RotatedTo == "" {
+			keyIDs = append(keyIDs, info.ID)
+		}
+	}
+
+	if len(keyIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	backup, err := backupManager.BackupKeys(passphrase, keyIDs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to back up keys: %w", err)
+	}
+
+	return backup, keyIDs, nil
+}
+
+// importKeys restores the backup of the keys of an exported wallet, the restored keys are recorded as keys of user.
+// The restored keys are deleted if they can't be recorded.
+func importKeys(keyManager kms.KeyManager, user, passphrase string, contents *walletContents) error {
+	if len(contents.Keys) == 0 {
+		return nil
+	}
+
+	backupManager, ok := keyManager.(kms.KeyBackupManager)
+	if !ok {
+		return errors.New("wallet key manager does not support key import")
+	}
+
+	err := backupManager.Restore(contents.Keys, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to restore keys: %w", err)
+	}
+
+	for _, kid := range contents.KeyIDs {
+		err = tagWalletKey(keyManager, kid, user)
+		if err != nil {
+			deleteKeys(keyManager, contents.KeyIDs)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteKeys deletes the imported keys, failures are logged.
+func deleteKeys(keyManager kms.KeyManager, keyIDs []string) {
+	lifecycleManager, ok := keyManager.(kms.KeyLifecycleManager)
+	if !ok {
+		logger.Warnf("imported keys are not deleted, key manager %T does not support key deletion", keyManager)
+
+		return
+	}
+
+	for _, kid := range keyIDs {
+		if err := lifecycleManager.DeleteKey(kid); err != nil {
+			logger.Warnf("failed to delete imported key '%s': %s", kid, err)
+		}
+	}
+}
+
+// recordedKeyIDs returns the IDs of the keys the wallet recorded: the EDV keys of the profile and the keys of the
+// verification methods of the stored DID documents, either imported with the ID of the verification method or
+// created by the local key manager for an Ed25519 public key.
+func (c *Wallet) recordedKeyIDs(authToken string) ([]string, error) {
+	var keyIDs []string
+
+	if c.profile.EDVConf != nil {
+		keyIDs = append(keyIDs, c.profile.EDVConf.EncryptionKeyID, c.profile.EDVConf.MACKeyID)
+	}
+
+	docs, err := c.contents.GetAll(authToken, DIDResolutionResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DID documents: %w", err)
+	}
+
+	for _, docID := range sortedIDs(docs) {
+		docRes, e := did.ParseDocumentResolution(docs[docID], did.WithoutValidation())
+		if e != nil {
+			logger.Warnf("keys of DID document '%s' are not tagged: %s", docID, e)
+
+			continue
+		}
+
+		for _, vm := range docRes.DIDDocument.VerificationMethod {
+			keyIDs = append(keyIDs, getKID(vm.ID))
+
+			if strings.EqualFold(vm.Type, Ed25519VerificationKey2018) && len(vm.Value) > 0 {
+				if kid, e := localkms.CreateKID(vm.Value, kms.ED25519Type); e == nil {
+					keyIDs = append(keyIDs, kid)
+				}
+			}
+		}
+	}
+
+	return keyIDs, nil
+}
+
+// tagUserKeys tags the keys of keyIDs which are not tagged yet, typically the keys recorded by the wallet before
+// its keys were tagged. The key managers of the wallet users share the same store, a key is tagged only if the key
+// manager of user can use it. The other keys created before tagging are left untagged and are not exported.
+func tagUserKeys(keyManager kms.KeyManager, user string, keyIDs []string) error {
+	lifecycleManager, ok := keyManager.(kms.KeyLifecycleManager)
+	if !ok {
+		return nil
+	}
+
+	tagged := make(map[string]bool)
+
+	for _, kid := range keyIDs {
+		if kid == "" || tagged[kid] {
+			continue
+		}
+
+		tagged[kid] = true
+
+		info, err := lifecycleManager.GetKeyInfo(kid)
+		if err != nil {
+			if errors.Is(err, kms.ErrKeyNotFound) {
+				continue
+			}
+
+			return fmt.Errorf("failed to get wallet key '%s': %w", kid, err)
+		}
+
+		if _, isTagged := info.Metadata[walletUserKeyTag]; isTagged || info.RotatedTo != "" {
+			continue
+		}
+
+		if _, err = keyManager.Get(kid); err != nil {
+			continue
+		}
+
+		metadata := map[string]string{walletUserKeyTag: user}
+
+		for k, v := range info.Metadata {
+			metadata[k] = v
+		}
+
+		err = lifecycleManager.SetKeyMetadata(kid, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to tag wallet key '%s': %w", kid, err)
+		}
+	}
+
+	return nil
+}
+
+// lockWallet returns the encrypted wallet document of contents, they are encrypted with a key derived from
+// passphrase with pbes2Count iterations.
+func lockWallet(profileID string, contents *walletContents, passphrase string, pbes2Count int) (json.RawMessage,
+	error) {
+	plaintext, err := json.Marshal(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet contents: %w", err)
+	}
+
+	encrypter, err := gojose.NewEncrypter(gojose.A256GCM,
+		gojose.Recipient{Algorithm: gojose.PBES2_HS512_A256KW, Key: []byte(passphrase), PBES2Count: pbes2Count},
+		(&gojose.EncrypterOptions{}).WithContentType("application/json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet contents encrypter: %w", err)
+	}
+
+	jwe, err := encrypter.Encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt wallet contents: %w", err)
+	}
+
+	return json.Marshal(&encryptedWallet{
+		Context:      []string{credentialsContext, walletContext},
+		ID:           uuidURNPrefix + uuid.New().String(),
+		Type:         []string{credentialType, encryptedWalletType},
+		Issuer:       uuidURNPrefix + profileID,
+		IssuanceDate: time.Now().UTC().Format(time.RFC3339),
+		CredentialSubject: &encryptedWalletSubject{
+			ID:                      uuidURNPrefix + profileID,
+			EncryptedWalletContents: json.RawMessage(jwe.FullSerialize()),
+		},
+	})
+}
+
+// unlockWallet decrypts the contents of an encrypted wallet document with passphrase, the key of the contents must
+// be derived with at most maxPBES2Count iterations.
+func unlockWallet(exported json.RawMessage, passphrase string, maxPBES2Count int) (*walletContents, error) {
+	var locked encryptedWallet
+
+	err := json.Unmarshal(exported, &locked)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exported wallet: %w", err)
+	}
+
+	if !hasType(locked.Type, encryptedWalletType) || locked.CredentialSubject == nil {
+		return nil, fmt.Errorf("invalid exported wallet: expected %s", encryptedWalletType)
+	}
+
+	jwe, err := gojose.ParseEncrypted(string(locked.CredentialSubject.EncryptedWalletContents))
+	if err != nil {
+		return nil, fmt.Errorf("invalid exported wallet contents: %w", err)
+	}
+
+	if jwe.Header.Algorithm != string(gojose.PBES2_HS512_A256KW) {
+		return nil, fmt.Errorf("unsupported exported wallet key encryption algorithm '%s'", jwe.Header.Algorithm)
+	}
+
+	if p2c, ok := jwe.Header.ExtraHeaders["p2c"].(float64); ok && p2c > float64(maxPBES2Count) {
+		return nil, fmt.Errorf("invalid exported wallet PBES2 count %.0f", p2c)
+	}
+
+	plaintext, err := jwe.Decrypt([]byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt exported wallet, invalid passphrase or corrupted contents: %w", err)
+	}
+
+	var contents walletContents
+
+	err = json.Unmarshal(plaintext, &contents)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exported wallet contents: %w", err)
+	}
+
+	return &contents, nil
+}
+
+func hasType(types []string, t string) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedIDs(contents map[string]json.RawMessage) []string {
+	ids := make([]string, 0, len(contents))
+
+	for id := range contents {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
@@ -42,6 +42,9 @@ const (
 	p384Alg = "ES384"
 	p521Alg = "ES521"
 	edAlg   = "EdDSA"
+
+	// metadata tag of the keys created or imported by the wallet, its value is the wallet user.
+	walletUserKeyTag = "vcwalletUser"
 )
 
 // supported key types for import key base58 (all constants defined in lower case).
@@ -291,12 +294,12 @@ func importKeyJWK(auth string, key *keyContent) error {
 		return fmt.Errorf("unsupported Key type %s", j.Crv)
 	}
 
-	_, _, err = keyManager.ImportPrivateKey(j.Key, keyType, kms.WithKeyID(getKIDFromJWK(key.ID, &j)))
+	kid, _, err := keyManager.ImportPrivateKey(j.Key, keyType, kms.WithKeyID(getKIDFromJWK(key.ID, &j)))
 	if err != nil {
 		return fmt.Errorf("failed to import jwk key : %w", err)
 	}
 
-	return nil
+	return tagWalletKey(keyManager, kid, session.user)
 }
 
 // importKeyBase58 imports private key base58 found in key contents,
@@ -317,23 +320,41 @@ func importKeyBase58(auth string, key *keyContent) error {
 	case Ed25519VerificationKey2018:
 		edPriv := ed25519.PrivateKey(base58.Decode(key.PrivateKeyBase58))
 
-		_, _, err := keyManager.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(getKID(key.ID)))
+		kid, _, err := keyManager.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(getKID(key.ID)))
 		if err != nil {
 			return fmt.Errorf("failed to import Ed25519Signature2018 key : %w", err)
 		}
+
+		return tagWalletKey(keyManager, kid, session.user)
 	case Bls12381G1Key2020:
 		blsKey, err := bbs12381g2pub.UnmarshalPrivateKey(base58.Decode(key.PrivateKeyBase58))
 		if err != nil {
 			return fmt.Errorf("failed to unmarshal %s private key : %w", kms.BLS12381G2Type, err)
 		}
 
-		_, _, err = keyManager.ImportPrivateKey(blsKey, kms.BLS12381G2, kms.WithKeyID(getKID(key.ID)))
+		kid, _, err := keyManager.ImportPrivateKey(blsKey, kms.BLS12381G2, kms.WithKeyID(getKID(key.ID)))
 		if err != nil {
 			return fmt.Errorf("failed to import Ed25519Signature2018 key : %w", err)
 		}
+
+		return tagWalletKey(keyManager, kid, session.user)
 	default:
 		return errors.New("only Ed25519VerificationKey2018 &  Bls12381G1Key2020 are supported in base58 format")
 	}
+}
+
+// tagWalletKey records user as the owner of the key referenced by kid so that the key can be exported with the
+// wallet contents, the key managers not keeping track of their keys are left unchanged.
+func tagWalletKey(keyManager kms.KeyManager, kid, user string) error {
+	lifecycleManager, ok := keyManager.(kms.KeyLifecycleManager)
+	if !ok {
+		return nil
+	}
+
+	err := lifecycleManager.SetKeyMetadata(kid, map[string]string{walletUserKeyTag: user})
+	if err != nil {
+		return fmt.Errorf("failed to tag wallet key : %w", err)
+	}
 
 	return nil
 }
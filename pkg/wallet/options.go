@@ -205,6 +205,8 @@ type verifyOpts struct {
 	rawCredential json.RawMessage
 	// raw presentation to be verified from wallet.
 	rawPresentation json.RawMessage
+	// fetcher of the status list credentials of the StatusList2021 credential statuses.
+	statusListFetcher verifiable.StatusListCredentialFetcher
 }
 
 // VerificationOption options for verifying credential from wallet.
@@ -231,6 +233,14 @@ func WithRawPresentationToVerify(raw json.RawMessage) VerificationOption {
 	}
 }
 
+// WithStatusListCredentialFetcher option for providing the fetcher of the status list credentials used to check the
+// StatusList2021 status of the verified credentials. By default, status list credentials are fetched over HTTP.
+func WithStatusListCredentialFetcher(fetcher verifiable.StatusListCredentialFetcher) VerificationOption {
+	return func(opts *verifyOpts) {
+		opts.statusListFetcher = fetcher
+	}
+}
+
 // verifyOpts contains options for deriving credentials.
 type deriveOpts struct {
 	// for deriving credential from stored credential.
@@ -42,6 +42,10 @@ type profile struct {
 
 	// EDV configuration
 	EDVConf *edvConf
+
+	// KeysTagged is set once the keys of the profile are tagged with the wallet user, the keys recorded by the wallet
+	// before their tagging was introduced are tagged when the wallet is opened.
+	KeysTagged bool
 }
 
 type edvConf struct {
@@ -61,7 +65,7 @@ type edvConf struct {
 // createProfile creates new verifiable credential wallet profile for given user and saves it in store.
 // This profile is required for creating verifiable credential wallet client.
 func createProfile(user string, opts *profileOpts) (*profile, error) {
-	profile := &profile{User: user, ID: uuid.New().String()}
+	profile := &profile{User: user, ID: uuid.New().String(), KeysTagged: true}
 
 	err := profile.setKMSOptions(opts.passphrase, opts.secretLockSvc, opts.keyServerURL)
 	if err != nil {
@@ -131,7 +135,7 @@ func (pr *profile) setupEDVEncryptionKey(keyManager kms.KeyManager) error {
 
 	pr.EDVConf.EncryptionKeyID = kid
 
-	return nil
+	return tagWalletKey(keyManager, kid, pr.User)
 }
 
 func (pr *profile) setupEDVMacKey(keyManager kms.KeyManager) error {
@@ -142,7 +146,7 @@ func (pr *profile) setupEDVMacKey(keyManager kms.KeyManager) error {
 
 	pr.EDVConf.MACKeyID = kid
 
-	return nil
+	return tagWalletKey(keyManager, kid, pr.User)
 }
 
 func (pr *profile) resetKMSOptions() {
@@ -259,12 +259,18 @@ func (cm *credentialMatcher) MatchFrame(credential *verifiable.Credential) bool
 }
 
 func preparePresentation(credentials map[*verifiable.Credential]struct{}) (*verifiable.Presentation, error) {
-	var opts []verifiable.CreatePresentationOpt
+	var (
+		opts  []verifiable.CreatePresentationOpt
+		creds []*verifiable.Credential
+	)
 
 	for cred := range credentials {
 		opts = append(opts, verifiable.WithCredentials(cred))
+		creds = append(creds, cred)
 	}
 
+	opts = append(opts, verifiable.WithDataModelVersion(verifiable.CredentialsDataModelVersion(creds...)))
+
 	return verifiable.NewPresentation(opts...)
 }
 
@@ -11,6 +11,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 
@@ -48,6 +50,9 @@ const (
 	// web redirect constants.
 	webRedirectStatusKey = "status"
 	webRedirectURLKey    = "url"
+
+	// statusListFetchTimeout bounds the fetch of a status list credential when checking the status of a credential.
+	statusListFetchTimeout = 10 * time.Second
 )
 
 // proof options.
@@ -102,6 +107,10 @@ type Wallet struct {
 
 	// document loader for JSON-LD contexts
 	jsonldDocumentLoader ld.DocumentLoader
+
+	// PBES2 iterations of the exported wallets and their upper bound when imported
+	pbes2Count    int
+	maxPBES2Count int
 }
 
 // New returns new verifiable credential wallet for given user.
@@ -127,6 +136,8 @@ func New(userID string, ctx provider) (*Wallet, error) {
 		contents:             newContentStore(ctx.StorageProvider(), ctx.JSONLDDocumentLoader(), profile),
 		vdr:                  ctx.VDRegistry(),
 		jsonldDocumentLoader: ctx.JSONLDDocumentLoader(),
+		pbes2Count:           defaultPBES2Count,
+		maxPBES2Count:        defaultMaxPBES2Count,
 	}, nil
 }
 
@@ -293,9 +304,46 @@ func (c *Wallet) Open(options ...UnlockOptions) (string, error) {
 		return "", err
 	}
 
+	if !c.profile.KeysTagged {
+		err = c.tagKeys(token, keyManager)
+		if err != nil {
+			c.Close()
+
+			return "", err
+		}
+	}
+
 	return token, nil
 }
 
+// tagKeys tags the keys recorded by the wallet before its keys were tagged, so that they are exported with the
+// wallet. It is done once per profile.
+func (c *Wallet) tagKeys(authToken string, keyManager kms.KeyManager) error {
+	keyIDs, err := c.recordedKeyIDs(authToken)
+	if err != nil {
+		return fmt.Errorf("failed to tag wallet keys: %w", err)
+	}
+
+	err = tagUserKeys(keyManager, c.userID, keyIDs)
+	if err != nil {
+		return fmt.Errorf("failed to tag wallet keys: %w", err)
+	}
+
+	store, err := newProfileStore(c.storeProvider)
+	if err != nil {
+		return fmt.Errorf("failed to get store to save VC wallet profile: %w", err)
+	}
+
+	c.profile.KeysTagged = true
+
+	err = store.save(c.profile, true)
+	if err != nil {
+		return fmt.Errorf("failed to save VC wallet profile: %w", err)
+	}
+
+	return nil
+}
+
 // Close expires token issued to this VC wallet, removes the key manager instance and closes wallet content store.
 // returns false if token is not found or already expired for this wallet user.
 func (c *Wallet) Close() bool {
@@ -303,10 +351,14 @@ func (c *Wallet) Close() bool {
 }
 
 // Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// The wallet contents and the keys created or imported by the wallet are encrypted with a key derived from the
+// passphrase into a locked wallet document.
+// The keys are exported only if the wallet key manager supports key backup, the keys of a remote key server are
+// never exported.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- authToken: authorization for performing export operation.
+//		- passphrase: passphrase to be used to lock the wallet before exporting.
 //
 //	Returns exported locked wallet.
 //
@@ -316,18 +368,41 @@ func (c *Wallet) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Wallet) Export(authToken, passphrase string) (json.RawMessage, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required to lock the exported wallet")
+	}
+
+	session, err := sessionManager().getSession(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := c.exportContents(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet contents: %w", err)
+	}
+
+	keys, keyIDs, err := exportKeys(session.KeyManager, c.userID, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet keys: %w", err)
+	}
+
+	return lockWallet(c.profile.ID, &walletContents{Contents: contents, Keys: keys, KeyIDs: keyIDs}, passphrase,
+		c.pbes2Count)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
+// The keys are imported first, the import fails if one of them or one of the contents already exists in wallet, in
+// which case the keys and the contents imported before the failure are removed.
 //
 //	Args:
+//		- authToken: authorization for performing import operation.
+//		- passphrase: passphrase used while exporting the wallet.
 //		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -337,9 +412,30 @@ func (c *Wallet) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Wallet) Import(authToken, passphrase string, contents json.RawMessage) error {
+	session, err := sessionManager().getSession(authToken)
+	if err != nil {
+		return err
+	}
+
+	exported, err := unlockWallet(contents, passphrase, c.maxPBES2Count)
+	if err != nil {
+		return err
+	}
+
+	err = importKeys(session.KeyManager, c.userID, passphrase, exported)
+	if err != nil {
+		return fmt.Errorf("failed to import wallet keys: %w", err)
+	}
+
+	err = c.importContents(authToken, exported.Contents)
+	if err != nil {
+		deleteKeys(session.KeyManager, exported.KeyIDs)
+
+		return err
+	}
+
+	return nil
 }
 
 // Add adds given data model to wallet contents store.
@@ -534,6 +630,11 @@ func (c *Wallet) Verify(authToken string, options VerificationOption) (bool, err
 
 	options(requestOpts)
 
+	if requestOpts.statusListFetcher == nil {
+		requestOpts.statusListFetcher = verifiable.NewHTTPStatusListCredentialFetcher(
+			&http.Client{Timeout: statusListFetchTimeout})
+	}
+
 	switch {
 	case requestOpts.credentialID != "":
 		raw, err := c.contents.Get(authToken, requestOpts.credentialID, Credential)
@@ -541,11 +642,11 @@ func (c *Wallet) Verify(authToken string, options VerificationOption) (bool, err
 			return false, fmt.Errorf("failed to get credential: %w", err)
 		}
 
-		return c.verifyCredential(authToken, raw)
+		return c.verifyCredential(authToken, raw, requestOpts.statusListFetcher)
 	case len(requestOpts.rawCredential) > 0:
-		return c.verifyCredential(authToken, requestOpts.rawCredential)
+		return c.verifyCredential(authToken, requestOpts.rawCredential, requestOpts.statusListFetcher)
 	case len(requestOpts.rawPresentation) > 0:
-		return c.verifyPresentation(authToken, requestOpts.rawPresentation)
+		return c.verifyPresentation(authToken, requestOpts.rawPresentation, requestOpts.statusListFetcher)
 	default:
 		return false, fmt.Errorf("invalid verify request")
 	}
@@ -591,6 +692,11 @@ func (c *Wallet) CreateKeyPair(authToken string, keyType kms.KeyType) (*KeyPair,
 		return nil, err
 	}
 
+	err = tagWalletKey(session.KeyManager, kid, session.user)
+	if err != nil {
+		return nil, err
+	}
+
 	return &KeyPair{
 		KeyID:     kid,
 		PublicKey: base64.RawURLEncoding.EncodeToString(pubBytes),
@@ -734,7 +840,8 @@ func (c *Wallet) resolveOptionsToPresent(auth string, credentials ...ProveOption
 		return vp, nil
 	}
 
-	return verifiable.NewPresentation(verifiable.WithCredentials(allCredentials...))
+	return verifiable.NewPresentation(verifiable.WithCredentials(allCredentials...),
+		verifiable.WithDataModelVersion(verifiable.CredentialsDataModelVersion(allCredentials...)))
 }
 
 func (c *Wallet) resolveCredentialToDerive(auth string, credential CredentialToDerive) (*verifiable.Credential, error) {
@@ -772,10 +879,12 @@ func (c *Wallet) resolveCredentialToDerive(auth string, credential CredentialToD
 	return nil, errors.New("invalid request to derive credential")
 }
 
-func (c *Wallet) verifyCredential(authToken string, credential json.RawMessage) (bool, error) {
+func (c *Wallet) verifyCredential(authToken string, credential json.RawMessage,
+	statusListFetcher verifiable.StatusListCredentialFetcher) (bool, error) {
 	_, err := verifiable.ParseCredential(credential, verifiable.WithPublicKeyFetcher(
 		verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
-	), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader),
+		verifiable.WithStatusList2021Check(statusListFetcher))
 	if err != nil {
 		return false, fmt.Errorf("credential verification failed: %w", err)
 	}
@@ -783,7 +892,8 @@ func (c *Wallet) verifyCredential(authToken string, credential json.RawMessage)
 	return true, nil
 }
 
-func (c *Wallet) verifyPresentation(authToken string, presentation json.RawMessage) (bool, error) {
+func (c *Wallet) verifyPresentation(authToken string, presentation json.RawMessage,
+	statusListFetcher verifiable.StatusListCredentialFetcher) (bool, error) {
 	vp, err := verifiable.ParsePresentation(presentation, verifiable.WithPresPublicKeyFetcher(
 		verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
 	), verifiable.WithPresJSONLDDocumentLoader(c.jsonldDocumentLoader))
@@ -798,7 +908,7 @@ func (c *Wallet) verifyPresentation(authToken string, presentation json.RawMessa
 			return false, fmt.Errorf("failed to read credentials from presentation: %w", err)
 		}
 
-		_, err = c.verifyCredential(authToken, vc)
+		_, err = c.verifyCredential(authToken, vc, statusListFetcher)
 		if err != nil {
 			return false, fmt.Errorf("presentation verification failed: %w", err)
 		}
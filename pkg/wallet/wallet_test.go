@@ -24,6 +24,7 @@ import (
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
+	gojose "github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
 	"github.com/markcryptohash/aries-framework-go/component/storage/edv"
@@ -55,7 +56,6 @@ import (
 const (
 	sampleUserID            = "sample-user01"
 	sampleFakeTkn           = "fake-auth-tkn"
-	toBeImplementedErr      = "to be implemented"
 	sampleWalletErr         = "sample wallet err"
 	sampleCreatedDate       = "2020-12-25"
 	sampleChallenge         = "sample-challenge"
@@ -687,33 +687,357 @@ func TestWallet_OpenClose(t *testing.T) {
 	})
 }
 
-func TestWallet_Export(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
-	require.NoError(t, err)
+func TestWallet_ExportImport(t *testing.T) {
+	const orgCollection = `{
+                    "@context": ["https://w3id.org/wallet/v1"],
+                    "id": "did:example:acme123456789abcdefghi",
+                    "type": "Organization",
+                    "name": "Acme Corp"
+                }`
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
-	require.NoError(t, err)
+	const (
+		collectionID   = "did:example:acme123456789abcdefghi"
+		metadataID     = "did:example:123456789abcdefghi"
+		exportPassword = "export passphrase"
 
-	result, err := walletInstance.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+		// small PBES2 iterations, the production ones make the tests slow.
+		testPBES2Count    = 1000
+		testMaxPBES2Count = 2000
+	)
 
-func TestWallet_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
-	require.NoError(t, err)
+	newWallet := func(t *testing.T, user string, mockctx *mockprovider.Provider) *Wallet {
+		t.Helper()
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
-	require.NoError(t, err)
+		walletInstance, err := New(user, mockctx)
+		require.NoError(t, err)
 
-	err = walletInstance.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+		walletInstance.pbes2Count = testPBES2Count
+		walletInstance.maxPBES2Count = testMaxPBES2Count
+
+		return walletInstance
+	}
+
+	exportWallet := func(t *testing.T) (json.RawMessage, []string) {
+		t.Helper()
+
+		mockctx := newMockProvider(t)
+		user := uuid.New().String()
+
+		require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+		walletInstance := newWallet(t, user, mockctx)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer walletInstance.Close()
+
+		require.NoError(t, walletInstance.Add(tkn, Collection, []byte(orgCollection)))
+		require.NoError(t, walletInstance.Add(tkn, Metadata, []byte(sampleContentValid), AddByCollection(collectionID)))
+		require.NoError(t, walletInstance.Add(tkn, DIDResolutionResponse, []byte(didResolutionResult)))
+		require.NoError(t, walletInstance.Add(tkn, Credential, []byte(sampleJWTCredContentValid)))
+		require.NoError(t, walletInstance.Add(tkn, Key, []byte(sampleKeyContentBase58Valid)))
+
+		keyPair, err := walletInstance.CreateKeyPair(tkn, kms.ED25519Type)
+		require.NoError(t, err)
+
+		// keys created without the wallet are not exported.
+		session, err := sessionManager().getSession(tkn)
+		require.NoError(t, err)
+
+		_, _, err = session.KeyManager.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		exported, err := walletInstance.Export(tkn, exportPassword)
+		require.NoError(t, err)
+
+		return exported, []string{getKID("did:example:123456789abcdefghi#key-1"), keyPair.KeyID}
+	}
+
+	t.Run("export and import into a fresh profile", func(t *testing.T) {
+		exported, keyIDs := exportWallet(t)
+
+		var locked map[string]interface{}
+		require.NoError(t, json.Unmarshal(exported, &locked))
+		require.Equal(t, []interface{}{"VerifiableCredential", "EncryptedWallet"}, locked["type"])
+		require.NotContains(t, string(exported), "John Smith")
+
+		mockctx := newMockProvider(t)
+		user := uuid.New().String()
+
+		require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+		walletInstance := newWallet(t, user, mockctx)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer walletInstance.Close()
+
+		err = walletInstance.Import(tkn, "wrong passphrase", exported)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid passphrase or corrupted contents")
+
+		require.NoError(t, walletInstance.Import(tkn, exportPassword, exported))
+
+		content, err := walletInstance.Get(tkn, Metadata, metadataID)
+		require.NoError(t, err)
+		require.JSONEq(t, sampleContentValid, string(content))
+
+		contents, err := walletInstance.GetAll(tkn, Metadata, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+
+		for ct, count := range map[ContentType]int{Collection: 1, Credential: 1, DIDResolutionResponse: 1} {
+			contents, err = walletInstance.GetAll(tkn, ct)
+			require.NoError(t, err)
+			require.Len(t, contents, count, ct)
+		}
+
+		session, err := sessionManager().getSession(tkn)
+		require.NoError(t, err)
+
+		for _, keyID := range keyIDs {
+			_, err = session.KeyManager.Get(keyID)
+			require.NoError(t, err)
+		}
+
+		// the imported keys are exported with the wallet.
+		reexported, err := walletInstance.Export(tkn, exportPassword)
+		require.NoError(t, err)
+
+		walletContents, err := unlockWallet(reexported, exportPassword, testMaxPBES2Count)
+		require.NoError(t, err)
+		require.ElementsMatch(t, keyIDs, walletContents.KeyIDs)
+		require.Len(t, walletContents.Contents, 4)
+
+		err = walletInstance.Import(tkn, exportPassword, exported)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already exists")
+	})
+
+	t.Run("failed import is rolled back", func(t *testing.T) {
+		exported, keyIDs := exportWallet(t)
+
+		mockctx := newMockProvider(t)
+		user := uuid.New().String()
+
+		require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+		walletInstance := newWallet(t, user, mockctx)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer walletInstance.Close()
+
+		require.NoError(t, walletInstance.Add(tkn, Credential, []byte(sampleJWTCredContentValid)))
+
+		err = walletInstance.Import(tkn, exportPassword, exported)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already exists")
+
+		for _, ct := range []ContentType{Collection, Metadata, DIDResolutionResponse} {
+			contents, e := walletInstance.GetAll(tkn, ct)
+			require.NoError(t, e)
+			require.Empty(t, contents, ct)
+		}
+
+		session, err := sessionManager().getSession(tkn)
+		require.NoError(t, err)
+
+		for _, keyID := range keyIDs {
+			_, err = session.KeyManager.Get(keyID)
+			require.True(t, errors.Is(err, kms.ErrKeyNotFound), keyID)
+		}
+
+		// nothing is left behind, the wallet can be imported once the conflicting content is removed.
+		require.NoError(t, walletInstance.Remove(tkn, Credential, "http://example.edu/credentials/1872"))
+		require.NoError(t, walletInstance.Import(tkn, exportPassword, exported))
+	})
+
+	t.Run("recorded keys created before they were tagged are exported", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+		user, other := uuid.New().String(), uuid.New().String()
+
+		var recorded, unrecorded []string
+
+		for _, u := range []string{user, other} {
+			require.NoError(t, CreateProfile(u, mockctx, WithPassphrase(samplePassPhrase)))
+
+			walletInstance, err := New(u, mockctx)
+			require.NoError(t, err)
+
+			tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+			require.NoError(t, err)
+
+			session, err := sessionManager().getSession(tkn)
+			require.NoError(t, err)
+
+			// key of a DID document stored in the wallet.
+			kid, pubKey, err := session.KeyManager.CreateAndExportPubKeyBytes(kms.ED25519Type)
+			require.NoError(t, err)
+
+			doc := did.BuildDoc(did.WithVerificationMethod([]did.VerificationMethod{
+				*did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", "", pubKey),
+			}))
+			doc.ID = "did:example:" + u
+
+			docBytes, err := (&did.DocResolution{DIDDocument: doc}).JSONBytes()
+			require.NoError(t, err)
+			require.NoError(t, walletInstance.Add(tkn, DIDResolutionResponse, docBytes))
+
+			recorded = append(recorded, kid)
+
+			// key the wallet does not know about, e.g. created by the agent sharing the key store.
+			kid, _, err = session.KeyManager.Create(kms.ED25519Type)
+			require.NoError(t, err)
+
+			unrecorded = append(unrecorded, kid)
+
+			require.True(t, walletInstance.Close())
+		}
+
+		// the profile predates the tagging of the wallet keys.
+		store, err := newProfileStore(mockctx.StorageProvider())
+		require.NoError(t, err)
+
+		legacy, err := store.get(user)
+		require.NoError(t, err)
+
+		legacy.KeysTagged = false
+		require.NoError(t, store.save(legacy, true))
+
+		walletInstance := newWallet(t, user, mockctx)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer walletInstance.Close()
+
+		exported, err := walletInstance.Export(tkn, exportPassword)
+		require.NoError(t, err)
+
+		walletContents, err := unlockWallet(exported, exportPassword, testMaxPBES2Count)
+		require.NoError(t, err)
+		require.Equal(t, []string{recorded[0]}, walletContents.KeyIDs)
+		require.NotContains(t, walletContents.KeyIDs, unrecorded[0])
+
+		updated, err := store.get(user)
+		require.NoError(t, err)
+		require.True(t, updated.KeysTagged)
+	})
+
+	t.Run("keys of a remote key server are not exported", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+		user := uuid.New().String()
+
+		require.NoError(t, CreateProfile(user, mockctx, WithKeyServerURL(sampleKeyServerURL)))
+
+		walletInstance := newWallet(t, user, mockctx)
+
+		tkn, err := walletInstance.Open(WithUnlockByAuthorizationToken(sampleRemoteKMSAuth))
+		require.NoError(t, err)
+
+		defer walletInstance.Close()
+
+		require.NoError(t, walletInstance.Add(tkn, Metadata, []byte(sampleContentValid)))
+
+		exported, err := walletInstance.Export(tkn, exportPassword)
+		require.NoError(t, err)
+
+		walletContents, err := unlockWallet(exported, exportPassword, testMaxPBES2Count)
+		require.NoError(t, err)
+		require.Empty(t, walletContents.Keys)
+		require.Len(t, walletContents.Contents, 1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		exported, _ := exportWallet(t)
+
+		mockctx := newMockProvider(t)
+		user := uuid.New().String()
+
+		require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+		walletInstance := newWallet(t, user, mockctx)
+
+		// wallet locked
+		_, err := walletInstance.Export(sampleFakeTkn, exportPassword)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		err = walletInstance.Import(sampleFakeTkn, exportPassword, exported)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer walletInstance.Close()
+
+		_, err = walletInstance.Export(tkn, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "passphrase is required")
+
+		err = walletInstance.Import(tkn, exportPassword, []byte("{"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid exported wallet")
+
+		err = walletInstance.Import(tkn, exportPassword, []byte(`{"type":["VerifiableCredential"]}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected EncryptedWallet")
+
+		err = walletInstance.Import(tkn, exportPassword,
+			[]byte(`{"type":["EncryptedWallet"],"credentialSubject":{"encryptedWalletContents":{}}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid exported wallet contents")
+
+		encrypter, err := gojose.NewEncrypter(gojose.A256GCM,
+			gojose.Recipient{
+				Algorithm: gojose.PBES2_HS512_A256KW, Key: []byte(exportPassword), PBES2Count: testMaxPBES2Count + 1,
+			},
+			nil)
+		require.NoError(t, err)
+
+		jwe, err := encrypter.Encrypt([]byte("{}"))
+		require.NoError(t, err)
+
+		err = walletInstance.Import(tkn, exportPassword, []byte(fmt.Sprintf(
+			`{"type":["EncryptedWallet"],"credentialSubject":{"encryptedWalletContents":%s}}`, jwe.FullSerialize())))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid exported wallet PBES2 count 2001")
+
+		encrypter, err = gojose.NewEncrypter(gojose.A256GCM,
+			gojose.Recipient{Algorithm: gojose.A256KW, Key: make([]byte, 32)}, nil)
+		require.NoError(t, err)
+
+		jwe, err = encrypter.Encrypt([]byte("{}"))
+		require.NoError(t, err)
+
+		err = walletInstance.Import(tkn, exportPassword, []byte(fmt.Sprintf(
+			`{"type":["EncryptedWallet"],"credentialSubject":{"encryptedWalletContents":%s}}`, jwe.FullSerialize())))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported exported wallet key encryption algorithm 'A256KW'")
+
+		// key contents can't be imported from the wallet contents.
+		locked, err := lockWallet("profile", &walletContents{Contents: []*exportedContent{
+			{ContentType: Key, Content: []byte(sampleKeyContentBase58Valid)},
+		}}, exportPassword, testPBES2Count)
+		require.NoError(t, err)
+
+		err = walletInstance.Import(tkn, exportPassword, locked)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "keys can only be imported from the key backup")
+
+		err = importKeys(&mockkms.KeyManager{RestoreErr: errors.New(sampleWalletErr)}, user, exportPassword,
+			&walletContents{Keys: []byte("{}")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to restore keys")
+
+		// key manager not supporting key backup.
+		err = importKeys(&webkms.RemoteKMS{}, user, exportPassword, &walletContents{Keys: []byte("{}")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not support key import")
+	})
 }
 
 func TestWallet_Add(t *testing.T) {